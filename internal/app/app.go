@@ -13,6 +13,7 @@ import (
 	"go-base-project/internal/validator"
 	"go-base-project/platform/database"
 	"go-base-project/platform/redis"
+	"go-base-project/platform/storage"
 	"net/http"
 	"os"
 	"os/signal"
@@ -27,9 +28,10 @@ import (
 
 // App represents the main application.
 type App struct {
-	echo *echo.Echo
-	cfg  config.Config
-	db   *gorm.DB
+	echo     *echo.Echo
+	cfg      config.Config
+	db       *gorm.DB
+	services *bootstrap.Services
 }
 
 // New creates a new application instance.
@@ -58,10 +60,29 @@ func New(cfg config.Config) (*App, error) {
 	}
 	log.Info().Msg("Redis connected successfully")
 
+	// Initialize Blob Storage
+	blobStore, err := storage.New(storage.Config{
+		Driver:            cfg.StorageDriver,
+		PublicBaseURL:     cfg.StoragePublicURL,
+		SigningKey:        cfg.JWTSecret,
+		LocalPath:         cfg.StorageLocalPath,
+		LocalSignedURL:    cfg.StorageSignedURLs,
+		S3Endpoint:        cfg.S3Endpoint,
+		S3Region:          cfg.S3Region,
+		S3Bucket:          cfg.S3Bucket,
+		S3AccessKeyID:     cfg.S3AccessKeyID,
+		S3SecretAccessKey: cfg.S3SecretAccessKey,
+		S3UsePathStyle:    cfg.S3UsePathStyle,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("storage initialization failed: %w", err)
+	}
+	log.Info().Str("driver", cfg.StorageDriver).Msg("Blob storage initialized")
+
 	// Dependency Injection
 	repositories := bootstrap.InitRepositories(db)
-	services := bootstrap.InitServices(repositories, redisClient, cfg)
-	handlers := bootstrap.InitHandlers(services, cfg)
+	services := bootstrap.InitServices(repositories, redisClient, blobStore, cfg)
+	handlers := bootstrap.InitHandlers(services, blobStore, cfg)
 	middlewares := customMiddleware.NewMiddleware(services.Authorization, cfg.JWTSecret)
 
 	// Initialize Echo
@@ -72,11 +93,15 @@ func New(cfg config.Config) (*App, error) {
 	// Setup Routes
 	router.SetupRoutes(e, cfg, handlers, middlewares, redisClient)
 
-	return &App{echo: e, cfg: cfg, db: db}, nil
+	return &App{echo: e, cfg: cfg, db: db, services: services}, nil
 }
 
 // Start runs the HTTP server and handles graceful shutdown.
 func (a *App) Start() {
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+	a.startBackgroundJobs(jobsCtx)
+
 	go func() {
 		log.Info().Msgf("Server starting on port %s", a.cfg.Port)
 		if err := a.echo.Start(":" + a.cfg.Port); err != nil && !errors.Is(err, http.ErrServerClosed) {
@@ -89,6 +114,7 @@ func (a *App) Start() {
 	<-quit
 
 	log.Info().Msg("Shutting down server...")
+	stopJobs()
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
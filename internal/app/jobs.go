@@ -0,0 +1,125 @@
+package app
+
+import (
+	"context"
+	"go-base-project/internal/service"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// jobPurgeInterval is how often finished background jobs past their retention are removed
+const jobPurgeInterval = time.Hour
+
+// suspensionExpiryInterval is how often users whose suspension has ended are marked active again
+const suspensionExpiryInterval = time.Minute
+
+// changeRequestExpiryInterval is how often pending change requests past their deadline are marked expired
+const changeRequestExpiryInterval = time.Minute
+
+// startBackgroundJobs launches the periodic maintenance jobs and the background job workers. They stop when
+// ctx is cancelled.
+func (a *App) startBackgroundJobs(ctx context.Context) {
+	if a.cfg.OrganizationPurgeInterval > 0 {
+		go runOrganizationPurge(ctx, a.services.Organization, a.cfg.OrganizationRetention, a.cfg.OrganizationPurgeInterval)
+	} else {
+		log.Info().Msg("Organization purge job disabled")
+	}
+
+	go runSuspensionExpiry(ctx, a.services.User)
+	go runChangeRequestExpiry(ctx, a.services.Approval)
+
+	if a.cfg.JobWorkers > 0 {
+		go a.services.Job.RunWorkers(ctx, a.cfg.JobWorkers)
+	} else {
+		log.Info().Msg("Background job workers disabled")
+	}
+	if a.cfg.JobRetention > 0 {
+		go runJobPurge(ctx, a.services.Job, a.cfg.JobRetention)
+	}
+}
+
+// runJobPurge removes finished background jobs past the retention period, once at startup and then hourly.
+func runJobPurge(ctx context.Context, jobService service.JobServiceInterface, retention time.Duration) {
+	ticker := time.NewTicker(jobPurgeInterval)
+	defer ticker.Stop()
+
+	for {
+		purged, err := jobService.PurgeFinishedJobs(ctx, retention)
+		if err != nil && ctx.Err() == nil {
+			log.Error().Err(err).Int64("purged", purged).Msg("Job purge failed")
+		} else if purged > 0 {
+			log.Info().Int64("purged", purged).Dur("retention", retention).Msg("Purged finished jobs")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runSuspensionExpiry records the end of expired user suspensions, once at startup and then every minute.
+func runSuspensionExpiry(ctx context.Context, userService service.UserServiceInterface) {
+	ticker := time.NewTicker(suspensionExpiryInterval)
+	defer ticker.Stop()
+
+	for {
+		reactivated, err := userService.ReactivateExpiredSuspensions(ctx)
+		if err != nil && ctx.Err() == nil {
+			log.Error().Err(err).Int64("reactivated", reactivated).Msg("Suspension expiry job failed")
+		} else if reactivated > 0 {
+			log.Info().Int64("reactivated", reactivated).Msg("Reactivated users with expired suspensions")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runChangeRequestExpiry marks overdue pending change requests as expired, once at startup and then every minute.
+func runChangeRequestExpiry(ctx context.Context, approvalService service.ApprovalServiceInterface) {
+	ticker := time.NewTicker(changeRequestExpiryInterval)
+	defer ticker.Stop()
+
+	for {
+		expired, err := approvalService.ExpireChangeRequests(ctx)
+		if err != nil && ctx.Err() == nil {
+			log.Error().Err(err).Msg("Change request expiry job failed")
+		} else if expired > 0 {
+			log.Info().Int64("expired", expired).Msg("Expired overdue change requests")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runOrganizationPurge permanently removes soft-deleted organizations past the retention period,
+// once at startup and then on every interval.
+func runOrganizationPurge(ctx context.Context, orgService service.OrganizationServiceInterface, retention, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		purged, err := orgService.PurgeDeletedOrganizations(ctx, retention)
+		if err != nil && ctx.Err() == nil {
+			log.Error().Err(err).Int64("purged", purged).Msg("Organization purge job failed")
+		} else if purged > 0 {
+			log.Info().Int64("purged", purged).Dur("retention", retention).Msg("Purged deleted organizations")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
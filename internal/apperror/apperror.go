@@ -51,6 +51,21 @@ func NewForbiddenError(message string) *AppError {
 	return NewAppErrorWithCode(http.StatusForbidden, message, "FORBIDDEN", nil)
 }
 
+// NewQuotaExceededError adalah helper untuk error 403 saat batas kuota organisasi terlampaui.
+func NewQuotaExceededError(message string) *AppError {
+	return NewAppErrorWithCode(http.StatusForbidden, message, "QUOTA_EXCEEDED", nil)
+}
+
+// NewAccountSuspendedError adalah helper untuk error 403 saat akun user sedang ditangguhkan.
+func NewAccountSuspendedError(message string) *AppError {
+	return NewAppErrorWithCode(http.StatusForbidden, message, "ACCOUNT_SUSPENDED", nil)
+}
+
+// NewAccountPendingActivationError adalah helper untuk error 403 saat akun user belum diaktifkan.
+func NewAccountPendingActivationError(message string) *AppError {
+	return NewAppErrorWithCode(http.StatusForbidden, message, "ACCOUNT_PENDING_ACTIVATION", nil)
+}
+
 // NewValidationError adalah helper untuk error 400 validation.
 func NewValidationError(message string) *AppError {
 	return NewAppErrorWithCode(http.StatusBadRequest, message, "VALIDATION_ERROR", nil)
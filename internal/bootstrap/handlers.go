@@ -4,6 +4,7 @@ import (
 	"go-base-project/internal/config"
 	"go-base-project/internal/handler"
 	"go-base-project/internal/util"
+	"go-base-project/platform/storage"
 )
 
 // Handlers menampung semua instance handler untuk aplikasi.
@@ -13,17 +14,45 @@ type Handlers struct {
 	Organization *handler.OrganizationHandler
 	Role         *handler.RoleHandler
 	User         *handler.UserHandler
+	Membership   *handler.MembershipHandler
+	Approval     *handler.ApprovalHandler
+	Invitation   *handler.InvitationHandler
+	OrgType      *handler.OrganizationTypeHandler
+	OrgTemplate  *handler.OrganizationTemplateHandler
+	OrgSetting   *handler.OrganizationSettingHandler
+	OrgQuota     *handler.OrganizationQuotaHandler
+	OrgTransfer  *handler.OrganizationTransferHandler
+	Search       *handler.SearchHandler
+	UserImport   *handler.UserImportHandler
+	Job          *handler.JobHandler
+	PersonalData *handler.PersonalDataHandler
+	Avatar       *handler.AvatarHandler
+	Media        *handler.MediaHandler
 }
 
 // InitHandlers menginisialisasi semua handler untuk aplikasi.
-func InitHandlers(services *Services, cfg config.Config) *Handlers {
+func InitHandlers(services *Services, blobStore storage.BlobStore, cfg config.Config) *Handlers {
 	googleOauthConfig := util.SetupGoogleOauth(cfg)
 
 	authHandler := handler.NewAuthHandler(services.Auth, googleOauthConfig, cfg)
 	healthHandler := handler.NewHealthHandler()
-	organizationHandler := handler.NewOrganizationHandler(services.Organization)
-	roleHandler := handler.NewRoleHandler(services.Role)
-	userHandler := handler.NewUserHandler(services.User)
+	organizationHandler := handler.NewOrganizationHandler(services.Organization, services.Approval, services.Job)
+	roleHandler := handler.NewRoleHandler(services.Role, services.Approval)
+	userHandler := handler.NewUserHandler(services.User, services.Approval)
+	membershipHandler := handler.NewMembershipHandler(services.Membership, services.Approval, services.Job)
+	approvalHandler := handler.NewApprovalHandler(services.Approval)
+	invitationHandler := handler.NewInvitationHandler(services.Invitation)
+	orgTypeHandler := handler.NewOrganizationTypeHandler(services.OrgType)
+	orgTemplateHandler := handler.NewOrganizationTemplateHandler(services.OrgTemplate)
+	orgSettingHandler := handler.NewOrganizationSettingHandler(services.OrgSetting)
+	orgQuotaHandler := handler.NewOrganizationQuotaHandler(services.OrgQuota)
+	orgTransferHandler := handler.NewOrganizationTransferHandler(services.OrgTransfer, services.Job)
+	searchHandler := handler.NewSearchHandler(services.Search)
+	userImportHandler := handler.NewUserImportHandler(services.UserImport, services.Job)
+	jobHandler := handler.NewJobHandler(services.Job)
+	personalDataHandler := handler.NewPersonalDataHandler(services.PersonalData)
+	avatarHandler := handler.NewAvatarHandler(services.Avatar, cfg.AvatarMaxUploadSize)
+	mediaHandler := handler.NewMediaHandler(blobStore)
 
 	return &Handlers{
 		Auth:         authHandler,
@@ -31,5 +60,19 @@ func InitHandlers(services *Services, cfg config.Config) *Handlers {
 		Organization: organizationHandler,
 		Role:         roleHandler,
 		User:         userHandler,
+		Membership:   membershipHandler,
+		Approval:     approvalHandler,
+		Invitation:   invitationHandler,
+		OrgType:      orgTypeHandler,
+		OrgTemplate:  orgTemplateHandler,
+		OrgSetting:   orgSettingHandler,
+		OrgQuota:     orgQuotaHandler,
+		OrgTransfer:  orgTransferHandler,
+		Search:       searchHandler,
+		UserImport:   userImportHandler,
+		Job:          jobHandler,
+		PersonalData: personalDataHandler,
+		Avatar:       avatarHandler,
+		Media:        mediaHandler,
 	}
 }
@@ -8,20 +8,56 @@ import (
 
 // Repositories menampung semua instance repository untuk aplikasi.
 type Repositories struct {
-	Organization repository.OrganizationRepositoryInterface
-	User         repository.UserRepositoryInterface
-	Role         repository.RoleRepositoryInterface
+	Organization  repository.OrganizationRepositoryInterface
+	User          repository.UserRepositoryInterface
+	Membership    repository.MembershipRepositoryInterface
+	Role          repository.RoleRepositoryInterface
+	ChangeRequest repository.ChangeRequestRepositoryInterface
+	Invitation    repository.InvitationRepositoryInterface
+	JoinRequest   repository.JoinRequestRepositoryInterface
+	OrgAudit      repository.OrganizationAuditRepositoryInterface
+	OrgType       repository.OrganizationTypeRepositoryInterface
+	OrgTemplate   repository.OrganizationTemplateRepositoryInterface
+	OrgSetting    repository.OrganizationSettingRepositoryInterface
+	OrgQuota      repository.OrganizationQuotaRepositoryInterface
+	Job           repository.JobRepositoryInterface
+	PersonalData  repository.PersonalDataRepositoryInterface
+	TxManager     repository.TransactionManagerInterface
 }
 
 // InitRepositories menginisialisasi semua repository untuk aplikasi.
 func InitRepositories(db *gorm.DB) *Repositories {
 	organizationRepository := repository.NewOrganizationRepository(db)
 	userRepository := repository.NewUserRepository(db)
+	membershipRepository := repository.NewMembershipRepository(db)
 	roleRepository := repository.NewRoleRepository(db)
+	changeRequestRepository := repository.NewChangeRequestRepository(db)
+	invitationRepository := repository.NewInvitationRepository(db)
+	joinRequestRepository := repository.NewJoinRequestRepository(db)
+	orgAuditRepository := repository.NewOrganizationAuditRepository(db)
+	orgTypeRepository := repository.NewOrganizationTypeRepository(db)
+	orgTemplateRepository := repository.NewOrganizationTemplateRepository(db)
+	orgSettingRepository := repository.NewOrganizationSettingRepository(db)
+	orgQuotaRepository := repository.NewOrganizationQuotaRepository(db)
+	jobRepository := repository.NewJobRepository(db)
+	personalDataRepository := repository.NewPersonalDataRepository(db)
+	txManager := repository.NewTransactionManager(db)
 
 	return &Repositories{
-		Organization: organizationRepository,
-		User:         userRepository,
-		Role:         roleRepository,
+		Organization:  organizationRepository,
+		User:          userRepository,
+		Membership:    membershipRepository,
+		Role:          roleRepository,
+		ChangeRequest: changeRequestRepository,
+		Invitation:    invitationRepository,
+		JoinRequest:   joinRequestRepository,
+		OrgAudit:      orgAuditRepository,
+		OrgType:       orgTypeRepository,
+		OrgTemplate:   orgTemplateRepository,
+		OrgSetting:    orgSettingRepository,
+		OrgQuota:      orgQuotaRepository,
+		Job:           jobRepository,
+		PersonalData:  personalDataRepository,
+		TxManager:     txManager,
 	}
 }
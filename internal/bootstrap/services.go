@@ -3,6 +3,8 @@ package bootstrap
 import (
 	"go-base-project/internal/config"
 	"go-base-project/internal/service"
+	"go-base-project/internal/util"
+	"go-base-project/platform/storage"
 
 	"github.com/go-redis/redis/v8"
 )
@@ -13,22 +15,65 @@ type Services struct {
 	Organization  service.OrganizationServiceInterface
 	Role          service.RoleServiceInterface
 	User          service.UserServiceInterface
+	Membership    service.MembershipServiceInterface
 	Authorization service.AuthorizationServiceInterface
+	Approval      service.ApprovalServiceInterface
+	Invitation    service.InvitationServiceInterface
+	OrgType       service.OrganizationTypeServiceInterface
+	OrgTemplate   service.OrganizationTemplateServiceInterface
+	OrgSetting    service.OrganizationSettingServiceInterface
+	OrgQuota      service.OrganizationQuotaServiceInterface
+	OrgTransfer   service.OrganizationTransferServiceInterface
+	Search        service.SearchServiceInterface
+	UserImport    service.UserImportServiceInterface
+	Job           service.JobServiceInterface
+	PersonalData  service.PersonalDataServiceInterface
+	Avatar        service.AvatarServiceInterface
 }
 
 // InitServices menginisialisasi semua service untuk aplikasi.
-func InitServices(repos *Repositories, redisClient *redis.Client, cfg config.Config) *Services {
-	authorizationService := service.NewAuthorizationService(repos.Role, repos.User, redisClient)
-	authService := service.NewAuthService(repos.User, repos.Role, authorizationService, redisClient, cfg.JWTSecret)
-	organizationService := service.NewOrganizationService(repos.Organization, repos.User)
-	roleService := service.NewRoleService(repos.Role, authorizationService)
-	userService := service.NewUserService(repos.User, repos.Role, organizationService, redisClient)
+func InitServices(repos *Repositories, redisClient *redis.Client, blobStore storage.BlobStore, cfg config.Config) *Services {
+	authorizationService := service.NewAuthorizationService(repos.Role, repos.User, repos.Membership, redisClient)
+	usernameService := service.NewUsernameService(repos.User)
+	authService := service.NewAuthService(repos.User, repos.Role, authorizationService, usernameService, redisClient, cfg.JWTSecret)
+	orgCodeGenerator := util.NewOrganizationCodeGenerator(cfg.OrganizationCodeAlphabet, cfg.OrganizationCodeLength, cfg.OrganizationCodePrefixLength, cfg.OrganizationCodeCheckDigit)
+	organizationSettingService := service.NewOrganizationSettingService(repos.OrgSetting, repos.Organization, redisClient)
+	organizationQuotaService := service.NewOrganizationQuotaService(repos.OrgQuota, repos.Organization)
+	membershipService := service.NewMembershipService(repos.Membership, repos.User, repos.Role, repos.Organization, repos.OrgType, repos.TxManager, organizationQuotaService)
+	organizationService := service.NewOrganizationService(repos.Organization, repos.User, repos.Membership, repos.Role, repos.JoinRequest, repos.OrgAudit, repos.OrgType, repos.TxManager, authorizationService, organizationSettingService, organizationQuotaService, membershipService, orgCodeGenerator)
+	roleService := service.NewRoleService(repos.Role, repos.User, repos.Membership, repos.OrgType, repos.TxManager, organizationService, membershipService, authorizationService)
+	userService := service.NewUserService(repos.User, repos.Role, repos.Membership, organizationService, redisClient)
+	approvalService := service.NewApprovalService(repos.ChangeRequest, repos.User, repos.TxManager, organizationService, cfg)
+	personalDataService := service.NewPersonalDataService(repos.PersonalData, repos.User, approvalService, repos.TxManager, blobStore, redisClient)
+	jobService := service.NewJobService(repos.Job, cfg)
+	service.RegisterChangeRequestExecutors(approvalService, organizationService, userService, roleService, personalDataService, jobService)
+	invitationService := service.NewInvitationService(repos.Invitation, repos.Organization, repos.User, repos.Role, repos.Membership, repos.TxManager, authorizationService, membershipService, cfg)
+	organizationTypeService := service.NewOrganizationTypeService(repos.OrgType, repos.Role)
+	organizationTemplateService := service.NewOrganizationTemplateService(repos.OrgTemplate, repos.Organization, repos.OrgType, repos.Role, repos.User, repos.TxManager, organizationQuotaService, membershipService, orgCodeGenerator)
+	organizationTransferService := service.NewOrganizationTransferService(repos.Organization, repos.User, repos.Membership, repos.Role, repos.OrgType, repos.OrgSetting, repos.TxManager, organizationSettingService, organizationQuotaService, membershipService, orgCodeGenerator)
+	searchService := service.NewSearchService(repos.User, repos.Organization, organizationService, authorizationService)
+	userImportService := service.NewUserImportService(repos.User, repos.Role, repos.Organization, repos.TxManager, organizationService, invitationService, membershipService, redisClient)
+	avatarService := service.NewAvatarService(repos.User, blobStore, cfg)
+	service.RegisterJobHandlers(jobService, membershipService, userImportService, organizationService, organizationTransferService)
 
 	return &Services{
 		Auth:          authService,
 		Organization:  organizationService,
 		Role:          roleService,
 		User:          userService,
+		Membership:    membershipService,
 		Authorization: authorizationService,
+		Approval:      approvalService,
+		Invitation:    invitationService,
+		OrgType:       organizationTypeService,
+		OrgTemplate:   organizationTemplateService,
+		OrgSetting:    organizationSettingService,
+		OrgQuota:      organizationQuotaService,
+		OrgTransfer:   organizationTransferService,
+		Search:        searchService,
+		UserImport:    userImportService,
+		Job:           jobService,
+		PersonalData:  personalDataService,
+		Avatar:        avatarService,
 	}
 }
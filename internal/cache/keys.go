@@ -10,9 +10,30 @@ import (
 const (
 	// PermissionsCacheDuration adalah TTL default untuk cache izin peran.
 	PermissionsCacheDuration = 15 * time.Minute
+	// OrganizationSettingsCacheDuration adalah TTL default untuk cache pengaturan efektif organisasi.
+	OrganizationSettingsCacheDuration = 10 * time.Minute
+	// UserImportResultDuration adalah lama file hasil impor user dapat diunduh.
+	UserImportResultDuration = 24 * time.Hour
+	// UserStatusCacheDuration adalah TTL cache status aktif akun user yang dicek di setiap request.
+	UserStatusCacheDuration = 5 * time.Minute
 )
 
 // GetRolePermissionsCacheKey menghasilkan kunci Redis untuk cache izin sebuah peran.
 func GetRolePermissionsCacheKey(roleID uuid.UUID) string {
 	return fmt.Sprintf("permissions:role:%s", roleID.String())
 }
+
+// GetOrganizationSettingsCacheKey menghasilkan kunci Redis untuk cache pengaturan efektif sebuah organisasi.
+func GetOrganizationSettingsCacheKey(orgID uuid.UUID) string {
+	return fmt.Sprintf("settings:organization:%s", orgID.String())
+}
+
+// GetUserImportResultKey menghasilkan kunci Redis untuk file hasil sebuah impor user.
+func GetUserImportResultKey(importID uuid.UUID) string {
+	return fmt.Sprintf("imports:users:%s", importID.String())
+}
+
+// GetUserStatusCacheKey menghasilkan kunci Redis untuk cache status akun seorang user.
+func GetUserStatusCacheKey(userID uuid.UUID) string {
+	return fmt.Sprintf("status:user:%s", userID.String())
+}
@@ -46,6 +46,46 @@ type Config struct {
 
 	// CORS Settings
 	DisableCORS bool // Set to true to allow all origins (for development/testing only)
+
+	// Approval Settings - four-eyes approval for sensitive admin actions
+	ApprovalRequiredApprovals   int           // Number of distinct approvers needed before execution
+	ApprovalMinApproverLevel    int           // Minimum role level an approver must have
+	ApprovalExpiry              time.Duration // How long a change request stays open
+	ApprovalBulkAssignThreshold int           // Bulk assignments above this many users need approval
+
+	// Invitation Settings - organization invite links
+	InvitationExpiry  time.Duration // How long an invitation token stays valid
+	InvitationMaxUses int           // Upper bound for max_uses on a single invitation
+
+	// Organization Retention Settings - soft-deleted organizations are purged after the retention period
+	OrganizationRetention     time.Duration // How long a deleted organization can still be restored
+	OrganizationPurgeInterval time.Duration // How often the purge job runs; zero disables it
+
+	// Background Job Settings - long operations run by workers outside the HTTP request
+	JobWorkers      int           // Number of workers in this instance; zero disables them
+	JobPollInterval time.Duration // How often an idle worker checks the queue
+	JobMaxAttempts  int           // Attempts before a failing job is given up
+	JobRetention    time.Duration // How long finished jobs and their results are kept; zero keeps them
+
+	// Organization Code Settings - codes are [name prefix][random][check character]
+	OrganizationCodeAlphabet     string // Characters codes are built from; ambiguous ones are excluded by default
+	OrganizationCodeLength       int    // Full code length including the check character
+	OrganizationCodePrefixLength int    // Characters derived from the organization name
+	OrganizationCodeCheckDigit   bool   // Append a Luhn mod N check character
+
+	// Storage Settings - uploaded files such as avatars and organization logos
+	StorageDriver       string        // "local" or "s3"
+	StorageLocalPath    string        // Directory the local driver stores objects in
+	StoragePublicURL    string        // Base URL objects are served from; defaults to BACKEND_URL/media for local storage
+	StorageSignedURLs   bool          // Serve objects through short-lived signed URLs instead of public ones
+	StorageURLExpiry    time.Duration // Validity of signed URLs
+	S3Endpoint          string        // S3-compatible endpoint, e.g. http://localhost:9000 for MinIO
+	S3Region            string
+	S3Bucket            string
+	S3AccessKeyID       string
+	S3SecretAccessKey   string
+	S3UsePathStyle      bool  // Address the bucket in the path, required by MinIO
+	AvatarMaxUploadSize int64 // Largest accepted avatar upload in bytes
 }
 
 // Load loads environment variables from a .env file or from the system environment.
@@ -86,6 +126,91 @@ func Load(path ...string) (Config, error) {
 		return Config{}, fmt.Errorf("invalid RATE_LIMIT_BURST value: %w", err)
 	}
 
+	// Approval configuration
+	approvalRequiredApprovals, err := strconv.Atoi(getEnv("APPROVAL_REQUIRED_APPROVALS", "1"))
+	if err != nil || approvalRequiredApprovals < 1 {
+		return Config{}, fmt.Errorf("invalid APPROVAL_REQUIRED_APPROVALS value: must be a positive integer")
+	}
+	approvalMinApproverLevel, err := strconv.Atoi(getEnv("APPROVAL_MIN_APPROVER_LEVEL", "76"))
+	if err != nil {
+		return Config{}, fmt.Errorf("invalid APPROVAL_MIN_APPROVER_LEVEL value: %w", err)
+	}
+	approvalExpiry, err := time.ParseDuration(getEnv("APPROVAL_EXPIRY", "72h"))
+	if err != nil {
+		return Config{}, fmt.Errorf("invalid APPROVAL_EXPIRY value: %w", err)
+	}
+	approvalBulkAssignThreshold, err := strconv.Atoi(getEnv("APPROVAL_BULK_ASSIGN_THRESHOLD", "50"))
+	if err != nil {
+		return Config{}, fmt.Errorf("invalid APPROVAL_BULK_ASSIGN_THRESHOLD value: %w", err)
+	}
+
+	// Invitation configuration
+	invitationExpiry, err := time.ParseDuration(getEnv("INVITATION_EXPIRY", "168h"))
+	if err != nil {
+		return Config{}, fmt.Errorf("invalid INVITATION_EXPIRY value: %w", err)
+	}
+	invitationMaxUses, err := strconv.Atoi(getEnv("INVITATION_MAX_USES", "100"))
+	if err != nil || invitationMaxUses < 1 {
+		return Config{}, fmt.Errorf("invalid INVITATION_MAX_USES value: must be a positive integer")
+	}
+
+	// Organization retention configuration
+	organizationRetention, err := time.ParseDuration(getEnv("ORGANIZATION_RETENTION", "720h"))
+	if err != nil || organizationRetention < 0 {
+		return Config{}, fmt.Errorf("invalid ORGANIZATION_RETENTION value: must be a non-negative duration")
+	}
+	organizationPurgeInterval, err := time.ParseDuration(getEnv("ORGANIZATION_PURGE_INTERVAL", "24h"))
+	if err != nil || organizationPurgeInterval < 0 {
+		return Config{}, fmt.Errorf("invalid ORGANIZATION_PURGE_INTERVAL value: must be a non-negative duration")
+	}
+
+	// Background job configuration
+	jobWorkers, err := strconv.Atoi(getEnv("JOB_WORKERS", "2"))
+	if err != nil || jobWorkers < 0 {
+		return Config{}, fmt.Errorf("invalid JOB_WORKERS value: must be a non-negative integer")
+	}
+	jobPollInterval, err := time.ParseDuration(getEnv("JOB_POLL_INTERVAL", "2s"))
+	if err != nil || jobPollInterval <= 0 {
+		return Config{}, fmt.Errorf("invalid JOB_POLL_INTERVAL value: must be a positive duration")
+	}
+	jobMaxAttempts, err := strconv.Atoi(getEnv("JOB_MAX_ATTEMPTS", "3"))
+	if err != nil || jobMaxAttempts < 1 {
+		return Config{}, fmt.Errorf("invalid JOB_MAX_ATTEMPTS value: must be a positive integer")
+	}
+	jobRetention, err := time.ParseDuration(getEnv("JOB_RETENTION", "168h"))
+	if err != nil || jobRetention < 0 {
+		return Config{}, fmt.Errorf("invalid JOB_RETENTION value: must be a non-negative duration")
+	}
+
+	// Organization code configuration
+	organizationCodeAlphabet := strings.ToUpper(getEnv("ORGANIZATION_CODE_ALPHABET", "ABCDEFGHJKMNPQRSTUVWXYZ23456789"))
+	organizationCodeLength, err := strconv.Atoi(getEnv("ORGANIZATION_CODE_LENGTH", "12"))
+	if err != nil {
+		return Config{}, fmt.Errorf("invalid ORGANIZATION_CODE_LENGTH value: %w", err)
+	}
+	organizationCodePrefixLength, err := strconv.Atoi(getEnv("ORGANIZATION_CODE_PREFIX_LENGTH", "3"))
+	if err != nil {
+		return Config{}, fmt.Errorf("invalid ORGANIZATION_CODE_PREFIX_LENGTH value: %w", err)
+	}
+	organizationCodeCheckDigit := getEnvBool("ORGANIZATION_CODE_CHECK_DIGIT", true)
+	if err := validateOrganizationCodeSettings(organizationCodeAlphabet, organizationCodeLength, organizationCodePrefixLength, organizationCodeCheckDigit); err != nil {
+		return Config{}, err
+	}
+
+	// Storage configuration
+	storageDriver := strings.ToLower(getEnv("STORAGE_DRIVER", "local"))
+	if storageDriver != "local" && storageDriver != "s3" {
+		return Config{}, fmt.Errorf("invalid STORAGE_DRIVER value: must be local or s3")
+	}
+	storageURLExpiry, err := time.ParseDuration(getEnv("STORAGE_URL_EXPIRY", "15m"))
+	if err != nil || storageURLExpiry <= 0 || storageURLExpiry > 7*24*time.Hour {
+		return Config{}, fmt.Errorf("invalid STORAGE_URL_EXPIRY value: must be a positive duration of at most 168h")
+	}
+	avatarMaxUploadSize, err := strconv.ParseInt(getEnv("AVATAR_MAX_UPLOAD_SIZE", "5242880"), 10, 64)
+	if err != nil || avatarMaxUploadSize < 1 {
+		return Config{}, fmt.Errorf("invalid AVATAR_MAX_UPLOAD_SIZE value: must be a positive number of bytes")
+	}
+
 	// Load base URLs
 	frontendURL := getEnv("FRONTEND_URL", "http://localhost:5173")
 	backendURL := getEnv("BACKEND_URL", "http://localhost:8080")
@@ -129,6 +254,45 @@ func Load(path ...string) (Config, error) {
 		RateLimitBurst:        rateLimitBurst,
 		RateLimitStorage:      getEnv("RATE_LIMIT_STORAGE", "memory"), // default: memory
 		DisableCORS:           getEnvBool("DISABLE_CORS", false),      // Default: false (CORS enabled)
+
+		ApprovalRequiredApprovals:   approvalRequiredApprovals,
+		ApprovalMinApproverLevel:    approvalMinApproverLevel,
+		ApprovalExpiry:              approvalExpiry,
+		ApprovalBulkAssignThreshold: approvalBulkAssignThreshold,
+
+		InvitationExpiry:  invitationExpiry,
+		InvitationMaxUses: invitationMaxUses,
+
+		OrganizationRetention:     organizationRetention,
+		OrganizationPurgeInterval: organizationPurgeInterval,
+
+		JobWorkers:      jobWorkers,
+		JobPollInterval: jobPollInterval,
+		JobMaxAttempts:  jobMaxAttempts,
+		JobRetention:    jobRetention,
+
+		OrganizationCodeAlphabet:     organizationCodeAlphabet,
+		OrganizationCodeLength:       organizationCodeLength,
+		OrganizationCodePrefixLength: organizationCodePrefixLength,
+		OrganizationCodeCheckDigit:   organizationCodeCheckDigit,
+
+		StorageDriver:       storageDriver,
+		StorageLocalPath:    getEnv("STORAGE_LOCAL_PATH", "./data/uploads"),
+		StoragePublicURL:    getEnv("STORAGE_PUBLIC_URL", ""),
+		StorageSignedURLs:   getEnvBool("STORAGE_SIGNED_URLS", false),
+		StorageURLExpiry:    storageURLExpiry,
+		S3Endpoint:          getEnv("S3_ENDPOINT", ""),
+		S3Region:            getEnv("S3_REGION", "us-east-1"),
+		S3Bucket:            getEnv("S3_BUCKET", ""),
+		S3AccessKeyID:       getEnv("S3_ACCESS_KEY_ID", ""),
+		S3SecretAccessKey:   getEnv("S3_SECRET_ACCESS_KEY", ""),
+		S3UsePathStyle:      getEnvBool("S3_USE_PATH_STYLE", false),
+		AvatarMaxUploadSize: avatarMaxUploadSize,
+	}
+
+	// Local storage is served by the backend itself unless a public URL is given
+	if cfg.StorageDriver == "local" && cfg.StoragePublicURL == "" {
+		cfg.StoragePublicURL = strings.TrimRight(backendURL, "/") + "/media"
 	}
 
 	if cfg.JWTSecret == "" {
@@ -146,6 +310,41 @@ func Load(path ...string) (Config, error) {
 	return cfg, nil
 }
 
+// minOrganizationCodeRandomLength is the fewest random characters a code may have. Joining by code needs
+// no invitation, so codes must not be guessable: six characters of the default alphabet give about 887
+// million combinations per name prefix.
+const minOrganizationCodeRandomLength = 6
+
+// validateOrganizationCodeSettings makes sure the organization code settings leave enough random
+// characters for codes to be hard to guess and practically collision-free, and fit the organizations.code column.
+func validateOrganizationCodeSettings(alphabet string, length, prefixLength int, checkDigit bool) error {
+	if len(alphabet) < 10 {
+		return fmt.Errorf("invalid ORGANIZATION_CODE_ALPHABET value: at least 10 characters are required")
+	}
+	seen := make(map[rune]bool, len(alphabet))
+	for _, char := range alphabet {
+		if !(char >= 'A' && char <= 'Z') && !(char >= '0' && char <= '9') {
+			return fmt.Errorf("invalid ORGANIZATION_CODE_ALPHABET value: only letters and digits are allowed")
+		}
+		if seen[char] {
+			return fmt.Errorf("invalid ORGANIZATION_CODE_ALPHABET value: duplicate character %q", char)
+		}
+		seen[char] = true
+	}
+
+	if length < 4 || length > 16 {
+		return fmt.Errorf("invalid ORGANIZATION_CODE_LENGTH value: must be between 4 and 16")
+	}
+	randomLength := length - prefixLength
+	if checkDigit {
+		randomLength--
+	}
+	if prefixLength < 0 || randomLength < minOrganizationCodeRandomLength {
+		return fmt.Errorf("invalid ORGANIZATION_CODE_PREFIX_LENGTH value: at least %d random characters must remain", minOrganizationCodeRandomLength)
+	}
+	return nil
+}
+
 func getEnv(key, fallback string) string {
 	if value, ok := os.LookupEnv(key); ok {
 		return strings.TrimSpace(value)
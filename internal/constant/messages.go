@@ -41,6 +41,13 @@ const (
 	ErrMsgAuthorizationContextMissing = "Authorization context not found"
 	ErrMsgCurrentUserHasNoRole        = "Current user has no role assigned"
 
+	// Account Status Messages
+	ErrMsgInsufficientAuthorityOverUser = "Insufficient authority over this user account"
+	ErrMsgCannotEraseOwnAccount         = "Users cannot erase their own account"
+	ErrMsgCannotChangeOwnStatus         = "Users cannot change the status of their own account"
+	ErrMsgAccountSuspended              = "This account has been suspended"
+	ErrMsgAccountPendingActivation      = "This account has not been activated yet"
+
 	// Role Approval Error Messages
 	ErrMsgInvalidApprovalID        = "Invalid approval request ID format"
 	ErrMsgApprovalNotFound         = "Approval request not found"
@@ -32,9 +32,151 @@ const (
 
 // Role approval status constants
 const (
-	RoleApprovalStatusPending  = "pending"
-	RoleApprovalStatusApproved = "approved"
-	RoleApprovalStatusRejected = "rejected"
+	RoleApprovalStatusPending   = "pending"
+	RoleApprovalStatusApproved  = "approved"
+	RoleApprovalStatusRejected  = "rejected"
+	RoleApprovalStatusCancelled = "cancelled"
+)
+
+// Change request action constants - sensitive operations that need approval before execution
+const (
+	ChangeRequestActionDeleteOrganization    = "organization.delete"
+	ChangeRequestActionDeleteUser            = "user.delete"
+	ChangeRequestActionEraseUser             = "user.erase"
+	ChangeRequestActionUpdateRolePermissions = "role.update_permissions"
+	ChangeRequestActionBulkAssignUsers       = "organization.bulk_assign_users"
+)
+
+// Change request status constants
+const (
+	ChangeRequestStatusPending   = "pending"
+	ChangeRequestStatusExecuting = "executing"
+	ChangeRequestStatusExecuted  = "executed"
+	ChangeRequestStatusRejected  = "rejected"
+	ChangeRequestStatusCancelled = "cancelled"
+	ChangeRequestStatusExpired   = "expired"
+)
+
+// User account status constants - only active accounts can sign in
+const (
+	UserStatusActive            = "active"
+	UserStatusSuspended         = "suspended"          // Blocked by an administrator, optionally until a given time
+	UserStatusPendingActivation = "pending_activation" // Created but not yet activated by an administrator
+)
+
+// Background job type constants - long-running operations executed by the job workers
+const (
+	JobTypeBulkAssignUsers     = "users.bulk_assign_organization"
+	JobTypeImportUsers         = "users.import"
+	JobTypeArchiveOrganization = "organization.archive"
+	JobTypeExportOrganization  = "organization.export"
+)
+
+// Background job status constants
+const (
+	JobStatusQueued    = "queued"
+	JobStatusRunning   = "running"
+	JobStatusSucceeded = "succeeded"
+	JobStatusFailed    = "failed"
+	JobStatusCancelled = "cancelled"
+)
+
+// Change request decision constants
+const (
+	ChangeRequestDecisionApproved = "approved"
+	ChangeRequestDecisionRejected = "rejected"
+)
+
+// Organization join policy constants - how joining by organization code is handled
+const (
+	JoinPolicyOpen             = "open"              // Membership is granted immediately
+	JoinPolicyApprovalRequired = "approval_required" // A join request must be approved by an org admin
+	JoinPolicyInviteOnly       = "invite_only"       // Members can only join through invitations
+)
+
+// Organization join request status constants
+const (
+	JoinRequestStatusPending  = "pending"
+	JoinRequestStatusApproved = "approved"
+	JoinRequestStatusRejected = "rejected"
+)
+
+// Membership history actions, one history entry per membership change
+const (
+	MembershipActionAssigned          = "assigned" // Created, or reactivated after leaving
+	MembershipActionRemoved           = "removed"
+	MembershipActionRoleUpdated       = "role_updated" // The role changed, possibly together with the status
+	MembershipActionStatusChanged     = "status_changed"
+	MembershipActionAttributesUpdated = "attributes_updated"
+)
+
+// Organization audit log actions
+const (
+	OrganizationAuditActionMoved       = "moved"
+	OrganizationAuditActionArchived    = "archived"
+	OrganizationAuditActionUnarchived  = "unarchived"
+	OrganizationAuditActionDeleted     = "deleted"
+	OrganizationAuditActionRestored    = "restored"
+	OrganizationAuditActionCodeRotated = "code_rotated"
+	OrganizationAuditActionSettings    = "settings_updated"
+	OrganizationAuditActionQuotas      = "quotas_updated"
+)
+
+// Organization setting keys known to the settings schema
+const (
+	OrganizationSettingJoinPolicy            = "membership.join_policy"
+	OrganizationSettingTimezone              = "locale.timezone"
+	OrganizationSettingLocale                = "locale.language"
+	OrganizationSettingAllowedLoginProviders = "auth.allowed_login_providers"
+	OrganizationSettingRequireMFA            = "auth.require_mfa"
+	OrganizationSettingCustomPrefix          = "custom." // Free-form keys for applications, e.g. custom.pos.receipt_footer
+)
+
+// Organization quota names - the plan limits an organization can have
+const (
+	OrganizationQuotaMembers            = "members"             // Active members of the organization
+	OrganizationQuotaChildOrganizations = "child_organizations" // Direct child organizations
+)
+
+// Organization setting sources - where an effective setting or quota value comes from
+const (
+	OrganizationSettingSourceOrganization = "organization" // Set on the organization itself
+	OrganizationSettingSourceInherited    = "inherited"    // Set on an ancestor
+	OrganizationSettingSourceDefault      = "default"      // Default of the settings schema
+)
+
+// Organization import conflict strategies - what happens when an imported code already exists
+const (
+	OrganizationImportConflictSkip      = "skip"      // Keep the existing organization untouched
+	OrganizationImportConflictOverwrite = "overwrite" // Update the existing organization from the document
+	OrganizationImportConflictRename    = "rename"    // Create a new organization with a generated code
+)
+
+// Organization import actions reported per imported organization
+const (
+	OrganizationImportActionCreate = "create"
+	OrganizationImportActionRename = "rename"
+	OrganizationImportActionUpdate = "update"
+	OrganizationImportActionSkip   = "skip"
+)
+
+// User import actions and row statuses reported per imported row
+const (
+	UserImportActionCreate = "create" // Create the account, and the membership when an organization is given
+	UserImportActionInvite = "invite" // Send an organization invitation to the email instead
+
+	UserImportStatusValid   = "valid"   // Passed validation (dry run)
+	UserImportStatusInvalid = "invalid" // Failed validation, never applied
+	UserImportStatusCreated = "created"
+	UserImportStatusInvited = "invited"
+	UserImportStatusFailed  = "failed" // Valid, but its batch could not be applied
+)
+
+// Organization invitation status constants
+const (
+	InvitationStatusPending  = "pending"
+	InvitationStatusAccepted = "accepted" // All uses consumed
+	InvitationStatusRevoked  = "revoked"
 )
 
 // Scan type constants
@@ -0,0 +1,90 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ChangeRequestResponse represents a sensitive admin operation waiting for (or done with) approval
+type ChangeRequestResponse struct {
+	ID                uuid.UUID                       `json:"id"`
+	Action            string                          `json:"action"`
+	TargetID          *uuid.UUID                      `json:"target_id,omitempty"`
+	Summary           string                          `json:"summary"`
+	Payload           json.RawMessage                 `json:"payload" swaggertype:"object"`
+	RequestedBy       uuid.UUID                       `json:"requested_by"`
+	RequestedByUser   string                          `json:"requested_by_user"`
+	Status            string                          `json:"status"`
+	RequiredApprovals int                             `json:"required_approvals"`
+	ApprovalCount     int                             `json:"approval_count"`
+	MinApproverLevel  int                             `json:"min_approver_level"`
+	ExpiresAt         time.Time                       `json:"expires_at"`
+	ExecutedAt        *time.Time                      `json:"executed_at,omitempty"`
+	Result            json.RawMessage                 `json:"result,omitempty" swaggertype:"object"` // Outcome of the executed action, e.g. the ID of the job it queued
+	Decisions         []ChangeRequestDecisionResponse `json:"decisions"`
+	CreatedAt         time.Time                       `json:"created_at"`
+	UpdatedAt         time.Time                       `json:"updated_at"`
+}
+
+// ChangeRequestDecisionResponse represents a single approver decision on a change request
+type ChangeRequestDecisionResponse struct {
+	ApproverID uuid.UUID `json:"approver_id"`
+	Approver   string    `json:"approver"`
+	Decision   string    `json:"decision"`
+	Comment    string    `json:"comment,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// PagedChangeRequestResponse is the paginated list of change requests
+type PagedChangeRequestResponse struct {
+	ChangeRequests []ChangeRequestResponse `json:"change_requests"`
+	Page           int                     `json:"page" example:"1"`
+	Limit          int                     `json:"limit" example:"10"`
+	Total          int64                   `json:"total" example:"25"`
+	TotalPages     int                     `json:"total_pages" example:"3"`
+}
+
+// ChangeRequestDecisionRequest is the payload for approving or rejecting a change request
+type ChangeRequestDecisionRequest struct {
+	Decision string `json:"decision" validate:"required,oneof=approved rejected"`
+	Comment  string `json:"comment" validate:"max=255"`
+}
+
+// Change request payloads - serialized operation input executed once a request is approved
+
+// DeleteOrganizationPayload is the payload of an organization.delete change request
+type DeleteOrganizationPayload struct {
+	OrganizationID uuid.UUID `json:"organization_id"`
+	RequestedBy    uuid.UUID `json:"requested_by"`
+}
+
+// DeleteUserPayload is the payload of a user.delete change request
+type DeleteUserPayload struct {
+	UserID uuid.UUID `json:"user_id"`
+}
+
+// EraseUserPayload is the payload of a user.erase change request
+type EraseUserPayload struct {
+	UserID      uuid.UUID `json:"user_id"`
+	Reason      string    `json:"reason"`
+	RequestedBy uuid.UUID `json:"requested_by"`
+}
+
+// BulkAssignUsersPayload is the payload of an organization.bulk_assign_users change request
+type BulkAssignUsersPayload struct {
+	BulkAssignUsersToOrganizationRequest
+	RequestedBy uuid.UUID `json:"requested_by"`
+}
+
+// UpdateRolePermissionsPayload is the payload of a role.update_permissions change request
+type UpdateRolePermissionsPayload struct {
+	RoleID          uuid.UUID `json:"role_id"`
+	PermissionNames []string  `json:"permission_names"`
+}
+
+// ChangeRequestJobResult is the result of a change request whose action runs as a background job
+type ChangeRequestJobResult struct {
+	JobID uuid.UUID `json:"job_id"`
+}
@@ -0,0 +1,49 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CreateInvitationRequest is the payload for inviting someone into an organization.
+// Target either an email address or an existing user; leave both empty for a shareable link.
+type CreateInvitationRequest struct {
+	RoleID         uuid.UUID  `json:"role_id" validate:"required"`
+	Email          string     `json:"email,omitempty" validate:"omitempty,email,max=255" example:"jane@example.com"`
+	UserID         *uuid.UUID `json:"user_id,omitempty"`
+	MaxUses        int        `json:"max_uses,omitempty" validate:"omitempty,min=1" example:"1"`
+	ExpiresInHours int        `json:"expires_in_hours,omitempty" validate:"omitempty,min=1,max=720" example:"72"`
+}
+
+// AcceptInvitationRequest is the payload for accepting an invitation
+type AcceptInvitationRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
+// InvitationResponse represents an organization invitation
+type InvitationResponse struct {
+	ID               uuid.UUID  `json:"id"`
+	OrganizationID   uuid.UUID  `json:"organization_id"`
+	OrganizationName string     `json:"organization_name"`
+	RoleID           uuid.UUID  `json:"role_id"`
+	RoleName         string     `json:"role_name"`
+	Email            string     `json:"email,omitempty"`
+	InvitedUserID    *uuid.UUID `json:"invited_user_id,omitempty"`
+	Status           string     `json:"status"`
+	MaxUses          int        `json:"max_uses"`
+	UseCount         int        `json:"use_count"`
+	ExpiresAt        time.Time  `json:"expires_at"`
+	InvitedBy        uuid.UUID  `json:"invited_by"`
+	InvitedByUser    string     `json:"invited_by_user"`
+	RevokedAt        *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+}
+
+// CreateInvitationResponse is returned once when an invitation is created.
+// The token is not stored and cannot be retrieved again.
+type CreateInvitationResponse struct {
+	InvitationResponse
+	Token     string `json:"token"`
+	AcceptURL string `json:"accept_url"`
+}
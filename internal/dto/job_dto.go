@@ -0,0 +1,58 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// JobResponse represents the state of a background job
+type JobResponse struct {
+	ID              uuid.UUID           `json:"id"`
+	Type            string              `json:"type" example:"users.bulk_assign_organization"`
+	Status          string              `json:"status" example:"running"` // queued, running, succeeded, failed, cancelled
+	Progress        JobProgressResponse `json:"progress"`
+	Result          json.RawMessage     `json:"result,omitempty" swaggertype:"object"` // Final result, or the partial result while running and after a failure or cancellation
+	Error           string              `json:"error,omitempty"`
+	Attempts        int                 `json:"attempts" example:"1"`
+	MaxAttempts     int                 `json:"max_attempts" example:"3"`
+	CancelRequested bool                `json:"cancel_requested"`
+	StatusURL       string              `json:"status_url" example:"/api/jobs/0190f3a2-7c41-7d2e-9b1a-3c4d5e6f7a8b"`
+	CreatedAt       time.Time           `json:"created_at"`
+	StartedAt       *time.Time          `json:"started_at,omitempty"`
+	FinishedAt      *time.Time          `json:"finished_at,omitempty"`
+}
+
+// JobProgressResponse is the number of items a job has processed out of the items it has to process
+type JobProgressResponse struct {
+	Processed int     `json:"processed" example:"250"`
+	Total     int     `json:"total" example:"1000"`
+	Percent   float64 `json:"percent" example:"25"`
+}
+
+// ImportUsersJobPayload is the input of a user import job
+type ImportUsersJobPayload struct {
+	Filename string `json:"filename"`
+	Data     []byte `json:"data"`
+}
+
+// ArchiveOrganizationJobPayload is the input of a cascade archive job
+type ArchiveOrganizationJobPayload struct {
+	OrganizationID uuid.UUID                  `json:"organization_id"`
+	Request        ArchiveOrganizationRequest `json:"request"`
+}
+
+// ExportOrganizationJobPayload is the input of an organization export job
+type ExportOrganizationJobPayload struct {
+	OrganizationID uuid.UUID `json:"organization_id"`
+	Format         string    `json:"format"`
+}
+
+// OrganizationExportJobResult is the result of an organization export job; Document is set for the json
+// format and CSV for the csv format
+type OrganizationExportJobResult struct {
+	Format   string                      `json:"format" example:"json"`
+	Document *OrganizationExportDocument `json:"document,omitempty"`
+	CSV      string                      `json:"csv,omitempty"`
+}
@@ -1,6 +1,7 @@
 package dto
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
@@ -9,7 +10,7 @@ import (
 // CreateOrganizationRequest represents the request payload for creating an organization
 type CreateOrganizationRequest struct {
 	Name                 string     `json:"name" validate:"required,min=3,max=100"`
-	OrganizationType     string     `json:"type" validate:"required,oneof=platform holding company store"`
+	OrganizationType     string     `json:"type" validate:"required,max=50"`
 	ParentOrganizationID *uuid.UUID `json:"parentOrganizationId,omitempty"`
 	Description          string     `json:"description,omitempty" validate:"max=500"`
 }
@@ -19,6 +20,7 @@ type UpdateOrganizationRequest struct {
 	Name        string `json:"name,omitempty" validate:"omitempty,min=3,max=100"`
 	Description string `json:"description,omitempty" validate:"max=500"`
 	IsActive    *bool  `json:"is_active,omitempty"`
+	JoinPolicy  string `json:"join_policy,omitempty" validate:"omitempty,oneof=open approval_required invite_only"` // Shortcut for the membership.join_policy setting
 }
 
 // OrganizationResponse represents the response payload for organization data
@@ -33,6 +35,8 @@ type OrganizationResponse struct {
 	CreatedBy            uuid.UUID              `json:"created_by"`
 	Creator              *UserResponse          `json:"creator,omitempty"`
 	IsActive             bool                   `json:"is_active"`
+	ArchivedAt           *time.Time             `json:"archived_at,omitempty"`
+	DeletedAt            *time.Time             `json:"deleted_at,omitempty"` // Only set in listings of deleted organizations
 	CreatedAt            time.Time              `json:"created_at"`
 	UpdatedAt            time.Time              `json:"updated_at"`
 	ChildOrganizations   []OrganizationResponse `json:"child_organizations,omitempty"`
@@ -41,18 +45,56 @@ type OrganizationResponse struct {
 
 // JoinOrganizationRequest represents the request to join an existing organization
 type JoinOrganizationRequest struct {
-	OrganizationCode string `json:"organization_code" validate:"required,len=8"`
+	OrganizationCode string `json:"organization_code" validate:"required,min=4,max=16"`
+	Message          string `json:"message,omitempty" validate:"max=500"` // Shown to org admins when the organization requires approval
+}
+
+// JoinOrganizationResponse is the outcome of joining by code: an immediate membership
+// for open organizations, or a pending join request when approval is required
+type JoinOrganizationResponse struct {
+	Status      string                    `json:"status" example:"joined"` // joined, pending
+	Membership  *UserOrganizationResponse `json:"membership,omitempty"`
+	JoinRequest *JoinRequestResponse      `json:"join_request,omitempty"`
+}
+
+// JoinRequestResponse represents a request to join an organization
+type JoinRequestResponse struct {
+	ID               uuid.UUID  `json:"id"`
+	OrganizationID   uuid.UUID  `json:"organization_id"`
+	OrganizationName string     `json:"organization_name"`
+	OrganizationCode string     `json:"organization_code"`
+	UserID           uuid.UUID  `json:"user_id"`
+	Username         string     `json:"username"`
+	Email            string     `json:"email,omitempty"`
+	Status           string     `json:"status"`
+	Message          string     `json:"message,omitempty"`
+	RoleID           *uuid.UUID `json:"role_id,omitempty"`
+	RoleName         string     `json:"role_name,omitempty"`
+	DecidedBy        *uuid.UUID `json:"decided_by,omitempty"`
+	DecidedAt        *time.Time `json:"decided_at,omitempty"`
+	Reason           string     `json:"reason,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+}
+
+// ApproveJoinRequestRequest is the payload for approving a join request with the role to grant
+type ApproveJoinRequestRequest struct {
+	RoleID uuid.UUID `json:"role_id" validate:"required"`
+}
+
+// RejectJoinRequestRequest is the payload for rejecting a join request
+type RejectJoinRequestRequest struct {
+	Reason string `json:"reason,omitempty" validate:"max=255"`
 }
 
 // CreateRoleWithOrganizationRequest extends CreateRoleApprovalRequest with organization context
 type CreateRoleWithOrganizationRequest struct {
 	CreateRoleApprovalRequest
 	// For joining existing organization
-	OrganizationCode string `json:"organization_code,omitempty" validate:"omitempty,len=8"`
+	OrganizationCode string `json:"organization_code,omitempty" validate:"omitempty,min=4,max=16"`
 	// For creating new organization
 	IsNewOrganization         bool   `json:"is_new_organization"`
 	RequestedOrganizationName string `json:"requested_organization_name,omitempty" validate:"required_if=IsNewOrganization true,omitempty,min=3,max=100"`
-	RequestedOrganizationType string `json:"requested_organization_type,omitempty" validate:"required_if=IsNewOrganization true,omitempty,oneof=platform holding company store"`
+	RequestedOrganizationType string `json:"requested_organization_type,omitempty" validate:"required_if=IsNewOrganization true,omitempty,max=50"`
 	OrganizationDescription   string `json:"organization_description,omitempty" validate:"max=500"`
 }
 
@@ -69,20 +111,25 @@ type RoleApprovalWithOrganizationResponse struct {
 // UserOrganizationResponse represents user-organization relationship
 type UserOrganizationResponse struct {
 	UserID         uuid.UUID            `json:"user_id"`
+	User           *UserResponse        `json:"user,omitempty"` // Included when listing the members of an organization
 	OrganizationID uuid.UUID            `json:"organization_id"`
 	Organization   OrganizationResponse `json:"organization"`
 	RoleID         *uuid.UUID           `json:"role_id,omitempty"`
 	Role           *RoleResponse        `json:"role,omitempty"`
 	JoinedAt       time.Time            `json:"joined_at"`
 	IsActive       bool                 `json:"is_active"`
+	Attributes     json.RawMessage      `json:"attributes,omitempty" swaggertype:"object"` // Custom attributes defined by the organization type
 }
 
 // ListOrganizationsRequest represents query parameters for listing organizations
 type ListOrganizationsRequest struct {
-	OrganizationType     string     `query:"type" validate:"omitempty,oneof=platform holding company store"`
+	OrganizationType     string     `query:"type" validate:"omitempty,max=50"`
 	ParentOrganizationID *uuid.UUID `query:"parent_id"`
 	IsActive             *bool      `query:"active"`
+	IsArchived           *bool      `query:"archived"`
 	Search               string     `query:"search"`
+	Sort                 string     `query:"sort" validate:"max=200"`    // Comma separated fields, '-' prefix for descending
+	Cursor               string     `query:"cursor" validate:"max=2048"` // next_cursor of the previous page; replaces page
 	Page                 int        `query:"page" validate:"min=1"`
 	Limit                int        `query:"limit" validate:"min=1,max=100"`
 }
@@ -96,14 +143,67 @@ type OrganizationStatsResponse struct {
 	OrganizationLevel int `json:"organization_level"`
 }
 
+// MoveOrganizationRequest represents the request to move an organization under a new parent
+type MoveOrganizationRequest struct {
+	NewParentOrganizationID *uuid.UUID `json:"new_parent_organization_id"` // Omit only when moving a holding to the root
+	Reason                  string     `json:"reason,omitempty" validate:"max=500"`
+}
+
+// ArchiveOrganizationRequest represents the request to archive or unarchive an organization
+type ArchiveOrganizationRequest struct {
+	Cascade bool   `json:"cascade"` // Apply to the whole subtree below the organization as well
+	Reason  string `json:"reason,omitempty" validate:"max=500"`
+}
+
+// ArchiveOrganizationResponse is the outcome of archiving or unarchiving an organization
+type ArchiveOrganizationResponse struct {
+	Organization            *OrganizationResponse `json:"organization"`
+	AffectedOrganizationIDs []uuid.UUID           `json:"affected_organization_ids"` // Organizations whose state changed, including the root
+}
+
+// RestoreOrganizationRequest represents the request to restore a soft-deleted organization
+type RestoreOrganizationRequest struct {
+	Reason string `json:"reason,omitempty" validate:"max=500"`
+}
+
+// RotateOrganizationCodeRequest represents the request to replace the join code of an organization
+type RotateOrganizationCodeRequest struct {
+	Reason string `json:"reason,omitempty" validate:"max=500"`
+}
+
+// OrganizationAuditLogResponse represents an entry of the organization audit trail
+type OrganizationAuditLogResponse struct {
+	ID             uuid.UUID       `json:"id"`
+	OrganizationID uuid.UUID       `json:"organization_id"`
+	Action         string          `json:"action"`
+	ActorID        *uuid.UUID      `json:"actor_id,omitempty"`
+	ActorUsername  string          `json:"actor_username,omitempty"`
+	Details        json.RawMessage `json:"details" swaggertype:"object"`
+	Reason         string          `json:"reason,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+}
+
+// OrganizationNodeResponse is an organization with its distance from the organization being queried
+type OrganizationNodeResponse struct {
+	OrganizationResponse
+	Distance int `json:"distance"`
+}
+
+// OrganizationHierarchyResponse describes where an organization sits in the hierarchy
+type OrganizationHierarchyResponse struct {
+	Organization  OrganizationResponse       `json:"organization"`
+	Depth         int                        `json:"depth"`          // Levels below the root organization; roots have depth 0
+	SubtreeHeight int                        `json:"subtree_height"` // Levels that exist below the organization
+	Ancestors     []OrganizationNodeResponse `json:"ancestors"`      // Nearest parent first
+	Descendants   []OrganizationNodeResponse `json:"descendants"`    // Ordered by distance
+}
+
 // HierarchyStatsResponse represents overall hierarchy statistics
 type HierarchyStatsResponse struct {
-	TotalOrganizations  int `json:"total_organizations"`
-	ActiveOrganizations int `json:"active_organizations"`
-	HoldingCount        int `json:"holding_count"`
-	CompanyCount        int `json:"company_count"`
-	StoreCount          int `json:"store_count"`
-	MaxDepth            int `json:"max_depth"`
+	TotalOrganizations  int              `json:"total_organizations"`
+	ActiveOrganizations int              `json:"active_organizations"`
+	OrganizationsByType map[string]int64 `json:"organizations_by_type"` // Keyed by organization type name; registered types without organizations count zero
+	MaxDepth            int              `json:"max_depth"`
 }
 
 // CreateCompleteStructureRequest represents request for creating complete organization structure
@@ -154,4 +254,5 @@ type PaginationInfo struct {
 type PaginatedOrganizationsResponse struct {
 	Organizations []OrganizationResponse `json:"organizations"`
 	Pagination    PaginationInfo         `json:"pagination"`
+	NextCursor    string                 `json:"next_cursor,omitempty"` // Empty on the last page
 }
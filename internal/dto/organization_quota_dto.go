@@ -0,0 +1,34 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UpdateOrganizationQuotasRequest sets or removes plan limits of an organization. Quotas not mentioned
+// are left unchanged.
+type UpdateOrganizationQuotasRequest struct {
+	Limits  map[string]*int `json:"limits,omitempty"`                                   // Quota name to limit; null lifts an inherited limit
+	Inherit []string        `json:"inherit,omitempty" validate:"omitempty,dive,max=50"` // Quotas whose own limit is removed so the inherited one applies again
+	Reason  string          `json:"reason,omitempty" validate:"max=500"`
+}
+
+// OrganizationQuotaUsageResponse reports the usage of an organization against its plan limits.
+type OrganizationQuotaUsageResponse struct {
+	OrganizationID uuid.UUID                `json:"organization_id"`
+	Quotas         []OrganizationQuotaUsage `json:"quotas"`
+}
+
+// OrganizationQuotaUsage is the usage of one quota and the limit that applies to it.
+type OrganizationQuotaUsage struct {
+	Quota                string     `json:"quota" example:"members"`
+	Description          string     `json:"description"`
+	Limit                *int       `json:"limit"` // Null when unlimited
+	Used                 int64      `json:"used"`
+	Remaining            *int64     `json:"remaining,omitempty"`
+	Exceeded             bool       `json:"exceeded"`                   // Usage is above a limit that was lowered afterwards
+	Source               string     `json:"source" example:"inherited"` // organization, inherited, default
+	SourceOrganizationID *uuid.UUID `json:"source_organization_id,omitempty"`
+	UpdatedAt            *time.Time `json:"updated_at,omitempty"`
+}
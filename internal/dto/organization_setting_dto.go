@@ -0,0 +1,39 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UpdateOrganizationSettingsRequest sets or removes settings of an organization. A null value removes the
+// organization's own value so the setting is inherited again.
+type UpdateOrganizationSettingsRequest struct {
+	Settings map[string]json.RawMessage `json:"settings" validate:"required,min=1,max=100" swaggertype:"object"`
+}
+
+// OrganizationSettingsResponse lists the effective settings of an organization.
+type OrganizationSettingsResponse struct {
+	OrganizationID uuid.UUID                  `json:"organization_id"`
+	Settings       []OrganizationSettingValue `json:"settings"`
+}
+
+// OrganizationSettingValue is the effective value of one setting and where it comes from.
+type OrganizationSettingValue struct {
+	Key                  string          `json:"key"`
+	Value                json.RawMessage `json:"value" swaggertype:"object"`
+	Source               string          `json:"source" example:"inherited"` // organization, inherited, default
+	SourceOrganizationID *uuid.UUID      `json:"source_organization_id,omitempty"`
+	UpdatedBy            *uuid.UUID      `json:"updated_by,omitempty"` // Only set when the value comes from an organization
+	UpdatedAt            *time.Time      `json:"updated_at,omitempty"`
+}
+
+// OrganizationSettingDefinitionResponse describes a setting of the settings schema.
+type OrganizationSettingDefinitionResponse struct {
+	Key           string          `json:"key" example:"membership.join_policy"`
+	Type          string          `json:"type" example:"string"` // string, boolean, string_list, json
+	Description   string          `json:"description"`
+	Default       json.RawMessage `json:"default,omitempty" swaggertype:"object"`
+	AllowedValues []string        `json:"allowed_values,omitempty"`
+}
@@ -0,0 +1,104 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OrganizationTemplateDefinition describes an organization structure as a tree. Names, descriptions and
+// member references may contain {{variable}} placeholders that are filled in when the template is applied.
+type OrganizationTemplateDefinition struct {
+	Organizations []OrganizationTemplateNode `json:"organizations" validate:"required,min=1,dive"` // Root nodes of the tree
+}
+
+// OrganizationTemplateNode defines one organization of a template and the organizations below it.
+type OrganizationTemplateNode struct {
+	Name             string                       `json:"name" validate:"required,min=2,max=255"`
+	OrganizationType string                       `json:"type" validate:"required,max=50"`
+	Description      string                       `json:"description,omitempty" validate:"max=1000"`
+	Members          []OrganizationTemplateMember `json:"members,omitempty" validate:"dive"`
+	Children         []OrganizationTemplateNode   `json:"children,omitempty" validate:"dive"`
+}
+
+// OrganizationTemplateMember assigns a user to the organization of a template node.
+type OrganizationTemplateMember struct {
+	User string `json:"user" validate:"required,max=255"`  // User ID, email or username
+	Role string `json:"role,omitempty" validate:"max=100"` // Role name; the member role of the organization type when omitted
+}
+
+// CreateOrganizationTemplateRequest defines the structure for saving a reusable organization template.
+type CreateOrganizationTemplateRequest struct {
+	Name        string                         `json:"name" validate:"required,min=2,max=100"`
+	Description string                         `json:"description" validate:"max=1000"`
+	Definition  OrganizationTemplateDefinition `json:"definition" validate:"required"`
+}
+
+// UpdateOrganizationTemplateRequest defines the structure for updating a saved organization template.
+type UpdateOrganizationTemplateRequest struct {
+	Name        string                         `json:"name" validate:"required,min=2,max=100"`
+	Description string                         `json:"description" validate:"max=1000"`
+	Definition  OrganizationTemplateDefinition `json:"definition" validate:"required"`
+}
+
+// OrganizationTemplateResponse defines the structure for a saved organization template API response.
+type OrganizationTemplateResponse struct {
+	ID                uuid.UUID                      `json:"id"`
+	Name              string                         `json:"name"`
+	Description       string                         `json:"description,omitempty"`
+	Definition        OrganizationTemplateDefinition `json:"definition"`
+	Variables         []string                       `json:"variables"` // Placeholders that must be provided when applying
+	OrganizationCount int                            `json:"organization_count"`
+	CreatedBy         *uuid.UUID                     `json:"created_by,omitempty"`
+	UpdatedBy         *uuid.UUID                     `json:"updated_by,omitempty"`
+	CreatedAt         time.Time                      `json:"created_at"`
+	UpdatedAt         time.Time                      `json:"updated_at"`
+}
+
+// ApplyOrganizationTemplateRequest defines the structure for validating or applying an organization template.
+// Exactly one of template_id and definition must be given.
+type ApplyOrganizationTemplateRequest struct {
+	TemplateID           *uuid.UUID                      `json:"template_id,omitempty" validate:"required_without=Definition,excluded_with=Definition"`
+	Definition           *OrganizationTemplateDefinition `json:"definition,omitempty" validate:"required_without=TemplateID,omitempty"`
+	Variables            map[string]string               `json:"variables,omitempty"`              // Values for {{variable}} placeholders
+	ParentOrganizationID *uuid.UUID                      `json:"parent_organization_id,omitempty"` // Attach the root nodes under an existing organization
+}
+
+// OrganizationTemplatePlanResponse describes the organizations a template creates. It is returned by the
+// dry run, where IDs and codes are empty, and by apply, where they are filled in.
+type OrganizationTemplatePlanResponse struct {
+	Valid             bool                            `json:"valid"`
+	Applied           bool                            `json:"applied"`
+	OrganizationCount int                             `json:"organization_count"`
+	MemberCount       int                             `json:"member_count"`
+	Organizations     []OrganizationTemplatePlanEntry `json:"organizations"`
+	Issues            []OrganizationTemplateIssue     `json:"issues,omitempty"`
+}
+
+// OrganizationTemplatePlanEntry is one organization of a template plan, listed parents first.
+type OrganizationTemplatePlanEntry struct {
+	Path             string                           `json:"path"` // Position in the tree, e.g. "organizations[0].children[2]"
+	ParentPath       string                           `json:"parent_path,omitempty"`
+	Depth            int                              `json:"depth"`
+	Name             string                           `json:"name"`
+	OrganizationType string                           `json:"type"`
+	Description      string                           `json:"description,omitempty"`
+	ID               *uuid.UUID                       `json:"id,omitempty"`
+	Code             string                           `json:"code,omitempty"`
+	Members          []OrganizationTemplatePlanMember `json:"members,omitempty"`
+}
+
+// OrganizationTemplatePlanMember is a resolved member assignment of a template plan.
+type OrganizationTemplatePlanMember struct {
+	User     string     `json:"user"`
+	UserID   *uuid.UUID `json:"user_id,omitempty"`
+	Username string     `json:"username,omitempty"`
+	RoleID   *uuid.UUID `json:"role_id,omitempty"`
+	RoleName string     `json:"role_name,omitempty"`
+}
+
+// OrganizationTemplateIssue reports a problem found while validating a template.
+type OrganizationTemplateIssue struct {
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
@@ -0,0 +1,86 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OrganizationExportDocument is a versioned snapshot of an organization subtree that can be imported into
+// another environment. Organizations are listed parents first; IDs are those of the source environment and
+// only serve to link parents, they are remapped on import.
+type OrganizationExportDocument struct {
+	Version            int                       `json:"version" validate:"required" example:"1"`
+	ExportedAt         time.Time                 `json:"exported_at"`
+	RootOrganizationID uuid.UUID                 `json:"root_organization_id" validate:"required"`
+	Organizations      []OrganizationExportEntry `json:"organizations" validate:"required,min=1,dive"`
+}
+
+// OrganizationExportEntry is one organization of an export document.
+type OrganizationExportEntry struct {
+	ID               uuid.UUID                  `json:"id" validate:"required"`
+	ParentID         *uuid.UUID                 `json:"parent_id,omitempty"` // Empty for the exported root
+	Name             string                     `json:"name" validate:"required,max=100"`
+	Code             string                     `json:"code" validate:"required,min=4,max=16"`
+	OrganizationType string                     `json:"type" validate:"required,max=50"`
+	Description      string                     `json:"description,omitempty"`
+	IsActive         bool                       `json:"is_active"`
+	Settings         map[string]json.RawMessage `json:"settings,omitempty" swaggertype:"object"` // Values set on the organization itself, inherited ones are not repeated
+	Members          []OrganizationExportMember `json:"members,omitempty" validate:"dive"`
+}
+
+// OrganizationExportMember is a membership of an exported organization. Users are matched by email,
+// then username, when importing.
+type OrganizationExportMember struct {
+	Email    string `json:"email,omitempty" validate:"omitempty,email,max=255"`
+	Username string `json:"username,omitempty" validate:"required_without=Email,max=100"`
+	Role     string `json:"role,omitempty"` // Role name; the member role of the organization type when empty or unknown
+	IsActive bool   `json:"is_active"`
+}
+
+// ImportOrganizationTreeRequest defines the structure for importing an export document.
+type ImportOrganizationTreeRequest struct {
+	Document             OrganizationExportDocument `json:"document" validate:"required"`
+	ParentOrganizationID *uuid.UUID                 `json:"parent_organization_id,omitempty"` // Attach the imported root under this organization
+	ConflictStrategy     string                     `json:"conflict_strategy" validate:"required,oneof=skip overwrite rename" example:"skip"`
+	DryRun               bool                       `json:"dry_run"`
+}
+
+// OrganizationImportReport describes what an import does, or did when applied. With errors the import
+// is refused as a whole; warnings are applied as described.
+type OrganizationImportReport struct {
+	DryRun        bool                      `json:"dry_run"`
+	Applied       bool                      `json:"applied"`
+	Created       int                       `json:"created"`
+	Updated       int                       `json:"updated"`
+	Skipped       int                       `json:"skipped"`
+	Renamed       int                       `json:"renamed"`
+	Organizations []OrganizationImportEntry `json:"organizations"`
+	Warnings      []OrganizationImportIssue `json:"warnings,omitempty"`
+	Errors        []OrganizationImportIssue `json:"errors,omitempty"`
+}
+
+// OrganizationImportEntry is the outcome for one organization of the document.
+type OrganizationImportEntry struct {
+	SourceID   uuid.UUID                  `json:"source_id"`
+	TargetID   *uuid.UUID                 `json:"target_id,omitempty"` // Set for existing organizations, and for created ones once applied
+	Name       string                     `json:"name"`
+	SourceCode string                     `json:"source_code"`
+	Code       string                     `json:"code,omitempty"`          // Code in the target environment; generated codes are only known once applied
+	Action     string                     `json:"action" example:"create"` // create, rename, update, skip
+	Changes    []OrganizationImportChange `json:"changes,omitempty"`
+}
+
+// OrganizationImportChange is a difference the import applies to an organization.
+type OrganizationImportChange struct {
+	Field string `json:"field" example:"name"` // name, description, is_active, settings.<key>, member:<user>
+	From  string `json:"from,omitempty"`
+	To    string `json:"to,omitempty"`
+}
+
+// OrganizationImportIssue reports a problem with one organization of the document.
+type OrganizationImportIssue struct {
+	SourceID *uuid.UUID `json:"source_id,omitempty"`
+	Message  string     `json:"message"`
+}
@@ -0,0 +1,88 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CreateOrganizationTypeRequest defines the structure for registering a new organization type.
+type CreateOrganizationTypeRequest struct {
+	Name               string     `json:"name" validate:"required,min=2,max=50,lowercase"`
+	DisplayName        string     `json:"display_name" validate:"required,min=2,max=100"`
+	Description        string     `json:"description" validate:"max=500"`
+	AllowRoot          bool       `json:"allow_root"`                                  // Organizations of the type may have no parent
+	AllowedParentTypes []string   `json:"allowed_parent_types" validate:"dive,max=50"` // Types an organization of the type may be placed under
+	MaxDepth           *int       `json:"max_depth" validate:"omitempty,min=0"`        // Deepest level (roots are 0); omit for unlimited
+	MinRoleLevel       int        `json:"min_role_level" validate:"required,min=1,max=99"`
+	MaxRoleLevel       int        `json:"max_role_level" validate:"required,min=1,max=99,gtefield=MinRoleLevel"`
+	CreatorRoleID      *uuid.UUID `json:"creator_role_id,omitempty"` // Optional default role for organization creators
+	MemberRoleID       *uuid.UUID `json:"member_role_id,omitempty"`  // Optional default role for joining members
+}
+
+// UpdateOrganizationTypeRequest defines the structure for updating a registered organization type.
+type UpdateOrganizationTypeRequest struct {
+	DisplayName        string     `json:"display_name" validate:"required,min=2,max=100"`
+	Description        string     `json:"description" validate:"max=500"`
+	AllowRoot          bool       `json:"allow_root"`
+	AllowedParentTypes []string   `json:"allowed_parent_types" validate:"dive,max=50"`
+	MaxDepth           *int       `json:"max_depth" validate:"omitempty,min=0"`
+	MinRoleLevel       int        `json:"min_role_level" validate:"required,min=1,max=99"`
+	MaxRoleLevel       int        `json:"max_role_level" validate:"required,min=1,max=99,gtefield=MinRoleLevel"`
+	IsActive           bool       `json:"is_active"`
+	CreatorRoleID      *uuid.UUID `json:"creator_role_id,omitempty"` // Set together with member_role_id to change the default roles
+	MemberRoleID       *uuid.UUID `json:"member_role_id,omitempty"`
+}
+
+// OrganizationTypeResponse defines the structure for an organization type API response.
+type OrganizationTypeResponse struct {
+	Name               string     `json:"name"`
+	DisplayName        string     `json:"display_name"`
+	Description        string     `json:"description,omitempty"`
+	AllowRoot          bool       `json:"allow_root"`
+	AllowedParentTypes []string   `json:"allowed_parent_types"`
+	MaxDepth           *int       `json:"max_depth,omitempty"`
+	MinRoleLevel       int        `json:"min_role_level"`
+	MaxRoleLevel       int        `json:"max_role_level"`
+	IsActive           bool       `json:"is_active"`
+	CreatorRoleID      *uuid.UUID `json:"creator_role_id,omitempty"` // Configured default creator role
+	MemberRoleID       *uuid.UUID `json:"member_role_id,omitempty"`  // Configured default member role
+	OrganizationCount  int64      `json:"organization_count"`
+	CreatedAt          time.Time  `json:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
+}
+
+// OrganizationTypeAttributeRequest defines one custom attribute of an organization type's attribute schema.
+type OrganizationTypeAttributeRequest struct {
+	Key           string   `json:"key" validate:"required,max=50" example:"employee_number"` // Lowercase letters, digits and underscores
+	DisplayName   string   `json:"display_name" validate:"required,max=100" example:"Employee number"`
+	Description   string   `json:"description" validate:"max=500"`
+	Type          string   `json:"type" validate:"required,oneof=string number boolean" example:"string"`
+	Required      bool     `json:"required"`                                                     // Must be present whenever the attributes of a membership are set
+	AllowedValues []string `json:"allowed_values,omitempty" validate:"max=100,dive,max=100"`     // Accepted values of string attributes; empty accepts any
+	Pattern       string   `json:"pattern,omitempty" validate:"max=255" example:"^E-[0-9]{4,}$"` // Regular expression string values must match
+	MaxLength     *int     `json:"max_length,omitempty" validate:"omitempty,min=1,max=1000"`
+}
+
+// UpdateOrganizationTypeAttributesRequest defines the structure for replacing the attribute schema of an organization type.
+type UpdateOrganizationTypeAttributesRequest struct {
+	Attributes []OrganizationTypeAttributeRequest `json:"attributes" validate:"max=50,dive"`
+}
+
+// OrganizationTypeAttributeResponse defines the structure for a custom attribute of an organization type.
+type OrganizationTypeAttributeResponse struct {
+	Key           string   `json:"key"`
+	DisplayName   string   `json:"display_name"`
+	Description   string   `json:"description,omitempty"`
+	Type          string   `json:"type"`
+	Required      bool     `json:"required"`
+	AllowedValues []string `json:"allowed_values,omitempty"`
+	Pattern       string   `json:"pattern,omitempty"`
+	MaxLength     *int     `json:"max_length,omitempty"`
+}
+
+// OrganizationTypeAttributesResponse defines the structure for the attribute schema of an organization type.
+type OrganizationTypeAttributesResponse struct {
+	OrganizationType string                              `json:"organization_type"`
+	Attributes       []OrganizationTypeAttributeResponse `json:"attributes"`
+}
@@ -0,0 +1,81 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PersonalDataExport adalah DTO untuk bundel semua data pribadi yang disimpan tentang seorang user.
+type PersonalDataExport struct {
+	ExportedAt          time.Time                         `json:"exported_at" example:"2024-01-15T10:30:00Z"`
+	Profile             PersonalDataProfile               `json:"profile"`
+	Identities          []PersonalDataIdentity            `json:"identities"`
+	Memberships         []PersonalDataMembership          `json:"memberships"`
+	OrganizationHistory []UserOrganizationHistoryResponse `json:"organization_history"`
+	StatusHistory       []UserStatusHistoryResponse       `json:"status_history"`
+	Sessions            []PersonalDataSession             `json:"sessions"`
+	AuditEvents         []PersonalDataAuditEvent          `json:"audit_events"`
+}
+
+// PersonalDataProfile adalah DTO untuk data profil di dalam ekspor data pribadi.
+type PersonalDataProfile struct {
+	ID             uuid.UUID  `json:"id" example:"a1b2c3d4-e5f6-7890-1234-567890abcdef"`
+	Username       string     `json:"username" example:"johndoe"`
+	Email          string     `json:"email" example:"john.doe@example.com"`
+	AvatarURL      string     `json:"avatar_url,omitempty" example:"https://example.com/avatar.png"`
+	DisplayName    string     `json:"display_name,omitempty" example:"John Doe"`
+	Phone          string     `json:"phone,omitempty" example:"+6281234567890"`
+	Locale         string     `json:"locale,omitempty" example:"id-ID"`
+	Timezone       string     `json:"timezone,omitempty" example:"Asia/Jakarta"`
+	JobTitle       string     `json:"job_title,omitempty" example:"Store Supervisor"`
+	Role           string     `json:"role,omitempty" example:"user"`
+	Status         string     `json:"status" example:"active"`
+	StatusReason   string     `json:"status_reason,omitempty"`
+	SuspendedUntil *time.Time `json:"suspended_until,omitempty"`
+	CreatedAt      time.Time  `json:"created_at" example:"2024-01-01T00:00:00Z"`
+	UpdatedAt      time.Time  `json:"updated_at" example:"2024-01-10T00:00:00Z"`
+}
+
+// PersonalDataIdentity adalah DTO untuk satu cara login user di dalam ekspor data pribadi.
+type PersonalDataIdentity struct {
+	Provider string `json:"provider" example:"google"`
+	Subject  string `json:"subject" example:"110539596352895004866"` // Username for local accounts, provider user ID otherwise
+	Email    string `json:"email,omitempty" example:"john.doe@example.com"`
+}
+
+// PersonalDataMembership adalah DTO untuk satu keanggotaan organisasi di dalam ekspor data pribadi.
+type PersonalDataMembership struct {
+	OrganizationID   uuid.UUID       `json:"organization_id" example:"b1c2d3e4-f5g6-7890-1234-567890abcdef"`
+	OrganizationName string          `json:"organization_name" example:"Acme Store"`
+	Role             string          `json:"role,omitempty" example:"member"`
+	JoinedAt         time.Time       `json:"joined_at" example:"2024-01-01T00:00:00Z"`
+	IsActive         bool            `json:"is_active" example:"true"`
+	Attributes       json.RawMessage `json:"attributes,omitempty" swaggertype:"object"` // Custom attributes defined by the organization type
+}
+
+// PersonalDataSession adalah DTO untuk satu sesi login aktif di dalam ekspor data pribadi.
+// Token tidak pernah diekspor, hanya sidik jarinya.
+type PersonalDataSession struct {
+	ID        string     `json:"id" example:"3f2a9c1b7d4e"` // Fingerprint of the refresh token
+	ExpiresAt *time.Time `json:"expires_at,omitempty" example:"2024-01-22T10:30:00Z"`
+}
+
+// PersonalDataAuditEvent adalah DTO untuk satu catatan yang dibuat oleh atau tentang user di dalam ekspor data pribadi.
+type PersonalDataAuditEvent struct {
+	Source         string     `json:"source" example:"change_requests"` // Table the record comes from
+	ID             uuid.UUID  `json:"id" example:"e4f5g6h7-i8j9-0123-4567-890123defghi"`
+	Action         string     `json:"action" example:"user.delete"`
+	Role           string     `json:"role" example:"actor"` // actor when the user performed the action, subject when it concerned them
+	OrganizationID *uuid.UUID `json:"organization_id,omitempty" example:"b1c2d3e4-f5g6-7890-1234-567890abcdef"`
+	TargetID       *uuid.UUID `json:"target_id,omitempty" example:"a1b2c3d4-e5f6-7890-1234-567890abcdef"`
+	Status         string     `json:"status,omitempty" example:"executed"`
+	Details        string     `json:"details,omitempty" example:"Delete user johndoe"`
+	OccurredAt     time.Time  `json:"occurred_at" example:"2024-01-15T10:30:00Z"`
+}
+
+// EraseUserRequest adalah DTO untuk meminta penghapusan data pribadi seorang user.
+type EraseUserRequest struct {
+	Reason string `json:"reason" validate:"required,max=500" example:"Erasure request from the data subject"`
+}
@@ -6,18 +6,18 @@ import "github.com/google/uuid"
 type CreateRoleRequest struct {
 	Name              string   `json:"name" validate:"required,min=3,max=50"`
 	Description       string   `json:"description" validate:"max=255"`
-	Level             int      `json:"level" validate:"required,min=0,max=99"`                                            // Level 100 reserved for superadmin
-	PredefinedName    string   `json:"predefined_name" validate:"required,min=3,max=50"`                                  // NEW: Android-style name
-	OrganizationTypes []string `json:"organization_types" validate:"omitempty,dive,oneof=platform holding company store"` // Organization context
+	Level             int      `json:"level" validate:"required,min=0,max=99"`              // Level 100 reserved for superadmin
+	PredefinedName    string   `json:"predefined_name" validate:"required,min=3,max=50"`    // NEW: Android-style name
+	OrganizationTypes []string `json:"organization_types" validate:"omitempty,dive,max=50"` // Organization context
 }
 
 // UpdateRoleRequest defines the structure for updating an existing role.
 type UpdateRoleRequest struct {
 	Name              string   `json:"name" validate:"required,min=3,max=50"`
 	Description       string   `json:"description" validate:"max=255"`
-	Level             int      `json:"level" validate:"required,min=0,max=99"`                                            // Level 100 reserved for superadmin
-	PredefinedName    string   `json:"predefined_name" validate:"required,min=3,max=50"`                                  // Android-style name
-	OrganizationTypes []string `json:"organization_types" validate:"omitempty,dive,oneof=platform holding company store"` // Organization context
+	Level             int      `json:"level" validate:"required,min=0,max=99"`              // Level 100 reserved for superadmin
+	PredefinedName    string   `json:"predefined_name" validate:"required,min=3,max=50"`    // Android-style name
+	OrganizationTypes []string `json:"organization_types" validate:"omitempty,dive,max=50"` // Organization context
 }
 
 // RoleResponse defines the structure for a role API response.
@@ -39,6 +39,23 @@ type UpdateRolePermissionsRequest struct {
 	PermissionNames []string `json:"permission_names" validate:"dive,min=1"`
 }
 
+// UpdateOrganizationTypeDefaultRolesRequest defines the structure for configuring the default roles of an organization type.
+type UpdateOrganizationTypeDefaultRolesRequest struct {
+	CreatorRoleID uuid.UUID `json:"creator_role_id" validate:"required"` // Granted to whoever creates an organization of the type
+	MemberRoleID  uuid.UUID `json:"member_role_id" validate:"required"`  // Granted to users who join an organization of the type
+}
+
+// OrganizationTypeDefaultRolesResponse defines the structure for an organization type's default roles.
+type OrganizationTypeDefaultRolesResponse struct {
+	OrganizationType string        `json:"organization_type"`
+	CreatorRoleID    *uuid.UUID    `json:"creator_role_id"`        // Configured creator role, nil when not configured
+	MemberRoleID     *uuid.UUID    `json:"member_role_id"`         // Configured member role, nil when not configured
+	CreatorRole      *RoleResponse `json:"creator_role,omitempty"` // Role actually granted to creators
+	MemberRole       *RoleResponse `json:"member_role,omitempty"`  // Role actually granted to joining members
+	UpdatedBy        *uuid.UUID    `json:"updated_by,omitempty"`
+	UpdatedAt        *string       `json:"updated_at,omitempty"`
+}
+
 // CreateRoleApprovalRequest defines the structure for requesting role creation approval.
 type CreateRoleApprovalRequest struct {
 	RequestedRoleName string `json:"requested_role_name" validate:"required,min=3,max=50"`
@@ -58,6 +75,8 @@ type RoleApprovalResponse struct {
 	Approver          *string    `json:"approver,omitempty"` // Username of approver
 	Status            string     `json:"status"`
 	Reason            string     `json:"reason"`
+	CreatedRoleID     *uuid.UUID `json:"created_role_id,omitempty"` // Role created on approval
+	DecidedAt         *string    `json:"decided_at,omitempty"`
 	CreatedAt         string     `json:"created_at"`
 	UpdatedAt         string     `json:"updated_at"`
 }
@@ -68,6 +87,11 @@ type ApprovalDecisionRequest struct {
 	Reason string `json:"reason" validate:"max=255"`
 }
 
+// CancelRoleApprovalRequest defines the structure for cancelling a pending role request.
+type CancelRoleApprovalRequest struct {
+	Reason string `json:"reason" validate:"max=255"`
+}
+
 // PredefinedRoleOption defines available predefined role names and levels.
 type PredefinedRoleOption struct {
 	Name        string `json:"name"`
@@ -102,7 +126,7 @@ type UpdatePermissionRequest struct {
 
 // GetRolesForOrganizationRequest defines the structure for getting roles suitable for an organization type.
 type GetRolesForOrganizationRequest struct {
-	OrganizationType string `query:"organization_type" validate:"required,oneof=platform holding company store"`
+	OrganizationType string `query:"organization_type" validate:"required,max=50"`
 }
 
 // OrganizationRoleAssignmentRequest defines the structure for assigning role with organization context.
@@ -0,0 +1,29 @@
+package dto
+
+// SearchRequest represents the query parameters of the unified search
+type SearchRequest struct {
+	Query string   `query:"q" validate:"required,min=2,max=100"`
+	Types []string `query:"type" validate:"dive,oneof=users organizations"` // Result types to include; all when empty
+	Limit int      `query:"limit" validate:"min=0,max=50"`
+}
+
+// SearchResponse groups the ranked results of a search by type. Types the caller may not search are omitted.
+type SearchResponse struct {
+	Query         string                     `json:"query" example:"jakarta"`
+	Users         []UserSearchResult         `json:"users,omitempty"`
+	Organizations []OrganizationSearchResult `json:"organizations,omitempty"`
+}
+
+// UserSearchResult is a user matching a search with its relevance and highlighted fields
+type UserSearchResult struct {
+	User       UserResponse      `json:"user"`
+	Score      float64           `json:"score" example:"1.25"`
+	Highlights map[string]string `json:"highlights,omitempty"` // Field to value with matches wrapped in <mark>, HTML escaped
+}
+
+// OrganizationSearchResult is an organization matching a search with its relevance and highlighted fields
+type OrganizationSearchResult struct {
+	Organization OrganizationResponse `json:"organization"`
+	Score        float64              `json:"score" example:"0.87"`
+	Highlights   map[string]string    `json:"highlights,omitempty"` // Field to value with matches wrapped in <mark>, HTML escaped
+}
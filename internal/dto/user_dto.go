@@ -1,6 +1,11 @@
 package dto
 
-import "github.com/google/uuid"
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
 
 // UserResponse adalah DTO untuk data publik seorang user.
 // Ini menyembunyikan detail implementasi seperti password hash.
@@ -12,7 +17,16 @@ type UserResponse struct {
 	RoleID         *uuid.UUID `json:"role_id,omitempty" example:"b1c2d3e4-f5g6-7890-1234-567890abcdef"`
 	OrganizationID *uuid.UUID `json:"organization_id,omitempty" example:"c1d2e3f4-g5h6-7890-1234-567890abcdef"`
 	AvatarURL      string     `json:"avatar_url" example:"https://example.com/avatar.png"`
+	DisplayName    string     `json:"display_name,omitempty" example:"John Doe"`
+	Phone          string     `json:"phone,omitempty" example:"+6281234567890"`
+	Locale         string     `json:"locale,omitempty" example:"id-ID"`
+	Timezone       string     `json:"timezone,omitempty" example:"Asia/Jakarta"`
+	JobTitle       string     `json:"job_title,omitempty" example:"Store Supervisor"`
 	AuthProvider   string     `json:"auth_provider" example:"local"` // Authentication method
+	Status         string     `json:"status" example:"active"`       // active, suspended or pending_activation
+	StatusReason   string     `json:"status_reason,omitempty" example:"Repeated policy violations"`
+	SuspendedUntil *time.Time `json:"suspended_until,omitempty" example:"2024-02-01T00:00:00Z"`
+	CreatedAt      time.Time  `json:"created_at" example:"2024-01-01T00:00:00Z"`
 }
 
 // CreateUserRequest adalah DTO untuk membuat user baru.
@@ -21,8 +35,9 @@ type CreateUserRequest struct {
 	Email        string    `json:"email" validate:"required,email" example:"new.user@example.com"`
 	Password     string    `json:"password" validate:"omitempty,min=8" example:"strongpassword123"` // Optional for OAuth users
 	RoleID       uuid.UUID `json:"role_id" validate:"required" example:"b1c2d3e4-f5g6-7890-1234-567890abcdef"`
-	AuthProvider string    `json:"auth_provider" validate:"omitempty,oneof=local google" example:"local"` // Authentication method
-	GoogleID     *string   `json:"google_id" validate:"omitempty" example:"110539596352895004866"`        // Google OAuth ID
+	AuthProvider string    `json:"auth_provider" validate:"omitempty,oneof=local google" example:"local"`        // Authentication method
+	GoogleID     *string   `json:"google_id" validate:"omitempty" example:"110539596352895004866"`               // Google OAuth ID
+	Status       string    `json:"status" validate:"omitempty,oneof=active pending_activation" example:"active"` // Defaults to active
 }
 
 // UpdateUserRequest adalah DTO untuk memperbarui user yang ada.
@@ -38,6 +53,57 @@ type UpdateUserResponse struct {
 	Message string       `json:"message" example:"User updated successfully"`
 }
 
+// UserProfileResponse adalah DTO untuk profil seorang user.
+type UserProfileResponse struct {
+	UserID      uuid.UUID `json:"user_id" example:"a1b2c3d4-e5f6-7890-1234-567890abcdef"`
+	Username    string    `json:"username" example:"johndoe"`
+	Email       string    `json:"email" example:"john.doe@example.com"`
+	AvatarURL   string    `json:"avatar_url,omitempty" example:"https://example.com/avatar.png"`
+	DisplayName string    `json:"display_name" example:"John Doe"`
+	Phone       string    `json:"phone" example:"+6281234567890"`
+	Locale      string    `json:"locale" example:"id-ID"`
+	Timezone    string    `json:"timezone" example:"Asia/Jakarta"`
+	JobTitle    string    `json:"job_title" example:"Store Supervisor"`
+	UpdatedAt   time.Time `json:"updated_at" example:"2024-01-10T00:00:00Z"`
+}
+
+// UpdateUserProfileRequest adalah DTO untuk memperbarui profil user secara parsial.
+// Field yang tidak dikirim tidak berubah; string kosong menghapus nilainya.
+type UpdateUserProfileRequest struct {
+	DisplayName *string `json:"display_name,omitempty" validate:"omitempty,max=100" example:"John Doe"`
+	Phone       *string `json:"phone,omitempty" validate:"omitempty,max=32" example:"+6281234567890"`  // E.164 format
+	Locale      *string `json:"locale,omitempty" validate:"omitempty,max=35" example:"id-ID"`          // Language tag
+	Timezone    *string `json:"timezone,omitempty" validate:"omitempty,max=64" example:"Asia/Jakarta"` // IANA time zone
+	JobTitle    *string `json:"job_title,omitempty" validate:"omitempty,max=100" example:"Store Supervisor"`
+}
+
+// UpdateUserOrganizationAttributesRequest adalah DTO untuk mengganti custom attributes keanggotaan user
+// di sebuah organisasi. Nilai divalidasi terhadap skema atribut tipe organisasinya.
+type UpdateUserOrganizationAttributesRequest struct {
+	Attributes map[string]json.RawMessage `json:"attributes" validate:"required" swaggertype:"object"`
+}
+
+// ListUsersRequest adalah DTO untuk filter, urutan dan paginasi daftar user.
+// Sort berisi field dipisah koma, awalan '-' untuk urutan menurun, misalnya "-created_at,username".
+// Cursor berasal dari next_cursor halaman sebelumnya dan menggantikan page.
+// Attributes berisi filter custom attribute dengan format "key:value", misalnya "employee_number:E-1024".
+type ListUsersRequest struct {
+	Search          string      `query:"search" validate:"max=100"`
+	RoleIDs         []uuid.UUID `query:"role_id"`
+	MinLevel        *int        `query:"min_level" validate:"omitempty,min=0"`
+	MaxLevel        *int        `query:"max_level" validate:"omitempty,min=0"`
+	AuthProviders   []string    `query:"auth_provider" validate:"dive,oneof=local google"`
+	Statuses        []string    `query:"status" validate:"dive,oneof=active suspended pending_activation"`
+	OrganizationIDs []uuid.UUID `query:"organization_id"`
+	Attributes      []string    `query:"attribute" validate:"max=10,dive,max=200"`
+	CreatedAfter    *time.Time  `query:"created_after"`
+	CreatedBefore   *time.Time  `query:"created_before"`
+	Sort            string      `query:"sort" validate:"max=200"`
+	Cursor          string      `query:"cursor" validate:"max=2048"`
+	Page            int         `query:"page" validate:"min=0"`
+	Limit           int         `query:"limit" validate:"min=0,max=100"`
+}
+
 // PagedUserResponse adalah DTO untuk response daftar user dengan metadata paginasi.
 type PagedUserResponse struct {
 	Users      []UserResponse `json:"users"`
@@ -45,6 +111,41 @@ type PagedUserResponse struct {
 	Limit      int            `json:"limit" example:"10"`
 	Total      int64          `json:"total" example:"100"`
 	TotalPages int            `json:"total_pages" example:"10"`
+	NextCursor string         `json:"next_cursor,omitempty" example:"WyIyMDI0LTAxLTAxVDAwOjAwOjAwWiJd"` // Empty on the last page
+}
+
+// SuspendUserRequest adalah DTO untuk menangguhkan akun user.
+// Tanpa until, penangguhan berlaku sampai user diaktifkan kembali.
+type SuspendUserRequest struct {
+	Reason string     `json:"reason" validate:"required,max=500" example:"Repeated policy violations"`
+	Until  *time.Time `json:"until,omitempty" example:"2024-02-01T00:00:00Z"`
+}
+
+// ReactivateUserRequest adalah DTO untuk mengaktifkan akun user yang ditangguhkan atau belum diaktifkan.
+type ReactivateUserRequest struct {
+	Reason string `json:"reason" validate:"max=500" example:"Appeal accepted"`
+}
+
+// UserStatusHistoryResponse adalah DTO untuk satu perubahan status akun user.
+type UserStatusHistoryResponse struct {
+	ID             uuid.UUID  `json:"id" example:"e4f5g6h7-i8j9-0123-4567-890123defghi"`
+	UserID         uuid.UUID  `json:"user_id" example:"a1b2c3d4-e5f6-7890-1234-567890abcdef"`
+	PreviousStatus string     `json:"previous_status" example:"active"`
+	NewStatus      string     `json:"new_status" example:"suspended"`
+	Reason         string     `json:"reason,omitempty" example:"Repeated policy violations"`
+	SuspendedUntil *time.Time `json:"suspended_until,omitempty" example:"2024-02-01T00:00:00Z"`
+	ActionBy       *uuid.UUID `json:"action_by,omitempty" example:"f5g6h7i8-j9k0-1234-5678-901234efghij"` // Empty when a suspension expired
+	ActionByName   string     `json:"action_by_name,omitempty" example:"admin"`
+	ActionAt       string     `json:"action_at" example:"2024-01-15T10:30:00Z"`
+}
+
+// PagedUserStatusHistoryResponse adalah DTO untuk paginated riwayat status akun user.
+type PagedUserStatusHistoryResponse struct {
+	History    []UserStatusHistoryResponse `json:"history"`
+	Page       int                         `json:"page" example:"1"`
+	Limit      int                         `json:"limit" example:"10"`
+	Total      int64                       `json:"total" example:"3"`
+	TotalPages int                         `json:"total_pages" example:"1"`
 }
 
 // User-Organization Management DTOs
@@ -55,12 +156,14 @@ type AssignUserToOrganizationRequest struct {
 	OrganizationID uuid.UUID  `json:"organization_id" validate:"required" example:"b1c2d3e4-f5g6-7890-1234-567890abcdef"`
 	RoleID         *uuid.UUID `json:"role_id" example:"c1d2e3f4-g5h6-7890-1234-567890abcdef"`
 	IsActive       bool       `json:"is_active" example:"true"`
+	Reason         string     `json:"reason,omitempty" validate:"max=500" example:"Transferred from the Bandung store"`
 }
 
 // UpdateUserOrganizationRequest adalah DTO untuk update user organization assignment.
 type UpdateUserOrganizationRequest struct {
 	RoleID   *uuid.UUID `json:"role_id" example:"c1d2e3f4-g5h6-7890-1234-567890abcdef"`
 	IsActive bool       `json:"is_active" example:"true"`
+	Reason   string     `json:"reason,omitempty" validate:"max=500" example:"Promoted to store manager"`
 }
 
 // BulkAssignUsersToOrganizationRequest adalah DTO untuk bulk assign users ke organization.
@@ -86,16 +189,19 @@ type BulkAssignError struct {
 }
 
 // UserOrganizationHistoryResponse adalah DTO untuk organization assignment history.
+// ActionBy kosong untuk perubahan yang dilakukan sistem.
 type UserOrganizationHistoryResponse struct {
-	ID             uuid.UUID `json:"id" example:"e4f5g6h7-i8j9-0123-4567-890123defghi"`
-	UserID         uuid.UUID `json:"user_id" example:"a1b2c3d4-e5f6-7890-1234-567890abcdef"`
-	OrganizationID uuid.UUID `json:"organization_id" example:"b1c2d3e4-f5g6-7890-1234-567890abcdef"`
-	Action         string    `json:"action" example:"assigned"`
-	PreviousRole   string    `json:"previous_role,omitempty" example:"member"`
-	NewRole        string    `json:"new_role,omitempty" example:"admin"`
-	ActionBy       uuid.UUID `json:"action_by" example:"f5g6h7i8-j9k0-1234-5678-901234efghij"`
-	ActionAt       string    `json:"action_at" example:"2024-01-15T10:30:00Z"`
-	Reason         string    `json:"reason,omitempty" example:"Promoted to admin role"`
+	ID             uuid.UUID  `json:"id" example:"e4f5g6h7-i8j9-0123-4567-890123defghi"`
+	UserID         uuid.UUID  `json:"user_id" example:"a1b2c3d4-e5f6-7890-1234-567890abcdef"`
+	OrganizationID uuid.UUID  `json:"organization_id" example:"b1c2d3e4-f5g6-7890-1234-567890abcdef"`
+	Action         string     `json:"action" example:"role_updated"`
+	PreviousRole   string     `json:"previous_role,omitempty" example:"member"`
+	NewRole        string     `json:"new_role,omitempty" example:"admin"`
+	PreviousStatus *bool      `json:"previous_status,omitempty" example:"true"`
+	NewStatus      *bool      `json:"new_status,omitempty" example:"true"`
+	ActionBy       *uuid.UUID `json:"action_by,omitempty" example:"f5g6h7i8-j9k0-1234-5678-901234efghij"`
+	ActionAt       string     `json:"action_at" example:"2024-01-15T10:30:00Z"`
+	Reason         string     `json:"reason,omitempty" example:"Promoted to admin role"`
 }
 
 // PagedUserOrganizationResponse adalah DTO untuk paginated user-organization relationships.
@@ -115,15 +221,3 @@ type PagedUserOrganizationHistoryResponse struct {
 	Total      int64                             `json:"total" example:"25"`
 	TotalPages int                               `json:"total_pages" example:"3"`
 }
-
-// LogUserOrganizationActionRequest adalah DTO untuk manually logging user organization actions.
-type LogUserOrganizationActionRequest struct {
-	UserID         uuid.UUID `json:"user_id" validate:"required" example:"a1b2c3d4-e5f6-7890-1234-567890abcdef"`
-	OrganizationID uuid.UUID `json:"organization_id" validate:"required" example:"b1c2d3e4-f5g6-7890-1234-567890abcdef"`
-	Action         string    `json:"action" validate:"required,oneof=assigned removed role_updated status_changed" example:"assigned"`
-	PreviousRole   string    `json:"previous_role,omitempty" example:"user"`
-	NewRole        string    `json:"new_role,omitempty" example:"admin"`
-	PreviousStatus *bool     `json:"previous_status,omitempty" example:"false"`
-	NewStatus      *bool     `json:"new_status,omitempty" example:"true"`
-	Reason         string    `json:"reason,omitempty" example:"Manual role update by administrator"`
-}
@@ -0,0 +1,33 @@
+package dto
+
+import "github.com/google/uuid"
+
+// UserImportReport is the per-row outcome of a user import. Dry runs validate every row without
+// changing anything; applied imports create the valid rows in batches.
+type UserImportReport struct {
+	ImportID      uuid.UUID             `json:"import_id"` // Identifies the downloadable result file
+	ResultFileURL string                `json:"result_file_url"`
+	DryRun        bool                  `json:"dry_run"`
+	Applied       bool                  `json:"applied"`
+	TotalRows     int                   `json:"total_rows"`
+	ValidRows     int                   `json:"valid_rows"`
+	InvalidRows   int                   `json:"invalid_rows"`
+	Created       int                   `json:"created"`
+	Invited       int                   `json:"invited"`
+	Failed        int                   `json:"failed"`
+	Rows          []UserImportRowReport `json:"rows"`
+}
+
+// UserImportRowReport is the outcome of one data row of an import file
+type UserImportRowReport struct {
+	Row              int        `json:"row" example:"2"` // Line of the file, the header being line 1
+	Username         string     `json:"username,omitempty"`
+	Email            string     `json:"email,omitempty"`
+	Role             string     `json:"role"`
+	OrganizationCode string     `json:"organization_code,omitempty"`
+	Action           string     `json:"action" example:"create"`
+	Status           string     `json:"status" example:"valid"`
+	UserID           *uuid.UUID `json:"user_id,omitempty"`
+	InvitationURL    string     `json:"invitation_url,omitempty"`
+	Errors           []string   `json:"errors,omitempty"`
+}
@@ -0,0 +1,155 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"go-base-project/internal/apperror"
+	"go-base-project/internal/constant"
+	"go-base-project/internal/dto"
+	"go-base-project/internal/service"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// ApprovalHandler handles HTTP requests related to change request approvals.
+type ApprovalHandler struct {
+	approvalService service.ApprovalServiceInterface
+}
+
+// NewApprovalHandler creates a new instance of ApprovalHandler.
+func NewApprovalHandler(approvalService service.ApprovalServiceInterface) *ApprovalHandler {
+	return &ApprovalHandler{
+		approvalService: approvalService,
+	}
+}
+
+// ListChangeRequests handles listing change requests.
+// @Summary      List change requests
+// @Description  Retrieves change requests for sensitive admin actions, optionally filtered by status. Requires 'approvals:read' permission.
+// @Tags         Admin, Approval
+// @Produce      json
+// @Param        status query string false "Filter by status" Enums(pending, executing, executed, rejected, cancelled, expired)
+// @Param        page query int false "Page number" default(1)
+// @Param        limit query int false "Items per page" default(10)
+// @Security     BearerAuth
+// @Success      200 {object} dto.PagedChangeRequestResponse "Change requests retrieved successfully"
+// @Failure      400 {object} apperror.AppError "Invalid status"
+// @Failure      403 {object} apperror.AppError "Insufficient permissions"
+// @Failure      500 {object} apperror.AppError "Internal server error"
+// @Router       /admin/approvals [get]
+func (h *ApprovalHandler) ListChangeRequests(c echo.Context) error {
+	page, _ := strconv.Atoi(c.QueryParam("page"))
+	limit, _ := strconv.Atoi(c.QueryParam("limit"))
+
+	changeRequests, err := h.approvalService.ListChangeRequests(c.Request().Context(), c.QueryParam("status"), page, limit)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, changeRequests)
+}
+
+// GetChangeRequest handles retrieving a single change request.
+// @Summary      Get a change request
+// @Description  Retrieves a change request with its approver decisions. Requires 'approvals:read' permission.
+// @Tags         Admin, Approval
+// @Produce      json
+// @Param        id path string true "Change Request ID" format(uuid)
+// @Security     BearerAuth
+// @Success      200 {object} dto.ChangeRequestResponse "Change request retrieved successfully"
+// @Failure      400 {object} apperror.AppError "Invalid change request ID format"
+// @Failure      403 {object} apperror.AppError "Insufficient permissions"
+// @Failure      404 {object} apperror.AppError "Change request not found"
+// @Failure      500 {object} apperror.AppError "Internal server error"
+// @Router       /admin/approvals/{id} [get]
+func (h *ApprovalHandler) GetChangeRequest(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return apperror.NewAppError(http.StatusBadRequest, "Invalid change request ID format", err)
+	}
+
+	changeRequest, err := h.approvalService.GetChangeRequest(c.Request().Context(), id)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, changeRequest)
+}
+
+// DecideChangeRequest handles approving or rejecting a change request.
+// @Summary      Approve or reject a change request
+// @Description  Records the caller's decision on a pending change request. The approval that reaches the required count executes the action; if the action fails, the decision is rolled back and the request stays pending. Requesters cannot decide on their own requests. Requires 'approvals:decide' permission.
+// @Tags         Admin, Approval
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "Change Request ID" format(uuid)
+// @Param        request body dto.ChangeRequestDecisionRequest true "Decision"
+// @Security     BearerAuth
+// @Success      200 {object} dto.ChangeRequestResponse "Decision recorded"
+// @Failure      400 {object} apperror.AppError "Invalid request payload"
+// @Failure      403 {object} apperror.AppError "Not allowed to decide on this request"
+// @Failure      404 {object} apperror.AppError "Change request not found"
+// @Failure      409 {object} apperror.AppError "Request no longer pending or already decided"
+// @Failure      500 {object} apperror.AppError "Internal server error"
+// @Router       /admin/approvals/{id}/decision [post]
+func (h *ApprovalHandler) DecideChangeRequest(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return apperror.NewAppError(http.StatusBadRequest, "Invalid change request ID format", err)
+	}
+
+	var req dto.ChangeRequestDecisionRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, constant.ErrMsgInvalidRequestFormat)
+	}
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	approverID, ok := c.Get(constant.UserIDKey).(uuid.UUID)
+	if !ok {
+		return apperror.NewAppError(http.StatusUnauthorized, "User ID not found in context", nil)
+	}
+
+	changeRequest, err := h.approvalService.DecideChangeRequest(c.Request().Context(), id, req, approverID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, changeRequest)
+}
+
+// CancelChangeRequest handles withdrawing a pending change request.
+// @Summary      Cancel a change request
+// @Description  Cancels a pending change request. Only the requester can cancel their own request.
+// @Tags         Admin, Approval
+// @Produce      json
+// @Param        id path string true "Change Request ID" format(uuid)
+// @Security     BearerAuth
+// @Success      200 {object} dto.ChangeRequestResponse "Change request cancelled"
+// @Failure      400 {object} apperror.AppError "Invalid change request ID format"
+// @Failure      403 {object} apperror.AppError "Not the requester"
+// @Failure      404 {object} apperror.AppError "Change request not found"
+// @Failure      409 {object} apperror.AppError "Request no longer pending"
+// @Failure      500 {object} apperror.AppError "Internal server error"
+// @Router       /admin/approvals/{id}/cancel [post]
+func (h *ApprovalHandler) CancelChangeRequest(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return apperror.NewAppError(http.StatusBadRequest, "Invalid change request ID format", err)
+	}
+
+	userID, ok := c.Get(constant.UserIDKey).(uuid.UUID)
+	if !ok {
+		return apperror.NewAppError(http.StatusUnauthorized, "User ID not found in context", nil)
+	}
+
+	changeRequest, err := h.approvalService.CancelChangeRequest(c.Request().Context(), id, userID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, changeRequest)
+}
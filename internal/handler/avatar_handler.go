@@ -0,0 +1,162 @@
+package handler
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"go-base-project/internal/apperror"
+	"go-base-project/internal/constant"
+	"go-base-project/internal/service"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// AvatarHandler handles HTTP requests for uploading, removing and serving user avatars.
+type AvatarHandler struct {
+	avatarService service.AvatarServiceInterface
+	maxUploadSize int64
+}
+
+// NewAvatarHandler creates a new instance of AvatarHandler.
+func NewAvatarHandler(avatarService service.AvatarServiceInterface, maxUploadSize int64) *AvatarHandler {
+	return &AvatarHandler{
+		avatarService: avatarService,
+		maxUploadSize: maxUploadSize,
+	}
+}
+
+// UploadMyAvatar handles the current user uploading a new avatar.
+// @Summary      Upload my avatar
+// @Description  Replaces the avatar of the caller. The image type is detected from the content, not the file name; JPEG, PNG and GIF images of at least 64x64 pixels are accepted. The image is cropped to a centered square and stored as JPEG in 64, 128 and 256 pixels. The returned avatar_url points to the 256 pixel image, or with signed storage URLs enabled to GET /avatars/{id}, which redirects to a short-lived URL.
+// @Tags         Auth
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        file formData file true "JPEG, PNG or GIF image (max 5 MB by default)"
+// @Security     BearerAuth
+// @Success      200 {object} dto.UserProfileResponse "Profile with the new avatar"
+// @Failure      400 {object} apperror.AppError "Missing file or unsupported image"
+// @Failure      401 {object} apperror.AppError "Unauthorized"
+// @Failure      413 {object} apperror.AppError "Image too large"
+// @Failure      500 {object} apperror.AppError "Internal server error"
+// @Router       /auth/me/avatar [put]
+func (h *AvatarHandler) UploadMyAvatar(c echo.Context) error {
+	userID, ok := c.Get(constant.UserIDKey).(uuid.UUID)
+	if !ok {
+		return apperror.NewAppError(http.StatusUnauthorized, "User ID not found in context", nil)
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return apperror.NewAppError(http.StatusBadRequest, "An image file is required", err)
+	}
+	if fileHeader.Size > h.maxUploadSize {
+		return apperror.NewAppError(http.StatusRequestEntityTooLarge, fmt.Sprintf("The avatar must not exceed %d KB", h.maxUploadSize>>10), nil)
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		return apperror.NewAppError(http.StatusBadRequest, "Failed to read the image file", err)
+	}
+	defer file.Close()
+	data, err := io.ReadAll(io.LimitReader(file, h.maxUploadSize))
+	if err != nil {
+		return apperror.NewAppError(http.StatusBadRequest, "Failed to read the image file", err)
+	}
+
+	profile, err := h.avatarService.UploadAvatar(c.Request().Context(), userID, data)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, profile)
+}
+
+// DeleteMyAvatar handles the current user removing their avatar.
+// @Summary      Remove my avatar
+// @Description  Removes the avatar of the caller, including a picture taken over from Google.
+// @Tags         Auth
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} dto.UserProfileResponse "Profile without avatar"
+// @Failure      401 {object} apperror.AppError "Unauthorized"
+// @Failure      500 {object} apperror.AppError "Internal server error"
+// @Router       /auth/me/avatar [delete]
+func (h *AvatarHandler) DeleteMyAvatar(c echo.Context) error {
+	userID, ok := c.Get(constant.UserIDKey).(uuid.UUID)
+	if !ok {
+		return apperror.NewAppError(http.StatusUnauthorized, "User ID not found in context", nil)
+	}
+
+	profile, err := h.avatarService.DeleteAvatar(c.Request().Context(), userID, userID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, profile)
+}
+
+// DeleteUserAvatar handles an administrator removing the avatar of a user, e.g. an inappropriate image.
+// @Summary      Remove a user's avatar
+// @Description  Removes the avatar of a user. The caller needs a higher role level than the user. Requires 'users:update' permission.
+// @Tags         Admin, User Management
+// @Produce      json
+// @Param        id path string true "User ID" format(uuid)
+// @Security     BearerAuth
+// @Success      200 {object} dto.UserProfileResponse "Profile without avatar"
+// @Failure      400 {object} apperror.AppError "Invalid user ID"
+// @Failure      403 {object} apperror.AppError "Insufficient permissions or authority"
+// @Failure      404 {object} apperror.AppError "User not found"
+// @Failure      500 {object} apperror.AppError "Internal server error"
+// @Router       /admin/users/{id}/avatar [delete]
+func (h *AvatarHandler) DeleteUserAvatar(c echo.Context) error {
+	currentUserID, ok := c.Get(constant.UserIDKey).(uuid.UUID)
+	if !ok {
+		return apperror.NewAppError(http.StatusUnauthorized, "User ID not found in context", nil)
+	}
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return apperror.NewAppError(http.StatusBadRequest, constant.ErrMsgInvalidUserID, err)
+	}
+
+	profile, err := h.avatarService.DeleteAvatar(c.Request().Context(), userID, currentUserID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, profile)
+}
+
+// GetAvatar handles the stable avatar URL of a user by redirecting to the image.
+// @Summary      Get a user's avatar
+// @Description  Redirects to the avatar of a user in the smallest standard size (64, 128 or 256 pixels) of at least the requested size. With signed storage URLs the target is only valid for a short time, so the redirect itself is cached briefly. No authentication is required, so the URL works in image tags.
+// @Tags         Users
+// @Param        id path string true "User ID" format(uuid)
+// @Param        size query int false "Requested size in pixels" default(256)
+// @Success      302 "Redirect to the image"
+// @Failure      400 {object} apperror.AppError "Invalid user ID or size"
+// @Failure      404 {object} apperror.AppError "User has no avatar"
+// @Failure      500 {object} apperror.AppError "Internal server error"
+// @Router       /avatars/{id} [get]
+func (h *AvatarHandler) GetAvatar(c echo.Context) error {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return apperror.NewAppError(http.StatusBadRequest, constant.ErrMsgInvalidUserID, err)
+	}
+	size := 0
+	if value := c.QueryParam("size"); value != "" {
+		size, err = strconv.Atoi(value)
+		if err != nil || size < 1 {
+			return apperror.NewAppError(http.StatusBadRequest, "Invalid size value", err)
+		}
+	}
+
+	avatarURL, err := h.avatarService.GetAvatarURL(c.Request().Context(), userID, size)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set("Cache-Control", "private, max-age=60")
+	return c.Redirect(http.StatusFound, avatarURL)
+}
@@ -0,0 +1,167 @@
+package handler
+
+import (
+	"net/http"
+
+	"go-base-project/internal/apperror"
+	"go-base-project/internal/constant"
+	"go-base-project/internal/dto"
+	"go-base-project/internal/service"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// InvitationHandler handles HTTP requests related to organization invitations.
+type InvitationHandler struct {
+	invitationService service.InvitationServiceInterface
+}
+
+// NewInvitationHandler creates a new instance of InvitationHandler.
+func NewInvitationHandler(invitationService service.InvitationServiceInterface) *InvitationHandler {
+	return &InvitationHandler{
+		invitationService: invitationService,
+	}
+}
+
+// CreateInvitation handles inviting an email address or user into the current organization.
+// @Summary      Create an organization invitation
+// @Description  Creates a signed, expiring invitation with a pre-selected role for the organization in context. Target an email or existing user for a single-use invite, or neither for a shareable link. The token is only returned once. Requires 'organizations:manage_members' permission.
+// @Tags         Organizations, Invitations
+// @Accept       json
+// @Produce      json
+// @Param        orgId path string true "Organization ID" format(uuid)
+// @Param        request body dto.CreateInvitationRequest true "Invitation Details"
+// @Security     BearerAuth
+// @Success      201 {object} dto.CreateInvitationResponse "Invitation created"
+// @Failure      400 {object} apperror.AppError "Invalid request payload"
+// @Failure      403 {object} apperror.AppError "Insufficient permissions or role above your level"
+// @Failure      404 {object} apperror.AppError "Organization, role or user not found"
+// @Failure      409 {object} apperror.AppError "User already a member"
+// @Failure      500 {object} apperror.AppError "Internal server error"
+// @Router       /organizations/{orgId}/invitations [post]
+func (h *InvitationHandler) CreateInvitation(c echo.Context) error {
+	organizationID, ok := c.Get(constant.OrganizationIDKey).(uuid.UUID)
+	if !ok {
+		return apperror.NewAppError(http.StatusBadRequest, constant.ErrMsgOrganizationContextRequired, nil)
+	}
+
+	var req dto.CreateInvitationRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, constant.ErrMsgInvalidRequestFormat)
+	}
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	userID, ok := c.Get(constant.UserIDKey).(uuid.UUID)
+	if !ok {
+		return apperror.NewAppError(http.StatusUnauthorized, "User ID not found in context", nil)
+	}
+
+	invitation, err := h.invitationService.CreateInvitation(c.Request().Context(), organizationID, req, userID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, invitation)
+}
+
+// ListInvitations handles listing the pending invitations of the current organization.
+// @Summary      List pending invitations
+// @Description  Lists invitations of the organization in context that can still be accepted. Requires 'organizations:manage_members' permission.
+// @Tags         Organizations, Invitations
+// @Produce      json
+// @Param        orgId path string true "Organization ID" format(uuid)
+// @Security     BearerAuth
+// @Success      200 {object} map[string][]dto.InvitationResponse "Pending invitations"
+// @Failure      403 {object} apperror.AppError "Insufficient permissions"
+// @Failure      500 {object} apperror.AppError "Internal server error"
+// @Router       /organizations/{orgId}/invitations [get]
+func (h *InvitationHandler) ListInvitations(c echo.Context) error {
+	organizationID, ok := c.Get(constant.OrganizationIDKey).(uuid.UUID)
+	if !ok {
+		return apperror.NewAppError(http.StatusBadRequest, constant.ErrMsgOrganizationContextRequired, nil)
+	}
+
+	invitations, err := h.invitationService.ListPendingInvitations(c.Request().Context(), organizationID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{"invitations": invitations})
+}
+
+// RevokeInvitation handles revoking a pending invitation.
+// @Summary      Revoke an invitation
+// @Description  Revokes a pending invitation of the organization in context so its token can no longer be used. Requires 'organizations:manage_members' permission.
+// @Tags         Organizations, Invitations
+// @Produce      json
+// @Param        orgId path string true "Organization ID" format(uuid)
+// @Param        invitationId path string true "Invitation ID" format(uuid)
+// @Security     BearerAuth
+// @Success      204 "Invitation revoked"
+// @Failure      400 {object} apperror.AppError "Invalid invitation ID format"
+// @Failure      403 {object} apperror.AppError "Insufficient permissions"
+// @Failure      404 {object} apperror.AppError "Invitation not found"
+// @Failure      409 {object} apperror.AppError "Invitation no longer pending"
+// @Failure      500 {object} apperror.AppError "Internal server error"
+// @Router       /organizations/{orgId}/invitations/{invitationId} [delete]
+func (h *InvitationHandler) RevokeInvitation(c echo.Context) error {
+	organizationID, ok := c.Get(constant.OrganizationIDKey).(uuid.UUID)
+	if !ok {
+		return apperror.NewAppError(http.StatusBadRequest, constant.ErrMsgOrganizationContextRequired, nil)
+	}
+
+	invitationID, err := uuid.Parse(c.Param("invitationId"))
+	if err != nil {
+		return apperror.NewAppError(http.StatusBadRequest, "Invalid invitation ID format", err)
+	}
+
+	userID, ok := c.Get(constant.UserIDKey).(uuid.UUID)
+	if !ok {
+		return apperror.NewAppError(http.StatusUnauthorized, "User ID not found in context", nil)
+	}
+
+	if err := h.invitationService.RevokeInvitation(c.Request().Context(), organizationID, invitationID, userID); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// AcceptInvitation handles accepting an invitation as the current user.
+// @Summary      Accept an invitation
+// @Description  Joins the invited organization with the role chosen by the inviter. Email or user targeted invitations can only be accepted by that account.
+// @Tags         Organizations, Invitations
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.AcceptInvitationRequest true "Invitation Token"
+// @Security     BearerAuth
+// @Success      201 {object} dto.UserOrganizationResponse "Joined organization"
+// @Failure      400 {object} apperror.AppError "Invalid, expired, used or revoked invitation"
+// @Failure      403 {object} apperror.AppError "Invitation issued to another account"
+// @Failure      409 {object} apperror.AppError "Already a member"
+// @Failure      500 {object} apperror.AppError "Internal server error"
+// @Router       /organizations/invitations/accept [post]
+func (h *InvitationHandler) AcceptInvitation(c echo.Context) error {
+	var req dto.AcceptInvitationRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, constant.ErrMsgInvalidRequestFormat)
+	}
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	userID, ok := c.Get(constant.UserIDKey).(uuid.UUID)
+	if !ok {
+		return apperror.NewAppError(http.StatusUnauthorized, "User ID not found in context", nil)
+	}
+
+	membership, err := h.invitationService.AcceptInvitation(c.Request().Context(), req, userID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, membership)
+}
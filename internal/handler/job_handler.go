@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"net/http"
+
+	"go-base-project/internal/apperror"
+	"go-base-project/internal/constant"
+	"go-base-project/internal/service"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// JobHandler handles HTTP requests for tracking background jobs.
+type JobHandler struct {
+	jobService service.JobServiceInterface
+}
+
+// NewJobHandler creates a new instance of JobHandler.
+func NewJobHandler(jobService service.JobServiceInterface) *JobHandler {
+	return &JobHandler{
+		jobService: jobService,
+	}
+}
+
+// GetJob handles retrieving the status of a background job.
+// @Summary      Get job status
+// @Description  Returns the status, progress and result of a background job. While a job runs, and after it failed or was cancelled, the result holds the partial result reported so far. Jobs are only visible to the user who submitted them.
+// @Tags         Jobs
+// @Produce      json
+// @Param        id path string true "Job ID" format(uuid)
+// @Security     BearerAuth
+// @Success      200 {object} dto.JobResponse "Job status"
+// @Failure      400 {object} apperror.AppError "Invalid job ID"
+// @Failure      404 {object} apperror.AppError "Job not found"
+// @Failure      500 {object} apperror.AppError "Internal server error"
+// @Router       /jobs/{id} [get]
+func (h *JobHandler) GetJob(c echo.Context) error {
+	id, userID, err := jobRequestIDs(c)
+	if err != nil {
+		return err
+	}
+
+	job, err := h.jobService.GetJob(c.Request().Context(), id, userID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, job)
+}
+
+// CancelJob handles cancelling a background job.
+// @Summary      Cancel job
+// @Description  Cancels a queued job right away. A running job is asked to stop and does so at its next progress update; the work it completed so far is kept and reported in its result.
+// @Tags         Jobs
+// @Produce      json
+// @Param        id path string true "Job ID" format(uuid)
+// @Security     BearerAuth
+// @Success      200 {object} dto.JobResponse "Job cancelled or cancellation requested"
+// @Failure      400 {object} apperror.AppError "Invalid job ID"
+// @Failure      404 {object} apperror.AppError "Job not found"
+// @Failure      409 {object} apperror.AppError "Job has already finished"
+// @Failure      500 {object} apperror.AppError "Internal server error"
+// @Router       /jobs/{id}/cancel [post]
+func (h *JobHandler) CancelJob(c echo.Context) error {
+	id, userID, err := jobRequestIDs(c)
+	if err != nil {
+		return err
+	}
+
+	job, err := h.jobService.CancelJob(c.Request().Context(), id, userID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, job)
+}
+
+// RetryJob handles running a failed or cancelled background job again.
+// @Summary      Retry job
+// @Description  Queues a failed or cancelled job again with a fresh set of attempts. The job starts over; the bulk operations skip work that a previous run already completed.
+// @Tags         Jobs
+// @Produce      json
+// @Param        id path string true "Job ID" format(uuid)
+// @Security     BearerAuth
+// @Success      202 {object} dto.JobResponse "Job queued again"
+// @Failure      400 {object} apperror.AppError "Invalid job ID"
+// @Failure      404 {object} apperror.AppError "Job not found"
+// @Failure      409 {object} apperror.AppError "Job is not failed or cancelled"
+// @Failure      500 {object} apperror.AppError "Internal server error"
+// @Router       /jobs/{id}/retry [post]
+func (h *JobHandler) RetryJob(c echo.Context) error {
+	id, userID, err := jobRequestIDs(c)
+	if err != nil {
+		return err
+	}
+
+	job, err := h.jobService.RetryJob(c.Request().Context(), id, userID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusAccepted, job)
+}
+
+// jobRequestIDs returns the job ID from the path and the ID of the authenticated user
+func jobRequestIDs(c echo.Context) (uuid.UUID, uuid.UUID, error) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return uuid.Nil, uuid.Nil, apperror.NewAppError(http.StatusBadRequest, "Invalid job ID format", err)
+	}
+	userID, ok := c.Get(constant.UserIDKey).(uuid.UUID)
+	if !ok {
+		return uuid.Nil, uuid.Nil, apperror.NewAppError(http.StatusUnauthorized, "User ID not found in context", nil)
+	}
+	return id, userID, nil
+}
@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	"go-base-project/platform/storage"
+
+	"github.com/labstack/echo/v4"
+)
+
+// MediaHandler serves stored objects, such as avatars, for storage drivers that are served by the backend.
+type MediaHandler struct {
+	server http.Handler // nil when objects are served by the storage itself, e.g. S3
+}
+
+// NewMediaHandler creates a new instance of MediaHandler.
+func NewMediaHandler(store storage.BlobStore) *MediaHandler {
+	server, _ := store.(http.Handler)
+	return &MediaHandler{server: server}
+}
+
+// ServeMedia handles fetching a stored object below /media. Objects stored with signed URLs enabled
+// require the expires and signature query parameters of their signed URL.
+// @Summary      Get a stored file
+// @Description  Serves a file of the local storage driver, such as an avatar image. Returns 404 for other drivers, which serve files themselves.
+// @Tags         Media
+// @Param        key path string true "Object key, e.g. avatars/{user id}/{version}/256.jpg"
+// @Param        expires query int false "Expiry of a signed URL (unix time)"
+// @Param        signature query string false "Signature of a signed URL"
+// @Success      200 "File content"
+// @Failure      403 "Invalid or expired signature"
+// @Failure      404 "File not found"
+// @Router       /media/{key} [get]
+func (h *MediaHandler) ServeMedia(c echo.Context) error {
+	if h.server == nil {
+		return echo.ErrNotFound
+	}
+	req := c.Request().Clone(c.Request().Context())
+	req.URL.Path = strings.TrimPrefix(req.URL.Path, "/media")
+	req.URL.RawPath = ""
+	h.server.ServeHTTP(c.Response(), req)
+	return nil
+}
@@ -0,0 +1,448 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"go-base-project/internal/apperror"
+	"go-base-project/internal/constant"
+	"go-base-project/internal/dto"
+	"go-base-project/internal/service"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// MembershipHandler handles HTTP requests related to user-organization memberships.
+type MembershipHandler struct {
+	membershipService service.MembershipServiceInterface
+	approvalService   service.ApprovalServiceInterface
+	jobService        service.JobServiceInterface
+}
+
+// NewMembershipHandler creates a new instance of MembershipHandler.
+func NewMembershipHandler(membershipService service.MembershipServiceInterface, approvalService service.ApprovalServiceInterface, jobService service.JobServiceInterface) *MembershipHandler {
+	return &MembershipHandler{
+		membershipService: membershipService,
+		approvalService:   approvalService,
+		jobService:        jobService,
+	}
+}
+
+// AddMember handles adding a user to an organization.
+// @Summary      Assign user to organization
+// @Description  Assigns a user to an organization with a specific role, or reactivates an inactive membership. The change is recorded in the membership history. Requires 'users:assign-organization' permission.
+// @Tags         Admin, Users, Organizations
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.AssignUserToOrganizationRequest true "Assignment Details"
+// @Security     BearerAuth
+// @Success      201 {object} dto.UserOrganizationResponse "User assigned successfully"
+// @Failure      400 {object} apperror.AppError "Invalid request payload"
+// @Failure      403 {object} apperror.AppError "Insufficient permissions"
+// @Failure      404 {object} apperror.AppError "User, organization or role not found"
+// @Failure      409 {object} apperror.AppError "User already assigned to organization or organization archived"
+// @Failure      500 {object} apperror.AppError "Internal server error"
+// @Router       /admin/users/assign-organization [post]
+func (h *MembershipHandler) AddMember(c echo.Context) error {
+	var req dto.AssignUserToOrganizationRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, constant.ErrMsgInvalidRequestFormat)
+	}
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	currentUserID, ok := c.Get(constant.UserIDKey).(uuid.UUID)
+	if !ok {
+		return apperror.NewAppError(http.StatusUnauthorized, constant.ErrMsgAuthorizationContextMissing, nil)
+	}
+
+	ctx := context.WithValue(c.Request().Context(), constant.RequestIDKey, c.Response().Header().Get(echo.HeaderXRequestID))
+	membership, err := h.membershipService.AddMember(ctx, req, currentUserID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, membership)
+}
+
+// BulkAddMembers handles bulk assignment of users to an organization.
+// @Summary      Bulk assign users to organization
+// @Description  Assigns multiple users to an organization with the same role as a background job; poll the returned status URL for progress and the dto.BulkAssignResponse result. Users are assigned in chunks of 100, so a cancelled or failed job keeps the chunks it completed. Batches above the configured threshold are submitted as a change request; the final approval queues the job and stores its ID in the change request result. Requires 'users:bulk-assign-organization' permission.
+// @Tags         Admin, Users, Organizations
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.BulkAssignUsersToOrganizationRequest true "Bulk Assignment Details"
+// @Security     BearerAuth
+// @Success      202 {object} dto.JobResponse "Bulk assignment queued as a job (a dto.ChangeRequestResponse when submitted for approval)"
+// @Failure      400 {object} apperror.AppError "Invalid request payload"
+// @Failure      403 {object} apperror.AppError "Insufficient permissions"
+// @Failure      500 {object} apperror.AppError "Internal server error"
+// @Router       /admin/users/bulk-assign-organization [post]
+func (h *MembershipHandler) BulkAddMembers(c echo.Context) error {
+	var req dto.BulkAssignUsersToOrganizationRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, constant.ErrMsgInvalidRequestFormat)
+	}
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	requestedBy, ok := c.Get(constant.UserIDKey).(uuid.UUID)
+	if !ok {
+		return apperror.NewAppError(http.StatusUnauthorized, "User ID not found in context", nil)
+	}
+
+	ctx := context.WithValue(c.Request().Context(), constant.RequestIDKey, c.Response().Header().Get(echo.HeaderXRequestID))
+
+	// Large batches need a second pair of eyes before they touch memberships
+	if h.approvalService.RequiresBulkAssignApproval(len(req.UserIDs)) {
+		changeRequest, err := h.approvalService.SubmitChangeRequest(
+			ctx,
+			constant.ChangeRequestActionBulkAssignUsers,
+			&req.OrganizationID,
+			fmt.Sprintf("Bulk assign %d users to organization %s", len(req.UserIDs), req.OrganizationID),
+			dto.BulkAssignUsersPayload{BulkAssignUsersToOrganizationRequest: req, RequestedBy: requestedBy},
+			requestedBy,
+		)
+		if err != nil {
+			return err
+		}
+		return c.JSON(http.StatusAccepted, changeRequest)
+	}
+
+	job, err := h.jobService.SubmitJob(ctx, constant.JobTypeBulkAssignUsers, req, requestedBy)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusAccepted, job)
+}
+
+// UpdateMember handles updating the role and status of a membership.
+// @Summary      Update user's role in organization
+// @Description  Updates a user's role and status in an organization and records the change in the membership history. Requires 'users:update-organization-role' permission.
+// @Tags         Admin, Users, Organizations
+// @Accept       json
+// @Produce      json
+// @Param        userId path string true "User ID" format(uuid)
+// @Param        organizationId path string true "Organization ID" format(uuid)
+// @Param        request body dto.UpdateUserOrganizationRequest true "Role Update Details"
+// @Security     BearerAuth
+// @Success      200 {object} dto.UserOrganizationResponse "Role updated successfully"
+// @Failure      400 {object} apperror.AppError "Invalid request payload or IDs"
+// @Failure      403 {object} apperror.AppError "Insufficient permissions"
+// @Failure      404 {object} apperror.AppError "User organization assignment, organization or role not found"
+// @Failure      409 {object} apperror.AppError "Organization archived"
+// @Failure      500 {object} apperror.AppError "Internal server error"
+// @Router       /admin/users/{userId}/organizations/{organizationId} [put]
+func (h *MembershipHandler) UpdateMember(c echo.Context) error {
+	userID, organizationID, err := parseMembershipIDs(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.UpdateUserOrganizationRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, constant.ErrMsgInvalidRequestFormat)
+	}
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	currentUserID, ok := c.Get(constant.UserIDKey).(uuid.UUID)
+	if !ok {
+		return apperror.NewAppError(http.StatusUnauthorized, constant.ErrMsgAuthorizationContextMissing, nil)
+	}
+
+	ctx := context.WithValue(c.Request().Context(), constant.RequestIDKey, c.Response().Header().Get(echo.HeaderXRequestID))
+	membership, err := h.membershipService.UpdateMember(ctx, userID, organizationID, req, currentUserID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, membership)
+}
+
+// UpdateMemberAttributes handles replacing the custom attributes of a membership.
+// @Summary      Set membership custom attributes
+// @Description  Replaces the custom attributes of a user's membership in an organization, e.g. the employee number at a store. Values are validated against the attribute schema of the organization's type: unknown keys are rejected, required attributes must be present and null values remove an attribute. Requires 'users:update-organization-role' permission.
+// @Tags         Admin, Users, Organizations
+// @Accept       json
+// @Produce      json
+// @Param        userId path string true "User ID" format(uuid)
+// @Param        organizationId path string true "Organization ID" format(uuid)
+// @Param        request body dto.UpdateUserOrganizationAttributesRequest true "Attribute values"
+// @Security     BearerAuth
+// @Success      200 {object} dto.UserOrganizationResponse "Attributes updated"
+// @Failure      400 {object} apperror.AppError "Invalid IDs or attributes not matching the schema"
+// @Failure      403 {object} apperror.AppError "Insufficient permissions"
+// @Failure      404 {object} apperror.AppError "User organization assignment not found"
+// @Failure      500 {object} apperror.AppError "Internal server error"
+// @Router       /admin/users/{userId}/organizations/{organizationId}/attributes [put]
+func (h *MembershipHandler) UpdateMemberAttributes(c echo.Context) error {
+	userID, organizationID, err := parseMembershipIDs(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.UpdateUserOrganizationAttributesRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, constant.ErrMsgInvalidRequestFormat)
+	}
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	currentUserID, ok := c.Get(constant.UserIDKey).(uuid.UUID)
+	if !ok {
+		return apperror.NewAppError(http.StatusUnauthorized, constant.ErrMsgAuthorizationContextMissing, nil)
+	}
+
+	ctx := context.WithValue(c.Request().Context(), constant.RequestIDKey, c.Response().Header().Get(echo.HeaderXRequestID))
+	membership, err := h.membershipService.UpdateMemberAttributes(ctx, userID, organizationID, req, currentUserID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, membership)
+}
+
+// RemoveMember handles removing a user from an organization.
+// @Summary      Remove user from organization
+// @Description  Removes a user from an organization and records the removal in the membership history. Requires 'users:remove-organization' permission.
+// @Tags         Admin, Users, Organizations
+// @Produce      json
+// @Param        userId path string true "User ID" format(uuid)
+// @Param        organizationId path string true "Organization ID" format(uuid)
+// @Security     BearerAuth
+// @Success      204 "No Content"
+// @Failure      400 {object} apperror.AppError "Invalid user or organization ID"
+// @Failure      403 {object} apperror.AppError "Insufficient permissions"
+// @Failure      404 {object} apperror.AppError "User organization assignment not found"
+// @Failure      500 {object} apperror.AppError "Internal server error"
+// @Router       /admin/users/{userId}/organizations/{organizationId} [delete]
+func (h *MembershipHandler) RemoveMember(c echo.Context) error {
+	userID, organizationID, err := parseMembershipIDs(c)
+	if err != nil {
+		return err
+	}
+
+	currentUserID, ok := c.Get(constant.UserIDKey).(uuid.UUID)
+	if !ok {
+		return apperror.NewAppError(http.StatusUnauthorized, constant.ErrMsgAuthorizationContextMissing, nil)
+	}
+
+	ctx := context.WithValue(c.Request().Context(), constant.RequestIDKey, c.Response().Header().Get(echo.HeaderXRequestID))
+	if err := h.membershipService.RemoveMember(ctx, userID, organizationID, currentUserID); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// LeaveOrganization handles the current user leaving an organization.
+// @Summary      Leave organization
+// @Description  Removes the current user from an organization
+// @Tags         Organizations, Users
+// @Produce      json
+// @Param        id path string true "Organization ID" format(uuid)
+// @Security     BearerAuth
+// @Success      204 "Successfully left organization"
+// @Failure      400 {object} apperror.AppError "Invalid organization ID"
+// @Failure      404 {object} apperror.AppError "User is not a member of the organization"
+// @Failure      500 {object} apperror.AppError "Internal server error"
+// @Router       /organizations/{id}/leave [delete]
+func (h *MembershipHandler) LeaveOrganization(c echo.Context) error {
+	organizationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return apperror.NewAppError(http.StatusBadRequest, "Invalid organization ID", err)
+	}
+
+	userID, ok := c.Get(constant.UserIDKey).(uuid.UUID)
+	if !ok {
+		return apperror.NewAppError(http.StatusUnauthorized, "User ID not found in context", nil)
+	}
+
+	ctx := context.WithValue(c.Request().Context(), constant.RequestIDKey, c.Response().Header().Get(echo.HeaderXRequestID))
+	if err := h.membershipService.LeaveOrganization(ctx, userID, organizationID); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// ListMyMemberships handles retrieving the organizations of the current user.
+// @Summary      Get my organizations
+// @Description  Retrieves the active memberships of the current user with pagination
+// @Tags         Organizations, Users
+// @Produce      json
+// @Param        page query int false "Page number" default(1) minimum(1)
+// @Param        limit query int false "Items per page" default(10) minimum(1) maximum(100)
+// @Security     BearerAuth
+// @Success      200 {object} dto.PagedUserOrganizationResponse "User organizations retrieved successfully"
+// @Failure      500 {object} apperror.AppError "Internal server error"
+// @Router       /users/me/organizations [get]
+func (h *MembershipHandler) ListMyMemberships(c echo.Context) error {
+	userID, ok := c.Get(constant.UserIDKey).(uuid.UUID)
+	if !ok {
+		return apperror.NewAppError(http.StatusUnauthorized, "User ID not found in context", nil)
+	}
+
+	page, _ := strconv.Atoi(c.QueryParam("page"))
+	limit, _ := strconv.Atoi(c.QueryParam("limit"))
+
+	ctx := context.WithValue(c.Request().Context(), constant.RequestIDKey, c.Response().Header().Get(echo.HeaderXRequestID))
+	memberships, err := h.membershipService.ListUserMemberships(ctx, userID, page, limit)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, memberships)
+}
+
+// ListUserMemberships handles retrieving the organizations a user belongs to.
+// @Summary      Get user's organizations
+// @Description  Retrieves the active memberships of a user with pagination. Requires 'users:read' permission.
+// @Tags         Admin, Users, Organizations
+// @Produce      json
+// @Param        userId path string true "User ID" format(uuid)
+// @Param        page query int false "Page number" default(1) minimum(1)
+// @Param        limit query int false "Items per page" default(10) minimum(1) maximum(100)
+// @Security     BearerAuth
+// @Success      200 {object} dto.PagedUserOrganizationResponse "User organizations retrieved successfully"
+// @Failure      400 {object} apperror.AppError "Invalid user ID or query parameters"
+// @Failure      403 {object} apperror.AppError "Insufficient permissions"
+// @Failure      500 {object} apperror.AppError "Internal server error"
+// @Router       /admin/users/{userId}/organizations [get]
+func (h *MembershipHandler) ListUserMemberships(c echo.Context) error {
+	userID, err := uuid.Parse(c.Param("userId"))
+	if err != nil {
+		return apperror.NewAppError(http.StatusBadRequest, constant.ErrMsgInvalidUserID, err)
+	}
+
+	page, _ := strconv.Atoi(c.QueryParam("page"))
+	limit, _ := strconv.Atoi(c.QueryParam("limit"))
+
+	ctx := context.WithValue(c.Request().Context(), constant.RequestIDKey, c.Response().Header().Get(echo.HeaderXRequestID))
+	memberships, err := h.membershipService.ListUserMemberships(ctx, userID, page, limit)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, memberships)
+}
+
+// ListOrganizationMembers handles retrieving the members of an organization.
+// @Summary      Get organization members
+// @Description  Retrieves the active members of an organization with their user and role, with pagination. Requires 'organizations:read-members' permission.
+// @Tags         Admin, Organizations, Users
+// @Produce      json
+// @Param        id path string true "Organization ID" format(uuid)
+// @Param        page query int false "Page number" default(1) minimum(1)
+// @Param        limit query int false "Items per page" default(10) minimum(1) maximum(100)
+// @Security     BearerAuth
+// @Success      200 {object} dto.PagedUserOrganizationResponse "Organization members retrieved successfully"
+// @Failure      400 {object} apperror.AppError "Invalid organization ID or query parameters"
+// @Failure      403 {object} apperror.AppError "Insufficient permissions"
+// @Failure      500 {object} apperror.AppError "Internal server error"
+// @Router       /admin/organizations/{id}/members [get]
+func (h *MembershipHandler) ListOrganizationMembers(c echo.Context) error {
+	organizationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return apperror.NewAppError(http.StatusBadRequest, "Invalid organization ID", err)
+	}
+
+	page, _ := strconv.Atoi(c.QueryParam("page"))
+	limit, _ := strconv.Atoi(c.QueryParam("limit"))
+
+	ctx := context.WithValue(c.Request().Context(), constant.RequestIDKey, c.Response().Header().Get(echo.HeaderXRequestID))
+	members, err := h.membershipService.ListOrganizationMembers(ctx, organizationID, page, limit)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, members)
+}
+
+// GetUserHistory handles retrieving the membership history of a user.
+// @Summary      Get user's organization history
+// @Description  Retrieves the membership history of a user, newest first. Every assignment, removal, role, status and attribute change is recorded automatically. Requires 'users:read-history' permission.
+// @Tags         Admin, Users, Organizations
+// @Produce      json
+// @Param        userId path string true "User ID" format(uuid)
+// @Param        page query int false "Page number" default(1) minimum(1)
+// @Param        limit query int false "Items per page" default(10) minimum(1) maximum(100)
+// @Security     BearerAuth
+// @Success      200 {object} dto.PagedUserOrganizationHistoryResponse "User organization history retrieved successfully"
+// @Failure      400 {object} apperror.AppError "Invalid user ID or query parameters"
+// @Failure      403 {object} apperror.AppError "Insufficient permissions"
+// @Failure      500 {object} apperror.AppError "Internal server error"
+// @Router       /admin/users/{userId}/organization-history [get]
+func (h *MembershipHandler) GetUserHistory(c echo.Context) error {
+	userID, err := uuid.Parse(c.Param("userId"))
+	if err != nil {
+		return apperror.NewAppError(http.StatusBadRequest, constant.ErrMsgInvalidUserID, err)
+	}
+
+	page, _ := strconv.Atoi(c.QueryParam("page"))
+	limit, _ := strconv.Atoi(c.QueryParam("limit"))
+
+	ctx := context.WithValue(c.Request().Context(), constant.RequestIDKey, c.Response().Header().Get(echo.HeaderXRequestID))
+	history, err := h.membershipService.GetUserHistory(ctx, userID, page, limit)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, history)
+}
+
+// GetOrganizationHistory handles retrieving the membership history of an organization.
+// @Summary      Get organization user history
+// @Description  Retrieves the membership history of an organization, newest first, with pagination. Requires 'organizations:read-history' permission.
+// @Tags         Admin, Organizations, Users
+// @Produce      json
+// @Param        id path string true "Organization ID" format(uuid)
+// @Param        page query int false "Page number" default(1) minimum(1)
+// @Param        limit query int false "Items per page" default(10) minimum(1) maximum(100)
+// @Security     BearerAuth
+// @Success      200 {object} dto.PagedUserOrganizationHistoryResponse "Organization user history retrieved successfully"
+// @Failure      400 {object} apperror.AppError "Invalid organization ID or query parameters"
+// @Failure      403 {object} apperror.AppError "Insufficient permissions"
+// @Failure      500 {object} apperror.AppError "Internal server error"
+// @Router       /admin/organizations/{id}/user-history [get]
+func (h *MembershipHandler) GetOrganizationHistory(c echo.Context) error {
+	organizationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return apperror.NewAppError(http.StatusBadRequest, "Invalid organization ID", err)
+	}
+
+	page, _ := strconv.Atoi(c.QueryParam("page"))
+	limit, _ := strconv.Atoi(c.QueryParam("limit"))
+
+	ctx := context.WithValue(c.Request().Context(), constant.RequestIDKey, c.Response().Header().Get(echo.HeaderXRequestID))
+	history, err := h.membershipService.GetOrganizationHistory(ctx, organizationID, page, limit)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, history)
+}
+
+// parseMembershipIDs reads the user and organization IDs of a membership from the path.
+func parseMembershipIDs(c echo.Context) (uuid.UUID, uuid.UUID, error) {
+	userID, err := uuid.Parse(c.Param("userId"))
+	if err != nil {
+		return uuid.Nil, uuid.Nil, apperror.NewAppError(http.StatusBadRequest, constant.ErrMsgInvalidUserID, err)
+	}
+
+	organizationID, err := uuid.Parse(c.Param("organizationId"))
+	if err != nil {
+		return uuid.Nil, uuid.Nil, apperror.NewAppError(http.StatusBadRequest, "Invalid organization ID", err)
+	}
+
+	return userID, organizationID, nil
+}
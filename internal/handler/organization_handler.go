@@ -6,6 +6,7 @@ import (
 	"go-base-project/internal/dto"
 	"go-base-project/internal/service"
 	"context"
+	"fmt"
 	"net/http"
 	"strconv"
 
@@ -15,13 +16,17 @@ import (
 
 // OrganizationHandler handles HTTP requests related to organization management
 type OrganizationHandler struct {
-	orgService service.OrganizationServiceInterface
+	orgService      service.OrganizationServiceInterface
+	approvalService service.ApprovalServiceInterface
+	jobService      service.JobServiceInterface
 }
 
 // NewOrganizationHandler creates a new instance of OrganizationHandler
-func NewOrganizationHandler(orgService service.OrganizationServiceInterface) *OrganizationHandler {
+func NewOrganizationHandler(orgService service.OrganizationServiceInterface, approvalService service.ApprovalServiceInterface, jobService service.JobServiceInterface) *OrganizationHandler {
 	return &OrganizationHandler{
-		orgService: orgService,
+		orgService:      orgService,
+		approvalService: approvalService,
+		jobService:      jobService,
 	}
 }
 
@@ -160,16 +165,17 @@ func (h *OrganizationHandler) UpdateOrganization(c echo.Context) error {
 
 // DeleteOrganization handles organization deletion
 // @Summary      Delete organization
-// @Description  Deletes an organization. Requires platform level permissions.
+// @Description  Submits a change request to delete an organization. Once approved the organization is soft deleted: it disappears from listings and can be restored until the purge job removes it after the retention period. Requires platform level permissions.
 // @Tags         Admin, Organizations
 // @Accept       json
 // @Produce      json
 // @Param        id path string true "Organization ID"
 // @Security     BearerAuth
-// @Success      204 "Organization deleted successfully"
+// @Success      202 {object} dto.ChangeRequestResponse "Deletion submitted for approval"
 // @Failure      400 {object} apperror.AppError "Invalid request"
 // @Failure      403 {object} apperror.AppError "Insufficient permissions"
 // @Failure      404 {object} apperror.AppError "Organization not found"
+// @Failure      409 {object} apperror.AppError "Deletion already pending approval"
 // @Failure      500 {object} apperror.AppError "Internal server error"
 // @Router       /admin/organizations/{id} [delete]
 func (h *OrganizationHandler) DeleteOrganization(c echo.Context) error {
@@ -185,11 +191,98 @@ func (h *OrganizationHandler) DeleteOrganization(c echo.Context) error {
 		return apperror.NewAppError(http.StatusUnauthorized, "User ID not found in context", nil)
 	}
 
-	if err := h.orgService.DeleteOrganization(c.Request().Context(), id, userID); err != nil {
+	org, err := h.orgService.GetOrganizationByID(c.Request().Context(), id)
+	if err != nil {
 		return err
 	}
 
-	return c.NoContent(http.StatusNoContent)
+	changeRequest, err := h.approvalService.SubmitChangeRequest(
+		c.Request().Context(),
+		constant.ChangeRequestActionDeleteOrganization,
+		&id,
+		fmt.Sprintf("Delete organization %s (%s)", org.Name, org.Code),
+		dto.DeleteOrganizationPayload{OrganizationID: id, RequestedBy: userID},
+		userID,
+	)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusAccepted, changeRequest)
+}
+
+// MoveOrganization handles moving an organization under a new parent
+// @Summary      Move organization
+// @Description  Moves an organization and its whole subtree under a new parent. The parent type and resulting depth must be allowed by the organization type registry, the parent cannot be archived, and it cannot be inside the moved subtree. The move is recorded in the organization audit trail. Requires 'organizations:update' permission.
+// @Tags         Admin, Organizations
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "Organization ID"
+// @Param        move body dto.MoveOrganizationRequest true "New parent organization"
+// @Security     BearerAuth
+// @Success      200 {object} dto.OrganizationResponse "Organization moved successfully"
+// @Failure      400 {object} apperror.AppError "Invalid parent type or cycle"
+// @Failure      403 {object} apperror.AppError "Insufficient permissions"
+// @Failure      404 {object} apperror.AppError "Organization or parent not found"
+// @Failure      500 {object} apperror.AppError "Internal server error"
+// @Router       /admin/organizations/{id}/move [post]
+func (h *OrganizationHandler) MoveOrganization(c echo.Context) error {
+	idStr := c.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid organization ID format")
+	}
+
+	var req dto.MoveOrganizationRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, constant.ErrMsgInvalidRequestFormat)
+	}
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	userID, ok := c.Get(constant.UserIDKey).(uuid.UUID)
+	if !ok {
+		return apperror.NewAppError(http.StatusUnauthorized, "User ID not found in context", nil)
+	}
+
+	org, err := h.orgService.MoveOrganization(c.Request().Context(), id, req, userID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, org)
+}
+
+// ListOrganizationAuditLogs handles retrieving the audit trail of an organization
+// @Summary      List organization audit trail
+// @Description  Retrieves the most recent structural changes recorded for an organization, newest first. Requires 'organizations:read' permission.
+// @Tags         Admin, Organizations
+// @Produce      json
+// @Param        id path string true "Organization ID"
+// @Param        limit query int false "Maximum number of entries (default: 50, max: 100)"
+// @Security     BearerAuth
+// @Success      200 {object} map[string][]dto.OrganizationAuditLogResponse "Audit trail retrieved successfully"
+// @Failure      400 {object} apperror.AppError "Invalid organization ID"
+// @Failure      403 {object} apperror.AppError "Insufficient permissions"
+// @Failure      404 {object} apperror.AppError "Organization not found"
+// @Failure      500 {object} apperror.AppError "Internal server error"
+// @Router       /admin/organizations/{id}/audit-logs [get]
+func (h *OrganizationHandler) ListOrganizationAuditLogs(c echo.Context) error {
+	idStr := c.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid organization ID format")
+	}
+
+	limit, _ := strconv.Atoi(c.QueryParam("limit"))
+
+	auditLogs, err := h.orgService.ListOrganizationAuditLogs(c.Request().Context(), id, limit)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{"audit_logs": auditLogs})
 }
 
 // ListOrganizations handles organization listing with filters
@@ -201,11 +294,14 @@ func (h *OrganizationHandler) DeleteOrganization(c echo.Context) error {
 // @Param        type query string false "Organization Type" Enums(platform, company, store)
 // @Param        parent_id query string false "Parent Organization ID"
 // @Param        active query boolean false "Is Active"
+// @Param        archived query boolean false "Only archived (true) or only unarchived (false) organizations"
+// @Param        sort query string false "Comma separated sort fields (name, code, type, created_at, updated_at); prefix with - for descending" default(name)
+// @Param        cursor query string false "Cursor of the next page, from next_cursor"
 // @Param        page query int false "Page number" default(1)
 // @Param        limit query int false "Items per page" default(10)
 // @Security     BearerAuth
-// @Success      200 {array} dto.OrganizationResponse "Organizations retrieved successfully"
-// @Failure      400 {object} apperror.AppError "Invalid query parameters"
+// @Success      200 {object} dto.PaginatedOrganizationsResponse "Organizations retrieved successfully"
+// @Failure      400 {object} apperror.AppError "Invalid query parameters, sort or cursor"
 // @Failure      500 {object} apperror.AppError "Internal server error"
 // @Router       /organizations [get]
 func (h *OrganizationHandler) ListOrganizations(c echo.Context) error {
@@ -214,6 +310,8 @@ func (h *OrganizationHandler) ListOrganizations(c echo.Context) error {
 	// Parse query parameters
 	req.OrganizationType = c.QueryParam("type")
 	req.Search = c.QueryParam("search")
+	req.Sort = c.QueryParam("sort")
+	req.Cursor = c.QueryParam("cursor")
 
 	if parentIDStr := c.QueryParam("parent_id"); parentIDStr != "" {
 		parentID, err := uuid.Parse(parentIDStr)
@@ -231,6 +329,14 @@ func (h *OrganizationHandler) ListOrganizations(c echo.Context) error {
 		req.IsActive = &active
 	}
 
+	if archivedStr := c.QueryParam("archived"); archivedStr != "" {
+		archived, err := strconv.ParseBool(archivedStr)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid archived parameter format")
+		}
+		req.IsArchived = &archived
+	}
+
 	// Parse pagination
 	if pageStr := c.QueryParam("page"); pageStr != "" {
 		page, err := strconv.Atoi(pageStr)
@@ -273,16 +379,18 @@ func (h *OrganizationHandler) ListOrganizations(c echo.Context) error {
 
 // JoinOrganization handles user joining an organization
 // @Summary      Join organization
-// @Description  Allows a user to join an organization using organization code
+// @Description  Allows a user to join an organization using organization code. Open organizations grant membership immediately, approval-required organizations create a pending join request, and invite-only organizations reject the request.
 // @Tags         Organizations, Users
 // @Accept       json
 // @Produce      json
 // @Param        request body dto.JoinOrganizationRequest true "Join Organization Request"
 // @Security     BearerAuth
-// @Success      201 {object} dto.UserOrganizationResponse "Successfully joined organization"
+// @Success      201 {object} dto.JoinOrganizationResponse "Successfully joined organization"
+// @Success      202 {object} dto.JoinOrganizationResponse "Join request submitted for approval"
 // @Failure      400 {object} apperror.AppError "Invalid request payload"
+// @Failure      403 {object} apperror.AppError "Organization only accepts invitations"
 // @Failure      404 {object} apperror.AppError "Organization not found"
-// @Failure      409 {object} apperror.AppError "User already member of organization"
+// @Failure      409 {object} apperror.AppError "User already member of organization or request already pending"
 // @Failure      500 {object} apperror.AppError "Internal server error"
 // @Router       /organizations/join [post]
 func (h *OrganizationHandler) JoinOrganization(c echo.Context) error {
@@ -300,98 +408,202 @@ func (h *OrganizationHandler) JoinOrganization(c echo.Context) error {
 		return apperror.NewAppError(http.StatusUnauthorized, "User ID not found in context", nil)
 	}
 
-	userOrg, err := h.orgService.JoinOrganization(c.Request().Context(), userID, req)
+	result, err := h.orgService.JoinOrganization(c.Request().Context(), userID, req)
 	if err != nil {
 		return err
 	}
 
-	return c.JSON(http.StatusCreated, userOrg)
+	if result.JoinRequest != nil {
+		return c.JSON(http.StatusAccepted, result)
+	}
+	return c.JSON(http.StatusCreated, result)
 }
 
-// LeaveOrganization handles user leaving an organization
-// @Summary      Leave organization
-// @Description  Allows a user to leave an organization
+// ListMyJoinRequests handles listing the current user's join requests.
+// @Summary      List my join requests
+// @Description  Retrieves the join requests made by the current user, optionally filtered by status.
 // @Tags         Organizations, Users
-// @Accept       json
 // @Produce      json
-// @Param        id path string true "Organization ID"
+// @Param        status query string false "Filter by status" Enums(pending, approved, rejected)
 // @Security     BearerAuth
-// @Success      204 "Successfully left organization"
-// @Failure      400 {object} apperror.AppError "Invalid organization ID"
+// @Success      200 {object} map[string][]dto.JoinRequestResponse "Join requests retrieved successfully"
+// @Failure      400 {object} apperror.AppError "Invalid status"
+// @Failure      500 {object} apperror.AppError "Internal server error"
+// @Router       /organizations/join-requests/me [get]
+func (h *OrganizationHandler) ListMyJoinRequests(c echo.Context) error {
+	userID, ok := c.Get(constant.UserIDKey).(uuid.UUID)
+	if !ok {
+		return apperror.NewAppError(http.StatusUnauthorized, "User ID not found in context", nil)
+	}
+
+	joinRequests, err := h.orgService.ListMyJoinRequests(c.Request().Context(), userID, c.QueryParam("status"))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{"join_requests": joinRequests})
+}
+
+// GetOrganizationHierarchy handles retrieving the position of the current organization in the hierarchy.
+// @Summary      Get organization hierarchy
+// @Description  Retrieves the ancestors, descendants, depth and subtree height of the organization in context. Requires 'organizations:read' permission.
+// @Tags         Organizations
+// @Produce      json
+// @Param        orgId path string true "Organization ID" format(uuid)
+// @Param        max_depth query int false "Maximum number of descendant levels to return (default: unlimited)"
+// @Security     BearerAuth
+// @Success      200 {object} dto.OrganizationHierarchyResponse "Organization hierarchy retrieved successfully"
+// @Failure      400 {object} apperror.AppError "Invalid max_depth"
+// @Failure      403 {object} apperror.AppError "Insufficient permissions"
 // @Failure      404 {object} apperror.AppError "Organization not found"
 // @Failure      500 {object} apperror.AppError "Internal server error"
-// @Router       /organizations/{id}/leave [delete]
-func (h *OrganizationHandler) LeaveOrganization(c echo.Context) error {
-	idStr := c.Param("id")
-	orgID, err := uuid.Parse(idStr)
+// @Router       /organizations/{orgId}/hierarchy [get]
+func (h *OrganizationHandler) GetOrganizationHierarchy(c echo.Context) error {
+	orgID, ok := c.Get(constant.OrganizationIDKey).(uuid.UUID)
+	if !ok {
+		return apperror.NewAppError(http.StatusBadRequest, constant.ErrMsgOrganizationContextRequired, nil)
+	}
+
+	maxDepth := 0
+	if maxDepthStr := c.QueryParam("max_depth"); maxDepthStr != "" {
+		parsed, err := strconv.Atoi(maxDepthStr)
+		if err != nil || parsed < 0 {
+			return apperror.NewValidationError("max_depth must be a non-negative integer")
+		}
+		maxDepth = parsed
+	}
+
+	hierarchy, err := h.orgService.GetOrganizationHierarchy(c.Request().Context(), orgID, maxDepth)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, "Invalid organization ID format")
+		return err
 	}
 
-	// Get user ID from context
-	userID, ok := c.Get(constant.UserIDKey).(uuid.UUID)
+	return c.JSON(http.StatusOK, hierarchy)
+}
+
+// ListJoinRequests handles listing the join requests of the current organization.
+// @Summary      List organization join requests
+// @Description  Retrieves join requests for the organization in context, optionally filtered by status. Requires 'organizations:manage_members' permission.
+// @Tags         Organizations
+// @Produce      json
+// @Param        orgId path string true "Organization ID" format(uuid)
+// @Param        status query string false "Filter by status" Enums(pending, approved, rejected)
+// @Security     BearerAuth
+// @Success      200 {object} map[string][]dto.JoinRequestResponse "Join requests retrieved successfully"
+// @Failure      400 {object} apperror.AppError "Invalid status"
+// @Failure      403 {object} apperror.AppError "Insufficient permissions"
+// @Failure      500 {object} apperror.AppError "Internal server error"
+// @Router       /organizations/{orgId}/join-requests [get]
+func (h *OrganizationHandler) ListJoinRequests(c echo.Context) error {
+	orgID, ok := c.Get(constant.OrganizationIDKey).(uuid.UUID)
 	if !ok {
-		return apperror.NewAppError(http.StatusUnauthorized, "User ID not found in context", nil)
+		return apperror.NewAppError(http.StatusBadRequest, constant.ErrMsgOrganizationContextRequired, nil)
 	}
 
-	if err := h.orgService.LeaveOrganization(c.Request().Context(), userID, orgID); err != nil {
+	joinRequests, err := h.orgService.ListJoinRequests(c.Request().Context(), orgID, c.QueryParam("status"))
+	if err != nil {
 		return err
 	}
 
-	return c.NoContent(http.StatusNoContent)
+	return c.JSON(http.StatusOK, map[string]interface{}{"join_requests": joinRequests})
 }
 
-// GetUserOrganizations handles retrieving user's organizations
-// @Summary      Get user organizations
-// @Description  Retrieves all organizations for the current user
-// @Tags         Organizations, Users
+// ApproveJoinRequest handles approving a join request with the role to grant.
+// @Summary      Approve a join request
+// @Description  Approves a pending join request of the organization in context and adds the requester with the chosen role. The role must be below the approver's level. Requires 'organizations:manage_members' permission.
+// @Tags         Organizations
 // @Accept       json
 // @Produce      json
+// @Param        orgId path string true "Organization ID" format(uuid)
+// @Param        requestId path string true "Join Request ID" format(uuid)
+// @Param        request body dto.ApproveJoinRequestRequest true "Role to grant"
 // @Security     BearerAuth
-// @Success      200 {array} dto.UserOrganizationResponse "User organizations retrieved successfully"
+// @Success      200 {object} dto.UserOrganizationResponse "Join request approved"
+// @Failure      400 {object} apperror.AppError "Invalid request payload or role"
+// @Failure      403 {object} apperror.AppError "Insufficient permissions or role above your level"
+// @Failure      404 {object} apperror.AppError "Join request or role not found"
+// @Failure      409 {object} apperror.AppError "Join request already decided"
 // @Failure      500 {object} apperror.AppError "Internal server error"
-// @Router       /users/me/organizations [get]
-func (h *OrganizationHandler) GetUserOrganizations(c echo.Context) error {
-	// Get user ID from context
+// @Router       /organizations/{orgId}/join-requests/{requestId}/approve [post]
+func (h *OrganizationHandler) ApproveJoinRequest(c echo.Context) error {
+	orgID, ok := c.Get(constant.OrganizationIDKey).(uuid.UUID)
+	if !ok {
+		return apperror.NewAppError(http.StatusBadRequest, constant.ErrMsgOrganizationContextRequired, nil)
+	}
+
+	requestID, err := uuid.Parse(c.Param("requestId"))
+	if err != nil {
+		return apperror.NewAppError(http.StatusBadRequest, "Invalid join request ID format", err)
+	}
+
+	var req dto.ApproveJoinRequestRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, constant.ErrMsgInvalidRequestFormat)
+	}
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
 	userID, ok := c.Get(constant.UserIDKey).(uuid.UUID)
 	if !ok {
 		return apperror.NewAppError(http.StatusUnauthorized, "User ID not found in context", nil)
 	}
 
-	userOrgs, err := h.orgService.GetUserOrganizations(c.Request().Context(), userID)
+	membership, err := h.orgService.ApproveJoinRequest(c.Request().Context(), orgID, requestID, req, userID)
 	if err != nil {
 		return err
 	}
 
-	return c.JSON(http.StatusOK, userOrgs)
+	return c.JSON(http.StatusOK, membership)
 }
 
-// GetOrganizationMembers handles retrieving organization members
-// @Summary      Get organization members
-// @Description  Retrieves all members of an organization
-// @Tags         Admin, Organizations
+// RejectJoinRequest handles rejecting a join request.
+// @Summary      Reject a join request
+// @Description  Rejects a pending join request of the organization in context. Requires 'organizations:manage_members' permission.
+// @Tags         Organizations
 // @Accept       json
 // @Produce      json
-// @Param        id path string true "Organization ID"
+// @Param        orgId path string true "Organization ID" format(uuid)
+// @Param        requestId path string true "Join Request ID" format(uuid)
+// @Param        request body dto.RejectJoinRequestRequest false "Rejection reason"
 // @Security     BearerAuth
-// @Success      200 {array} dto.UserOrganizationResponse "Organization members retrieved successfully"
-// @Failure      400 {object} apperror.AppError "Invalid organization ID"
+// @Success      200 {object} dto.JoinRequestResponse "Join request rejected"
+// @Failure      400 {object} apperror.AppError "Invalid request payload"
 // @Failure      403 {object} apperror.AppError "Insufficient permissions"
+// @Failure      404 {object} apperror.AppError "Join request not found"
+// @Failure      409 {object} apperror.AppError "Join request already decided"
 // @Failure      500 {object} apperror.AppError "Internal server error"
-// @Router       /admin/organizations/{id}/members [get]
-func (h *OrganizationHandler) GetOrganizationMembers(c echo.Context) error {
-	idStr := c.Param("id")
-	orgID, err := uuid.Parse(idStr)
+// @Router       /organizations/{orgId}/join-requests/{requestId}/reject [post]
+func (h *OrganizationHandler) RejectJoinRequest(c echo.Context) error {
+	orgID, ok := c.Get(constant.OrganizationIDKey).(uuid.UUID)
+	if !ok {
+		return apperror.NewAppError(http.StatusBadRequest, constant.ErrMsgOrganizationContextRequired, nil)
+	}
+
+	requestID, err := uuid.Parse(c.Param("requestId"))
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, "Invalid organization ID format")
+		return apperror.NewAppError(http.StatusBadRequest, "Invalid join request ID format", err)
+	}
+
+	var req dto.RejectJoinRequestRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, constant.ErrMsgInvalidRequestFormat)
+	}
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	userID, ok := c.Get(constant.UserIDKey).(uuid.UUID)
+	if !ok {
+		return apperror.NewAppError(http.StatusUnauthorized, "User ID not found in context", nil)
 	}
 
-	members, err := h.orgService.GetOrganizationMembers(c.Request().Context(), orgID)
+	joinRequest, err := h.orgService.RejectJoinRequest(c.Request().Context(), orgID, requestID, req, userID)
 	if err != nil {
 		return err
 	}
 
-	return c.JSON(http.StatusOK, members)
+	return c.JSON(http.StatusOK, joinRequest)
 }
 
 // CreateCompleteOrganizationStructure handles creation of complete organization structure for new users
@@ -455,3 +667,204 @@ func (h *OrganizationHandler) GetOrganizationStatistics(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, statistics)
 }
+
+// ArchiveOrganization handles archiving an organization
+// @Summary      Archive organization
+// @Description  Archives an organization, and with cascade its whole subtree. Archived organizations keep their data but accept no joins, invitations or role grants, and members can no longer use them as organization context. Each affected organization gets an audit entry. A cascade archive runs as a background job in batches of 100 organizations; poll the returned status URL for progress and the dto.ArchiveOrganizationResponse result. Requires 'organizations:update' permission.
+// @Tags         Admin, Organizations
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "Organization ID"
+// @Param        archive body dto.ArchiveOrganizationRequest true "Archive options"
+// @Security     BearerAuth
+// @Success      200 {object} dto.ArchiveOrganizationResponse "Organization archived successfully"
+// @Success      202 {object} dto.JobResponse "Cascade archive queued as a job"
+// @Failure      400 {object} apperror.AppError "Invalid request"
+// @Failure      403 {object} apperror.AppError "Insufficient permissions"
+// @Failure      404 {object} apperror.AppError "Organization not found"
+// @Failure      409 {object} apperror.AppError "Organization already archived"
+// @Failure      500 {object} apperror.AppError "Internal server error"
+// @Router       /admin/organizations/{id}/archive [post]
+func (h *OrganizationHandler) ArchiveOrganization(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid organization ID format")
+	}
+
+	var req dto.ArchiveOrganizationRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, constant.ErrMsgInvalidRequestFormat)
+	}
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	userID, ok := c.Get(constant.UserIDKey).(uuid.UUID)
+	if !ok {
+		return apperror.NewAppError(http.StatusUnauthorized, "User ID not found in context", nil)
+	}
+
+	if req.Cascade {
+		job, err := h.jobService.SubmitJob(c.Request().Context(), constant.JobTypeArchiveOrganization, dto.ArchiveOrganizationJobPayload{
+			OrganizationID: id,
+			Request:        req,
+		}, userID)
+		if err != nil {
+			return err
+		}
+		return c.JSON(http.StatusAccepted, job)
+	}
+
+	response, err := h.orgService.ArchiveOrganization(c.Request().Context(), id, req, userID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// UnarchiveOrganization handles lifting the archive of an organization
+// @Summary      Unarchive organization
+// @Description  Lifts the archive of an organization, and with cascade of every archived organization in its subtree. Requires 'organizations:update' permission.
+// @Tags         Admin, Organizations
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "Organization ID"
+// @Param        unarchive body dto.ArchiveOrganizationRequest true "Unarchive options"
+// @Security     BearerAuth
+// @Success      200 {object} dto.ArchiveOrganizationResponse "Organization unarchived successfully"
+// @Failure      400 {object} apperror.AppError "Invalid request"
+// @Failure      403 {object} apperror.AppError "Insufficient permissions"
+// @Failure      404 {object} apperror.AppError "Organization not found"
+// @Failure      409 {object} apperror.AppError "Organization not archived"
+// @Failure      500 {object} apperror.AppError "Internal server error"
+// @Router       /admin/organizations/{id}/unarchive [post]
+func (h *OrganizationHandler) UnarchiveOrganization(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid organization ID format")
+	}
+
+	var req dto.ArchiveOrganizationRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, constant.ErrMsgInvalidRequestFormat)
+	}
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	userID, ok := c.Get(constant.UserIDKey).(uuid.UUID)
+	if !ok {
+		return apperror.NewAppError(http.StatusUnauthorized, "User ID not found in context", nil)
+	}
+
+	response, err := h.orgService.UnarchiveOrganization(c.Request().Context(), id, req, userID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// RotateOrganizationCode handles replacing the join code of an organization
+// @Summary      Rotate organization code
+// @Description  Replaces the join code of an organization with a newly generated one, for example after it leaked. The old code stops working immediately. Requires 'organizations:update' permission.
+// @Tags         Admin, Organizations
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "Organization ID"
+// @Param        rotate body dto.RotateOrganizationCodeRequest false "Rotation reason"
+// @Security     BearerAuth
+// @Success      200 {object} dto.OrganizationResponse "Organization code rotated successfully"
+// @Failure      400 {object} apperror.AppError "Invalid request payload"
+// @Failure      403 {object} apperror.AppError "Insufficient permissions"
+// @Failure      404 {object} apperror.AppError "Organization not found"
+// @Failure      500 {object} apperror.AppError "Internal server error"
+// @Router       /admin/organizations/{id}/rotate-code [post]
+func (h *OrganizationHandler) RotateOrganizationCode(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid organization ID format")
+	}
+
+	var req dto.RotateOrganizationCodeRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, constant.ErrMsgInvalidRequestFormat)
+	}
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	userID, ok := c.Get(constant.UserIDKey).(uuid.UUID)
+	if !ok {
+		return apperror.NewAppError(http.StatusUnauthorized, "User ID not found in context", nil)
+	}
+
+	org, err := h.orgService.RotateOrganizationCode(c.Request().Context(), id, req, userID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, org)
+}
+
+// RestoreOrganization handles restoring a soft-deleted organization
+// @Summary      Restore deleted organization
+// @Description  Restores a soft-deleted organization that has not been purged yet. A deleted parent has to be restored first. Requires 'organizations:delete' permission.
+// @Tags         Admin, Organizations
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "Organization ID"
+// @Param        restore body dto.RestoreOrganizationRequest false "Restore reason"
+// @Security     BearerAuth
+// @Success      200 {object} dto.OrganizationResponse "Organization restored successfully"
+// @Failure      400 {object} apperror.AppError "Parent deleted or placement no longer allowed"
+// @Failure      403 {object} apperror.AppError "Insufficient permissions"
+// @Failure      404 {object} apperror.AppError "Deleted organization not found"
+// @Failure      500 {object} apperror.AppError "Internal server error"
+// @Router       /admin/organizations/{id}/restore [post]
+func (h *OrganizationHandler) RestoreOrganization(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid organization ID format")
+	}
+
+	var req dto.RestoreOrganizationRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, constant.ErrMsgInvalidRequestFormat)
+	}
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	userID, ok := c.Get(constant.UserIDKey).(uuid.UUID)
+	if !ok {
+		return apperror.NewAppError(http.StatusUnauthorized, "User ID not found in context", nil)
+	}
+
+	org, err := h.orgService.RestoreOrganization(c.Request().Context(), id, req, userID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, org)
+}
+
+// ListDeletedOrganizations handles listing soft-deleted organizations
+// @Summary      List deleted organizations
+// @Description  Lists soft-deleted organizations that can still be restored, most recently deleted first. Requires 'organizations:delete' permission.
+// @Tags         Admin, Organizations
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} map[string][]dto.OrganizationResponse "Deleted organizations"
+// @Failure      403 {object} apperror.AppError "Insufficient permissions"
+// @Failure      500 {object} apperror.AppError "Internal server error"
+// @Router       /admin/organizations/deleted [get]
+func (h *OrganizationHandler) ListDeletedOrganizations(c echo.Context) error {
+	orgs, err := h.orgService.ListDeletedOrganizations(c.Request().Context())
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{"organizations": orgs})
+}
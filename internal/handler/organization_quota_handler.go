@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"net/http"
+
+	"go-base-project/internal/apperror"
+	"go-base-project/internal/constant"
+	"go-base-project/internal/dto"
+	"go-base-project/internal/service"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// OrganizationQuotaHandler handles HTTP requests for organization plan limits.
+type OrganizationQuotaHandler struct {
+	quotaService service.OrganizationQuotaServiceInterface
+}
+
+// NewOrganizationQuotaHandler creates a new instance of OrganizationQuotaHandler.
+func NewOrganizationQuotaHandler(quotaService service.OrganizationQuotaServiceInterface) *OrganizationQuotaHandler {
+	return &OrganizationQuotaHandler{
+		quotaService: quotaService,
+	}
+}
+
+// GetQuotaUsage handles reporting the usage of the current organization against its limits.
+// @Summary      Get organization quota usage
+// @Description  Reports the current usage of every quota of the organization in context together with the limit that applies to it. Limits not set on the organization are inherited from the nearest ancestor that sets them; a null limit means unlimited. Requires 'organizations:read' permission.
+// @Tags         Organizations, Organization Quotas
+// @Produce      json
+// @Param        orgId path string true "Organization ID" format(uuid)
+// @Security     BearerAuth
+// @Success      200 {object} dto.OrganizationQuotaUsageResponse "Quota usage"
+// @Failure      403 {object} apperror.AppError "Insufficient permissions"
+// @Failure      404 {object} apperror.AppError "Organization not found"
+// @Failure      500 {object} apperror.AppError "Internal server error"
+// @Router       /organizations/{orgId}/quotas [get]
+func (h *OrganizationQuotaHandler) GetQuotaUsage(c echo.Context) error {
+	orgID, ok := c.Get(constant.OrganizationIDKey).(uuid.UUID)
+	if !ok {
+		return apperror.NewAppError(http.StatusBadRequest, constant.ErrMsgOrganizationContextRequired, nil)
+	}
+
+	usage, err := h.quotaService.GetUsage(c.Request().Context(), orgID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, usage)
+}
+
+// UpdateQuotas handles setting the plan limits of an organization.
+// @Summary      Update organization quotas
+// @Description  Sets limits on an organization; descendants inherit them unless they have their own. A null limit lifts an inherited limit, quotas listed in inherit drop the organization's own limit. Operations that would exceed a limit fail with error code QUOTA_EXCEEDED. Requires 'organizations:manage_quotas' permission.
+// @Tags         Admin, Organization Quotas
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "Organization ID" format(uuid)
+// @Param        request body dto.UpdateOrganizationQuotasRequest true "Limits by quota"
+// @Security     BearerAuth
+// @Success      200 {object} dto.OrganizationQuotaUsageResponse "Quota usage with the new limits"
+// @Failure      400 {object} apperror.AppError "Unknown quota or invalid limit"
+// @Failure      403 {object} apperror.AppError "Insufficient permissions"
+// @Failure      404 {object} apperror.AppError "Organization not found"
+// @Failure      500 {object} apperror.AppError "Internal server error"
+// @Router       /admin/organizations/{id}/quotas [put]
+func (h *OrganizationQuotaHandler) UpdateQuotas(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return apperror.NewAppError(http.StatusBadRequest, "Invalid organization ID format", err)
+	}
+
+	var req dto.UpdateOrganizationQuotasRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request format")
+	}
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	userID, ok := c.Get(constant.UserIDKey).(uuid.UUID)
+	if !ok {
+		return apperror.NewAppError(http.StatusUnauthorized, "User ID not found in context", nil)
+	}
+
+	usage, err := h.quotaService.UpdateQuotas(c.Request().Context(), id, req, userID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, usage)
+}
@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"net/http"
+
+	"go-base-project/internal/apperror"
+	"go-base-project/internal/constant"
+	"go-base-project/internal/dto"
+	"go-base-project/internal/service"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// OrganizationSettingHandler handles HTTP requests for per-organization settings.
+type OrganizationSettingHandler struct {
+	settingService service.OrganizationSettingServiceInterface
+}
+
+// NewOrganizationSettingHandler creates a new instance of OrganizationSettingHandler.
+func NewOrganizationSettingHandler(settingService service.OrganizationSettingServiceInterface) *OrganizationSettingHandler {
+	return &OrganizationSettingHandler{
+		settingService: settingService,
+	}
+}
+
+// GetSettingsSchema handles listing the settings organizations can configure.
+// @Summary      Get the organization settings schema
+// @Description  Lists every organization setting with its type, default and accepted values. Keys under custom.* are free-form values for applications.
+// @Tags         Organizations, Organization Settings
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} map[string][]dto.OrganizationSettingDefinitionResponse "Settings schema"
+// @Router       /organizations/settings/schema [get]
+func (h *OrganizationSettingHandler) GetSettingsSchema(c echo.Context) error {
+	return c.JSON(http.StatusOK, map[string]interface{}{"settings": h.settingService.GetSchema()})
+}
+
+// GetSettings handles retrieving the effective settings of the current organization.
+// @Summary      Get organization settings
+// @Description  Returns the effective value of every setting of the organization in context. Values not set on the organization are inherited from the nearest ancestor that sets them, or fall back to the schema default; source tells which applies. Requires 'organizations:read' permission.
+// @Tags         Organizations, Organization Settings
+// @Produce      json
+// @Param        orgId path string true "Organization ID" format(uuid)
+// @Security     BearerAuth
+// @Success      200 {object} dto.OrganizationSettingsResponse "Effective organization settings"
+// @Failure      403 {object} apperror.AppError "Insufficient permissions"
+// @Failure      404 {object} apperror.AppError "Organization not found"
+// @Failure      500 {object} apperror.AppError "Internal server error"
+// @Router       /organizations/{orgId}/settings [get]
+func (h *OrganizationSettingHandler) GetSettings(c echo.Context) error {
+	orgID, ok := c.Get(constant.OrganizationIDKey).(uuid.UUID)
+	if !ok {
+		return apperror.NewAppError(http.StatusBadRequest, constant.ErrMsgOrganizationContextRequired, nil)
+	}
+
+	settings, err := h.settingService.GetSettings(c.Request().Context(), orgID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, settings)
+}
+
+// UpdateSettings handles setting or removing settings of the current organization.
+// @Summary      Update organization settings
+// @Description  Sets the given settings on the organization in context; child organizations inherit them unless they override them. A null value removes the organization's own value so the setting is inherited again. Settings not in the body are left unchanged. Requires 'organizations:update' permission.
+// @Tags         Organizations, Organization Settings
+// @Accept       json
+// @Produce      json
+// @Param        orgId path string true "Organization ID" format(uuid)
+// @Param        request body dto.UpdateOrganizationSettingsRequest true "Settings by key"
+// @Security     BearerAuth
+// @Success      200 {object} dto.OrganizationSettingsResponse "Effective organization settings"
+// @Failure      400 {object} apperror.AppError "Unknown setting or invalid value"
+// @Failure      403 {object} apperror.AppError "Insufficient permissions"
+// @Failure      404 {object} apperror.AppError "Organization not found"
+// @Failure      500 {object} apperror.AppError "Internal server error"
+// @Router       /organizations/{orgId}/settings [put]
+func (h *OrganizationSettingHandler) UpdateSettings(c echo.Context) error {
+	orgID, ok := c.Get(constant.OrganizationIDKey).(uuid.UUID)
+	if !ok {
+		return apperror.NewAppError(http.StatusBadRequest, constant.ErrMsgOrganizationContextRequired, nil)
+	}
+
+	var req dto.UpdateOrganizationSettingsRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request format")
+	}
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	userID, ok := c.Get(constant.UserIDKey).(uuid.UUID)
+	if !ok {
+		return apperror.NewAppError(http.StatusUnauthorized, "User ID not found in context", nil)
+	}
+
+	settings, err := h.settingService.UpdateSettings(c.Request().Context(), orgID, req, userID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, settings)
+}
@@ -0,0 +1,269 @@
+package handler
+
+import (
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+
+	"go-base-project/internal/apperror"
+	"go-base-project/internal/constant"
+	"go-base-project/internal/dto"
+	"go-base-project/internal/service"
+
+	"github.com/ghodss/yaml"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// maxTemplateBodySize bounds the size of a template request body
+const maxTemplateBodySize = 1 << 20
+
+// OrganizationTemplateHandler handles HTTP requests for organization structure templates.
+type OrganizationTemplateHandler struct {
+	templateService service.OrganizationTemplateServiceInterface
+}
+
+// NewOrganizationTemplateHandler creates a new instance of OrganizationTemplateHandler.
+func NewOrganizationTemplateHandler(templateService service.OrganizationTemplateServiceInterface) *OrganizationTemplateHandler {
+	return &OrganizationTemplateHandler{
+		templateService: templateService,
+	}
+}
+
+// ListTemplates handles listing the saved organization templates.
+// @Summary      List organization templates
+// @Description  Lists the saved organization structure templates with the variables they need. Requires 'organizations:read' permission.
+// @Tags         Admin, Organization Templates
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} map[string][]dto.OrganizationTemplateResponse "Organization templates"
+// @Failure      403 {object} apperror.AppError "Insufficient permissions"
+// @Failure      500 {object} apperror.AppError "Internal server error"
+// @Router       /admin/organization-templates [get]
+func (h *OrganizationTemplateHandler) ListTemplates(c echo.Context) error {
+	templates, err := h.templateService.ListTemplates(c.Request().Context())
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{"templates": templates})
+}
+
+// GetTemplate handles fetching a single organization template.
+// @Summary      Get an organization template
+// @Description  Returns one saved organization structure template. Requires 'organizations:read' permission.
+// @Tags         Admin, Organization Templates
+// @Produce      json
+// @Param        id path string true "Template ID" format(uuid)
+// @Security     BearerAuth
+// @Success      200 {object} dto.OrganizationTemplateResponse "Organization template"
+// @Failure      400 {object} apperror.AppError "Invalid template ID"
+// @Failure      403 {object} apperror.AppError "Insufficient permissions"
+// @Failure      404 {object} apperror.AppError "Organization template not found"
+// @Failure      500 {object} apperror.AppError "Internal server error"
+// @Router       /admin/organization-templates/{id} [get]
+func (h *OrganizationTemplateHandler) GetTemplate(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return apperror.NewAppError(http.StatusBadRequest, "Invalid template ID format", err)
+	}
+
+	template, err := h.templateService.GetTemplate(c.Request().Context(), id)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, template)
+}
+
+// CreateTemplate handles saving a new organization template.
+// @Summary      Create an organization template
+// @Description  Saves a reusable organization structure template. The body may be sent as JSON or YAML (Content-Type application/yaml). Names, descriptions and member references may use {{variable}} placeholders. Requires 'organizations:create' permission.
+// @Tags         Admin, Organization Templates
+// @Accept       json
+// @Accept       application/yaml
+// @Produce      json
+// @Param        request body dto.CreateOrganizationTemplateRequest true "Template Details"
+// @Security     BearerAuth
+// @Success      201 {object} dto.OrganizationTemplateResponse "Organization template created"
+// @Failure      400 {object} apperror.AppError "Invalid request payload or structure"
+// @Failure      403 {object} apperror.AppError "Insufficient permissions"
+// @Failure      409 {object} apperror.AppError "Template name already exists"
+// @Failure      500 {object} apperror.AppError "Internal server error"
+// @Router       /admin/organization-templates [post]
+func (h *OrganizationTemplateHandler) CreateTemplate(c echo.Context) error {
+	var req dto.CreateOrganizationTemplateRequest
+	if err := bindTemplateRequest(c, &req); err != nil {
+		return err
+	}
+
+	userID, ok := c.Get(constant.UserIDKey).(uuid.UUID)
+	if !ok {
+		return apperror.NewAppError(http.StatusUnauthorized, "User ID not found in context", nil)
+	}
+
+	template, err := h.templateService.CreateTemplate(c.Request().Context(), req, userID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, template)
+}
+
+// UpdateTemplate handles updating a saved organization template.
+// @Summary      Update an organization template
+// @Description  Replaces the name, description and definition of a saved organization template. The body may be sent as JSON or YAML. Requires 'organizations:create' permission.
+// @Tags         Admin, Organization Templates
+// @Accept       json
+// @Accept       application/yaml
+// @Produce      json
+// @Param        id path string true "Template ID" format(uuid)
+// @Param        request body dto.UpdateOrganizationTemplateRequest true "Template Details"
+// @Security     BearerAuth
+// @Success      200 {object} dto.OrganizationTemplateResponse "Organization template updated"
+// @Failure      400 {object} apperror.AppError "Invalid request payload or structure"
+// @Failure      403 {object} apperror.AppError "Insufficient permissions"
+// @Failure      404 {object} apperror.AppError "Organization template not found"
+// @Failure      409 {object} apperror.AppError "Template name already exists"
+// @Failure      500 {object} apperror.AppError "Internal server error"
+// @Router       /admin/organization-templates/{id} [put]
+func (h *OrganizationTemplateHandler) UpdateTemplate(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return apperror.NewAppError(http.StatusBadRequest, "Invalid template ID format", err)
+	}
+
+	var req dto.UpdateOrganizationTemplateRequest
+	if err := bindTemplateRequest(c, &req); err != nil {
+		return err
+	}
+
+	userID, ok := c.Get(constant.UserIDKey).(uuid.UUID)
+	if !ok {
+		return apperror.NewAppError(http.StatusUnauthorized, "User ID not found in context", nil)
+	}
+
+	template, err := h.templateService.UpdateTemplate(c.Request().Context(), id, req, userID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, template)
+}
+
+// DeleteTemplate handles deleting a saved organization template.
+// @Summary      Delete an organization template
+// @Description  Deletes a saved organization template. Organizations created from it are kept. Requires 'organizations:create' permission.
+// @Tags         Admin, Organization Templates
+// @Param        id path string true "Template ID" format(uuid)
+// @Security     BearerAuth
+// @Success      204 "Organization template deleted"
+// @Failure      400 {object} apperror.AppError "Invalid template ID"
+// @Failure      403 {object} apperror.AppError "Insufficient permissions"
+// @Failure      404 {object} apperror.AppError "Organization template not found"
+// @Failure      500 {object} apperror.AppError "Internal server error"
+// @Router       /admin/organization-templates/{id} [delete]
+func (h *OrganizationTemplateHandler) DeleteTemplate(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return apperror.NewAppError(http.StatusBadRequest, "Invalid template ID format", err)
+	}
+
+	if err := h.templateService.DeleteTemplate(c.Request().Context(), id); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// ValidateTemplate handles the dry run of an organization template.
+// @Summary      Validate an organization template (dry run)
+// @Description  Resolves a saved (template_id) or inline (definition) template with its variables against the organization type registry, users and roles, and returns the organizations it would create together with every issue found. Nothing is created. The body may be sent as JSON or YAML. Requires 'organizations:create' permission.
+// @Tags         Admin, Organization Templates
+// @Accept       json
+// @Accept       application/yaml
+// @Produce      json
+// @Param        request body dto.ApplyOrganizationTemplateRequest true "Template and variables"
+// @Security     BearerAuth
+// @Success      200 {object} dto.OrganizationTemplatePlanResponse "Template plan; valid is false when issues were found"
+// @Failure      400 {object} apperror.AppError "Invalid request payload"
+// @Failure      403 {object} apperror.AppError "Insufficient permissions"
+// @Failure      404 {object} apperror.AppError "Template or parent organization not found"
+// @Failure      409 {object} apperror.AppError "Parent organization is archived"
+// @Failure      500 {object} apperror.AppError "Internal server error"
+// @Router       /admin/organization-templates/validate [post]
+func (h *OrganizationTemplateHandler) ValidateTemplate(c echo.Context) error {
+	var req dto.ApplyOrganizationTemplateRequest
+	if err := bindTemplateRequest(c, &req); err != nil {
+		return err
+	}
+
+	plan, err := h.templateService.ValidateTemplate(c.Request().Context(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, plan)
+}
+
+// ApplyTemplate handles creating the organization structure of a template.
+// @Summary      Apply an organization template
+// @Description  Creates all organizations and member assignments of a saved or inline template in a single transaction and reports the generated organization codes. A template with issues is rejected as a whole; use the validate endpoint to review them. The body may be sent as JSON or YAML. Requires 'organizations:create' permission.
+// @Tags         Admin, Organization Templates
+// @Accept       json
+// @Accept       application/yaml
+// @Produce      json
+// @Param        request body dto.ApplyOrganizationTemplateRequest true "Template and variables"
+// @Security     BearerAuth
+// @Success      201 {object} dto.OrganizationTemplatePlanResponse "Organization structure created"
+// @Failure      400 {object} apperror.AppError "Invalid request payload or template issues"
+// @Failure      403 {object} apperror.AppError "Insufficient permissions"
+// @Failure      404 {object} apperror.AppError "Template or parent organization not found"
+// @Failure      409 {object} apperror.AppError "Parent organization is archived"
+// @Failure      500 {object} apperror.AppError "Internal server error"
+// @Router       /admin/organization-templates/apply [post]
+func (h *OrganizationTemplateHandler) ApplyTemplate(c echo.Context) error {
+	var req dto.ApplyOrganizationTemplateRequest
+	if err := bindTemplateRequest(c, &req); err != nil {
+		return err
+	}
+
+	userID, ok := c.Get(constant.UserIDKey).(uuid.UUID)
+	if !ok {
+		return apperror.NewAppError(http.StatusUnauthorized, "User ID not found in context", nil)
+	}
+
+	plan, err := h.templateService.ApplyTemplate(c.Request().Context(), req, userID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, plan)
+}
+
+// bindTemplateRequest decodes a JSON or YAML request body into req and validates it. YAML is converted to
+// JSON first so both formats share the json tags of the DTOs.
+func bindTemplateRequest(c echo.Context, req interface{}) error {
+	body, err := io.ReadAll(io.LimitReader(c.Request().Body, maxTemplateBodySize+1))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, constant.ErrMsgInvalidRequestFormat)
+	}
+	if len(body) > maxTemplateBodySize {
+		return echo.NewHTTPError(http.StatusRequestEntityTooLarge, "Template body is too large")
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(c.Request().Header.Get(echo.HeaderContentType))
+	switch mediaType {
+	case "application/yaml", "application/x-yaml", "text/yaml":
+		body, err = yaml.YAMLToJSON(body)
+		if err != nil {
+			return apperror.NewAppError(http.StatusBadRequest, "Invalid YAML body", err)
+		}
+	}
+
+	if err := json.Unmarshal(body, req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, constant.ErrMsgInvalidRequestFormat)
+	}
+	return c.Validate(req)
+}
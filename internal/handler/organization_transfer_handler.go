@@ -0,0 +1,149 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"go-base-project/internal/apperror"
+	"go-base-project/internal/constant"
+	"go-base-project/internal/dto"
+	"go-base-project/internal/service"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// OrganizationTransferHandler handles HTTP requests for exporting and importing organization subtrees.
+type OrganizationTransferHandler struct {
+	transferService service.OrganizationTransferServiceInterface
+	jobService      service.JobServiceInterface
+}
+
+// NewOrganizationTransferHandler creates a new instance of OrganizationTransferHandler.
+func NewOrganizationTransferHandler(transferService service.OrganizationTransferServiceInterface, jobService service.JobServiceInterface) *OrganizationTransferHandler {
+	return &OrganizationTransferHandler{
+		transferService: transferService,
+		jobService:      jobService,
+	}
+}
+
+// ExportOrganizationTree handles exporting an organization and its descendants.
+// @Summary      Export an organization subtree
+// @Description  Exports an organization and all its descendants with their memberships, role names and own settings. The JSON document is versioned and can be imported into another environment; format=csv returns a flattened report with one row per membership. Requires 'organizations:read' permission.
+// @Tags         Admin, Organization Transfer
+// @Produce      json
+// @Produce      text/csv
+// @Param        id path string true "Root organization ID" format(uuid)
+// @Param        format query string false "Export format" Enums(json, csv) default(json)
+// @Security     BearerAuth
+// @Success      200 {object} dto.OrganizationExportDocument "Export document"
+// @Failure      400 {object} apperror.AppError "Invalid organization ID or format"
+// @Failure      403 {object} apperror.AppError "Insufficient permissions"
+// @Failure      404 {object} apperror.AppError "Organization not found"
+// @Failure      500 {object} apperror.AppError "Internal server error"
+// @Router       /admin/organizations/{id}/export [get]
+func (h *OrganizationTransferHandler) ExportOrganizationTree(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return apperror.NewAppError(http.StatusBadRequest, "Invalid organization ID format", err)
+	}
+
+	switch c.QueryParam("format") {
+	case "", "json":
+		document, err := h.transferService.ExportOrganizationTree(c.Request().Context(), id)
+		if err != nil {
+			return err
+		}
+		return c.JSON(http.StatusOK, document)
+	case "csv":
+		data, err := h.transferService.ExportOrganizationTreeCSV(c.Request().Context(), id)
+		if err != nil {
+			return err
+		}
+		c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=\"organization-%s.csv\"", id))
+		return c.Blob(http.StatusOK, "text/csv", data)
+	default:
+		return apperror.NewAppError(http.StatusBadRequest, "Invalid export format, expected json or csv", nil)
+	}
+}
+
+// SubmitOrganizationExport handles queueing the export of a large organization subtree.
+// @Summary      Export an organization subtree in the background
+// @Description  Queues the export of an organization and all its descendants as a background job, for subtrees too large to export within a request. Poll the returned status URL; the dto.OrganizationExportJobResult result holds the JSON document or the CSV report. Requires 'organizations:read' permission.
+// @Tags         Admin, Organization Transfer
+// @Produce      json
+// @Param        id path string true "Root organization ID" format(uuid)
+// @Param        format query string false "Export format" Enums(json, csv) default(json)
+// @Security     BearerAuth
+// @Success      202 {object} dto.JobResponse "Export queued as a job"
+// @Failure      400 {object} apperror.AppError "Invalid organization ID or format"
+// @Failure      403 {object} apperror.AppError "Insufficient permissions"
+// @Failure      500 {object} apperror.AppError "Internal server error"
+// @Router       /admin/organizations/{id}/export [post]
+func (h *OrganizationTransferHandler) SubmitOrganizationExport(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return apperror.NewAppError(http.StatusBadRequest, "Invalid organization ID format", err)
+	}
+
+	format := c.QueryParam("format")
+	switch format {
+	case "":
+		format = "json"
+	case "json", "csv":
+	default:
+		return apperror.NewAppError(http.StatusBadRequest, "Invalid export format, expected json or csv", nil)
+	}
+
+	userID, ok := c.Get(constant.UserIDKey).(uuid.UUID)
+	if !ok {
+		return apperror.NewAppError(http.StatusUnauthorized, "User ID not found in context", nil)
+	}
+
+	job, err := h.jobService.SubmitJob(c.Request().Context(), constant.JobTypeExportOrganization, dto.ExportOrganizationJobPayload{
+		OrganizationID: id,
+		Format:         format,
+	}, userID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusAccepted, job)
+}
+
+// ImportOrganizationTree handles importing an exported organization subtree.
+// @Summary      Import an organization subtree
+// @Description  Imports an export document below the given parent organization, or at the top level. Organizations get new IDs; members are matched to existing users by email, then username. Codes already in use are handled by the conflict strategy: skip leaves the existing organization untouched, overwrite updates it, rename creates a new organization with a generated code. With dry_run the report is returned without changing anything. Nothing is applied when the report contains errors. Requires 'organizations:create' permission.
+// @Tags         Admin, Organization Transfer
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.ImportOrganizationTreeRequest true "Export document and import options"
+// @Security     BearerAuth
+// @Success      200 {object} dto.OrganizationImportReport "Import report"
+// @Failure      400 {object} apperror.AppError "Invalid document"
+// @Failure      403 {object} apperror.AppError "Insufficient permissions or quota exceeded"
+// @Failure      404 {object} apperror.AppError "Parent organization not found"
+// @Failure      409 {object} apperror.AppError "Organization code taken during import"
+// @Failure      500 {object} apperror.AppError "Internal server error"
+// @Router       /admin/organizations/import [post]
+func (h *OrganizationTransferHandler) ImportOrganizationTree(c echo.Context) error {
+	var req dto.ImportOrganizationTreeRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request format")
+	}
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	userID, ok := c.Get(constant.UserIDKey).(uuid.UUID)
+	if !ok {
+		return apperror.NewAppError(http.StatusUnauthorized, "User ID not found in context", nil)
+	}
+
+	report, err := h.transferService.ImportOrganizationTree(c.Request().Context(), req, userID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,210 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"go-base-project/internal/apperror"
+	"go-base-project/internal/constant"
+	"go-base-project/internal/dto"
+	"go-base-project/internal/service"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// OrganizationTypeHandler handles HTTP requests for the organization type registry.
+type OrganizationTypeHandler struct {
+	orgTypeService service.OrganizationTypeServiceInterface
+}
+
+// NewOrganizationTypeHandler creates a new instance of OrganizationTypeHandler.
+func NewOrganizationTypeHandler(orgTypeService service.OrganizationTypeServiceInterface) *OrganizationTypeHandler {
+	return &OrganizationTypeHandler{
+		orgTypeService: orgTypeService,
+	}
+}
+
+// ListOrganizationTypes handles listing the registered organization types.
+// @Summary      List organization types
+// @Description  Lists the organization types of the registry with their placement rules, role level band and default roles. Requires 'organizations:read' permission.
+// @Tags         Admin, Organization Types
+// @Produce      json
+// @Param        include_inactive query bool false "Include deactivated types"
+// @Security     BearerAuth
+// @Success      200 {object} map[string][]dto.OrganizationTypeResponse "Organization types"
+// @Failure      400 {object} apperror.AppError "Invalid query parameter"
+// @Failure      403 {object} apperror.AppError "Insufficient permissions"
+// @Failure      500 {object} apperror.AppError "Internal server error"
+// @Router       /admin/organization-types [get]
+func (h *OrganizationTypeHandler) ListOrganizationTypes(c echo.Context) error {
+	includeInactive := false
+	if includeStr := c.QueryParam("include_inactive"); includeStr != "" {
+		parsed, err := strconv.ParseBool(includeStr)
+		if err != nil {
+			return apperror.NewAppError(http.StatusBadRequest, "Invalid include_inactive parameter", err)
+		}
+		includeInactive = parsed
+	}
+
+	orgTypes, err := h.orgTypeService.ListOrganizationTypes(c.Request().Context(), includeInactive)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{"organization_types": orgTypes})
+}
+
+// GetOrganizationType handles fetching a single organization type.
+// @Summary      Get an organization type
+// @Description  Returns one organization type of the registry. Requires 'organizations:read' permission.
+// @Tags         Admin, Organization Types
+// @Produce      json
+// @Param        name path string true "Organization type name"
+// @Security     BearerAuth
+// @Success      200 {object} dto.OrganizationTypeResponse "Organization type"
+// @Failure      403 {object} apperror.AppError "Insufficient permissions"
+// @Failure      404 {object} apperror.AppError "Organization type not found"
+// @Failure      500 {object} apperror.AppError "Internal server error"
+// @Router       /admin/organization-types/{name} [get]
+func (h *OrganizationTypeHandler) GetOrganizationType(c echo.Context) error {
+	orgType, err := h.orgTypeService.GetOrganizationType(c.Request().Context(), c.Param("name"))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, orgType)
+}
+
+// CreateOrganizationType handles registering a new organization type.
+// @Summary      Create an organization type
+// @Description  Registers a new organization type with its allowed parent types, maximum depth, role level band and optional default roles. Requires 'organizations:create' permission.
+// @Tags         Admin, Organization Types
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.CreateOrganizationTypeRequest true "Organization Type Details"
+// @Security     BearerAuth
+// @Success      201 {object} dto.OrganizationTypeResponse "Organization type created"
+// @Failure      400 {object} apperror.AppError "Invalid request payload or placement rules"
+// @Failure      403 {object} apperror.AppError "Insufficient permissions"
+// @Failure      404 {object} apperror.AppError "Parent type or default role not found"
+// @Failure      409 {object} apperror.AppError "Organization type already exists"
+// @Failure      500 {object} apperror.AppError "Internal server error"
+// @Router       /admin/organization-types [post]
+func (h *OrganizationTypeHandler) CreateOrganizationType(c echo.Context) error {
+	var req dto.CreateOrganizationTypeRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, constant.ErrMsgInvalidRequestFormat)
+	}
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	userID, ok := c.Get(constant.UserIDKey).(uuid.UUID)
+	if !ok {
+		return apperror.NewAppError(http.StatusUnauthorized, "User ID not found in context", nil)
+	}
+
+	orgType, err := h.orgTypeService.CreateOrganizationType(c.Request().Context(), req, userID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, orgType)
+}
+
+// UpdateOrganizationType handles updating a registered organization type.
+// @Summary      Update an organization type
+// @Description  Updates the placement rules, role level band, activation state and optionally the default roles of an organization type. A type still used by organizations cannot be deactivated. Requires 'organizations:update' permission.
+// @Tags         Admin, Organization Types
+// @Accept       json
+// @Produce      json
+// @Param        name path string true "Organization type name"
+// @Param        request body dto.UpdateOrganizationTypeRequest true "Organization Type Details"
+// @Security     BearerAuth
+// @Success      200 {object} dto.OrganizationTypeResponse "Organization type updated"
+// @Failure      400 {object} apperror.AppError "Invalid request payload or placement rules"
+// @Failure      403 {object} apperror.AppError "Insufficient permissions"
+// @Failure      404 {object} apperror.AppError "Organization type, parent type or default role not found"
+// @Failure      409 {object} apperror.AppError "Organization type still in use"
+// @Failure      500 {object} apperror.AppError "Internal server error"
+// @Router       /admin/organization-types/{name} [put]
+func (h *OrganizationTypeHandler) UpdateOrganizationType(c echo.Context) error {
+	var req dto.UpdateOrganizationTypeRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, constant.ErrMsgInvalidRequestFormat)
+	}
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	userID, ok := c.Get(constant.UserIDKey).(uuid.UUID)
+	if !ok {
+		return apperror.NewAppError(http.StatusUnauthorized, "User ID not found in context", nil)
+	}
+
+	orgType, err := h.orgTypeService.UpdateOrganizationType(c.Request().Context(), c.Param("name"), req, userID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, orgType)
+}
+
+// GetOrganizationTypeAttributes handles retrieving the custom attribute schema of an organization type.
+// @Summary      Get organization type attributes
+// @Description  Returns the custom attributes members of organizations of this type carry, such as an employee number for stores. Requires 'organizations:read' permission.
+// @Tags         Admin, Organization Types
+// @Produce      json
+// @Param        name path string true "Organization type name"
+// @Security     BearerAuth
+// @Success      200 {object} dto.OrganizationTypeAttributesResponse "Attribute schema"
+// @Failure      403 {object} apperror.AppError "Insufficient permissions"
+// @Failure      404 {object} apperror.AppError "Organization type not found"
+// @Failure      500 {object} apperror.AppError "Internal server error"
+// @Router       /admin/organization-types/{name}/attributes [get]
+func (h *OrganizationTypeHandler) GetOrganizationTypeAttributes(c echo.Context) error {
+	attributes, err := h.orgTypeService.GetOrganizationTypeAttributes(c.Request().Context(), c.Param("name"))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, attributes)
+}
+
+// UpdateOrganizationTypeAttributes handles replacing the custom attribute schema of an organization type.
+// @Summary      Update organization type attributes
+// @Description  Replaces the custom attribute schema of an organization type. Attributes are strings, numbers or booleans; string attributes can be limited to allowed values, a pattern and a maximum length. Values already stored on memberships are kept and validated against the new schema the next time they are set. Requires 'organizations:update' permission.
+// @Tags         Admin, Organization Types
+// @Accept       json
+// @Produce      json
+// @Param        name path string true "Organization type name"
+// @Param        request body dto.UpdateOrganizationTypeAttributesRequest true "Attribute schema"
+// @Security     BearerAuth
+// @Success      200 {object} dto.OrganizationTypeAttributesResponse "Attribute schema updated"
+// @Failure      400 {object} apperror.AppError "Invalid request payload or attribute definitions"
+// @Failure      403 {object} apperror.AppError "Insufficient permissions"
+// @Failure      404 {object} apperror.AppError "Organization type not found"
+// @Failure      500 {object} apperror.AppError "Internal server error"
+// @Router       /admin/organization-types/{name}/attributes [put]
+func (h *OrganizationTypeHandler) UpdateOrganizationTypeAttributes(c echo.Context) error {
+	var req dto.UpdateOrganizationTypeAttributesRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, constant.ErrMsgInvalidRequestFormat)
+	}
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	userID, ok := c.Get(constant.UserIDKey).(uuid.UUID)
+	if !ok {
+		return apperror.NewAppError(http.StatusUnauthorized, "User ID not found in context", nil)
+	}
+
+	attributes, err := h.orgTypeService.UpdateOrganizationTypeAttributes(c.Request().Context(), c.Param("name"), req, userID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, attributes)
+}
@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"go-base-project/internal/apperror"
+	"go-base-project/internal/constant"
+	"go-base-project/internal/dto"
+	"go-base-project/internal/service"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// PersonalDataHandler handles HTTP requests for exporting and erasing personal data.
+type PersonalDataHandler struct {
+	personalDataService service.PersonalDataServiceInterface
+}
+
+// NewPersonalDataHandler creates a new instance of PersonalDataHandler.
+func NewPersonalDataHandler(personalDataService service.PersonalDataServiceInterface) *PersonalDataHandler {
+	return &PersonalDataHandler{
+		personalDataService: personalDataService,
+	}
+}
+
+// ExportMyData handles exporting the personal data of the current user.
+// @Summary      Export my personal data
+// @Description  Returns a machine-readable bundle of everything held about the caller: profile, sign-in identities, organization memberships, organization and status history entries, active sessions and the audit records the caller appears in as actor or subject. Sessions are identified by a fingerprint; tokens are never exported.
+// @Tags         Auth
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} dto.PersonalDataExport "Personal data bundle"
+// @Failure      401 {object} apperror.AppError "Unauthorized"
+// @Failure      500 {object} apperror.AppError "Internal server error"
+// @Router       /auth/me/export [get]
+func (h *PersonalDataHandler) ExportMyData(c echo.Context) error {
+	userID, ok := c.Get(constant.UserIDKey).(uuid.UUID)
+	if !ok {
+		return apperror.NewAppError(http.StatusUnauthorized, "User ID not found in context", nil)
+	}
+
+	export, err := h.personalDataService.ExportPersonalData(c.Request().Context(), userID)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=\"personal-data-%s.json\"", userID))
+	return c.JSON(http.StatusOK, export)
+}
+
+// RequestUserErasure handles requesting the erasure of a user's personal data.
+// @Summary      Erase a user's personal data
+// @Description  Submits a change request to erase a user's personal data, including deleted users. Once approved, the user row is anonymised in place and soft-deleted so history entries and organizations created by the user stay valid; memberships, pending join requests and pending invitations addressed to the user are removed, sessions are revoked and the erasure is logged. Callers cannot erase themselves or users whose role level is not below their own. Requires 'users:erase' permission.
+// @Tags         Admin, Users
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "User ID" format(uuid)
+// @Param        request body dto.EraseUserRequest true "Erasure details"
+// @Security     BearerAuth
+// @Success      202 {object} dto.ChangeRequestResponse "Erasure submitted for approval"
+// @Failure      400 {object} apperror.AppError "Invalid user ID or request payload"
+// @Failure      403 {object} apperror.AppError "Insufficient permissions or authority over the user"
+// @Failure      404 {object} apperror.AppError "User not found"
+// @Failure      409 {object} apperror.AppError "User already erased or erasure already pending approval"
+// @Failure      500 {object} apperror.AppError "Internal server error"
+// @Router       /admin/users/{id}/erase [post]
+func (h *PersonalDataHandler) RequestUserErasure(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return apperror.NewAppError(http.StatusBadRequest, constant.ErrMsgInvalidUserID, err)
+	}
+
+	var req dto.EraseUserRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, constant.ErrMsgInvalidRequestFormat)
+	}
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	requestedBy, ok := c.Get(constant.UserIDKey).(uuid.UUID)
+	if !ok {
+		return apperror.NewAppError(http.StatusUnauthorized, "User ID not found in context", nil)
+	}
+
+	changeRequest, err := h.personalDataService.RequestErasure(c.Request().Context(), id, req, requestedBy)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusAccepted, changeRequest)
+}
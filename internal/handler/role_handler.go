@@ -5,6 +5,7 @@ import (
 	"go-base-project/internal/constant"
 	"go-base-project/internal/dto"
 	"go-base-project/internal/service"
+	"fmt"
 	"net/http"
 
 	"github.com/google/uuid"
@@ -13,13 +14,15 @@ import (
 
 // RoleHandler handles HTTP requests related to role management.
 type RoleHandler struct {
-	roleService service.RoleServiceInterface
+	roleService     service.RoleServiceInterface
+	approvalService service.ApprovalServiceInterface
 }
 
 // NewRoleHandler creates a new instance of RoleHandler.
-func NewRoleHandler(roleService service.RoleServiceInterface) *RoleHandler {
+func NewRoleHandler(roleService service.RoleServiceInterface, approvalService service.ApprovalServiceInterface) *RoleHandler {
 	return &RoleHandler{
-		roleService: roleService,
+		roleService:     roleService,
+		approvalService: approvalService,
 	}
 }
 
@@ -160,7 +163,7 @@ func (h *RoleHandler) UpdateRole(c echo.Context) error {
 
 // UpdateRolePermissions handles updating permissions for a role.
 // @Summary      Update permissions for a role
-// @Description  Updates the list of permissions associated with a specific role. This action requires 'roles:assign' permission.
+// @Description  Updates the list of permissions associated with a specific role. Changes to system roles are submitted as a change request and applied once approved. This action requires 'roles:assign' permission.
 // @Tags         Admin, Roles
 // @Accept       json
 // @Produce      json
@@ -168,6 +171,7 @@ func (h *RoleHandler) UpdateRole(c echo.Context) error {
 // @Param        permissions body dto.UpdateRolePermissionsRequest true "List of permission names"
 // @Security     BearerAuth
 // @Success      200 {object} map[string]string "Role permissions updated successfully"
+// @Success      202 {object} dto.ChangeRequestResponse "System role change submitted for approval"
 // @Failure      400 {object} apperror.AppError "Invalid role ID format or validation failed"
 // @Failure      403 {object} apperror.AppError "Insufficient permissions"
 // @Failure      404 {object} apperror.AppError "Role not found"
@@ -188,6 +192,32 @@ func (h *RoleHandler) UpdateRolePermissions(c echo.Context) error {
 		return err // Error sudah dalam format HTTPError dari custom validator
 	}
 
+	role, err := h.roleService.GetRoleByID(c.Request().Context(), roleID)
+	if err != nil {
+		return err
+	}
+
+	// Permission changes on system roles affect every organization, so they go through approval
+	if role.IsSystemRole {
+		requestedBy, ok := c.Get(constant.UserIDKey).(uuid.UUID)
+		if !ok {
+			return apperror.NewAppError(http.StatusUnauthorized, "User ID not found in context", nil)
+		}
+
+		changeRequest, err := h.approvalService.SubmitChangeRequest(
+			c.Request().Context(),
+			constant.ChangeRequestActionUpdateRolePermissions,
+			&roleID,
+			fmt.Sprintf("Update permissions of system role %s", role.Name),
+			dto.UpdateRolePermissionsPayload{RoleID: roleID, PermissionNames: req.PermissionNames},
+			requestedBy,
+		)
+		if err != nil {
+			return err
+		}
+		return c.JSON(http.StatusAccepted, changeRequest)
+	}
+
 	if err := h.roleService.UpdateRolePermissions(c.Request().Context(), roleID, req.PermissionNames); err != nil {
 		return err // Serahkan ke error handler terpusat
 	}
@@ -195,23 +225,23 @@ func (h *RoleHandler) UpdateRolePermissions(c echo.Context) error {
 	return c.JSON(http.StatusOK, map[string]string{"message": constant.MsgRolePermsUpdated})
 }
 
-// DISABLED: Role approval functionality
 // CreateRoleApprovalRequest handles the creation of a new role approval request.
 // @Summary      Create a role approval request
-// @Description  Creates a new role approval request. Users can request new roles to be created.
+// @Description  Creates a new role approval request. The request can target an existing organization by code or ask for a new organization to be created on approval.
 // @Tags         Roles, Approval
 // @Accept       json
 // @Produce      json
-// @Param        request body dto.CreateRoleApprovalRequest true "Role Approval Request Details"
+// @Param        request body dto.CreateRoleWithOrganizationRequest true "Role Approval Request Details"
 // @Security     BearerAuth
-// @Success      201 {object} dto.RoleApprovalResponse "Role approval request created successfully"
+// @Success      201 {object} dto.RoleApprovalWithOrganizationResponse "Role approval request created successfully"
 // @Failure      400 {object} apperror.AppError "Invalid request payload"
 // @Failure      401 {object} apperror.AppError "Unauthorized"
+// @Failure      404 {object} apperror.AppError "Organization not found"
+// @Failure      409 {object} apperror.AppError "Role already exists or request already pending"
 // @Failure      500 {object} apperror.AppError "Internal server error"
 // @Router       /roles/approval-requests [post]
-/*
 func (h *RoleHandler) CreateRoleApprovalRequest(c echo.Context) error {
-	var req dto.CreateRoleApprovalRequest
+	var req dto.CreateRoleWithOrganizationRequest
 	if err := c.Bind(&req); err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, constant.ErrMsgInvalidRequestFormat)
 	}
@@ -232,22 +262,91 @@ func (h *RoleHandler) CreateRoleApprovalRequest(c echo.Context) error {
 
 	return c.JSON(http.StatusCreated, approval)
 }
-*/
 
-/*
-// ListRoleApprovalRequests handles the retrieval of all role approval requests.
-// @Summary      List all role approval requests
-// @Description  Retrieves all role approval requests. Requires 'roles:approve' permission.
+// ListMyRoleApprovalRequests handles the retrieval of the current user's role approval requests.
+// @Summary      List my role approval requests
+// @Description  Retrieves all role approval requests made by the current user.
+// @Tags         Roles, Approval
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {array} dto.RoleApprovalWithOrganizationResponse "Role approval requests retrieved successfully"
+// @Failure      401 {object} apperror.AppError "Unauthorized"
+// @Failure      500 {object} apperror.AppError "Internal server error"
+// @Router       /roles/approval-requests/me [get]
+func (h *RoleHandler) ListMyRoleApprovalRequests(c echo.Context) error {
+	userID, ok := c.Get(constant.UserIDKey).(uuid.UUID)
+	if !ok {
+		return apperror.NewAppError(http.StatusUnauthorized, "User ID not found in context", nil)
+	}
+
+	approvals, err := h.roleService.ListMyRoleApprovalRequests(c.Request().Context(), userID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"approval_requests": approvals,
+	})
+}
+
+// CancelRoleApprovalRequest handles cancelling a pending role approval request by its requester.
+// @Summary      Cancel a role approval request
+// @Description  Cancels a pending role approval request. Only the requester can cancel their own request.
+// @Tags         Roles, Approval
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "Approval Request ID" format(uuid)
+// @Param        request body dto.CancelRoleApprovalRequest false "Cancellation Details"
+// @Security     BearerAuth
+// @Success      200 {object} dto.RoleApprovalWithOrganizationResponse "Role request cancelled successfully"
+// @Failure      400 {object} apperror.AppError "Invalid request ID format"
+// @Failure      403 {object} apperror.AppError "Not the requester"
+// @Failure      404 {object} apperror.AppError "Approval request not found"
+// @Failure      409 {object} apperror.AppError "Request already processed"
+// @Failure      500 {object} apperror.AppError "Internal server error"
+// @Router       /roles/approval-requests/{id}/cancel [post]
+func (h *RoleHandler) CancelRoleApprovalRequest(c echo.Context) error {
+	approvalID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return apperror.NewAppError(http.StatusBadRequest, "Invalid approval request ID format", err)
+	}
+
+	var req dto.CancelRoleApprovalRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, constant.ErrMsgInvalidRequestFormat)
+	}
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	userID, ok := c.Get(constant.UserIDKey).(uuid.UUID)
+	if !ok {
+		return apperror.NewAppError(http.StatusUnauthorized, "User ID not found in context", nil)
+	}
+
+	approval, err := h.roleService.CancelRoleApprovalRequest(c.Request().Context(), approvalID, req, userID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, approval)
+}
+
+// ListRoleApprovalRequests handles the retrieval of role approval requests.
+// @Summary      List role approval requests
+// @Description  Retrieves role approval requests, optionally filtered by status. Requires 'roles:approve' permission.
 // @Tags         Admin, Roles, Approval
 // @Accept       json
 // @Produce      json
+// @Param        status query string false "Approval status" Enums(pending, approved, rejected, cancelled)
 // @Security     BearerAuth
-// @Success      200 {array} dto.RoleApprovalResponse "Role approval requests retrieved successfully"
+// @Success      200 {array} dto.RoleApprovalWithOrganizationResponse "Role approval requests retrieved successfully"
+// @Failure      400 {object} apperror.AppError "Invalid status filter"
 // @Failure      403 {object} apperror.AppError "Insufficient permissions"
 // @Failure      500 {object} apperror.AppError "Internal server error"
 // @Router       /admin/roles/approval-requests [get]
 func (h *RoleHandler) ListRoleApprovalRequests(c echo.Context) error {
-	approvals, err := h.roleService.ListRoleApprovalRequests(c.Request().Context())
+	approvals, err := h.roleService.ListRoleApprovalRequests(c.Request().Context(), c.QueryParam("status"))
 	if err != nil {
 		return err
 	}
@@ -256,21 +355,19 @@ func (h *RoleHandler) ListRoleApprovalRequests(c echo.Context) error {
 		"approval_requests": approvals,
 	})
 }
-*/
 
-/*
 // ApproveRejectRoleRequest handles approving or rejecting a role approval request.
 // @Summary      Approve or reject a role request
-// @Description  Approves or rejects a role approval request. Requires 'roles:approve' permission.
+// @Description  Approves or rejects a pending role approval request. The approver must rank above the requested level and have access to the request's organization. Requires 'roles:approve' permission.
 // @Tags         Admin, Roles, Approval
 // @Accept       json
 // @Produce      json
 // @Param        id path string true "Approval Request ID" format(uuid)
 // @Param        decision body dto.ApprovalDecisionRequest true "Approval Decision"
 // @Security     BearerAuth
-// @Success      200 {object} dto.RoleApprovalResponse "Role request processed successfully"
+// @Success      200 {object} dto.RoleApprovalWithOrganizationResponse "Role request processed successfully"
 // @Failure      400 {object} apperror.AppError "Invalid request ID format or validation failed"
-// @Failure      403 {object} apperror.AppError "Insufficient permissions"
+// @Failure      403 {object} apperror.AppError "Insufficient permissions or not eligible to decide"
 // @Failure      404 {object} apperror.AppError "Approval request not found"
 // @Failure      409 {object} apperror.AppError "Request already processed"
 // @Failure      500 {object} apperror.AppError "Internal server error"
@@ -303,7 +400,6 @@ func (h *RoleHandler) ApproveRejectRoleRequest(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, approval)
 }
-*/
 
 // GetPredefinedRoleOptions handles the retrieval of predefined role options.
 // @Summary      Get predefined role options
@@ -516,3 +612,58 @@ func (h *RoleHandler) GetRolesForOrganizationType(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, roles)
 }
+
+// ListOrganizationTypeDefaultRoles handles retrieving the default roles of every organization type.
+// @Summary      List default roles per organization type
+// @Description  Retrieves the configured creator and member roles of each organization type together with the roles actually granted on organization creation and join. Requires 'roles:read' permission.
+// @Tags         Admin, Roles, Organizations
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {array} dto.OrganizationTypeDefaultRolesResponse "Default roles retrieved successfully"
+// @Failure      403 {object} apperror.AppError "Insufficient permissions"
+// @Failure      500 {object} apperror.AppError "Internal server error"
+// @Router       /admin/roles/default-roles [get]
+func (h *RoleHandler) ListOrganizationTypeDefaultRoles(c echo.Context) error {
+	defaults, err := h.roleService.ListOrganizationTypeDefaultRoles(c.Request().Context())
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, defaults)
+}
+
+// UpdateOrganizationTypeDefaultRoles handles configuring the default roles of an organization type.
+// @Summary      Update default roles of an organization type
+// @Description  Sets the role granted to whoever creates an organization of the type and the role granted to users who join one. Both roles must be active and mapped to the type. Requires 'roles:assign' permission.
+// @Tags         Admin, Roles, Organizations
+// @Accept       json
+// @Produce      json
+// @Param        organizationType path string true "Organization Type" Enums(platform, holding, company, store)
+// @Param        defaults body dto.UpdateOrganizationTypeDefaultRolesRequest true "Default roles"
+// @Security     BearerAuth
+// @Success      200 {object} dto.OrganizationTypeDefaultRolesResponse "Default roles updated successfully"
+// @Failure      400 {object} apperror.AppError "Invalid organization type or role"
+// @Failure      403 {object} apperror.AppError "Insufficient permissions"
+// @Failure      500 {object} apperror.AppError "Internal server error"
+// @Router       /admin/roles/default-roles/{organizationType} [put]
+func (h *RoleHandler) UpdateOrganizationTypeDefaultRoles(c echo.Context) error {
+	var req dto.UpdateOrganizationTypeDefaultRolesRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, constant.ErrMsgInvalidRequestFormat)
+	}
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	userID, ok := c.Get(constant.UserIDKey).(uuid.UUID)
+	if !ok {
+		return apperror.NewAppError(http.StatusUnauthorized, "User ID not found in context", nil)
+	}
+
+	defaults, err := h.roleService.UpdateOrganizationTypeDefaultRoles(c.Request().Context(), c.Param("organizationType"), req, userID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, defaults)
+}
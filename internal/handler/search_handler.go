@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"net/http"
+
+	"go-base-project/internal/apperror"
+	"go-base-project/internal/constant"
+	"go-base-project/internal/dto"
+	"go-base-project/internal/service"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// SearchHandler handles HTTP requests for searching users and organizations.
+type SearchHandler struct {
+	searchService service.SearchServiceInterface
+}
+
+// NewSearchHandler creates a new instance of SearchHandler.
+func NewSearchHandler(searchService service.SearchServiceInterface) *SearchHandler {
+	return &SearchHandler{
+		searchService: searchService,
+	}
+}
+
+// Search handles ranked search across users and organizations.
+// @Summary      Search users and organizations
+// @Description  Finds users by username or email and organizations by name, code or description, tolerating typos. Results are ranked by relevance per type and literal matches are highlighted with <mark>. Users are only searched for callers with 'users:read' and follow the visibility rules of the user listing; organizations are limited to the ones the caller can access.
+// @Tags         Search
+// @Produce      json
+// @Param        q query string true "Search term (2-100 characters)"
+// @Param        type query []string false "Result types to include" collectionFormat(multi) Enums(users, organizations)
+// @Param        limit query int false "Maximum results per type (max 50)" default(10)
+// @Security     BearerAuth
+// @Success      200 {object} dto.SearchResponse "Ranked search results"
+// @Failure      400 {object} apperror.AppError "Invalid search query"
+// @Failure      500 {object} apperror.AppError "Internal server error"
+// @Router       /search [get]
+func (h *SearchHandler) Search(c echo.Context) error {
+	var req dto.SearchRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid query parameters")
+	}
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	userID, ok := c.Get(constant.UserIDKey).(uuid.UUID)
+	if !ok {
+		return apperror.NewAppError(http.StatusUnauthorized, "User ID not found in context", nil)
+	}
+
+	results, err := h.searchService.Search(c.Request().Context(), req, userID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, results)
+}
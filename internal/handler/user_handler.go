@@ -6,6 +6,7 @@ import (
 	"go-base-project/internal/dto"
 	"go-base-project/internal/service"
 	"context"
+	"fmt"
 	"net/http"
 	"strconv"
 
@@ -15,19 +16,21 @@ import (
 
 // UserHandler handles HTTP requests related to user management.
 type UserHandler struct {
-	userService service.UserServiceInterface
+	userService     service.UserServiceInterface
+	approvalService service.ApprovalServiceInterface
 }
 
 // NewUserHandler creates a new instance of UserHandler.
-func NewUserHandler(userService service.UserServiceInterface) *UserHandler {
+func NewUserHandler(userService service.UserServiceInterface, approvalService service.ApprovalServiceInterface) *UserHandler {
 	return &UserHandler{
-		userService: userService,
+		userService:     userService,
+		approvalService: approvalService,
 	}
 }
 
 // CreateUser handles the creation of a new user.
 // @Summary      Create a new user
-// @Description  Creates a new user with the provided details. Requires 'users:create' permission.
+// @Description  Creates a new user with the provided details. Users created with status pending_activation cannot sign in until they are reactivated. Requires 'users:create' permission.
 // @Tags         Admin, Users
 // @Accept       json
 // @Produce      json
@@ -56,23 +59,39 @@ func (h *UserHandler) CreateUser(c echo.Context) error {
 	return c.JSON(http.StatusCreated, user)
 }
 
-// ListUsers handles the retrieval of a paginated list of users filtered by level and organization.
-// @Summary      List users with level and organization filtering
-// @Description  Retrieves a paginated list of users filtered based on the requesting user's level and organization context. Users can only see users with levels below their own level and from their accessible organizations.
+// ListUsers handles the retrieval of a filtered, sorted and paginated list of users.
+// @Summary      List users with filters, sorting and cursor pagination
+// @Description  Retrieves users visible to the requesting user: only users with levels below their own level and, for organization-level users, from their accessible organizations. The caller is never listed. Filters combine with AND; repeat role_id, auth_provider, status or organization_id to match any of several values. Large listings should page with cursor: pass next_cursor of the previous response with the same sort instead of page.
 // @Tags         Admin, Users
 // @Produce      json
 // @Param        page query int false "Page number for pagination" default(1)
-// @Param        limit query int false "Number of items per page for pagination" default(10)
-// @Param        search query string false "Search term for username or email"
+// @Param        limit query int false "Number of items per page for pagination (max 100)" default(10)
+// @Param        search query string false "Search term for username, email or display name"
+// @Param        role_id query []string false "Global role IDs" collectionFormat(multi)
+// @Param        min_level query int false "Minimum role level, inclusive"
+// @Param        max_level query int false "Maximum role level, inclusive"
+// @Param        auth_provider query []string false "Authentication providers" collectionFormat(multi) Enums(local, google)
+// @Param        status query []string false "Account statuses" collectionFormat(multi) Enums(active, suspended, pending_activation)
+// @Param        organization_id query []string false "Only active members of these organizations" collectionFormat(multi)
+// @Param        attribute query []string false "Custom membership attributes as key:value, e.g. employee_number:E-1024; combined with organization_id the membership must be in those organizations" collectionFormat(multi)
+// @Param        created_after query string false "Created at or after (RFC 3339)" format(date-time)
+// @Param        created_before query string false "Created before (RFC 3339)" format(date-time)
+// @Param        sort query string false "Comma separated sort fields (username, email, created_at, updated_at, level); prefix with - for descending" default(username)
+// @Param        cursor query string false "Cursor of the next page, from next_cursor"
 // @Security     BearerAuth
 // @Success      200 {object} dto.PagedUserResponse "A paginated list of filtered users"
+// @Failure      400 {object} apperror.AppError "Invalid filter, sort or cursor"
 // @Failure      403 {object} apperror.AppError "Insufficient permissions"
 // @Failure      500 {object} apperror.AppError "Internal server error"
 // @Router       /admin/users [get]
 func (h *UserHandler) ListUsers(c echo.Context) error {
-	page, _ := strconv.Atoi(c.QueryParam("page"))
-	limit, _ := strconv.Atoi(c.QueryParam("limit"))
-	search := c.QueryParam("search")
+	var req dto.ListUsersRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid query parameters")
+	}
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
 
 	// Get current user ID from JWT middleware context
 	currentUserID, ok := c.Get(constant.UserIDKey).(uuid.UUID)
@@ -83,7 +102,7 @@ func (h *UserHandler) ListUsers(c echo.Context) error {
 	// Create context with current user ID for service layer filtering
 	ctx := context.WithValue(c.Request().Context(), "current_user_id", currentUserID)
 
-	pagedResponse, err := h.userService.ListUsers(ctx, page, limit, search)
+	pagedResponse, err := h.userService.ListUsers(ctx, req)
 	if err != nil {
 		return err // Serahkan ke error handler terpusat
 	}
@@ -170,14 +189,15 @@ func (h *UserHandler) UpdateUser(c echo.Context) error {
 
 // DeleteUser handles deleting a user.
 // @Summary      Delete a user
-// @Description  Deletes a user by their ID. Requires 'users:delete' permission.
+// @Description  Submits a change request to delete a user. The deletion runs once the request is approved. Requires 'users:delete' permission.
 // @Tags         Admin, Users
 // @Produce      json
 // @Param        id path string true "User ID" format(uuid)
 // @Security     BearerAuth
-// @Success      204 "No Content"
+// @Success      202 {object} dto.ChangeRequestResponse "Deletion submitted for approval"
 // @Failure      403 {object} apperror.AppError "Insufficient permissions"
 // @Failure      404 {object} apperror.AppError "User not found"
+// @Failure      409 {object} apperror.AppError "Deletion already pending approval"
 // @Failure      500 {object} apperror.AppError "Internal server error"
 // @Router       /admin/users/{id} [delete]
 func (h *UserHandler) DeleteUser(c echo.Context) error {
@@ -186,111 +206,54 @@ func (h *UserHandler) DeleteUser(c echo.Context) error {
 		return apperror.NewAppError(http.StatusBadRequest, constant.ErrMsgInvalidUserID, err)
 	}
 
-	err = h.userService.DeleteUser(c.Request().Context(), id)
-	if err != nil {
-		return err // Serahkan ke error handler terpusat
-	}
-
-	return c.NoContent(http.StatusNoContent)
-}
-
-// User-Organization Management Handlers
-
-// AssignUserToOrganization handles assigning a user to an organization.
-// @Summary      Assign user to organization
-// @Description  Assigns a user to an organization with a specific role. Requires 'users:assign-organization' permission.
-// @Tags         Admin, Users, Organizations
-// @Accept       json
-// @Produce      json
-// @Param        request body dto.AssignUserToOrganizationRequest true "Assignment Details"
-// @Security     BearerAuth
-// @Success      201 {object} dto.UserOrganizationResponse "User assigned successfully"
-// @Failure      400 {object} apperror.AppError "Invalid request payload"
-// @Failure      403 {object} apperror.AppError "Insufficient permissions"
-// @Failure      404 {object} apperror.AppError "User or organization not found"
-// @Failure      409 {object} apperror.AppError "User already assigned to organization"
-// @Failure      500 {object} apperror.AppError "Internal server error"
-// @Router       /admin/users/assign-organization [post]
-func (h *UserHandler) AssignUserToOrganization(c echo.Context) error {
-	var req dto.AssignUserToOrganizationRequest
-	if err := c.Bind(&req); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, constant.ErrMsgInvalidRequestFormat)
-	}
-	if err := c.Validate(&req); err != nil {
-		return err
-	}
-
-	ctx := context.WithValue(c.Request().Context(), constant.RequestIDKey, c.Response().Header().Get(echo.HeaderXRequestID))
-	assignment, err := h.userService.AssignUserToOrganization(ctx, req)
-	if err != nil {
-		return err
-	}
-
-	return c.JSON(http.StatusCreated, assignment)
-}
-
-// RemoveUserFromOrganization handles removing a user from an organization.
-// @Summary      Remove user from organization
-// @Description  Removes a user from an organization. Requires 'users:remove-organization' permission.
-// @Tags         Admin, Users, Organizations
-// @Produce      json
-// @Param        userId path string true "User ID" format(uuid)
-// @Param        organizationId path string true "Organization ID" format(uuid)
-// @Security     BearerAuth
-// @Success      204 "No Content"
-// @Failure      400 {object} apperror.AppError "Invalid user or organization ID"
-// @Failure      403 {object} apperror.AppError "Insufficient permissions"
-// @Failure      404 {object} apperror.AppError "User organization assignment not found"
-// @Failure      500 {object} apperror.AppError "Internal server error"
-// @Router       /admin/users/{userId}/organizations/{organizationId} [delete]
-func (h *UserHandler) RemoveUserFromOrganization(c echo.Context) error {
-	userID, err := uuid.Parse(c.Param("userId"))
-	if err != nil {
-		return apperror.NewAppError(http.StatusBadRequest, constant.ErrMsgInvalidUserID, err)
+	requestedBy, ok := c.Get(constant.UserIDKey).(uuid.UUID)
+	if !ok {
+		return apperror.NewAppError(http.StatusUnauthorized, "User ID not found in context", nil)
 	}
 
-	organizationID, err := uuid.Parse(c.Param("organizationId"))
+	user, err := h.userService.GetUserByID(c.Request().Context(), id)
 	if err != nil {
-		return apperror.NewAppError(http.StatusBadRequest, "Invalid organization ID", err)
+		return err // Serahkan ke error handler terpusat
 	}
 
-	ctx := context.WithValue(c.Request().Context(), constant.RequestIDKey, c.Response().Header().Get(echo.HeaderXRequestID))
-	err = h.userService.RemoveUserFromOrganization(ctx, userID, organizationID)
+	changeRequest, err := h.approvalService.SubmitChangeRequest(
+		c.Request().Context(),
+		constant.ChangeRequestActionDeleteUser,
+		&id,
+		fmt.Sprintf("Delete user %s", user.Username),
+		dto.DeleteUserPayload{UserID: id},
+		requestedBy,
+	)
 	if err != nil {
 		return err
 	}
 
-	return c.NoContent(http.StatusNoContent)
+	return c.JSON(http.StatusAccepted, changeRequest)
 }
 
-// UpdateUserOrganizationRole handles updating a user's role in an organization.
-// @Summary      Update user's role in organization
-// @Description  Updates a user's role in an organization. Requires 'users:update-organization-role' permission.
-// @Tags         Admin, Users, Organizations
+// SuspendUser handles suspending a user account.
+// @Summary      Suspend a user
+// @Description  Blocks a user from signing in and ends their sessions without touching their organization memberships. Without until the suspension lasts until the user is reactivated; with until it lifts itself at that time. Suspending a suspended user replaces the reason and end. Callers cannot suspend themselves or users whose role level is not below their own. Every change is recorded in the status history. Requires 'users:suspend' permission.
+// @Tags         Admin, Users
 // @Accept       json
 // @Produce      json
-// @Param        userId path string true "User ID" format(uuid)
-// @Param        organizationId path string true "Organization ID" format(uuid)
-// @Param        request body dto.UpdateUserOrganizationRequest true "Role Update Details"
+// @Param        id path string true "User ID" format(uuid)
+// @Param        request body dto.SuspendUserRequest true "Suspension details"
 // @Security     BearerAuth
-// @Success      200 {object} dto.UserOrganizationResponse "Role updated successfully"
-// @Failure      400 {object} apperror.AppError "Invalid request payload or IDs"
-// @Failure      403 {object} apperror.AppError "Insufficient permissions"
-// @Failure      404 {object} apperror.AppError "User organization assignment not found"
+// @Success      200 {object} dto.UserResponse "User suspended"
+// @Failure      400 {object} apperror.AppError "Invalid user ID or request payload"
+// @Failure      403 {object} apperror.AppError "Insufficient permissions or authority over the user"
+// @Failure      404 {object} apperror.AppError "User not found"
+// @Failure      409 {object} apperror.AppError "User status changed concurrently"
 // @Failure      500 {object} apperror.AppError "Internal server error"
-// @Router       /admin/users/{userId}/organizations/{organizationId} [put]
-func (h *UserHandler) UpdateUserOrganizationRole(c echo.Context) error {
-	userID, err := uuid.Parse(c.Param("userId"))
+// @Router       /admin/users/{id}/suspend [post]
+func (h *UserHandler) SuspendUser(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
 		return apperror.NewAppError(http.StatusBadRequest, constant.ErrMsgInvalidUserID, err)
 	}
 
-	organizationID, err := uuid.Parse(c.Param("organizationId"))
-	if err != nil {
-		return apperror.NewAppError(http.StatusBadRequest, "Invalid organization ID", err)
-	}
-
-	var req dto.UpdateUserOrganizationRequest
+	var req dto.SuspendUserRequest
 	if err := c.Bind(&req); err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, constant.ErrMsgInvalidRequestFormat)
 	}
@@ -298,97 +261,42 @@ func (h *UserHandler) UpdateUserOrganizationRole(c echo.Context) error {
 		return err
 	}
 
-	ctx := context.WithValue(c.Request().Context(), constant.RequestIDKey, c.Response().Header().Get(echo.HeaderXRequestID))
-	assignment, err := h.userService.UpdateUserOrganizationRole(ctx, userID, organizationID, req)
-	if err != nil {
-		return err
-	}
-
-	return c.JSON(http.StatusOK, assignment)
-}
-
-// GetUserOrganizations handles retrieving all organizations that a user belongs to.
-// @Summary      Get user's organizations
-// @Description  Retrieves all organizations that a user belongs to with pagination. Requires 'users:read' permission.
-// @Tags         Admin, Users, Organizations
-// @Produce      json
-// @Param        userId path string true "User ID" format(uuid)
-// @Param        page query int false "Page number" default(1) minimum(1)
-// @Param        limit query int false "Items per page" default(10) minimum(1) maximum(100)
-// @Security     BearerAuth
-// @Success      200 {object} dto.PagedUserOrganizationResponse "User organizations retrieved successfully"
-// @Failure      400 {object} apperror.AppError "Invalid user ID or query parameters"
-// @Failure      403 {object} apperror.AppError "Insufficient permissions"
-// @Failure      404 {object} apperror.AppError "User not found"
-// @Failure      500 {object} apperror.AppError "Internal server error"
-// @Router       /admin/users/{userId}/organizations [get]
-func (h *UserHandler) GetUserOrganizations(c echo.Context) error {
-	userID, err := uuid.Parse(c.Param("userId"))
-	if err != nil {
-		return apperror.NewAppError(http.StatusBadRequest, constant.ErrMsgInvalidUserID, err)
+	currentUserID, ok := c.Get(constant.UserIDKey).(uuid.UUID)
+	if !ok {
+		return apperror.NewAppError(http.StatusUnauthorized, "User ID not found in context", nil)
 	}
 
-	page, _ := strconv.Atoi(c.QueryParam("page"))
-	limit, _ := strconv.Atoi(c.QueryParam("limit"))
-
-	ctx := context.WithValue(c.Request().Context(), constant.RequestIDKey, c.Response().Header().Get(echo.HeaderXRequestID))
-	organizations, err := h.userService.GetUserOrganizations(ctx, userID, page, limit)
+	user, err := h.userService.SuspendUser(c.Request().Context(), id, req, currentUserID)
 	if err != nil {
 		return err
 	}
 
-	return c.JSON(http.StatusOK, organizations)
+	return c.JSON(http.StatusOK, user)
 }
 
-// GetOrganizationMembers handles retrieving all members of an organization.
-// @Summary      Get organization members
-// @Description  Retrieves all members of an organization with pagination. Requires 'organizations:read-members' permission.
-// @Tags         Admin, Organizations, Users
+// ReactivateUser handles reactivating a suspended or not yet activated user account.
+// @Summary      Reactivate a user
+// @Description  Makes a suspended account, or one created pending activation, active again so the user can sign in. The same authority rules as suspension apply. Every change is recorded in the status history. Requires 'users:suspend' permission.
+// @Tags         Admin, Users
+// @Accept       json
 // @Produce      json
-// @Param        organizationId path string true "Organization ID" format(uuid)
-// @Param        page query int false "Page number" default(1) minimum(1)
-// @Param        limit query int false "Items per page" default(10) minimum(1) maximum(100)
+// @Param        id path string true "User ID" format(uuid)
+// @Param        request body dto.ReactivateUserRequest false "Reactivation details"
 // @Security     BearerAuth
-// @Success      200 {object} dto.PagedUserOrganizationResponse "Organization members retrieved successfully"
-// @Failure      400 {object} apperror.AppError "Invalid organization ID or query parameters"
-// @Failure      403 {object} apperror.AppError "Insufficient permissions"
-// @Failure      404 {object} apperror.AppError "Organization not found"
+// @Success      200 {object} dto.UserResponse "User reactivated"
+// @Failure      400 {object} apperror.AppError "Invalid user ID or request payload"
+// @Failure      403 {object} apperror.AppError "Insufficient permissions or authority over the user"
+// @Failure      404 {object} apperror.AppError "User not found"
+// @Failure      409 {object} apperror.AppError "User is already active"
 // @Failure      500 {object} apperror.AppError "Internal server error"
-// @Router       /admin/organizations/{organizationId}/members [get]
-func (h *UserHandler) GetOrganizationMembers(c echo.Context) error {
-	organizationID, err := uuid.Parse(c.Param("organizationId"))
-	if err != nil {
-		return apperror.NewAppError(http.StatusBadRequest, "Invalid organization ID", err)
-	}
-
-	page, _ := strconv.Atoi(c.QueryParam("page"))
-	limit, _ := strconv.Atoi(c.QueryParam("limit"))
-
-	ctx := context.WithValue(c.Request().Context(), constant.RequestIDKey, c.Response().Header().Get(echo.HeaderXRequestID))
-	members, err := h.userService.GetOrganizationMembers(ctx, organizationID, page, limit)
+// @Router       /admin/users/{id}/reactivate [post]
+func (h *UserHandler) ReactivateUser(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		return err
+		return apperror.NewAppError(http.StatusBadRequest, constant.ErrMsgInvalidUserID, err)
 	}
 
-	return c.JSON(http.StatusOK, members)
-}
-
-// BulkAssignUsersToOrganization handles bulk assignment of users to an organization.
-// @Summary      Bulk assign users to organization
-// @Description  Assigns multiple users to an organization with the same role. Requires 'users:bulk-assign-organization' permission.
-// @Tags         Admin, Users, Organizations
-// @Accept       json
-// @Produce      json
-// @Param        request body dto.BulkAssignUsersToOrganizationRequest true "Bulk Assignment Details"
-// @Security     BearerAuth
-// @Success      200 {object} dto.BulkAssignResponse "Bulk assignment completed"
-// @Failure      400 {object} apperror.AppError "Invalid request payload"
-// @Failure      403 {object} apperror.AppError "Insufficient permissions"
-// @Failure      404 {object} apperror.AppError "Organization not found"
-// @Failure      500 {object} apperror.AppError "Internal server error"
-// @Router       /admin/users/bulk-assign-organization [post]
-func (h *UserHandler) BulkAssignUsersToOrganization(c echo.Context) error {
-	var req dto.BulkAssignUsersToOrganizationRequest
+	var req dto.ReactivateUserRequest
 	if err := c.Bind(&req); err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, constant.ErrMsgInvalidRequestFormat)
 	}
@@ -396,74 +304,43 @@ func (h *UserHandler) BulkAssignUsersToOrganization(c echo.Context) error {
 		return err
 	}
 
-	ctx := context.WithValue(c.Request().Context(), constant.RequestIDKey, c.Response().Header().Get(echo.HeaderXRequestID))
-	result, err := h.userService.BulkAssignUsersToOrganization(ctx, req)
-	if err != nil {
-		return err
-	}
-
-	return c.JSON(http.StatusOK, result)
-}
-
-// GetUserOrganizationHistory handles retrieving organization assignment history for a user.
-// @Summary      Get user's organization history
-// @Description  Retrieves the organization assignment history for a user. Requires 'users:read-history' permission.
-// @Tags         Admin, Users, Organizations
-// @Produce      json
-// @Param        userId path string true "User ID" format(uuid)
-// @Param        page query int false "Page number" default(1) minimum(1)
-// @Param        limit query int false "Items per page" default(10) minimum(1) maximum(100)
-// @Security     BearerAuth
-// @Success      200 {array} dto.UserOrganizationHistoryResponse "User organization history retrieved successfully"
-// @Failure      400 {object} apperror.AppError "Invalid user ID or query parameters"
-// @Failure      403 {object} apperror.AppError "Insufficient permissions"
-// @Failure      404 {object} apperror.AppError "User not found"
-// @Failure      500 {object} apperror.AppError "Internal server error"
-// @Router       /admin/users/{userId}/organization-history [get]
-func (h *UserHandler) GetUserOrganizationHistory(c echo.Context) error {
-	userID, err := uuid.Parse(c.Param("userId"))
-	if err != nil {
-		return apperror.NewAppError(http.StatusBadRequest, constant.ErrMsgInvalidUserID, err)
+	currentUserID, ok := c.Get(constant.UserIDKey).(uuid.UUID)
+	if !ok {
+		return apperror.NewAppError(http.StatusUnauthorized, "User ID not found in context", nil)
 	}
 
-	page, _ := strconv.Atoi(c.QueryParam("page"))
-	limit, _ := strconv.Atoi(c.QueryParam("limit"))
-
-	ctx := context.WithValue(c.Request().Context(), constant.RequestIDKey, c.Response().Header().Get(echo.HeaderXRequestID))
-	history, err := h.userService.GetUserOrganizationHistory(ctx, userID, page, limit)
+	user, err := h.userService.ReactivateUser(c.Request().Context(), id, req, currentUserID)
 	if err != nil {
 		return err
 	}
 
-	return c.JSON(http.StatusOK, history)
+	return c.JSON(http.StatusOK, user)
 }
 
-// GetOrganizationUserHistory handles retrieving user assignment history for an organization.
-// @Summary      Get organization user history
-// @Description  Retrieves the user assignment history for an organization with pagination. Requires 'organizations:read-history' permission.
-// @Tags         Admin, Organizations, Users
+// GetUserStatusHistory handles retrieving the account status history of a user.
+// @Summary      Get user's status history
+// @Description  Retrieves the account status transitions of a user, newest first. Transitions without action_by are suspensions that expired on their own. Requires 'users:read-history' permission.
+// @Tags         Admin, Users
 // @Produce      json
-// @Param        organizationId path string true "Organization ID" format(uuid)
+// @Param        id path string true "User ID" format(uuid)
 // @Param        page query int false "Page number" default(1) minimum(1)
 // @Param        limit query int false "Items per page" default(10) minimum(1) maximum(100)
 // @Security     BearerAuth
-// @Success      200 {object} dto.PagedUserOrganizationHistoryResponse "Organization user history retrieved successfully"
-// @Failure      400 {object} apperror.AppError "Invalid organization ID or query parameters"
+// @Success      200 {object} dto.PagedUserStatusHistoryResponse "User status history retrieved successfully"
+// @Failure      400 {object} apperror.AppError "Invalid user ID"
 // @Failure      403 {object} apperror.AppError "Insufficient permissions"
-// @Failure      404 {object} apperror.AppError "Organization not found"
 // @Failure      500 {object} apperror.AppError "Internal server error"
-// @Router       /admin/organizations/{organizationId}/user-history [get]
-func (h *UserHandler) GetOrganizationUserHistory(c echo.Context) error {
-	organizationID, err := uuid.Parse(c.Param("organizationId"))
+// @Router       /admin/users/{id}/status-history [get]
+func (h *UserHandler) GetUserStatusHistory(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		return apperror.NewAppError(http.StatusBadRequest, "Invalid organization ID", err)
+		return apperror.NewAppError(http.StatusBadRequest, constant.ErrMsgInvalidUserID, err)
 	}
 
 	page, _ := strconv.Atoi(c.QueryParam("page"))
 	limit, _ := strconv.Atoi(c.QueryParam("limit"))
 
-	ctx := context.WithValue(c.Request().Context(), constant.RequestIDKey, c.Response().Header().Get(echo.HeaderXRequestID))
-	history, err := h.userService.GetOrganizationUserHistory(ctx, organizationID, page, limit)
+	history, err := h.userService.GetUserStatusHistory(c.Request().Context(), id, page, limit)
 	if err != nil {
 		return err
 	}
@@ -471,48 +348,7 @@ func (h *UserHandler) GetOrganizationUserHistory(c echo.Context) error {
 	return c.JSON(http.StatusOK, history)
 }
 
-// LogUserOrganizationAction handles manually logging a user organization action.
-// @Summary      Log user organization action
-// @Description  Manually logs a user organization action for audit purposes. Requires 'users:log-actions' permission.
-// @Tags         Admin, Users, Organizations
-// @Accept       json
-// @Produce      json
-// @Param        request body dto.LogUserOrganizationActionRequest true "Action Log Details"
-// @Security     BearerAuth
-// @Success      201 {object} dto.UserOrganizationHistoryResponse "Action logged successfully"
-// @Failure      400 {object} apperror.AppError "Invalid request payload"
-// @Failure      403 {object} apperror.AppError "Insufficient permissions"
-// @Failure      404 {object} apperror.AppError "User or organization not found"
-// @Failure      500 {object} apperror.AppError "Internal server error"
-// @Router       /admin/users/log-organization-action [post]
-func (h *UserHandler) LogUserOrganizationAction(c echo.Context) error {
-	var req dto.LogUserOrganizationActionRequest
-	if err := c.Bind(&req); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, constant.ErrMsgInvalidRequestFormat)
-	}
-	if err := c.Validate(&req); err != nil {
-		return err
-	}
-
-	// Get current user ID from JWT context
-	userIDValue := c.Get(constant.UserIDKey)
-	if userIDValue == nil {
-		return apperror.NewUnauthorizedError("No user information found in context")
-	}
-
-	userID, ok := userIDValue.(uuid.UUID)
-	if !ok {
-		return apperror.NewUnauthorizedError("Invalid user information in context")
-	}
-
-	ctx := context.WithValue(c.Request().Context(), constant.RequestIDKey, c.Response().Header().Get(echo.HeaderXRequestID))
-	history, err := h.userService.LogUserOrganizationAction(ctx, req, userID)
-	if err != nil {
-		return err
-	}
-
-	return c.JSON(http.StatusCreated, history)
-}
+// User-Organization Management Handlers
 
 // AssignRoleToUserInOrganization assigns a role to a user within a specific organization context.
 // This method demonstrates multi-tenant role isolation.
@@ -650,3 +486,131 @@ func (h *UserHandler) ListUsersByOrganization(c echo.Context) error {
 		"note":            "This demonstrates organization-scoped data access using OrganizationContext middleware",
 	})
 }
+
+// GetMyProfile handles retrieving the profile of the current user.
+// @Summary      Get my profile
+// @Description  Returns the profile of the caller: display name, phone, locale, timezone and job title.
+// @Tags         Auth
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} dto.UserProfileResponse "Profile"
+// @Failure      401 {object} apperror.AppError "Unauthorized"
+// @Failure      500 {object} apperror.AppError "Internal server error"
+// @Router       /auth/me/profile [get]
+func (h *UserHandler) GetMyProfile(c echo.Context) error {
+	userID, ok := c.Get(constant.UserIDKey).(uuid.UUID)
+	if !ok {
+		return apperror.NewAppError(http.StatusUnauthorized, "User ID not found in context", nil)
+	}
+
+	profile, err := h.userService.GetUserProfile(c.Request().Context(), userID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, profile)
+}
+
+// UpdateMyProfile handles the current user editing their own profile.
+// @Summary      Update my profile
+// @Description  Partially updates the profile of the caller. Fields left out are unchanged and empty strings clear a field. Phone numbers use E.164 format, locales are language tags such as id-ID and timezones are IANA names such as Asia/Jakarta.
+// @Tags         Auth
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.UpdateUserProfileRequest true "Profile fields to change"
+// @Security     BearerAuth
+// @Success      200 {object} dto.UserProfileResponse "Profile updated"
+// @Failure      400 {object} apperror.AppError "Invalid request payload"
+// @Failure      401 {object} apperror.AppError "Unauthorized"
+// @Failure      500 {object} apperror.AppError "Internal server error"
+// @Router       /auth/me [patch]
+func (h *UserHandler) UpdateMyProfile(c echo.Context) error {
+	userID, ok := c.Get(constant.UserIDKey).(uuid.UUID)
+	if !ok {
+		return apperror.NewAppError(http.StatusUnauthorized, "User ID not found in context", nil)
+	}
+
+	var req dto.UpdateUserProfileRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, constant.ErrMsgInvalidRequestFormat)
+	}
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	profile, err := h.userService.UpdateUserProfile(c.Request().Context(), userID, req, userID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, profile)
+}
+
+// GetUserProfile handles retrieving the profile of a user.
+// @Summary      Get a user's profile
+// @Description  Returns the profile of a user. Requires 'users:read' permission.
+// @Tags         Admin, Users
+// @Produce      json
+// @Param        id path string true "User ID" format(uuid)
+// @Security     BearerAuth
+// @Success      200 {object} dto.UserProfileResponse "Profile"
+// @Failure      400 {object} apperror.AppError "Invalid user ID"
+// @Failure      403 {object} apperror.AppError "Insufficient permissions"
+// @Failure      404 {object} apperror.AppError "User not found"
+// @Failure      500 {object} apperror.AppError "Internal server error"
+// @Router       /admin/users/{id}/profile [get]
+func (h *UserHandler) GetUserProfile(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return apperror.NewAppError(http.StatusBadRequest, constant.ErrMsgInvalidUserID, err)
+	}
+
+	profile, err := h.userService.GetUserProfile(c.Request().Context(), id)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, profile)
+}
+
+// UpdateUserProfile handles an administrator editing the profile of a user.
+// @Summary      Update a user's profile
+// @Description  Partially updates the profile of a user with the same rules as the self-service endpoint. Callers cannot edit users whose role level is not below their own. Requires 'users:update' permission.
+// @Tags         Admin, Users
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "User ID" format(uuid)
+// @Param        request body dto.UpdateUserProfileRequest true "Profile fields to change"
+// @Security     BearerAuth
+// @Success      200 {object} dto.UserProfileResponse "Profile updated"
+// @Failure      400 {object} apperror.AppError "Invalid user ID or request payload"
+// @Failure      403 {object} apperror.AppError "Insufficient permissions or authority over the user"
+// @Failure      404 {object} apperror.AppError "User not found"
+// @Failure      500 {object} apperror.AppError "Internal server error"
+// @Router       /admin/users/{id}/profile [patch]
+func (h *UserHandler) UpdateUserProfile(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return apperror.NewAppError(http.StatusBadRequest, constant.ErrMsgInvalidUserID, err)
+	}
+
+	var req dto.UpdateUserProfileRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, constant.ErrMsgInvalidRequestFormat)
+	}
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	currentUserID, ok := c.Get(constant.UserIDKey).(uuid.UUID)
+	if !ok {
+		return apperror.NewAppError(http.StatusUnauthorized, "User ID not found in context", nil)
+	}
+
+	profile, err := h.userService.UpdateUserProfile(c.Request().Context(), id, req, currentUserID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, profile)
+}
@@ -0,0 +1,132 @@
+package handler
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"go-base-project/internal/apperror"
+	"go-base-project/internal/constant"
+	"go-base-project/internal/dto"
+	"go-base-project/internal/service"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// maxUserImportFileSize bounds the size of an uploaded import file
+const maxUserImportFileSize = 5 << 20
+
+// UserImportHandler handles HTTP requests for bulk importing users from spreadsheet files.
+type UserImportHandler struct {
+	importService service.UserImportServiceInterface
+	jobService    service.JobServiceInterface
+}
+
+// NewUserImportHandler creates a new instance of UserImportHandler.
+func NewUserImportHandler(importService service.UserImportServiceInterface, jobService service.JobServiceInterface) *UserImportHandler {
+	return &UserImportHandler{
+		importService: importService,
+		jobService:    jobService,
+	}
+}
+
+// ImportUsers handles bulk importing users from a CSV or XLSX file.
+// @Summary      Import users from a spreadsheet
+// @Description  Imports users from a CSV or XLSX file with the columns username, email, role and optionally organization_code, password and invite. Rows with invite=true send an invitation to the organization instead of creating an account. Every row is validated with the rules of user creation and the caller's role level; invalid rows are reported and never applied. With dry_run the report is returned right away. Otherwise the import runs as a background job that applies the valid rows in batches of 100, each in its own transaction; poll the returned status URL for progress and the dto.UserImportReport result. The report is also available as a CSV result file for 24 hours. Requires 'users:create' permission.
+// @Tags         Admin, User Import
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        file formData file true "CSV or XLSX file (max 5 MB, 5000 rows)"
+// @Param        dry_run formData bool false "Validate without applying" default(false)
+// @Security     BearerAuth
+// @Success      200 {object} dto.UserImportReport "Dry run report"
+// @Success      202 {object} dto.JobResponse "Import queued as a job"
+// @Failure      400 {object} apperror.AppError "Missing or malformed file"
+// @Failure      403 {object} apperror.AppError "Insufficient permissions"
+// @Failure      500 {object} apperror.AppError "Internal server error"
+// @Router       /admin/users/import [post]
+func (h *UserImportHandler) ImportUsers(c echo.Context) error {
+	userID, ok := c.Get(constant.UserIDKey).(uuid.UUID)
+	if !ok {
+		return apperror.NewAppError(http.StatusUnauthorized, "User ID not found in context", nil)
+	}
+
+	dryRun := false
+	if value := c.FormValue("dry_run"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return apperror.NewAppError(http.StatusBadRequest, "Invalid dry_run value", err)
+		}
+		dryRun = parsed
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return apperror.NewAppError(http.StatusBadRequest, "An import file is required", err)
+	}
+	if fileHeader.Size > maxUserImportFileSize {
+		return apperror.NewAppError(http.StatusBadRequest, fmt.Sprintf("The import file must not exceed %d MB", maxUserImportFileSize>>20), nil)
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		return apperror.NewAppError(http.StatusBadRequest, "Failed to read the import file", err)
+	}
+	defer file.Close()
+	data, err := io.ReadAll(io.LimitReader(file, maxUserImportFileSize))
+	if err != nil {
+		return apperror.NewAppError(http.StatusBadRequest, "Failed to read the import file", err)
+	}
+
+	if !dryRun {
+		job, err := h.jobService.SubmitJob(c.Request().Context(), constant.JobTypeImportUsers, dto.ImportUsersJobPayload{
+			Filename: fileHeader.Filename,
+			Data:     data,
+		}, userID)
+		if err != nil {
+			return err
+		}
+		return c.JSON(http.StatusAccepted, job)
+	}
+
+	report, err := h.importService.ImportUsers(c.Request().Context(), fileHeader.Filename, data, true, userID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, report)
+}
+
+// GetImportResult handles downloading the result file of a user import.
+// @Summary      Download a user import result file
+// @Description  Returns the CSV report of an import with the status, created user ID, invitation URL and errors of every row. Result files are kept for 24 hours and are only available to the user who ran the import. Requires 'users:create' permission.
+// @Tags         Admin, User Import
+// @Produce      text/csv
+// @Param        importId path string true "Import ID" format(uuid)
+// @Security     BearerAuth
+// @Success      200 {file} file "Import result file"
+// @Failure      400 {object} apperror.AppError "Invalid import ID"
+// @Failure      403 {object} apperror.AppError "Insufficient permissions"
+// @Failure      404 {object} apperror.AppError "Import result not found or expired"
+// @Failure      500 {object} apperror.AppError "Internal server error"
+// @Router       /admin/users/import/{importId}/result [get]
+func (h *UserImportHandler) GetImportResult(c echo.Context) error {
+	importID, err := uuid.Parse(c.Param("importId"))
+	if err != nil {
+		return apperror.NewAppError(http.StatusBadRequest, "Invalid import ID format", err)
+	}
+
+	userID, ok := c.Get(constant.UserIDKey).(uuid.UUID)
+	if !ok {
+		return apperror.NewAppError(http.StatusUnauthorized, "User ID not found in context", nil)
+	}
+
+	data, err := h.importService.GetImportResult(c.Request().Context(), importID, userID)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=\"user-import-%s.csv\"", importID))
+	return c.Blob(http.StatusOK, "text/csv", data)
+}
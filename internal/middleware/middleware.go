@@ -58,6 +58,11 @@ func (m *Middleware) JWT(next echo.HandlerFunc) echo.HandlerFunc {
 			return echo.NewHTTPError(http.StatusUnauthorized, err.Error())
 		}
 
+		// Suspended or not yet activated accounts lose access right away, not only once their token expires
+		if err := m.authorizationService.EnsureUserActive(c.Request().Context(), claims.UserID); err != nil {
+			return err
+		}
+
 		// Set user ID and role ID in the context for subsequent handlers.
 		// Use constants for keys to maintain consistency.
 		c.Set(constant.UserIDKey, claims.UserID)
@@ -0,0 +1,48 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ChangeRequest represents a sensitive admin operation that waits for approval before it is executed
+type ChangeRequest struct {
+	ID                uuid.UUID               `gorm:"type:uuid;primary_key;default:uuid_generate_v7()" json:"id"`
+	Action            string                  `gorm:"type:varchar(64);not null" json:"action"`
+	TargetID          *uuid.UUID              `gorm:"type:uuid" json:"target_id,omitempty"`
+	Summary           string                  `gorm:"type:text" json:"summary"`
+	Payload           string                  `gorm:"type:jsonb;not null" json:"payload"` // Serialized operation input
+	RequestedBy       uuid.UUID               `gorm:"type:uuid;not null" json:"requested_by"`
+	Requester         User                    `gorm:"foreignKey:RequestedBy" json:"requester"`
+	Status            string                  `gorm:"type:varchar(20);not null;default:'pending'" json:"status"` // pending, executing, executed, rejected, cancelled, expired
+	RequiredApprovals int                     `gorm:"not null;default:1" json:"required_approvals"`
+	MinApproverLevel  int                     `gorm:"not null" json:"min_approver_level"`
+	ExpiresAt         time.Time               `gorm:"not null" json:"expires_at"`
+	ExecutedAt        *time.Time              `json:"executed_at,omitempty"`
+	Result            *string                 `gorm:"type:jsonb" json:"result,omitempty"` // Serialized executor outcome
+	Approvals         []ChangeRequestApproval `gorm:"foreignKey:ChangeRequestID" json:"approvals,omitempty"`
+	CreatedAt         time.Time               `gorm:"default:now()" json:"created_at"`
+	UpdatedAt         time.Time               `gorm:"default:now()" json:"updated_at"`
+}
+
+// TableName sets the table name for ChangeRequest
+func (ChangeRequest) TableName() string {
+	return "change_requests"
+}
+
+// ChangeRequestApproval represents a single approver decision on a change request
+type ChangeRequestApproval struct {
+	ID              uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v7()" json:"id"`
+	ChangeRequestID uuid.UUID `gorm:"type:uuid;not null" json:"change_request_id"`
+	ApproverID      uuid.UUID `gorm:"type:uuid;not null" json:"approver_id"`
+	Approver        User      `gorm:"foreignKey:ApproverID" json:"approver"`
+	Decision        string    `gorm:"type:varchar(20);not null" json:"decision"` // approved, rejected
+	Comment         string    `gorm:"type:text" json:"comment"`
+	CreatedAt       time.Time `gorm:"default:now()" json:"created_at"`
+}
+
+// TableName sets the table name for ChangeRequestApproval
+func (ChangeRequestApproval) TableName() string {
+	return "change_request_approvals"
+}
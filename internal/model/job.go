@@ -0,0 +1,34 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Job is a long-running operation queued for the background workers
+type Job struct {
+	ID              uuid.UUID  `gorm:"type:uuid;primary_key;default:uuid_generate_v7()" json:"id"`
+	Type            string     `gorm:"type:varchar(64);not null" json:"type"`
+	Payload         string     `gorm:"type:jsonb;not null" json:"payload"`                       // Serialized operation input
+	Status          string     `gorm:"type:varchar(20);not null;default:'queued'" json:"status"` // queued, running, succeeded, failed, cancelled
+	SubmittedBy     uuid.UUID  `gorm:"type:uuid;not null" json:"submitted_by"`
+	ProcessedItems  int        `gorm:"not null;default:0" json:"processed_items"`
+	TotalItems      int        `gorm:"not null;default:0" json:"total_items"`
+	Result          *string    `gorm:"type:jsonb" json:"result,omitempty"` // Final result, or the partial result reported so far
+	Error           string     `gorm:"type:text" json:"error,omitempty"`
+	Attempts        int        `gorm:"not null;default:0" json:"attempts"`
+	MaxAttempts     int        `gorm:"not null;default:3" json:"max_attempts"`
+	CancelRequested bool       `gorm:"not null;default:false" json:"cancel_requested"`
+	RunAfter        time.Time  `gorm:"not null;default:now()" json:"run_after"`
+	LockedUntil     *time.Time `json:"locked_until,omitempty"` // Lease of the worker running the job, renewed while it runs
+	StartedAt       *time.Time `json:"started_at,omitempty"`
+	FinishedAt      *time.Time `json:"finished_at,omitempty"`
+	CreatedAt       time.Time  `gorm:"default:now()" json:"created_at"`
+	UpdatedAt       time.Time  `gorm:"default:now()" json:"updated_at"`
+}
+
+// TableName sets the table name for Job
+func (Job) TableName() string {
+	return "background_jobs"
+}
@@ -4,20 +4,24 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 // Organization represents an organization in the system (holding, company, or store)
 type Organization struct {
-	ID                   uuid.UUID  `gorm:"type:uuid;primary_key;default:uuid_generate_v7()" json:"id"`
-	Name                 string     `gorm:"type:varchar(100);not null" json:"name"`
-	Code                 string     `gorm:"type:varchar(8);unique;not null" json:"code"`
-	OrganizationType     string     `gorm:"type:varchar(20);not null;check:organization_type IN ('holding','company','store')" json:"organization_type"`
-	ParentOrganizationID *uuid.UUID `gorm:"type:uuid" json:"parent_organization_id,omitempty"`
-	Description          string     `gorm:"type:text" json:"description,omitempty"`
-	CreatedBy            uuid.UUID  `gorm:"type:uuid;not null" json:"created_by"`
-	IsActive             bool       `gorm:"type:boolean;not null;default:true" json:"is_active"`
-	CreatedAt            time.Time  `gorm:"default:now()" json:"created_at"`
-	UpdatedAt            time.Time  `gorm:"default:now()" json:"updated_at"`
+	ID                   uuid.UUID      `gorm:"type:uuid;primary_key;default:uuid_generate_v7()" json:"id"`
+	Name                 string         `gorm:"type:varchar(100);not null" json:"name"`
+	Code                 string         `gorm:"type:varchar(16);unique;not null" json:"code"`
+	OrganizationType     string         `gorm:"type:varchar(50);not null" json:"organization_type"`
+	ParentOrganizationID *uuid.UUID     `gorm:"type:uuid" json:"parent_organization_id,omitempty"`
+	Description          string         `gorm:"type:text" json:"description,omitempty"`
+	CreatedBy            uuid.UUID      `gorm:"type:uuid;not null" json:"created_by"`
+	IsActive             bool           `gorm:"type:boolean;not null;default:true" json:"is_active"`
+	ArchivedAt           *time.Time     `gorm:"type:timestamptz" json:"archived_at,omitempty"` // Archived organizations keep their data but accept no new members
+	ArchivedBy           *uuid.UUID     `gorm:"type:uuid" json:"archived_by,omitempty"`
+	CreatedAt            time.Time      `gorm:"default:now()" json:"created_at"`
+	UpdatedAt            time.Time      `gorm:"default:now()" json:"updated_at"`
+	DeletedAt            gorm.DeletedAt `gorm:"index" json:"-"`
 
 	// Relationships
 	ParentOrganization *Organization  `gorm:"foreignKey:ParentOrganizationID" json:"parent_organization,omitempty"`
@@ -30,3 +34,8 @@ type Organization struct {
 func (Organization) TableName() string {
 	return "organizations"
 }
+
+// IsArchived reports whether the organization has been archived
+func (o *Organization) IsArchived() bool {
+	return o.ArchivedAt != nil
+}
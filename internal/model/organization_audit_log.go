@@ -0,0 +1,26 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OrganizationAuditLog records a structural change made to an organization
+type OrganizationAuditLog struct {
+	ID             uuid.UUID  `gorm:"type:uuid;primary_key;default:uuid_generate_v7()" json:"id"`
+	OrganizationID uuid.UUID  `gorm:"type:uuid;not null" json:"organization_id"`
+	Action         string     `gorm:"type:varchar(50);not null" json:"action"`
+	ActorID        *uuid.UUID `gorm:"type:uuid" json:"actor_id,omitempty"`
+	Details        string     `gorm:"type:jsonb;not null;default:'{}'" json:"details"` // Serialized action-specific data
+	Reason         string     `gorm:"type:text" json:"reason,omitempty"`
+	CreatedAt      time.Time  `gorm:"default:now()" json:"created_at"`
+
+	// Relationships
+	Actor *User `gorm:"foreignKey:ActorID" json:"actor,omitempty"`
+}
+
+// TableName sets the table name for OrganizationAuditLog
+func (OrganizationAuditLog) TableName() string {
+	return "organization_audit_logs"
+}
@@ -0,0 +1,23 @@
+package model
+
+import (
+	"github.com/google/uuid"
+)
+
+// OrganizationClosure is one (ancestor, descendant) pair of the organization hierarchy.
+// Every organization has a self row at depth 0; rows are maintained by database triggers
+// on organizations, so inserts and parent changes keep the table consistent automatically.
+type OrganizationClosure struct {
+	AncestorID   uuid.UUID `gorm:"type:uuid;primaryKey" json:"ancestor_id"`
+	DescendantID uuid.UUID `gorm:"type:uuid;primaryKey" json:"descendant_id"`
+	Depth        int       `gorm:"not null" json:"depth"`
+
+	// Relationships
+	Ancestor   *Organization `gorm:"foreignKey:AncestorID" json:"ancestor,omitempty"`
+	Descendant *Organization `gorm:"foreignKey:DescendantID" json:"descendant,omitempty"`
+}
+
+// TableName sets the table name for OrganizationClosure
+func (OrganizationClosure) TableName() string {
+	return "organization_closures"
+}
@@ -0,0 +1,37 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OrganizationInvitation represents an invitation to join an organization with a pre-selected role.
+// Only the SHA-256 hash of the signed token is stored.
+type OrganizationInvitation struct {
+	ID             uuid.UUID  `gorm:"type:uuid;primary_key;default:uuid_generate_v7()" json:"id"`
+	OrganizationID uuid.UUID  `gorm:"type:uuid;not null" json:"organization_id"`
+	RoleID         uuid.UUID  `gorm:"type:uuid;not null" json:"role_id"`
+	Email          string     `gorm:"type:varchar(255)" json:"email,omitempty"`   // Set when the invitation targets an email address
+	InvitedUserID  *uuid.UUID `gorm:"type:uuid" json:"invited_user_id,omitempty"` // Set when the invitation targets an existing user
+	TokenHash      string     `gorm:"type:varchar(64);not null;unique" json:"-"`
+	Status         string     `gorm:"type:varchar(20);not null;default:'pending'" json:"status"` // pending, accepted, revoked
+	MaxUses        int        `gorm:"not null;default:1" json:"max_uses"`
+	UseCount       int        `gorm:"not null;default:0" json:"use_count"`
+	ExpiresAt      time.Time  `gorm:"not null" json:"expires_at"`
+	InvitedBy      uuid.UUID  `gorm:"type:uuid;not null" json:"invited_by"`
+	RevokedBy      *uuid.UUID `gorm:"type:uuid" json:"revoked_by,omitempty"`
+	RevokedAt      *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt      time.Time  `gorm:"default:now()" json:"created_at"`
+	UpdatedAt      time.Time  `gorm:"default:now()" json:"updated_at"`
+
+	// Relationships
+	Organization Organization `gorm:"foreignKey:OrganizationID" json:"organization,omitempty"`
+	Role         Role         `gorm:"foreignKey:RoleID" json:"role,omitempty"`
+	Inviter      User         `gorm:"foreignKey:InvitedBy" json:"inviter,omitempty"`
+}
+
+// TableName sets the table name for OrganizationInvitation
+func (OrganizationInvitation) TableName() string {
+	return "organization_invitations"
+}
@@ -0,0 +1,32 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OrganizationJoinRequest represents a request to join an organization whose join policy requires approval
+type OrganizationJoinRequest struct {
+	ID             uuid.UUID  `gorm:"type:uuid;primary_key;default:uuid_generate_v7()" json:"id"`
+	OrganizationID uuid.UUID  `gorm:"type:uuid;not null" json:"organization_id"`
+	UserID         uuid.UUID  `gorm:"type:uuid;not null" json:"user_id"`
+	Status         string     `gorm:"type:varchar(20);not null;default:'pending'" json:"status"` // pending, approved, rejected
+	Message        string     `gorm:"type:text" json:"message,omitempty"`
+	RoleID         *uuid.UUID `gorm:"type:uuid" json:"role_id,omitempty"` // Role granted on approval
+	DecidedBy      *uuid.UUID `gorm:"type:uuid" json:"decided_by,omitempty"`
+	DecidedAt      *time.Time `json:"decided_at,omitempty"`
+	Reason         string     `gorm:"type:text" json:"reason,omitempty"`
+	CreatedAt      time.Time  `gorm:"default:now()" json:"created_at"`
+	UpdatedAt      time.Time  `gorm:"default:now()" json:"updated_at"`
+
+	// Relationships
+	Organization Organization `gorm:"foreignKey:OrganizationID" json:"organization,omitempty"`
+	User         User         `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	Role         *Role        `gorm:"foreignKey:RoleID" json:"role,omitempty"`
+}
+
+// TableName sets the table name for OrganizationJoinRequest
+func (OrganizationJoinRequest) TableName() string {
+	return "organization_join_requests"
+}
@@ -0,0 +1,22 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OrganizationQuota is a plan limit set explicitly on an organization. Organizations without a limit
+// for a quota inherit it from their ancestors; a nil MaxValue lifts an inherited limit.
+type OrganizationQuota struct {
+	OrganizationID uuid.UUID  `gorm:"type:uuid;primaryKey" json:"organization_id"`
+	Quota          string     `gorm:"type:varchar(50);primaryKey" json:"quota"`
+	MaxValue       *int       `gorm:"type:int" json:"max_value"`
+	UpdatedBy      *uuid.UUID `gorm:"type:uuid" json:"updated_by,omitempty"`
+	UpdatedAt      time.Time  `gorm:"default:now()" json:"updated_at"`
+}
+
+// TableName sets the table name for OrganizationQuota
+func (OrganizationQuota) TableName() string {
+	return "organization_quotas"
+}
@@ -0,0 +1,22 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OrganizationSetting is a setting value set explicitly on an organization. Organizations without a
+// value for a key inherit it from their ancestors or fall back to the schema default.
+type OrganizationSetting struct {
+	OrganizationID uuid.UUID  `gorm:"type:uuid;primaryKey" json:"organization_id"`
+	Key            string     `gorm:"type:varchar(100);primaryKey" json:"key"`
+	Value          string     `gorm:"type:jsonb;not null" json:"value"` // JSON encoded value
+	UpdatedBy      *uuid.UUID `gorm:"type:uuid" json:"updated_by,omitempty"`
+	UpdatedAt      time.Time  `gorm:"default:now()" json:"updated_at"`
+}
+
+// TableName sets the table name for OrganizationSetting
+func (OrganizationSetting) TableName() string {
+	return "organization_settings"
+}
@@ -0,0 +1,25 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OrganizationTemplate is a saved organization structure that can be applied repeatedly, for example
+// to onboard new clients with the same holding/company/store layout
+type OrganizationTemplate struct {
+	ID          uuid.UUID  `gorm:"type:uuid;primary_key;default:uuid_generate_v7()" json:"id"`
+	Name        string     `gorm:"type:varchar(100);unique;not null" json:"name"`
+	Description string     `gorm:"type:text" json:"description,omitempty"`
+	Definition  string     `gorm:"type:jsonb;not null" json:"definition"` // Serialized dto.OrganizationTemplateDefinition
+	CreatedBy   *uuid.UUID `gorm:"type:uuid" json:"created_by,omitempty"`
+	UpdatedBy   *uuid.UUID `gorm:"type:uuid" json:"updated_by,omitempty"`
+	CreatedAt   time.Time  `gorm:"default:now()" json:"created_at"`
+	UpdatedAt   time.Time  `gorm:"default:now()" json:"updated_at"`
+}
+
+// TableName sets the table name for OrganizationTemplate
+func (OrganizationTemplate) TableName() string {
+	return "organization_templates"
+}
@@ -0,0 +1,61 @@
+package model
+
+import (
+	"slices"
+	"time"
+)
+
+// OrganizationType is an entry of the organization type registry. It defines where organizations
+// of the type may be placed in the hierarchy and which role levels belong to the type.
+type OrganizationType struct {
+	Name         string    `gorm:"type:varchar(50);primaryKey" json:"name"`
+	DisplayName  string    `gorm:"type:varchar(100);not null" json:"display_name"`
+	Description  string    `gorm:"type:text" json:"description,omitempty"`
+	AllowRoot    bool      `gorm:"not null;default:false" json:"allow_root"`
+	MaxDepth     *int      `json:"max_depth,omitempty"` // Deepest level (roots are 0) the type may sit at; nil means unlimited
+	MinRoleLevel int       `gorm:"not null" json:"min_role_level"`
+	MaxRoleLevel int       `gorm:"not null" json:"max_role_level"`
+	IsActive     bool      `gorm:"not null;default:true" json:"is_active"`
+	CreatedAt    time.Time `gorm:"default:now()" json:"created_at"`
+	UpdatedAt    time.Time `gorm:"default:now()" json:"updated_at"`
+
+	// Relationships
+	AllowedParents []OrganizationType `gorm:"many2many:organization_type_parents;foreignKey:Name;joinForeignKey:OrganizationType;references:Name;joinReferences:ParentType" json:"allowed_parents,omitempty"`
+}
+
+// TableName sets the table name for OrganizationType
+func (OrganizationType) TableName() string {
+	return "organization_types"
+}
+
+// AllowsParent reports whether an organization of this type may be placed under a parent of parentType.
+// An empty parentType asks whether the type may be a root organization.
+func (t *OrganizationType) AllowsParent(parentType string) bool {
+	if parentType == "" {
+		return t.AllowRoot
+	}
+	return slices.ContainsFunc(t.AllowedParents, func(parent OrganizationType) bool {
+		return parent.Name == parentType
+	})
+}
+
+// AllowsDepth reports whether an organization of this type may sit at the given depth
+func (t *OrganizationType) AllowsDepth(depth int) bool {
+	return t.MaxDepth == nil || depth <= *t.MaxDepth
+}
+
+// AllowsRoleLevel reports whether a role level falls within the level band of this type
+func (t *OrganizationType) AllowsRoleLevel(level int) bool {
+	return level >= t.MinRoleLevel && level <= t.MaxRoleLevel
+}
+
+// OrganizationTypeParent allows organizations of OrganizationType to be placed under organizations of ParentType
+type OrganizationTypeParent struct {
+	OrganizationType string `gorm:"type:varchar(50);primaryKey" json:"organization_type"`
+	ParentType       string `gorm:"type:varchar(50);primaryKey" json:"parent_type"`
+}
+
+// TableName sets the table name for OrganizationTypeParent
+func (OrganizationTypeParent) TableName() string {
+	return "organization_type_parents"
+}
@@ -0,0 +1,25 @@
+package model
+
+import "time"
+
+// OrganizationTypeAttribute defines a custom attribute members of organizations of a type carry,
+// e.g. an employee number for stores. Values are stored on the membership.
+type OrganizationTypeAttribute struct {
+	OrganizationType string    `gorm:"type:varchar(50);primaryKey" json:"organization_type"`
+	Key              string    `gorm:"type:varchar(50);primaryKey" json:"key"`
+	DisplayName      string    `gorm:"type:varchar(100);not null" json:"display_name"`
+	Description      string    `gorm:"type:text" json:"description,omitempty"`
+	Type             string    `gorm:"type:varchar(20);not null" json:"type"` // string, number or boolean
+	Required         bool      `gorm:"not null;default:false" json:"required"`
+	AllowedValues    *string   `gorm:"type:jsonb" json:"allowed_values,omitempty"` // JSON array of accepted string values; nil accepts any
+	Pattern          string    `gorm:"type:varchar(255)" json:"pattern,omitempty"` // Regular expression string values must match
+	MaxLength        *int      `json:"max_length,omitempty"`
+	Position         int       `gorm:"not null;default:0" json:"position"` // Order in which the attributes are listed
+	CreatedAt        time.Time `gorm:"default:now()" json:"created_at"`
+	UpdatedAt        time.Time `gorm:"default:now()" json:"updated_at"`
+}
+
+// TableName sets the table name for OrganizationTypeAttribute
+func (OrganizationTypeAttribute) TableName() string {
+	return "organization_type_attributes"
+}
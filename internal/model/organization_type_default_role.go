@@ -0,0 +1,27 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OrganizationTypeDefaultRole holds the roles granted automatically in organizations of a type:
+// the creator role for whoever creates the organization and the member role for users who join it
+type OrganizationTypeDefaultRole struct {
+	OrganizationType string     `gorm:"type:varchar(50);primaryKey" json:"organization_type"`
+	CreatorRoleID    *uuid.UUID `gorm:"type:uuid" json:"creator_role_id,omitempty"`
+	MemberRoleID     *uuid.UUID `gorm:"type:uuid" json:"member_role_id,omitempty"`
+	UpdatedBy        *uuid.UUID `gorm:"type:uuid" json:"updated_by,omitempty"`
+	CreatedAt        time.Time  `gorm:"default:now()" json:"created_at"`
+	UpdatedAt        time.Time  `gorm:"default:now()" json:"updated_at"`
+
+	// Relationships
+	CreatorRole *Role `gorm:"foreignKey:CreatorRoleID" json:"creator_role,omitempty"`
+	MemberRole  *Role `gorm:"foreignKey:MemberRoleID" json:"member_role,omitempty"`
+}
+
+// TableName sets the table name for OrganizationTypeDefaultRole
+func (OrganizationTypeDefaultRole) TableName() string {
+	return "organization_type_default_roles"
+}
@@ -16,16 +16,19 @@ type RoleApproval struct {
 	RequestedByUser   User       `gorm:"foreignKey:RequestedBy" json:"requested_by_user"`
 	ApproverID        *uuid.UUID `gorm:"type:uuid" json:"approver_id"`
 	Approver          *User      `gorm:"foreignKey:ApproverID" json:"approver,omitempty"`
-	Status            string     `gorm:"type:varchar(20);not null;default:'pending'" json:"status"` // pending, approved, rejected
+	Status            string     `gorm:"type:varchar(20);not null;default:'pending'" json:"status"` // pending, approved, rejected, cancelled
 	Reason            string     `gorm:"type:text" json:"reason"`
+	CreatedRoleID     *uuid.UUID `gorm:"type:uuid" json:"created_role_id,omitempty"` // Role created when the request is approved
+	DecidedAt         *time.Time `json:"decided_at,omitempty"`
 
 	// Organization context fields
 	OrganizationID            *uuid.UUID    `gorm:"type:uuid" json:"organization_id,omitempty"`
 	Organization              *Organization `gorm:"foreignKey:OrganizationID" json:"organization,omitempty"`
-	OrganizationCode          string        `gorm:"type:varchar(8)" json:"organization_code,omitempty"`
+	OrganizationCode          string        `gorm:"type:varchar(16)" json:"organization_code,omitempty"`
 	IsNewOrganization         bool          `gorm:"type:boolean;default:false" json:"is_new_organization"`
 	RequestedOrganizationName string        `gorm:"type:varchar(100)" json:"requested_organization_name,omitempty"`
-	RequestedOrganizationType string        `gorm:"type:varchar(20);check:requested_organization_type IN ('platform','holding','company','store')" json:"requested_organization_type,omitempty"`
+	RequestedOrganizationType string        `gorm:"type:varchar(50)" json:"requested_organization_type,omitempty"`
+	OrganizationDescription   string        `gorm:"type:text" json:"organization_description,omitempty"`
 
 	CreatedAt time.Time `gorm:"default:now()" json:"created_at"`
 	UpdatedAt time.Time `gorm:"default:now()" json:"updated_at"`
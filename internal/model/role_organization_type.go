@@ -7,7 +7,7 @@ import (
 // RoleOrganizationType represents which organization types a role is applicable to
 type RoleOrganizationType struct {
 	RoleID           uuid.UUID `gorm:"type:uuid;primaryKey" json:"role_id"`
-	OrganizationType string    `gorm:"type:varchar(50);primaryKey;check:organization_type IN ('platform', 'holding', 'company', 'store')" json:"organization_type"`
+	OrganizationType string    `gorm:"type:varchar(50);primaryKey" json:"organization_type"`
 
 	// Relationships
 	Role Role `gorm:"foreignKey:RoleID" json:"role,omitempty"`
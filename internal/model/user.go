@@ -9,17 +9,27 @@ import (
 
 // User represents a user account in the system.
 type User struct {
-	ID           uuid.UUID      `gorm:"type:uuid;primary_key;default:uuid_generate_v7()" json:"id"`
-	Username     string         `gorm:"type:varchar(50);unique;not null" json:"username"`
-	Email        string         `gorm:"type:varchar(255);unique" json:"email"`
-	Password     string         `gorm:"type:varchar(255)" json:"-"`         // Don't expose password in JSON
-	RoleID       *uuid.UUID     `gorm:"type:uuid" json:"role_id"`           // Foreign key for RBAC system
-	GoogleID     *string        `gorm:"type:varchar(255)" json:"google_id"` // Changed to pointer for proper NULL handling
-	AvatarURL    string         `gorm:"type:text" json:"avatar_url"`
-	AuthProvider string         `gorm:"type:varchar(20);default:'local'" json:"auth_provider"` // Track authentication method
-	CreatedAt    time.Time      `gorm:"default:now()" json:"created_at"`
-	UpdatedAt    time.Time      `gorm:"default:now()" json:"updated_at"`
-	DeletedAt    gorm.DeletedAt `gorm:"index" json:"-"`
+	ID             uuid.UUID      `gorm:"type:uuid;primary_key;default:uuid_generate_v7()" json:"id"`
+	Username       string         `gorm:"type:varchar(50);unique;not null" json:"username"`
+	Email          string         `gorm:"type:varchar(255);unique" json:"email"`
+	Password       string         `gorm:"type:varchar(255)" json:"-"`         // Don't expose password in JSON
+	RoleID         *uuid.UUID     `gorm:"type:uuid" json:"role_id"`           // Foreign key for RBAC system
+	GoogleID       *string        `gorm:"type:varchar(255)" json:"google_id"` // Changed to pointer for proper NULL handling
+	AvatarURL      string         `gorm:"type:text" json:"avatar_url"`
+	AvatarKey      string         `gorm:"type:varchar(255)" json:"-"` // Storage prefix of an uploaded avatar
+	DisplayName    string         `gorm:"type:varchar(100)" json:"display_name,omitempty"`
+	Phone          string         `gorm:"type:varchar(32)" json:"phone,omitempty"`    // E.164 format, e.g. +6281234567890
+	Locale         string         `gorm:"type:varchar(35)" json:"locale,omitempty"`   // Language tag, e.g. id-ID
+	Timezone       string         `gorm:"type:varchar(64)" json:"timezone,omitempty"` // IANA time zone, e.g. Asia/Jakarta
+	JobTitle       string         `gorm:"type:varchar(100)" json:"job_title,omitempty"`
+	AuthProvider   string         `gorm:"type:varchar(20);default:'local'" json:"auth_provider"`    // Track authentication method
+	Status         string         `gorm:"type:varchar(20);not null;default:'active'" json:"status"` // active, suspended or pending_activation
+	StatusReason   string         `gorm:"type:text" json:"status_reason,omitempty"`
+	SuspendedUntil *time.Time     `json:"suspended_until,omitempty"` // Lifts a suspension once passed; nil suspends indefinitely
+	ErasedAt       *time.Time     `json:"erased_at,omitempty"`       // Set once the personal data has been erased
+	CreatedAt      time.Time      `gorm:"default:now()" json:"created_at"`
+	UpdatedAt      time.Time      `gorm:"default:now()" json:"updated_at"`
+	DeletedAt      gorm.DeletedAt `gorm:"index" json:"-"`
 
 	// Relationships
 	Role          *Role          `gorm:"foreignKey:RoleID" json:"role,omitempty"`
@@ -38,6 +48,11 @@ func (u *User) BeforeCreate(tx *gorm.DB) error {
 		u.AuthProvider = "local"
 	}
 
+	// New accounts are active unless created otherwise
+	if u.Status == "" {
+		u.Status = "active"
+	}
+
 	// For local users, ensure GoogleID is nil, not empty string
 	if u.AuthProvider == "local" && u.GoogleID != nil && *u.GoogleID == "" {
 		u.GoogleID = nil
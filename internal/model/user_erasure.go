@@ -0,0 +1,23 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UserErasure records the erasure of a user's personal data. It holds no personal data itself.
+type UserErasure struct {
+	ID                 uuid.UUID  `gorm:"type:uuid;primary_key;default:uuid_generate_v7()" json:"id"`
+	UserID             uuid.UUID  `gorm:"type:uuid;not null" json:"user_id"`
+	ErasedBy           *uuid.UUID `gorm:"type:uuid" json:"erased_by,omitempty"`
+	Reason             string     `gorm:"type:text" json:"reason,omitempty"`
+	MembershipsRemoved int        `gorm:"not null;default:0" json:"memberships_removed"`
+	SessionsRevoked    int        `gorm:"not null;default:0" json:"sessions_revoked"`
+	ErasedAt           time.Time  `gorm:"default:now()" json:"erased_at"`
+}
+
+// TableName sets the table name for UserErasure
+func (UserErasure) TableName() string {
+	return "user_erasures"
+}
@@ -13,6 +13,7 @@ type UserOrganization struct {
 	RoleID         *uuid.UUID `gorm:"type:uuid" json:"role_id,omitempty"`
 	JoinedAt       time.Time  `gorm:"default:now()" json:"joined_at"`
 	IsActive       bool       `gorm:"type:boolean;not null;default:true" json:"is_active"`
+	Attributes     string     `gorm:"type:jsonb;not null;default:'{}'" json:"attributes"` // Custom attributes defined by the organization type
 
 	// Relationships
 	User         User         `gorm:"foreignKey:UserID" json:"user,omitempty"`
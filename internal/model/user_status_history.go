@@ -0,0 +1,27 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UserStatusHistory records every change of a user's account status
+type UserStatusHistory struct {
+	ID             uuid.UUID  `gorm:"type:uuid;primary_key;default:uuid_generate_v7()" json:"id"`
+	UserID         uuid.UUID  `gorm:"type:uuid;not null" json:"user_id"`
+	PreviousStatus string     `gorm:"type:varchar(20);not null" json:"previous_status"`
+	NewStatus      string     `gorm:"type:varchar(20);not null" json:"new_status"`
+	Reason         string     `gorm:"type:text" json:"reason,omitempty"`
+	SuspendedUntil *time.Time `json:"suspended_until,omitempty"`
+	ActionBy       *uuid.UUID `gorm:"type:uuid" json:"action_by,omitempty"` // Nil when a suspension expired on its own
+	ActionAt       time.Time  `gorm:"default:now()" json:"action_at"`
+
+	// Relationships
+	Actor *User `gorm:"foreignKey:ActionBy" json:"actor,omitempty"`
+}
+
+// TableName sets the table name for UserStatusHistory
+func (UserStatusHistory) TableName() string {
+	return "user_status_history"
+}
@@ -0,0 +1,177 @@
+package repository
+
+import (
+	"context"
+	"go-base-project/internal/constant"
+	"go-base-project/internal/model"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type changeRequestRepository struct {
+	db *gorm.DB
+}
+
+// NewChangeRequestRepository creates a new instance of ChangeRequestRepository.
+func NewChangeRequestRepository(db *gorm.DB) ChangeRequestRepositoryInterface {
+	return &changeRequestRepository{db: db}
+}
+
+// Create stores a new change request. The partial unique index on pending requests rejects a second pending
+// request for the same action and target, even when two are submitted concurrently. Its predicate is written as a
+// literal because Postgres cannot match a partial index against a bound parameter.
+func (r *changeRequestRepository) Create(ctx context.Context, changeRequest *model.ChangeRequest) (*model.ChangeRequest, error) {
+	result := dbFromContext(ctx, r.db).
+		Omit(clause.Associations).
+		Clauses(clause.OnConflict{
+			Columns:     []clause.Column{{Name: "action"}, {Name: "target_id"}},
+			TargetWhere: clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "status = 'pending'"}}},
+			DoNothing:   true,
+		}).
+		Create(changeRequest)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, ErrChangeRequestAlreadyPending
+	}
+	return changeRequest, nil
+}
+
+// FindByID finds a change request with its requester and approver decisions.
+func (r *changeRequestRepository) FindByID(ctx context.Context, id uuid.UUID) (*model.ChangeRequest, error) {
+	var changeRequest model.ChangeRequest
+	if err := dbFromContext(ctx, r.db).
+		Preload("Requester").
+		Preload("Approvals", func(db *gorm.DB) *gorm.DB { return db.Order("created_at ASC") }).
+		Preload("Approvals.Approver").
+		Where("id = ?", id).
+		First(&changeRequest).Error; err != nil {
+		return nil, err
+	}
+	return &changeRequest, nil
+}
+
+// FindPendingByActionAndTarget finds an open change request for the same action on the same target.
+func (r *changeRequestRepository) FindPendingByActionAndTarget(ctx context.Context, action string, targetID uuid.UUID) (*model.ChangeRequest, error) {
+	var changeRequest model.ChangeRequest
+	if err := dbFromContext(ctx, r.db).
+		Where("action = ? AND target_id = ? AND status = ?", action, targetID, constant.ChangeRequestStatusPending).
+		First(&changeRequest).Error; err != nil {
+		return nil, err
+	}
+	return &changeRequest, nil
+}
+
+// List returns change requests, newest first, optionally filtered by status.
+func (r *changeRequestRepository) List(ctx context.Context, status string, offset, limit int) ([]model.ChangeRequest, error) {
+	var changeRequests []model.ChangeRequest
+	query := dbFromContext(ctx, r.db).
+		Preload("Requester").
+		Preload("Approvals.Approver")
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if err := query.Order("created_at DESC").Offset(offset).Limit(limit).Find(&changeRequests).Error; err != nil {
+		return nil, err
+	}
+	return changeRequests, nil
+}
+
+// Count counts change requests, optionally filtered by status.
+func (r *changeRequestRepository) Count(ctx context.Context, status string) (int64, error) {
+	var count int64
+	query := dbFromContext(ctx, r.db).Model(&model.ChangeRequest{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if err := query.Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// UpdateStatus moves a change request between statuses and reports whether the transition happened.
+// The update only applies while the request still has fromStatus, which makes it safe under concurrency.
+func (r *changeRequestRepository) UpdateStatus(ctx context.Context, id uuid.UUID, fromStatus, toStatus string) (bool, error) {
+	result := dbFromContext(ctx, r.db).
+		Model(&model.ChangeRequest{}).
+		Where("id = ? AND status = ?", id, fromStatus).
+		Updates(map[string]interface{}{"status": toStatus, "updated_at": time.Now()})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// MarkExecuted records that an approved change request was executed, with the serialized executor result.
+func (r *changeRequestRepository) MarkExecuted(ctx context.Context, id uuid.UUID, executedAt time.Time, result *string) error {
+	return dbFromContext(ctx, r.db).
+		Model(&model.ChangeRequest{}).
+		Where("id = ? AND status = ?", id, constant.ChangeRequestStatusExecuting).
+		Updates(map[string]interface{}{
+			"status":      constant.ChangeRequestStatusExecuted,
+			"executed_at": executedAt,
+			"result":      result,
+			"updated_at":  time.Now(),
+		}).Error
+}
+
+// RecordDecision stores an approver decision and advances the change request status in one transaction.
+func (r *changeRequestRepository) RecordDecision(ctx context.Context, decision *model.ChangeRequestApproval) (*model.ChangeRequest, error) {
+	var changeRequest model.ChangeRequest
+	err := dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		// Lock the row so concurrent approvers cannot both trigger execution
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", decision.ChangeRequestID).
+			First(&changeRequest).Error; err != nil {
+			return err
+		}
+		if changeRequest.Status != constant.ChangeRequestStatusPending || !changeRequest.ExpiresAt.After(time.Now()) {
+			return ErrChangeRequestNotPending
+		}
+
+		if err := tx.Omit(clause.Associations).Create(decision).Error; err != nil {
+			return err
+		}
+
+		newStatus := ""
+		if decision.Decision == constant.ChangeRequestDecisionRejected {
+			newStatus = constant.ChangeRequestStatusRejected
+		} else {
+			var approvals int64
+			if err := tx.Model(&model.ChangeRequestApproval{}).
+				Where("change_request_id = ? AND decision = ?", changeRequest.ID, constant.ChangeRequestDecisionApproved).
+				Count(&approvals).Error; err != nil {
+				return err
+			}
+			if approvals >= int64(changeRequest.RequiredApprovals) {
+				newStatus = constant.ChangeRequestStatusExecuting
+			}
+		}
+
+		if newStatus != "" {
+			if err := tx.Model(&changeRequest).Updates(map[string]interface{}{"status": newStatus, "updated_at": time.Now()}).Error; err != nil {
+				return err
+			}
+			changeRequest.Status = newStatus
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &changeRequest, nil
+}
+
+// ExpirePending marks overdue pending change requests as expired.
+func (r *changeRequestRepository) ExpirePending(ctx context.Context, now time.Time) (int64, error) {
+	result := dbFromContext(ctx, r.db).
+		Model(&model.ChangeRequest{}).
+		Where("status = ? AND expires_at <= ?", constant.ChangeRequestStatusPending, now).
+		Updates(map[string]interface{}{"status": constant.ChangeRequestStatusExpired, "updated_at": now})
+	return result.RowsAffected, result.Error
+}
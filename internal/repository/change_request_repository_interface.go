@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"go-base-project/internal/model"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrChangeRequestNotPending is returned when a decision is recorded on a change request that is no longer pending.
+var ErrChangeRequestNotPending = errors.New("change request is not pending")
+
+// ErrChangeRequestAlreadyPending is returned when a change request is created for an action and target that already
+// have a pending one.
+var ErrChangeRequestAlreadyPending = errors.New("a pending change request already exists for this action and target")
+
+// ChangeRequestRepositoryInterface defines the data operations for approval change requests
+type ChangeRequestRepositoryInterface interface {
+	// Create stores a new change request, or returns ErrChangeRequestAlreadyPending when its action and target
+	// already have a pending one.
+	Create(ctx context.Context, changeRequest *model.ChangeRequest) (*model.ChangeRequest, error)
+	FindByID(ctx context.Context, id uuid.UUID) (*model.ChangeRequest, error)
+	FindPendingByActionAndTarget(ctx context.Context, action string, targetID uuid.UUID) (*model.ChangeRequest, error)
+	List(ctx context.Context, status string, offset, limit int) ([]model.ChangeRequest, error)
+	Count(ctx context.Context, status string) (int64, error)
+	UpdateStatus(ctx context.Context, id uuid.UUID, fromStatus, toStatus string) (bool, error)
+	MarkExecuted(ctx context.Context, id uuid.UUID, executedAt time.Time, result *string) error
+
+	// RecordDecision stores an approver decision under a row lock. A rejection closes the request,
+	// and the approval that reaches the required count moves it to executing.
+	RecordDecision(ctx context.Context, decision *model.ChangeRequestApproval) (*model.ChangeRequest, error)
+
+	// ExpirePending marks every pending change request whose expiry has passed as expired.
+	ExpirePending(ctx context.Context, now time.Time) (int64, error)
+}
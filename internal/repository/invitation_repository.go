@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"go-base-project/internal/constant"
+	"go-base-project/internal/model"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type invitationRepository struct {
+	db *gorm.DB
+}
+
+// NewInvitationRepository creates a new instance of InvitationRepository.
+func NewInvitationRepository(db *gorm.DB) InvitationRepositoryInterface {
+	return &invitationRepository{db: db}
+}
+
+// Create stores a new invitation.
+func (r *invitationRepository) Create(ctx context.Context, invitation *model.OrganizationInvitation) (*model.OrganizationInvitation, error) {
+	if err := dbFromContext(ctx, r.db).Omit(clause.Associations).Create(invitation).Error; err != nil {
+		return nil, err
+	}
+	return invitation, nil
+}
+
+// FindByID finds an invitation with its organization, role and inviter.
+func (r *invitationRepository) FindByID(ctx context.Context, id uuid.UUID) (*model.OrganizationInvitation, error) {
+	var invitation model.OrganizationInvitation
+	if err := dbFromContext(ctx, r.db).
+		Preload("Organization").
+		Preload("Role").
+		Preload("Inviter").
+		Where("id = ?", id).
+		First(&invitation).Error; err != nil {
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+// ListPendingByOrganization returns the invitations of an organization that can still be accepted, newest first.
+func (r *invitationRepository) ListPendingByOrganization(ctx context.Context, organizationID uuid.UUID, now time.Time) ([]model.OrganizationInvitation, error) {
+	var invitations []model.OrganizationInvitation
+	if err := dbFromContext(ctx, r.db).
+		Preload("Organization").
+		Preload("Role").
+		Preload("Inviter").
+		Where("organization_id = ? AND status = ? AND expires_at > ?", organizationID, constant.InvitationStatusPending, now).
+		Order("created_at DESC").
+		Find(&invitations).Error; err != nil {
+		return nil, err
+	}
+	return invitations, nil
+}
+
+// Revoke marks a pending invitation as revoked and reports whether it was still pending.
+func (r *invitationRepository) Revoke(ctx context.Context, id uuid.UUID, revokedBy uuid.UUID, revokedAt time.Time) (bool, error) {
+	result := dbFromContext(ctx, r.db).
+		Model(&model.OrganizationInvitation{}).
+		Where("id = ? AND status = ?", id, constant.InvitationStatusPending).
+		Updates(map[string]interface{}{
+			"status":     constant.InvitationStatusRevoked,
+			"revoked_by": revokedBy,
+			"revoked_at": revokedAt,
+			"updated_at": revokedAt,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// Redeem consumes one use of an invitation. The conditional increment is the concurrency guard: it only
+// succeeds while uses remain.
+func (r *invitationRepository) Redeem(ctx context.Context, invitationID uuid.UUID, now time.Time) error {
+	result := dbFromContext(ctx, r.db).Model(&model.OrganizationInvitation{}).
+		Where("id = ? AND status = ? AND expires_at > ? AND use_count < max_uses", invitationID, constant.InvitationStatusPending, now).
+		Updates(map[string]interface{}{
+			"use_count":  gorm.Expr("use_count + 1"),
+			"status":     gorm.Expr("CASE WHEN use_count + 1 >= max_uses THEN ? ELSE status END", constant.InvitationStatusAccepted),
+			"updated_at": now,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrInvitationNotRedeemable
+	}
+	return nil
+}
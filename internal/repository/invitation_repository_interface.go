@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"go-base-project/internal/model"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrInvitationNotRedeemable is returned when an invitation is revoked, expired or has no uses left.
+var ErrInvitationNotRedeemable = errors.New("invitation is no longer redeemable")
+
+// InvitationRepositoryInterface defines the data operations for organization invitations
+type InvitationRepositoryInterface interface {
+	Create(ctx context.Context, invitation *model.OrganizationInvitation) (*model.OrganizationInvitation, error)
+	FindByID(ctx context.Context, id uuid.UUID) (*model.OrganizationInvitation, error)
+	ListPendingByOrganization(ctx context.Context, organizationID uuid.UUID, now time.Time) ([]model.OrganizationInvitation, error)
+	Revoke(ctx context.Context, id uuid.UUID, revokedBy uuid.UUID, revokedAt time.Time) (bool, error)
+
+	// Redeem consumes one use of the invitation; the last use moves it to accepted. Callers grant the
+	// membership in the same transaction, so a failed grant gives the use back.
+	Redeem(ctx context.Context, invitationID uuid.UUID, now time.Time) error
+}
@@ -0,0 +1,201 @@
+package repository
+
+import (
+	"context"
+	"go-base-project/internal/constant"
+	"go-base-project/internal/model"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type jobRepository struct {
+	db *gorm.DB
+}
+
+// NewJobRepository creates a new instance of JobRepository.
+func NewJobRepository(db *gorm.DB) JobRepositoryInterface {
+	return &jobRepository{db: db}
+}
+
+// Create stores a new job.
+func (r *jobRepository) Create(ctx context.Context, job *model.Job) error {
+	return dbFromContext(ctx, r.db).Create(job).Error
+}
+
+// FindByID finds a job by its ID.
+func (r *jobRepository) FindByID(ctx context.Context, id uuid.UUID) (*model.Job, error) {
+	var job model.Job
+	if err := dbFromContext(ctx, r.db).Where("id = ?", id).First(&job).Error; err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// ClaimNext leases the oldest runnable job. SKIP LOCKED lets concurrent workers, also of other instances,
+// claim different jobs without waiting on each other.
+func (r *jobRepository) ClaimNext(ctx context.Context, now, lockedUntil time.Time) (*model.Job, error) {
+	var job model.Job
+	result := dbFromContext(ctx, r.db).Raw(`
+		UPDATE background_jobs
+		SET status = ?, attempts = attempts + 1, locked_until = ?, started_at = ?, updated_at = ?
+		WHERE id = (
+			SELECT id FROM background_jobs
+			WHERE (status = ? AND run_after <= ?) OR (status = ? AND locked_until < ?)
+			ORDER BY run_after, id
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *`,
+		constant.JobStatusRunning, lockedUntil, now, now,
+		constant.JobStatusQueued, now, constant.JobStatusRunning, now,
+	).Scan(&job)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &job, nil
+}
+
+// Heartbeat renews the lease of a running job.
+func (r *jobRepository) Heartbeat(ctx context.Context, id uuid.UUID, lockedUntil time.Time) (bool, error) {
+	return r.updateRunning(ctx, id, map[string]interface{}{"locked_until": lockedUntil})
+}
+
+// UpdateProgress records the progress and partial result of a running job.
+func (r *jobRepository) UpdateProgress(ctx context.Context, id uuid.UUID, processed, total int, result *string, lockedUntil time.Time) (bool, error) {
+	updates := map[string]interface{}{
+		"processed_items": processed,
+		"total_items":     total,
+		"locked_until":    lockedUntil,
+		"updated_at":      time.Now(),
+	}
+	if result != nil {
+		updates["result"] = *result
+	}
+	return r.updateRunning(ctx, id, updates)
+}
+
+// updateRunning applies updates to a running job and returns its cancel_requested flag.
+func (r *jobRepository) updateRunning(ctx context.Context, id uuid.UUID, updates map[string]interface{}) (bool, error) {
+	var job model.Job
+	result := dbFromContext(ctx, r.db).
+		Model(&job).
+		Clauses(clause.Returning{Columns: []clause.Column{{Name: "cancel_requested"}}}).
+		Where("id = ? AND status = ?", id, constant.JobStatusRunning).
+		Updates(updates)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return job.CancelRequested, nil
+}
+
+// Finish records the outcome of a running job and releases its lease.
+func (r *jobRepository) Finish(ctx context.Context, id uuid.UUID, status string, result *string, errorMessage string, finishedAt time.Time) error {
+	updates := map[string]interface{}{
+		"status":       status,
+		"error":        errorMessage,
+		"locked_until": nil,
+		"finished_at":  finishedAt,
+		"updated_at":   time.Now(),
+	}
+	if result != nil {
+		updates["result"] = *result
+	}
+	return dbFromContext(ctx, r.db).
+		Model(&model.Job{}).
+		Where("id = ? AND status = ?", id, constant.JobStatusRunning).
+		Updates(updates).Error
+}
+
+// Reschedule queues a running job for another attempt.
+func (r *jobRepository) Reschedule(ctx context.Context, id uuid.UUID, runAfter time.Time, errorMessage string) error {
+	return dbFromContext(ctx, r.db).
+		Model(&model.Job{}).
+		Where("id = ? AND status = ?", id, constant.JobStatusRunning).
+		Updates(map[string]interface{}{
+			"status":       constant.JobStatusQueued,
+			"error":        errorMessage,
+			"run_after":    runAfter,
+			"locked_until": nil,
+			"updated_at":   time.Now(),
+		}).Error
+}
+
+// Release hands a running job back to the queue and takes back its attempt.
+func (r *jobRepository) Release(ctx context.Context, id uuid.UUID) error {
+	return dbFromContext(ctx, r.db).
+		Model(&model.Job{}).
+		Where("id = ? AND status = ?", id, constant.JobStatusRunning).
+		Updates(map[string]interface{}{
+			"status":       constant.JobStatusQueued,
+			"attempts":     gorm.Expr("GREATEST(attempts - 1, 0)"),
+			"locked_until": nil,
+			"updated_at":   time.Now(),
+		}).Error
+}
+
+// CancelQueued cancels a job that is still waiting in the queue.
+func (r *jobRepository) CancelQueued(ctx context.Context, id uuid.UUID, now time.Time) (bool, error) {
+	result := dbFromContext(ctx, r.db).
+		Model(&model.Job{}).
+		Where("id = ? AND status = ?", id, constant.JobStatusQueued).
+		Updates(map[string]interface{}{
+			"status":           constant.JobStatusCancelled,
+			"cancel_requested": true,
+			"finished_at":      now,
+			"updated_at":       now,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// RequestCancellation flags a running job; its worker stops it at the next heartbeat or progress update.
+func (r *jobRepository) RequestCancellation(ctx context.Context, id uuid.UUID) (bool, error) {
+	result := dbFromContext(ctx, r.db).
+		Model(&model.Job{}).
+		Where("id = ? AND status = ?", id, constant.JobStatusRunning).
+		Updates(map[string]interface{}{"cancel_requested": true, "updated_at": time.Now()})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// Requeue resets a failed or cancelled job so it runs again from the start.
+func (r *jobRepository) Requeue(ctx context.Context, id uuid.UUID, now time.Time) (bool, error) {
+	result := dbFromContext(ctx, r.db).
+		Model(&model.Job{}).
+		Where("id = ? AND status IN ?", id, []string{constant.JobStatusFailed, constant.JobStatusCancelled}).
+		Updates(map[string]interface{}{
+			"status":           constant.JobStatusQueued,
+			"attempts":         0,
+			"cancel_requested": false,
+			"processed_items":  0,
+			"total_items":      0,
+			"result":           nil,
+			"error":            "",
+			"run_after":        now,
+			"started_at":       nil,
+			"finished_at":      nil,
+			"updated_at":       now,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// DeleteFinishedBefore removes jobs, with their payloads and results, that finished before the given time.
+func (r *jobRepository) DeleteFinishedBefore(ctx context.Context, before time.Time) (int64, error) {
+	result := dbFromContext(ctx, r.db).
+		Where("finished_at IS NOT NULL AND finished_at < ?", before).
+		Delete(&model.Job{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"context"
+	"go-base-project/internal/model"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// JobRepositoryInterface defines the data operations for background jobs
+type JobRepositoryInterface interface {
+	Create(ctx context.Context, job *model.Job) error
+	FindByID(ctx context.Context, id uuid.UUID) (*model.Job, error)
+
+	// ClaimNext marks the next runnable job as running, leased to the caller until lockedUntil, and counts the
+	// attempt. Runnable are queued jobs that are due and running jobs whose lease ran out because their worker
+	// stopped. It returns gorm.ErrRecordNotFound when no job is runnable.
+	ClaimNext(ctx context.Context, now, lockedUntil time.Time) (*model.Job, error)
+	// Heartbeat renews the lease of a running job and reports whether its cancellation was requested.
+	Heartbeat(ctx context.Context, id uuid.UUID, lockedUntil time.Time) (bool, error)
+	// UpdateProgress records the progress of a running job, renews its lease and reports whether its
+	// cancellation was requested. A nil result keeps the stored one.
+	UpdateProgress(ctx context.Context, id uuid.UUID, processed, total int, result *string, lockedUntil time.Time) (bool, error)
+	// Finish records the final status of a running job. A nil result keeps the partial result reported last.
+	Finish(ctx context.Context, id uuid.UUID, status string, result *string, errorMessage string, finishedAt time.Time) error
+	// Reschedule returns a failed attempt of a running job to the queue, to run again at runAfter.
+	Reschedule(ctx context.Context, id uuid.UUID, runAfter time.Time, errorMessage string) error
+	// Release returns a running job to the queue without counting the attempt, for workers that shut down.
+	Release(ctx context.Context, id uuid.UUID) error
+
+	// CancelQueued cancels a job that has not started yet and reports whether it was still queued.
+	CancelQueued(ctx context.Context, id uuid.UUID, now time.Time) (bool, error)
+	// RequestCancellation asks the worker of a running job to stop and reports whether the job was running.
+	RequestCancellation(ctx context.Context, id uuid.UUID) (bool, error)
+	// Requeue queues a failed or cancelled job again with a fresh set of attempts and reports whether it did.
+	Requeue(ctx context.Context, id uuid.UUID, now time.Time) (bool, error)
+
+	// DeleteFinishedBefore removes jobs that finished before the given time.
+	DeleteFinishedBefore(ctx context.Context, before time.Time) (int64, error)
+}
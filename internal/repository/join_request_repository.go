@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"context"
+	"go-base-project/internal/constant"
+	"go-base-project/internal/model"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type joinRequestRepository struct {
+	db *gorm.DB
+}
+
+// NewJoinRequestRepository creates a new instance of JoinRequestRepository.
+func NewJoinRequestRepository(db *gorm.DB) JoinRequestRepositoryInterface {
+	return &joinRequestRepository{db: db}
+}
+
+// Create stores a new join request.
+func (r *joinRequestRepository) Create(ctx context.Context, joinRequest *model.OrganizationJoinRequest) (*model.OrganizationJoinRequest, error) {
+	if err := dbFromContext(ctx, r.db).Omit(clause.Associations).Create(joinRequest).Error; err != nil {
+		return nil, err
+	}
+	return joinRequest, nil
+}
+
+// FindByID finds a join request with its organization, requester and granted role.
+func (r *joinRequestRepository) FindByID(ctx context.Context, id uuid.UUID) (*model.OrganizationJoinRequest, error) {
+	var joinRequest model.OrganizationJoinRequest
+	if err := dbFromContext(ctx, r.db).
+		Preload("Organization").
+		Preload("User").
+		Preload("Role").
+		Where("id = ?", id).
+		First(&joinRequest).Error; err != nil {
+		return nil, err
+	}
+	return &joinRequest, nil
+}
+
+// FindPendingByOrganizationAndUser finds the open join request of a user for an organization.
+func (r *joinRequestRepository) FindPendingByOrganizationAndUser(ctx context.Context, organizationID, userID uuid.UUID) (*model.OrganizationJoinRequest, error) {
+	var joinRequest model.OrganizationJoinRequest
+	if err := dbFromContext(ctx, r.db).
+		Where("organization_id = ? AND user_id = ? AND status = ?", organizationID, userID, constant.JoinRequestStatusPending).
+		First(&joinRequest).Error; err != nil {
+		return nil, err
+	}
+	return &joinRequest, nil
+}
+
+// ListByOrganization returns the join requests of an organization, newest first, optionally filtered by status.
+func (r *joinRequestRepository) ListByOrganization(ctx context.Context, organizationID uuid.UUID, status string) ([]model.OrganizationJoinRequest, error) {
+	var joinRequests []model.OrganizationJoinRequest
+	query := dbFromContext(ctx, r.db).
+		Preload("Organization").
+		Preload("User").
+		Preload("Role").
+		Where("organization_id = ?", organizationID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if err := query.Order("created_at DESC").Find(&joinRequests).Error; err != nil {
+		return nil, err
+	}
+	return joinRequests, nil
+}
+
+// ListByUser returns the join requests made by a user, newest first, optionally filtered by status.
+func (r *joinRequestRepository) ListByUser(ctx context.Context, userID uuid.UUID, status string) ([]model.OrganizationJoinRequest, error) {
+	var joinRequests []model.OrganizationJoinRequest
+	query := dbFromContext(ctx, r.db).
+		Preload("Organization").
+		Preload("User").
+		Preload("Role").
+		Where("user_id = ?", userID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if err := query.Order("created_at DESC").Find(&joinRequests).Error; err != nil {
+		return nil, err
+	}
+	return joinRequests, nil
+}
+
+// Reject marks a pending join request as rejected and reports whether it was still pending.
+func (r *joinRequestRepository) Reject(ctx context.Context, id, decidedBy uuid.UUID, reason string, decidedAt time.Time) (bool, error) {
+	result := dbFromContext(ctx, r.db).
+		Model(&model.OrganizationJoinRequest{}).
+		Where("id = ? AND status = ?", id, constant.JoinRequestStatusPending).
+		Updates(map[string]interface{}{
+			"status":     constant.JoinRequestStatusRejected,
+			"decided_by": decidedBy,
+			"decided_at": decidedAt,
+			"reason":     reason,
+			"updated_at": decidedAt,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// Approve marks a pending join request as approved with the granted role.
+func (r *joinRequestRepository) Approve(ctx context.Context, id, decidedBy, roleID uuid.UUID, decidedAt time.Time) error {
+	result := dbFromContext(ctx, r.db).Model(&model.OrganizationJoinRequest{}).
+		Where("id = ? AND status = ?", id, constant.JoinRequestStatusPending).
+		Updates(map[string]interface{}{
+			"status":     constant.JoinRequestStatusApproved,
+			"role_id":    roleID,
+			"decided_by": decidedBy,
+			"decided_at": decidedAt,
+			"updated_at": decidedAt,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrJoinRequestNotPending
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"go-base-project/internal/model"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrJoinRequestNotPending is returned when a decision is made on a join request that was already decided.
+var ErrJoinRequestNotPending = errors.New("join request is not pending")
+
+// JoinRequestRepositoryInterface defines the data operations for organization join requests
+type JoinRequestRepositoryInterface interface {
+	Create(ctx context.Context, joinRequest *model.OrganizationJoinRequest) (*model.OrganizationJoinRequest, error)
+	FindByID(ctx context.Context, id uuid.UUID) (*model.OrganizationJoinRequest, error)
+	FindPendingByOrganizationAndUser(ctx context.Context, organizationID, userID uuid.UUID) (*model.OrganizationJoinRequest, error)
+	ListByOrganization(ctx context.Context, organizationID uuid.UUID, status string) ([]model.OrganizationJoinRequest, error)
+	ListByUser(ctx context.Context, userID uuid.UUID, status string) ([]model.OrganizationJoinRequest, error)
+	Reject(ctx context.Context, id, decidedBy uuid.UUID, reason string, decidedAt time.Time) (bool, error)
+
+	// Approve marks a pending join request approved with the granted role. Callers grant the membership
+	// in the same transaction.
+	Approve(ctx context.Context, id, decidedBy, roleID uuid.UUID, decidedAt time.Time) error
+}
@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrInvalidSortField is returned when a list is sorted on a field the repository does not allow.
+	ErrInvalidSortField = errors.New("invalid sort field")
+	// ErrInvalidCursor is returned when cursor values do not match the sort of the list query.
+	ErrInvalidCursor = errors.New("invalid cursor")
+)
+
+// SortField orders a list query by one of the fields the repository allows sorting on.
+type SortField struct {
+	Field      string
+	Descending bool
+}
+
+// ListQuery describes the order and the page of a list query. Pages are addressed by Offset or, on large
+// tables, by After: the cursor values returned with the previous page. Rows are always ordered by ID last,
+// so every order is total and cursors never skip or repeat rows.
+type ListQuery struct {
+	Sort   []SortField
+	Limit  int
+	Offset int
+	After  []string
+}
+
+// ListPage is one page of a list query. NextCursor holds the cursor values of the last row when more rows follow.
+type ListPage[T any] struct {
+	Items      []T
+	NextCursor []string
+}
+
+// sortColumn maps a sortable field to its SQL expression. Expressions must never be NULL so keyset
+// comparisons stay total; cast is the SQL type cursor values are compared as and value renders the
+// field of a row in a form that cast accepts.
+type sortColumn[T any] struct {
+	expr  string
+	cast  string
+	value func(*T) string
+}
+
+// listColumns lists the sortable fields of an entity and its unique ID column used as tie-breaker.
+type listColumns[T any] struct {
+	id     sortColumn[T]
+	fields map[string]sortColumn[T]
+}
+
+// listOrder is a resolved sort column with its direction.
+type listOrder[T any] struct {
+	column     sortColumn[T]
+	descending bool
+}
+
+// applyListQuery adds ordering, the keyset condition and the page bounds of q to query. It requests one row
+// more than the limit so listPageFromRows can tell whether another page follows.
+func applyListQuery[T any](query *gorm.DB, q ListQuery, columns listColumns[T]) (*gorm.DB, []listOrder[T], error) {
+	orders := make([]listOrder[T], 0, len(q.Sort)+1)
+	for _, sort := range q.Sort {
+		column, ok := columns.fields[sort.Field]
+		if !ok {
+			return nil, nil, fmt.Errorf("%w: %s", ErrInvalidSortField, sort.Field)
+		}
+		orders = append(orders, listOrder[T]{column: column, descending: sort.Descending})
+	}
+	orders = append(orders, listOrder[T]{column: columns.id})
+
+	orderBy := make([]string, len(orders))
+	for i, order := range orders {
+		orderBy[i] = order.column.expr
+		if order.descending {
+			orderBy[i] += " DESC"
+		}
+	}
+	query = query.Order(strings.Join(orderBy, ", "))
+
+	if q.After != nil {
+		if len(q.After) != len(orders) {
+			return nil, nil, ErrInvalidCursor
+		}
+		for i, order := range orders {
+			if !validCursorValue(order.column.cast, q.After[i]) {
+				return nil, nil, ErrInvalidCursor
+			}
+		}
+		// (a > x) OR (a = x AND b > y) OR ..., with < for descending columns
+		var conditions []string
+		var args []interface{}
+		for i, order := range orders {
+			parts := make([]string, 0, i+1)
+			for j := 0; j < i; j++ {
+				parts = append(parts, fmt.Sprintf("%s = CAST(? AS %s)", orders[j].column.expr, orders[j].column.cast))
+				args = append(args, q.After[j])
+			}
+			operator := ">"
+			if order.descending {
+				operator = "<"
+			}
+			parts = append(parts, fmt.Sprintf("%s %s CAST(? AS %s)", order.column.expr, operator, order.column.cast))
+			args = append(args, q.After[i])
+			conditions = append(conditions, "("+strings.Join(parts, " AND ")+")")
+		}
+		query = query.Where("("+strings.Join(conditions, " OR ")+")", args...)
+	} else if q.Offset > 0 {
+		query = query.Offset(q.Offset)
+	}
+
+	return query.Limit(q.Limit + 1), orders, nil
+}
+
+// validCursorValue reports whether a cursor value can be cast to the SQL type of its column, so a tampered
+// cursor is rejected before the database fails on it.
+func validCursorValue(cast, value string) bool {
+	var err error
+	switch cast {
+	case "integer", "bigint":
+		_, err = strconv.ParseInt(value, 10, 64)
+	case "uuid":
+		_, err = uuid.Parse(value)
+	case "timestamptz":
+		_, err = time.Parse(time.RFC3339Nano, value)
+	case "text":
+	default:
+		return false
+	}
+	return err == nil
+}
+
+// listPageFromRows trims the extra row requested by applyListQuery and derives the next cursor from the last row.
+func listPageFromRows[T any](rows []T, limit int, orders []listOrder[T]) *ListPage[T] {
+	if len(rows) <= limit {
+		return &ListPage[T]{Items: rows}
+	}
+
+	rows = rows[:limit]
+	last := &rows[len(rows)-1]
+	cursor := make([]string, len(orders))
+	for i, order := range orders {
+		cursor[i] = order.column.value(last)
+	}
+	return &ListPage[T]{Items: rows, NextCursor: cursor}
+}
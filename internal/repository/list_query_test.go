@@ -0,0 +1,189 @@
+package repository
+
+import (
+	"errors"
+	"go-base-project/internal/model"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// listRow is a minimal sortable row for exercising the list query helpers
+type listRow struct {
+	ID   int
+	Name string
+	Rank int
+}
+
+var listRowColumns = listColumns[listRow]{
+	id: sortColumn[listRow]{expr: "id", cast: "integer", value: func(r *listRow) string { return strconv.Itoa(r.ID) }},
+	fields: map[string]sortColumn[listRow]{
+		"name": {expr: "name", cast: "text", value: func(r *listRow) string { return r.Name }},
+		"rank": {expr: "rank", cast: "integer", value: func(r *listRow) string { return strconv.Itoa(r.Rank) }},
+	},
+}
+
+// dryRunDB returns a session that renders PostgreSQL statements without connecting to a database
+func dryRunDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost dbname=test"}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+	})
+	if err != nil {
+		t.Fatalf("gorm.Open() error = %v", err)
+	}
+	return db.Table("list_rows")
+}
+
+func TestApplyListQuery(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    ListQuery
+		wantSQL  string
+		wantVars []interface{}
+	}{
+		{
+			name:     "orders by ID only by default",
+			query:    ListQuery{Limit: 10},
+			wantSQL:  `SELECT * FROM "list_rows" ORDER BY id LIMIT $1`,
+			wantVars: []interface{}{11},
+		},
+		{
+			name:     "offset page",
+			query:    ListQuery{Sort: []SortField{{Field: "name"}}, Limit: 10, Offset: 20},
+			wantSQL:  `SELECT * FROM "list_rows" ORDER BY name, id LIMIT $1 OFFSET $2`,
+			wantVars: []interface{}{11, 20},
+		},
+		{
+			name:  "keyset page over mixed directions ignores the offset",
+			query: ListQuery{Sort: []SortField{{Field: "rank", Descending: true}, {Field: "name"}}, Limit: 5, Offset: 20, After: []string{"7", "bob", "42"}},
+			wantSQL: `SELECT * FROM "list_rows" WHERE ((rank < CAST($1 AS integer)) OR ` +
+				`(rank = CAST($2 AS integer) AND name > CAST($3 AS text)) OR ` +
+				`(rank = CAST($4 AS integer) AND name = CAST($5 AS text) AND id > CAST($6 AS integer))) ` +
+				`ORDER BY rank DESC, name, id LIMIT $7`,
+			wantVars: []interface{}{"7", "7", "bob", "7", "bob", "42", 6},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, _, err := applyListQuery(dryRunDB(t), tt.query, listRowColumns)
+			if err != nil {
+				t.Fatalf("applyListQuery() error = %v", err)
+			}
+			var rows []listRow
+			statement := query.Find(&rows).Statement
+
+			if got := statement.SQL.String(); got != tt.wantSQL {
+				t.Errorf("SQL =\n%s\nwant\n%s", got, tt.wantSQL)
+			}
+			if !reflect.DeepEqual(statement.Vars, tt.wantVars) {
+				t.Errorf("vars = %#v, want %#v", statement.Vars, tt.wantVars)
+			}
+		})
+	}
+}
+
+func TestApplyListQueryRejectsInvalidInput(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   ListQuery
+		wantErr error
+	}{
+		{name: "unknown sort field", query: ListQuery{Sort: []SortField{{Field: "password"}}, Limit: 10}, wantErr: ErrInvalidSortField},
+		{name: "cursor shorter than the sort", query: ListQuery{Sort: []SortField{{Field: "name"}}, Limit: 10, After: []string{"42"}}, wantErr: ErrInvalidCursor},
+		{name: "cursor longer than the sort", query: ListQuery{Limit: 10, After: []string{"bob", "42"}}, wantErr: ErrInvalidCursor},
+		{name: "cursor value of the wrong type", query: ListQuery{Sort: []SortField{{Field: "rank"}}, Limit: 10, After: []string{"bob", "42"}}, wantErr: ErrInvalidCursor},
+		{name: "cursor ID of the wrong type", query: ListQuery{Sort: []SortField{{Field: "name"}}, Limit: 10, After: []string{"bob", "x"}}, wantErr: ErrInvalidCursor},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := applyListQuery(dryRunDB(t), tt.query, listRowColumns); !errors.Is(err, tt.wantErr) {
+				t.Errorf("applyListQuery() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidCursorValue(t *testing.T) {
+	tests := []struct {
+		cast  string
+		value string
+		want  bool
+	}{
+		{cast: "integer", value: "-42", want: true},
+		{cast: "integer", value: "4.2"},
+		{cast: "uuid", value: "0190b6a4-6d3f-7c2e-9b1a-2f4e5d6c7b8a", want: true},
+		{cast: "uuid", value: "not-a-uuid"},
+		{cast: "timestamptz", value: "2024-01-02T03:04:05.123456Z", want: true},
+		{cast: "timestamptz", value: "yesterday"},
+		{cast: "text", value: "anything ' goes", want: true},
+		{cast: "jsonb", value: "{}"},
+	}
+
+	for _, tt := range tests {
+		if got := validCursorValue(tt.cast, tt.value); got != tt.want {
+			t.Errorf("validCursorValue(%q, %q) = %v, want %v", tt.cast, tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestListPageFromRows(t *testing.T) {
+	orders := []listOrder[listRow]{
+		{column: listRowColumns.fields["rank"], descending: true},
+		{column: listRowColumns.id},
+	}
+	rows := []listRow{{ID: 1, Rank: 9}, {ID: 2, Rank: 8}, {ID: 3, Rank: 8}}
+
+	tests := []struct {
+		name       string
+		limit      int
+		wantItems  int
+		wantCursor []string
+	}{
+		{name: "extra row yields a cursor of the last row on the page", limit: 2, wantItems: 2, wantCursor: []string{"8", "2"}},
+		{name: "last page has no cursor", limit: 3, wantItems: 3},
+		{name: "short page has no cursor", limit: 5, wantItems: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page := listPageFromRows(append([]listRow(nil), rows...), tt.limit, orders)
+			if len(page.Items) != tt.wantItems {
+				t.Errorf("items = %d, want %d", len(page.Items), tt.wantItems)
+			}
+			if !reflect.DeepEqual(page.NextCursor, tt.wantCursor) {
+				t.Errorf("next cursor = %v, want %v", page.NextCursor, tt.wantCursor)
+			}
+		})
+	}
+}
+
+func TestListColumnsIssueValidCursorValues(t *testing.T) {
+	now := time.Now()
+	user := &model.User{ID: uuid.New(), Username: "ana", CreatedAt: now, UpdatedAt: now, Role: &model.Role{Level: 10}}
+	org := &model.Organization{ID: uuid.New(), Name: "Acme", Code: "ACME", OrganizationType: "company", CreatedAt: now, UpdatedAt: now}
+
+	checkListColumnCursors(t, "user", userListColumns, user)
+	checkListColumnCursors(t, "organization", organizationListColumns, org)
+}
+
+// checkListColumnCursors asserts that every cursor value a listing issues is accepted back by applyListQuery
+func checkListColumnCursors[T any](t *testing.T, listing string, columns listColumns[T], row *T) {
+	t.Helper()
+	if value := columns.id.value(row); !validCursorValue(columns.id.cast, value) {
+		t.Errorf("%s ID cursor value %q is not a valid %s", listing, value, columns.id.cast)
+	}
+	for field, column := range columns.fields {
+		if value := column.value(row); !validCursorValue(column.cast, value) {
+			t.Errorf("%s %s cursor value %q is not a valid %s", listing, field, value, column.cast)
+		}
+	}
+}
@@ -0,0 +1,370 @@
+package repository
+
+import (
+	"context"
+	"go-base-project/internal/constant"
+	"go-base-project/internal/model"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type membershipRepository struct {
+	db *gorm.DB
+}
+
+// NewMembershipRepository creates a new instance of MembershipRepository.
+func NewMembershipRepository(db *gorm.DB) MembershipRepositoryInterface {
+	return &membershipRepository{db: db}
+}
+
+// Find finds a membership with its user, organization and role.
+func (r *membershipRepository) Find(ctx context.Context, userID, organizationID uuid.UUID) (*model.UserOrganization, error) {
+	var membership model.UserOrganization
+	if err := dbFromContext(ctx, r.db).
+		Preload("User").
+		Preload("Organization").
+		Preload("Role").
+		Where("user_id = ? AND organization_id = ?", userID, organizationID).
+		First(&membership).Error; err != nil {
+		return nil, err
+	}
+	return &membership, nil
+}
+
+// FindByUser lists the active memberships of a user in organizations that are not deleted.
+func (r *membershipRepository) FindByUser(ctx context.Context, userID uuid.UUID, offset, limit int) ([]model.UserOrganization, error) {
+	var memberships []model.UserOrganization
+	err := dbFromContext(ctx, r.db).
+		Preload("Organization").
+		Preload("Role").
+		Joins("JOIN organizations ON organizations.id = user_organizations.organization_id AND organizations.deleted_at IS NULL").
+		Where("user_organizations.user_id = ? AND user_organizations.is_active = true", userID).
+		Order("user_organizations.joined_at ASC").
+		Offset(offset).
+		Limit(limit).
+		Find(&memberships).Error
+	return memberships, err
+}
+
+// CountByUser counts the active memberships of a user in organizations that are not deleted.
+func (r *membershipRepository) CountByUser(ctx context.Context, userID uuid.UUID) (int64, error) {
+	var count int64
+	err := dbFromContext(ctx, r.db).
+		Model(&model.UserOrganization{}).
+		Joins("JOIN organizations ON organizations.id = user_organizations.organization_id AND organizations.deleted_at IS NULL").
+		Where("user_organizations.user_id = ? AND user_organizations.is_active = true", userID).
+		Count(&count).Error
+	return count, err
+}
+
+// FindByOrganization lists the active members of an organization.
+func (r *membershipRepository) FindByOrganization(ctx context.Context, organizationID uuid.UUID, offset, limit int) ([]model.UserOrganization, error) {
+	var memberships []model.UserOrganization
+	err := dbFromContext(ctx, r.db).
+		Preload("User").
+		Preload("Role").
+		Where("organization_id = ? AND is_active = true", organizationID).
+		Order("joined_at ASC").
+		Offset(offset).
+		Limit(limit).
+		Find(&memberships).Error
+	return memberships, err
+}
+
+// CountByOrganization counts the active members of an organization.
+func (r *membershipRepository) CountByOrganization(ctx context.Context, organizationID uuid.UUID) (int64, error) {
+	var count int64
+	err := dbFromContext(ctx, r.db).
+		Model(&model.UserOrganization{}).
+		Where("organization_id = ? AND is_active = true", organizationID).
+		Count(&count).Error
+	return count, err
+}
+
+// FindByOrganizations lists all memberships of the given organizations with their users and roles.
+func (r *membershipRepository) FindByOrganizations(ctx context.Context, organizationIDs []uuid.UUID) ([]model.UserOrganization, error) {
+	var memberships []model.UserOrganization
+	if len(organizationIDs) == 0 {
+		return memberships, nil
+	}
+
+	err := dbFromContext(ctx, r.db).
+		Preload("User").
+		Preload("Role").
+		Where("organization_id IN ?", organizationIDs).
+		Order("joined_at ASC").
+		Find(&memberships).Error
+	return memberships, err
+}
+
+// Create grants the membership and records the history entry in a single transaction.
+func (r *membershipRepository) Create(ctx context.Context, membership *model.UserOrganization, change MembershipChange) error {
+	return dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		return grantMembership(tx, membership, change)
+	})
+}
+
+// Update changes the role and status of a membership under a row lock, so the history entry describes
+// exactly the transition that was written.
+func (r *membershipRepository) Update(ctx context.Context, userID, organizationID uuid.UUID, update MembershipUpdate, change MembershipChange) (*model.UserOrganization, error) {
+	err := dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		current, err := lockMembership(tx, userID, organizationID)
+		if err != nil {
+			return err
+		}
+
+		roleChanged := !sameRoleID(current.RoleID, update.RoleID)
+		if !roleChanged && current.IsActive == update.IsActive {
+			return nil
+		}
+
+		if err := tx.Model(&model.UserOrganization{}).
+			Where("user_id = ? AND organization_id = ?", userID, organizationID).
+			Updates(map[string]interface{}{
+				"role_id":   update.RoleID,
+				"is_active": update.IsActive,
+			}).Error; err != nil {
+			return err
+		}
+
+		history := &model.UserOrganizationHistory{
+			UserID:         userID,
+			OrganizationID: organizationID,
+			Action:         constant.MembershipActionStatusChanged,
+			PreviousStatus: &current.IsActive,
+			NewStatus:      &update.IsActive,
+		}
+		if roleChanged {
+			history.Action = constant.MembershipActionRoleUpdated
+			if history.PreviousRole, err = roleName(tx, current.RoleID); err != nil {
+				return err
+			}
+			if history.NewRole, err = roleName(tx, update.RoleID); err != nil {
+				return err
+			}
+		}
+		return recordMembershipHistory(tx, history, change)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r.Find(ctx, userID, organizationID)
+}
+
+// UpdateAttributes replaces the custom attributes of a membership and records the history entry.
+func (r *membershipRepository) UpdateAttributes(ctx context.Context, userID, organizationID uuid.UUID, attributes string, change MembershipChange) error {
+	return dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.UserOrganization{}).
+			Where("user_id = ? AND organization_id = ?", userID, organizationID).
+			Update("attributes", gorm.Expr("?::jsonb", attributes))
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		return recordMembershipHistory(tx, &model.UserOrganizationHistory{
+			UserID:         userID,
+			OrganizationID: organizationID,
+			Action:         constant.MembershipActionAttributesUpdated,
+		}, change)
+	})
+}
+
+// Delete removes a membership and records the role and status it had.
+func (r *membershipRepository) Delete(ctx context.Context, userID, organizationID uuid.UUID, change MembershipChange) error {
+	return dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		current, err := lockMembership(tx, userID, organizationID)
+		if err != nil {
+			return err
+		}
+		_, err = removeMemberships(tx, []model.UserOrganization{*current}, change)
+		return err
+	})
+}
+
+// DeleteAllByUser removes every membership of a user, recording one history entry per membership.
+func (r *membershipRepository) DeleteAllByUser(ctx context.Context, userID uuid.UUID, change MembershipChange) (int, error) {
+	removed := 0
+	err := dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		var err error
+		removed, err = removeUserMemberships(tx, userID, change)
+		return err
+	})
+	return removed, err
+}
+
+// FindHistoryByUser retrieves the membership history of a user with pagination.
+func (r *membershipRepository) FindHistoryByUser(ctx context.Context, userID uuid.UUID, offset, limit int) ([]model.UserOrganizationHistory, error) {
+	var history []model.UserOrganizationHistory
+	err := dbFromContext(ctx, r.db).
+		Preload("Organization").
+		Preload("Actor").
+		Where("user_id = ?", userID).
+		Order("action_at DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&history).Error
+	return history, err
+}
+
+// CountHistoryByUser counts the membership history entries of a user.
+func (r *membershipRepository) CountHistoryByUser(ctx context.Context, userID uuid.UUID) (int64, error) {
+	var count int64
+	err := dbFromContext(ctx, r.db).
+		Model(&model.UserOrganizationHistory{}).
+		Where("user_id = ?", userID).
+		Count(&count).Error
+	return count, err
+}
+
+// FindHistoryByOrganization retrieves the membership history of an organization with pagination.
+func (r *membershipRepository) FindHistoryByOrganization(ctx context.Context, organizationID uuid.UUID, offset, limit int) ([]model.UserOrganizationHistory, error) {
+	var history []model.UserOrganizationHistory
+	err := dbFromContext(ctx, r.db).
+		Preload("User").
+		Preload("Actor").
+		Where("organization_id = ?", organizationID).
+		Order("action_at DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&history).Error
+	return history, err
+}
+
+// CountHistoryByOrganization counts the membership history entries of an organization.
+func (r *membershipRepository) CountHistoryByOrganization(ctx context.Context, organizationID uuid.UUID) (int64, error) {
+	var count int64
+	err := dbFromContext(ctx, r.db).
+		Model(&model.UserOrganizationHistory{}).
+		Where("organization_id = ?", organizationID).
+		Count(&count).Error
+	return count, err
+}
+
+// grantMembership creates the membership, or reactivates a previous one, and records the history entry.
+// It never overwrites an active membership and must run inside the caller's transaction.
+func grantMembership(tx *gorm.DB, membership *model.UserOrganization, change MembershipChange) error {
+	if membership.JoinedAt.IsZero() {
+		membership.JoinedAt = time.Now()
+	}
+
+	// The columns are listed so an inactive membership is not replaced by the is_active column default
+	result := tx.Select("user_id", "organization_id", "role_id", "joined_at", "is_active").
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}, {Name: "organization_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"role_id", "is_active", "joined_at"}),
+			Where:     clause.Where{Exprs: []clause.Expression{clause.Eq{Column: clause.Column{Table: "user_organizations", Name: "is_active"}, Value: false}}},
+		}).
+		Create(membership)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrAlreadyOrganizationMember
+	}
+
+	newRole, err := roleName(tx, membership.RoleID)
+	if err != nil {
+		return err
+	}
+	return recordMembershipHistory(tx, &model.UserOrganizationHistory{
+		UserID:         membership.UserID,
+		OrganizationID: membership.OrganizationID,
+		Action:         constant.MembershipActionAssigned,
+		NewRole:        newRole,
+		NewStatus:      &membership.IsActive,
+	}, change)
+}
+
+// removeUserMemberships deletes every membership of a user with its history entries and returns how many
+// were removed. It must run inside the caller's transaction.
+func removeUserMemberships(tx *gorm.DB, userID uuid.UUID, change MembershipChange) (int, error) {
+	var memberships []model.UserOrganization
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("user_id = ?", userID).
+		Find(&memberships).Error; err != nil {
+		return 0, err
+	}
+	return removeMemberships(tx, memberships, change)
+}
+
+// removeMemberships deletes the given memberships and records a removed entry with the role and status
+// each one had.
+func removeMemberships(tx *gorm.DB, memberships []model.UserOrganization, change MembershipChange) (int, error) {
+	removed := 0
+	for i := range memberships {
+		membership := &memberships[i]
+		result := tx.Where("user_id = ? AND organization_id = ?", membership.UserID, membership.OrganizationID).
+			Delete(&model.UserOrganization{})
+		if result.Error != nil {
+			return removed, result.Error
+		}
+		if result.RowsAffected == 0 {
+			continue
+		}
+		removed++
+
+		previousRole, err := roleName(tx, membership.RoleID)
+		if err != nil {
+			return removed, err
+		}
+		if err := recordMembershipHistory(tx, &model.UserOrganizationHistory{
+			UserID:         membership.UserID,
+			OrganizationID: membership.OrganizationID,
+			Action:         constant.MembershipActionRemoved,
+			PreviousRole:   previousRole,
+			PreviousStatus: &membership.IsActive,
+		}, change); err != nil {
+			return removed, err
+		}
+	}
+	return removed, nil
+}
+
+// lockMembership loads a membership and locks its row until the transaction ends.
+func lockMembership(tx *gorm.DB, userID, organizationID uuid.UUID) (*model.UserOrganization, error) {
+	var membership model.UserOrganization
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("user_id = ? AND organization_id = ?", userID, organizationID).
+		First(&membership).Error; err != nil {
+		return nil, err
+	}
+	return &membership, nil
+}
+
+// recordMembershipHistory stamps the history entry with the actor and reason of the change and stores it.
+func recordMembershipHistory(tx *gorm.DB, history *model.UserOrganizationHistory, change MembershipChange) error {
+	history.ActionBy = change.ActionBy
+	history.Reason = change.Reason
+	history.ActionAt = time.Now()
+	return tx.Omit(clause.Associations).Create(history).Error
+}
+
+// roleName returns the name history entries record for a role, including deleted roles; memberships
+// without a role have none.
+func roleName(tx *gorm.DB, roleID *uuid.UUID) (string, error) {
+	if roleID == nil {
+		return "", nil
+	}
+	var names []string
+	if err := tx.Unscoped().Model(&model.Role{}).Where("id = ?", *roleID).Pluck("name", &names).Error; err != nil {
+		return "", err
+	}
+	if len(names) == 0 {
+		return "", nil
+	}
+	return names[0], nil
+}
+
+// sameRoleID reports whether two optional role IDs are both nil or hold the same value.
+func sameRoleID(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"go-base-project/internal/model"
+
+	"github.com/google/uuid"
+)
+
+// ErrAlreadyOrganizationMember is returned when a membership is granted to a user who is already an active member.
+var ErrAlreadyOrganizationMember = errors.New("user is already an active member of the organization")
+
+// MembershipChange describes who made a membership change and why, as recorded in its history entry.
+// A nil ActionBy marks a change made by the system.
+type MembershipChange struct {
+	ActionBy *uuid.UUID
+	Reason   string
+}
+
+// MembershipUpdate holds the role and status a membership is updated to.
+type MembershipUpdate struct {
+	RoleID   *uuid.UUID
+	IsActive bool
+}
+
+// MembershipRepositoryInterface defines the data operations for user-organization memberships.
+// Every mutation writes the membership and its user_organization_history entry in a single transaction.
+// Lookups and mutations of a single membership return gorm.ErrRecordNotFound when it does not exist.
+type MembershipRepositoryInterface interface {
+	// Find returns a membership, active or not, with its user, organization and role.
+	Find(ctx context.Context, userID, organizationID uuid.UUID) (*model.UserOrganization, error)
+	// FindByUser returns the active memberships of a user in organizations that are not deleted,
+	// with their organization and role.
+	FindByUser(ctx context.Context, userID uuid.UUID, offset, limit int) ([]model.UserOrganization, error)
+	CountByUser(ctx context.Context, userID uuid.UUID) (int64, error)
+	// FindByOrganization returns the active members of an organization with their user and role.
+	FindByOrganization(ctx context.Context, organizationID uuid.UUID, offset, limit int) ([]model.UserOrganization, error)
+	CountByOrganization(ctx context.Context, organizationID uuid.UUID) (int64, error)
+	// FindByOrganizations returns all memberships, active or not, of the given organizations with their
+	// users and roles, oldest first.
+	FindByOrganizations(ctx context.Context, organizationIDs []uuid.UUID) ([]model.UserOrganization, error)
+
+	// Create adds the membership, or reactivates an inactive one, and records an assigned entry.
+	// It returns ErrAlreadyOrganizationMember when the user is already an active member.
+	Create(ctx context.Context, membership *model.UserOrganization, change MembershipChange) error
+	// Update sets the role and status of a membership and returns it with its relationships. A role change
+	// is recorded as role_updated, a status change alone as status_changed; nothing is recorded when
+	// neither changes.
+	Update(ctx context.Context, userID, organizationID uuid.UUID, update MembershipUpdate, change MembershipChange) (*model.UserOrganization, error)
+	// UpdateAttributes replaces the custom attributes, a JSON object, of a membership.
+	UpdateAttributes(ctx context.Context, userID, organizationID uuid.UUID, attributes string, change MembershipChange) error
+	// Delete removes a membership and records a removed entry.
+	Delete(ctx context.Context, userID, organizationID uuid.UUID, change MembershipChange) error
+	// DeleteAllByUser removes every membership of a user and returns how many were removed.
+	DeleteAllByUser(ctx context.Context, userID uuid.UUID, change MembershipChange) (int, error)
+
+	// Membership history, newest first
+	FindHistoryByUser(ctx context.Context, userID uuid.UUID, offset, limit int) ([]model.UserOrganizationHistory, error)
+	CountHistoryByUser(ctx context.Context, userID uuid.UUID) (int64, error)
+	FindHistoryByOrganization(ctx context.Context, organizationID uuid.UUID, offset, limit int) ([]model.UserOrganizationHistory, error)
+	CountHistoryByOrganization(ctx context.Context, organizationID uuid.UUID) (int64, error)
+}
@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"context"
+	"go-base-project/internal/model"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type organizationAuditRepository struct {
+	db *gorm.DB
+}
+
+// NewOrganizationAuditRepository creates a new instance of OrganizationAuditRepository.
+func NewOrganizationAuditRepository(db *gorm.DB) OrganizationAuditRepositoryInterface {
+	return &organizationAuditRepository{db: db}
+}
+
+// Create appends an entry to the audit trail.
+func (r *organizationAuditRepository) Create(ctx context.Context, auditLog *model.OrganizationAuditLog) error {
+	return dbFromContext(ctx, r.db).Omit(clause.Associations).Create(auditLog).Error
+}
+
+// ListByOrganization returns the most recent audit entries of an organization, newest first.
+func (r *organizationAuditRepository) ListByOrganization(ctx context.Context, organizationID uuid.UUID, limit int) ([]model.OrganizationAuditLog, error) {
+	var auditLogs []model.OrganizationAuditLog
+	err := dbFromContext(ctx, r.db).
+		Preload("Actor").
+		Where("organization_id = ?", organizationID).
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&auditLogs).Error
+	return auditLogs, err
+}
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+	"go-base-project/internal/model"
+
+	"github.com/google/uuid"
+)
+
+// OrganizationAuditRepositoryInterface defines the data operations for the organization audit trail
+type OrganizationAuditRepositoryInterface interface {
+	Create(ctx context.Context, auditLog *model.OrganizationAuditLog) error
+	ListByOrganization(ctx context.Context, organizationID uuid.UUID, limit int) ([]model.OrganizationAuditLog, error)
+}
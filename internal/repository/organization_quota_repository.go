@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+	"go-base-project/internal/model"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type organizationQuotaRepository struct {
+	db *gorm.DB
+}
+
+// NewOrganizationQuotaRepository creates a new instance of OrganizationQuotaRepository.
+func NewOrganizationQuotaRepository(db *gorm.DB) OrganizationQuotaRepositoryInterface {
+	return &organizationQuotaRepository{db: db}
+}
+
+// FindInheritanceChain returns the limits of an organization and its ancestors, nearest organization first.
+func (r *organizationQuotaRepository) FindInheritanceChain(ctx context.Context, orgID uuid.UUID) ([]model.OrganizationQuota, error) {
+	var quotas []model.OrganizationQuota
+	err := dbFromContext(ctx, r.db).
+		Joins("JOIN organization_closures c ON c.ancestor_id = organization_quotas.organization_id").
+		Where("c.descendant_id = ?", orgID).
+		Order("c.depth ASC, organization_quotas.quota ASC").
+		Find(&quotas).Error
+	return quotas, err
+}
+
+// Save upserts and removes limits of an organization together with its audit log entry.
+func (r *organizationQuotaRepository) Save(ctx context.Context, orgID uuid.UUID, upserts []model.OrganizationQuota, removedQuotas []string, auditLog *model.OrganizationAuditLog) error {
+	return dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if len(upserts) > 0 {
+			if err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "organization_id"}, {Name: "quota"}},
+				DoUpdates: clause.AssignmentColumns([]string{"max_value", "updated_by", "updated_at"}),
+			}).Create(&upserts).Error; err != nil {
+				return err
+			}
+		}
+
+		if len(removedQuotas) > 0 {
+			if err := tx.Where("organization_id = ? AND quota IN ?", orgID, removedQuotas).
+				Delete(&model.OrganizationQuota{}).Error; err != nil {
+				return err
+			}
+		}
+
+		if auditLog == nil {
+			return nil
+		}
+		return tx.Omit(clause.Associations).Create(auditLog).Error
+	})
+}
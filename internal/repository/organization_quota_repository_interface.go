@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+	"go-base-project/internal/model"
+
+	"github.com/google/uuid"
+)
+
+// OrganizationQuotaRepositoryInterface defines the data operations for organization plan limits
+type OrganizationQuotaRepositoryInterface interface {
+	// FindInheritanceChain returns the limits set on an organization and all its ancestors, nearest
+	// organization first, so the first limit found for a quota is the effective one.
+	FindInheritanceChain(ctx context.Context, orgID uuid.UUID) ([]model.OrganizationQuota, error)
+	// Save upserts and removes limits of an organization and records the audit log in one transaction.
+	Save(ctx context.Context, orgID uuid.UUID, upserts []model.OrganizationQuota, removedQuotas []string, auditLog *model.OrganizationAuditLog) error
+}
@@ -6,9 +6,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type organizationRepository struct {
@@ -21,17 +23,37 @@ func NewOrganizationRepository(db *gorm.DB) OrganizationRepositoryInterface {
 }
 
 // Create creates a new organization
+// The code is claimed atomically through the unique index, so concurrent creates cannot share a code.
 func (r *organizationRepository) Create(ctx context.Context, org *model.Organization) (*model.Organization, error) {
-	if err := r.db.WithContext(ctx).Create(org).Error; err != nil {
-		return nil, err
+	result := dbFromContext(ctx, r.db).
+		Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "code"}}, DoNothing: true}).
+		Create(org)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, ErrOrganizationCodeTaken
 	}
 	return org, nil
 }
 
+// FindByIDForUpdate finds an organization without relationships and locks its row (SELECT ... FOR UPDATE).
+func (r *organizationRepository) FindByIDForUpdate(ctx context.Context, id uuid.UUID) (*model.Organization, error) {
+	var org model.Organization
+	err := dbFromContext(ctx, r.db).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", id).
+		First(&org).Error
+	if err != nil {
+		return nil, err
+	}
+	return &org, nil
+}
+
 // FindByID finds an organization by ID with relationships
 func (r *organizationRepository) FindByID(ctx context.Context, id uuid.UUID) (*model.Organization, error) {
 	var org model.Organization
-	err := r.db.WithContext(ctx).
+	err := dbFromContext(ctx, r.db).
 		Preload("ParentOrganization").
 		Preload("ChildOrganizations").
 		Where("id = ?", id).
@@ -45,7 +67,7 @@ func (r *organizationRepository) FindByID(ctx context.Context, id uuid.UUID) (*m
 // FindByIDWithDetails finds an organization by ID with detailed relationships
 func (r *organizationRepository) FindByIDWithDetails(ctx context.Context, id uuid.UUID) (*model.Organization, error) {
 	var org model.Organization
-	err := r.db.WithContext(ctx).
+	err := dbFromContext(ctx, r.db).
 		Preload("ParentOrganization").
 		Preload("ChildOrganizations").
 		Preload("UserOrganizations").
@@ -61,7 +83,7 @@ func (r *organizationRepository) FindByIDWithDetails(ctx context.Context, id uui
 // FindByCode finds an organization by code
 func (r *organizationRepository) FindByCode(ctx context.Context, code string) (*model.Organization, error) {
 	var org model.Organization
-	err := r.db.WithContext(ctx).
+	err := dbFromContext(ctx, r.db).
 		Preload("ParentOrganization").
 		Preload("ChildOrganizations").
 		Where("code = ?", code).
@@ -74,21 +96,138 @@ func (r *organizationRepository) FindByCode(ctx context.Context, code string) (*
 
 // Update updates an existing organization
 func (r *organizationRepository) Update(ctx context.Context, org *model.Organization) (*model.Organization, error) {
-	if err := r.db.WithContext(ctx).Save(org).Error; err != nil {
+	if err := dbFromContext(ctx, r.db).Save(org).Error; err != nil {
 		return nil, err
 	}
 	return org, nil
 }
 
-// Delete soft deletes an organization
-func (r *organizationRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Where("id = ?", id).Delete(&model.Organization{}).Error
+// Delete soft deletes an organization and records the audit entry in a single transaction
+func (r *organizationRepository) Delete(ctx context.Context, id uuid.UUID, auditLog *model.OrganizationAuditLog) error {
+	return dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("id = ?", id).Delete(&model.Organization{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		return tx.Omit(clause.Associations).Create(auditLog).Error
+	})
+}
+
+// FindDeletedByID finds a soft-deleted organization by ID
+func (r *organizationRepository) FindDeletedByID(ctx context.Context, id uuid.UUID) (*model.Organization, error) {
+	var org model.Organization
+	err := dbFromContext(ctx, r.db).
+		Unscoped().
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		First(&org).Error
+	if err != nil {
+		return nil, err
+	}
+	return &org, nil
+}
+
+// ListDeleted lists soft-deleted organizations that have not been purged yet, most recently deleted first
+func (r *organizationRepository) ListDeleted(ctx context.Context) ([]model.Organization, error) {
+	var orgs []model.Organization
+	err := dbFromContext(ctx, r.db).
+		Unscoped().
+		Where("deleted_at IS NOT NULL").
+		Order("deleted_at DESC").
+		Find(&orgs).Error
+	return orgs, err
+}
+
+// Restore clears the deletion mark of an organization and records the audit entry in a single transaction
+func (r *organizationRepository) Restore(ctx context.Context, id uuid.UUID, auditLog *model.OrganizationAuditLog) error {
+	return dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		result := tx.Unscoped().
+			Model(&model.Organization{}).
+			Where("id = ? AND deleted_at IS NOT NULL", id).
+			Updates(map[string]interface{}{
+				"deleted_at": nil,
+				"updated_at": gorm.Expr("NOW()"),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		return tx.Omit(clause.Associations).Create(auditLog).Error
+	})
+}
+
+// Archive marks the given organizations as archived and records their audit entries in a single transaction.
+// Organizations that are already archived keep their original archive timestamp.
+func (r *organizationRepository) Archive(ctx context.Context, orgIDs []uuid.UUID, archivedBy uuid.UUID, auditLogs []model.OrganizationAuditLog) error {
+	return dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&model.Organization{}).
+			Where("id IN ? AND archived_at IS NULL", orgIDs).
+			Updates(map[string]interface{}{
+				"archived_at": gorm.Expr("NOW()"),
+				"archived_by": archivedBy,
+				"updated_at":  gorm.Expr("NOW()"),
+			}).Error; err != nil {
+			return err
+		}
+
+		if len(auditLogs) == 0 {
+			return nil
+		}
+		return tx.Omit(clause.Associations).Create(&auditLogs).Error
+	})
+}
+
+// Unarchive clears the archive mark of the given organizations and records their audit entries in a single transaction
+func (r *organizationRepository) Unarchive(ctx context.Context, orgIDs []uuid.UUID, auditLogs []model.OrganizationAuditLog) error {
+	return dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&model.Organization{}).
+			Where("id IN ? AND archived_at IS NOT NULL", orgIDs).
+			Updates(map[string]interface{}{
+				"archived_at": nil,
+				"archived_by": nil,
+				"updated_at":  gorm.Expr("NOW()"),
+			}).Error; err != nil {
+			return err
+		}
+
+		if len(auditLogs) == 0 {
+			return nil
+		}
+		return tx.Omit(clause.Associations).Create(&auditLogs).Error
+	})
+}
+
+// PurgeDeleted permanently removes organizations soft-deleted before deletedBefore.
+// Organizations that still have child rows are kept until their children are purged, so a subtree
+// is removed bottom-up over successive passes and no child is silently turned into a root.
+func (r *organizationRepository) PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	var purged int64
+	for {
+		result := dbFromContext(ctx, r.db).
+			Unscoped().
+			Where("deleted_at IS NOT NULL AND deleted_at < ?", deletedBefore).
+			Where("NOT EXISTS (SELECT 1 FROM organizations child WHERE child.parent_organization_id = organizations.id)").
+			Delete(&model.Organization{})
+		if result.Error != nil {
+			return purged, result.Error
+		}
+		if result.RowsAffected == 0 {
+			return purged, nil
+		}
+		purged += result.RowsAffected
+	}
 }
 
 // FindAll finds all organizations with filters
 func (r *organizationRepository) FindAll(ctx context.Context, filters map[string]interface{}) ([]model.Organization, error) {
 	var orgs []model.Organization
-	query := r.db.WithContext(ctx).Preload("ParentOrganization").Preload("ChildOrganizations")
+	query := dbFromContext(ctx, r.db).Preload("ParentOrganization").Preload("ChildOrganizations")
 
 	for key, value := range filters {
 		query = query.Where(fmt.Sprintf("%s = ?", key), value)
@@ -98,12 +237,28 @@ func (r *organizationRepository) FindAll(ctx context.Context, filters map[string
 	return orgs, err
 }
 
-// List finds organizations with pagination and search
-func (r *organizationRepository) List(ctx context.Context, page, limit int, search string, filters map[string]interface{}) ([]model.Organization, int64, error) {
+// organizationListColumns are the fields organization listings can be sorted on.
+var organizationListColumns = listColumns[model.Organization]{
+	id: sortColumn[model.Organization]{expr: "organizations.id", cast: "uuid", value: func(o *model.Organization) string { return o.ID.String() }},
+	fields: map[string]sortColumn[model.Organization]{
+		"name": {expr: "organizations.name", cast: "text", value: func(o *model.Organization) string { return o.Name }},
+		"code": {expr: "organizations.code", cast: "text", value: func(o *model.Organization) string { return o.Code }},
+		"type": {expr: "organizations.organization_type", cast: "text", value: func(o *model.Organization) string { return o.OrganizationType }},
+		"created_at": {expr: "organizations.created_at", cast: "timestamptz", value: func(o *model.Organization) string {
+			return o.CreatedAt.UTC().Format(time.RFC3339Nano)
+		}},
+		"updated_at": {expr: "organizations.updated_at", cast: "timestamptz", value: func(o *model.Organization) string {
+			return o.UpdatedAt.UTC().Format(time.RFC3339Nano)
+		}},
+	},
+}
+
+// List retrieves one page of organizations with filters, search, sorting and pagination
+func (r *organizationRepository) List(ctx context.Context, search string, filters map[string]interface{}, listQuery ListQuery) (*ListPage[model.Organization], int64, error) {
 	var orgs []model.Organization
 	var total int64
 
-	query := r.db.WithContext(ctx).Model(&model.Organization{})
+	query := dbFromContext(ctx, r.db).Model(&model.Organization{})
 
 	// Apply filters
 	for key, value := range filters {
@@ -112,6 +267,12 @@ func (r *organizationRepository) List(ctx context.Context, page, limit int, sear
 			if orgIDs, ok := value.([]uuid.UUID); ok && len(orgIDs) > 0 {
 				query = query.Where("id IN ?", orgIDs)
 			}
+		} else if key == "archived" {
+			if archived, ok := value.(bool); ok && archived {
+				query = query.Where("archived_at IS NOT NULL")
+			} else {
+				query = query.Where("archived_at IS NULL")
+			}
 		} else {
 			query = query.Where(fmt.Sprintf("%s = ?", key), value)
 		}
@@ -129,22 +290,22 @@ func (r *organizationRepository) List(ctx context.Context, page, limit int, sear
 		return nil, 0, err
 	}
 
-	// Apply pagination
-	offset := (page - 1) * limit
-	err := query.
-		Preload("ParentOrganization").
-		Preload("ChildOrganizations").
-		Offset(offset).
-		Limit(limit).
-		Find(&orgs).Error
+	// Apply ordering and pagination
+	query, orders, err := applyListQuery(query.Preload("ParentOrganization").Preload("ChildOrganizations"), listQuery, organizationListColumns)
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := query.Find(&orgs).Error; err != nil {
+		return nil, 0, err
+	}
 
-	return orgs, total, err
+	return listPageFromRows(orgs, listQuery.Limit, orders), total, nil
 }
 
 // Count counts organizations with filters
 func (r *organizationRepository) Count(ctx context.Context, filters map[string]interface{}) (int64, error) {
 	var count int64
-	query := r.db.WithContext(ctx).Model(&model.Organization{})
+	query := dbFromContext(ctx, r.db).Model(&model.Organization{})
 
 	for key, value := range filters {
 		if key == "accessible_org_ids" {
@@ -152,6 +313,12 @@ func (r *organizationRepository) Count(ctx context.Context, filters map[string]i
 			if orgIDs, ok := value.([]uuid.UUID); ok && len(orgIDs) > 0 {
 				query = query.Where("id IN ?", orgIDs)
 			}
+		} else if key == "archived" {
+			if archived, ok := value.(bool); ok && archived {
+				query = query.Where("archived_at IS NOT NULL")
+			} else {
+				query = query.Where("archived_at IS NULL")
+			}
 		} else {
 			query = query.Where(fmt.Sprintf("%s = ?", key), value)
 		}
@@ -164,7 +331,7 @@ func (r *organizationRepository) Count(ctx context.Context, filters map[string]i
 // FindByType finds organizations by type
 func (r *organizationRepository) FindByType(ctx context.Context, orgType string) ([]model.Organization, error) {
 	var orgs []model.Organization
-	err := r.db.WithContext(ctx).
+	err := dbFromContext(ctx, r.db).
 		Preload("ParentOrganization").
 		Preload("ChildOrganizations").
 		Where("type = ?", orgType).
@@ -175,7 +342,7 @@ func (r *organizationRepository) FindByType(ctx context.Context, orgType string)
 // FindByParent finds direct children of a parent organization
 func (r *organizationRepository) FindByParent(ctx context.Context, parentID uuid.UUID) ([]model.Organization, error) {
 	var orgs []model.Organization
-	err := r.db.WithContext(ctx).
+	err := dbFromContext(ctx, r.db).
 		Preload("ParentOrganization").
 		Preload("ChildOrganizations").
 		Where("parent_organization_id = ?", parentID).
@@ -186,7 +353,7 @@ func (r *organizationRepository) FindByParent(ctx context.Context, parentID uuid
 // FindRootOrganizations finds all root organizations (no parent)
 func (r *organizationRepository) FindRootOrganizations(ctx context.Context) ([]model.Organization, error) {
 	var orgs []model.Organization
-	err := r.db.WithContext(ctx).
+	err := dbFromContext(ctx, r.db).
 		Preload("ParentOrganization").
 		Preload("ChildOrganizations").
 		Where("parent_organization_id IS NULL").
@@ -197,7 +364,7 @@ func (r *organizationRepository) FindRootOrganizations(ctx context.Context) ([]m
 // FindActiveOrganizations finds all active organizations
 func (r *organizationRepository) FindActiveOrganizations(ctx context.Context) ([]model.Organization, error) {
 	var orgs []model.Organization
-	err := r.db.WithContext(ctx).
+	err := dbFromContext(ctx, r.db).
 		Preload("ParentOrganization").
 		Preload("ChildOrganizations").
 		Where("status = ?", "active").
@@ -205,13 +372,54 @@ func (r *organizationRepository) FindActiveOrganizations(ctx context.Context) ([
 	return orgs, err
 }
 
+// Search ranks organizations by trigram similarity of their name or code and the word match of their name
+// and description, boosting exact code and substring name matches.
+func (r *organizationRepository) Search(ctx context.Context, term string, organizationIDs []uuid.UUID, limit int) ([]OrganizationSearchHit, error) {
+	pattern := containsPattern(term)
+
+	query := dbFromContext(ctx, r.db).
+		Model(&model.Organization{}).
+		Select(`organizations.id, GREATEST(similarity(organizations.name, ?), similarity(organizations.code, ?)) +
+			ts_rank(organizations.search_vector, plainto_tsquery('simple', ?)) +
+			CASE WHEN lower(organizations.code) = lower(?) THEN 1
+				WHEN organizations.name ILIKE ? THEN 0.5
+				ELSE 0 END AS score`, term, term, term, term, pattern).
+		Where(`(organizations.name ILIKE ? OR organizations.code ILIKE ? OR organizations.name % ?
+			OR organizations.search_vector @@ plainto_tsquery('simple', ?))`, pattern, pattern, term, term)
+	if organizationIDs != nil {
+		query = query.Where("organizations.id IN ?", organizationIDs)
+	}
+
+	var scores []searchScore
+	if err := query.Order("score DESC, organizations.name").Limit(limit).Scan(&scores).Error; err != nil || len(scores) == 0 {
+		return nil, err
+	}
+
+	var orgs []model.Organization
+	if err := dbFromContext(ctx, r.db).Where("id IN ?", scoredIDs(scores)).Find(&orgs).Error; err != nil {
+		return nil, err
+	}
+	orgsByID := make(map[uuid.UUID]model.Organization, len(orgs))
+	for _, org := range orgs {
+		orgsByID[org.ID] = org
+	}
+
+	hits := make([]OrganizationSearchHit, 0, len(scores))
+	for _, score := range scores {
+		if org, ok := orgsByID[score.ID]; ok {
+			hits = append(hits, OrganizationSearchHit{Organization: org, Score: score.Score})
+		}
+	}
+	return hits, nil
+}
+
 // GetOrganizationHierarchy gets organization hierarchy starting from given organization
 func (r *organizationRepository) GetOrganizationHierarchy(ctx context.Context, rootID uuid.UUID) ([]model.Organization, error) {
 	var hierarchy []model.Organization
 
 	// Get the root organization
 	var rootOrg model.Organization
-	err := r.db.WithContext(ctx).
+	err := dbFromContext(ctx, r.db).
 		Preload("ParentOrganization").
 		Preload("ChildOrganizations").
 		Where("id = ?", rootID).
@@ -235,147 +443,190 @@ func (r *organizationRepository) GetOrganizationHierarchy(ctx context.Context, r
 	return hierarchy, nil
 }
 
-// GetChildrenRecursive gets all children recursively for a parent organization
+// GetChildrenRecursive gets all descendants of a parent organization in a single closure-table query
 func (r *organizationRepository) GetChildrenRecursive(ctx context.Context, parentID uuid.UUID) ([]model.Organization, error) {
-	var allChildren []model.Organization
-
-	// Get direct children
-	var directChildren []model.Organization
-	err := r.db.WithContext(ctx).
+	var descendants []model.Organization
+	err := dbFromContext(ctx, r.db).
 		Preload("ParentOrganization").
-		Where("parent_organization_id = ?", parentID).
-		Find(&directChildren).Error
-	if err != nil {
+		Joins("JOIN organization_closures oc ON oc.descendant_id = organizations.id").
+		Where("oc.ancestor_id = ? AND oc.depth > 0", parentID).
+		Order("oc.depth ASC, organizations.name ASC").
+		Find(&descendants).Error
+	return descendants, err
+}
+
+// GetParentChain gets the parent chain for an organization, nearest parent first
+func (r *organizationRepository) GetParentChain(ctx context.Context, orgID uuid.UUID) ([]model.Organization, error) {
+	var exists int64
+	if err := dbFromContext(ctx, r.db).Model(&model.Organization{}).Where("id = ?", orgID).Count(&exists).Error; err != nil {
 		return nil, err
 	}
+	if exists == 0 {
+		return nil, errors.New("organization not found")
+	}
 
-	// Add direct children to result
-	allChildren = append(allChildren, directChildren...)
+	var parentChain []model.Organization
+	err := dbFromContext(ctx, r.db).
+		Preload("ParentOrganization").
+		Joins("JOIN organization_closures oc ON oc.ancestor_id = organizations.id").
+		Where("oc.descendant_id = ? AND oc.depth > 0", orgID).
+		Order("oc.depth ASC").
+		Find(&parentChain).Error
+	return parentChain, err
+}
 
-	// Recursively get children of each direct child
-	for _, child := range directChildren {
-		grandChildren, err := r.GetChildrenRecursive(ctx, child.ID)
-		if err != nil {
-			return nil, err
-		}
-		allChildren = append(allChildren, grandChildren...)
-	}
+// GetAncestors returns the ancestors of an organization, nearest first, with their distance as Depth
+func (r *organizationRepository) GetAncestors(ctx context.Context, orgID uuid.UUID) ([]model.OrganizationClosure, error) {
+	var ancestors []model.OrganizationClosure
+	err := dbFromContext(ctx, r.db).
+		Preload("Ancestor").
+		Joins("JOIN organizations o ON o.id = organization_closures.ancestor_id AND o.deleted_at IS NULL").
+		Where("descendant_id = ? AND depth > 0", orgID).
+		Order("depth ASC").
+		Find(&ancestors).Error
+	return ancestors, err
+}
 
-	return allChildren, nil
+// GetDescendants returns the descendants of an organization ordered by distance.
+// maxDepth limits how many levels below the organization are returned; zero or less means unlimited.
+func (r *organizationRepository) GetDescendants(ctx context.Context, orgID uuid.UUID, maxDepth int) ([]model.OrganizationClosure, error) {
+	var descendants []model.OrganizationClosure
+	query := dbFromContext(ctx, r.db).
+		Preload("Descendant").
+		Joins("JOIN organizations o ON o.id = organization_closures.descendant_id AND o.deleted_at IS NULL").
+		Where("ancestor_id = ? AND depth > 0", orgID)
+	if maxDepth > 0 {
+		query = query.Where("depth <= ?", maxDepth)
+	}
+	err := query.Order("depth ASC").Find(&descendants).Error
+	return descendants, err
 }
 
-// GetParentChain gets the parent chain for an organization
-func (r *organizationRepository) GetParentChain(ctx context.Context, orgID uuid.UUID) ([]model.Organization, error) {
-	var parentChain []model.Organization
+// GetSubtree returns the given organizations together with all their descendants, without duplicates
+func (r *organizationRepository) GetSubtree(ctx context.Context, rootIDs []uuid.UUID) ([]model.Organization, error) {
+	var subtree []model.Organization
+	if len(rootIDs) == 0 {
+		return subtree, nil
+	}
 
-	// Start with the given organization
-	var org model.Organization
-	err := r.db.WithContext(ctx).
-		Preload("ParentOrganization").
-		Where("id = ?", orgID).
-		First(&org).Error
+	err := dbFromContext(ctx, r.db).
+		Where("id IN (?)", r.db.Model(&model.OrganizationClosure{}).Select("descendant_id").Where("ancestor_id IN ?", rootIDs)).
+		Find(&subtree).Error
+	return subtree, err
+}
+
+// GetDepth returns how many levels an organization sits below its root; root organizations have depth 0
+func (r *organizationRepository) GetDepth(ctx context.Context, orgID uuid.UUID) (int, error) {
+	var depth *int
+	err := dbFromContext(ctx, r.db).
+		Model(&model.OrganizationClosure{}).
+		Select("MAX(depth)").
+		Where("descendant_id = ?", orgID).
+		Scan(&depth).Error
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("organization not found")
-		}
-		return nil, err
+		return 0, err
+	}
+	if depth == nil {
+		return 0, gorm.ErrRecordNotFound
 	}
+	return *depth, nil
+}
 
-	// Traverse up the parent chain
-	currentParentID := org.ParentOrganizationID
-	for currentParentID != nil {
-		var parent model.Organization
-		err := r.db.WithContext(ctx).
-			Preload("ParentOrganization").
-			Where("id = ?", *currentParentID).
-			First(&parent).Error
-		if err != nil {
-			if errors.Is(err, gorm.ErrRecordNotFound) {
-				break // Parent not found, end chain
-			}
-			return nil, err
-		}
+// GetSubtreeHeight returns how many levels exist below an organization; leaves have height 0
+func (r *organizationRepository) GetSubtreeHeight(ctx context.Context, orgID uuid.UUID) (int, error) {
+	var height int
+	err := dbFromContext(ctx, r.db).
+		Model(&model.OrganizationClosure{}).
+		Select("COALESCE(MAX(depth), 0)").
+		Where("ancestor_id = ?", orgID).
+		Scan(&height).Error
+	return height, err
+}
 
-		parentChain = append(parentChain, parent)
-		currentParentID = parent.ParentOrganizationID
+// IsAncestor reports whether ancestorID is a strict ancestor of descendantID
+func (r *organizationRepository) IsAncestor(ctx context.Context, ancestorID, descendantID uuid.UUID) (bool, error) {
+	var count int64
+	err := dbFromContext(ctx, r.db).
+		Model(&model.OrganizationClosure{}).
+		Where("ancestor_id = ? AND descendant_id = ? AND depth > 0", ancestorID, descendantID).
+		Count(&count).Error
+	return count > 0, err
+}
 
-		// Prevent infinite loops (circular references)
-		if len(parentChain) > 50 {
-			return nil, errors.New("circular reference detected in parent chain")
+// Move re-parents an organization and records the audit entry in a single transaction
+func (r *organizationRepository) Move(ctx context.Context, orgID uuid.UUID, newParentID *uuid.UUID, auditLog *model.OrganizationAuditLog) error {
+	return dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		// Re-check for cycles inside the transaction; the closure trigger is the last line of defence
+		if newParentID != nil {
+			var cycles int64
+			if err := tx.Model(&model.OrganizationClosure{}).
+				Where("ancestor_id = ? AND descendant_id = ?", orgID, *newParentID).
+				Count(&cycles).Error; err != nil {
+				return err
+			}
+			if cycles > 0 {
+				return ErrOrganizationCycle
+			}
 		}
-	}
 
-	return parentChain, nil
-}
+		result := tx.Model(&model.Organization{}).
+			Where("id = ?", orgID).
+			Updates(map[string]interface{}{
+				"parent_organization_id": newParentID,
+				"updated_at":             gorm.Expr("NOW()"),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
 
-// AddUserToOrganization adds a user to an organization with a role
-func (r *organizationRepository) AddUserToOrganization(ctx context.Context, userOrg *model.UserOrganization) error {
-	return r.db.WithContext(ctx).Create(userOrg).Error
+		return tx.Omit(clause.Associations).Create(auditLog).Error
+	})
 }
 
-// RemoveUserFromOrganization removes a user from an organization
-func (r *organizationRepository) RemoveUserFromOrganization(ctx context.Context, userID, orgID uuid.UUID) error {
-	return r.db.WithContext(ctx).
-		Where("user_id = ? AND organization_id = ?", userID, orgID).
-		Delete(&model.UserOrganization{}).Error
-}
+// FindMemberRoleIDs returns the distinct roles held by active members of the given organizations
+func (r *organizationRepository) FindMemberRoleIDs(ctx context.Context, orgIDs []uuid.UUID) ([]uuid.UUID, error) {
+	var roleIDs []uuid.UUID
+	if len(orgIDs) == 0 {
+		return roleIDs, nil
+	}
 
-// UpdateUserOrganizationRole updates a user's role in an organization
-func (r *organizationRepository) UpdateUserOrganizationRole(ctx context.Context, userID, orgID uuid.UUID, role string) error {
-	return r.db.WithContext(ctx).
+	err := dbFromContext(ctx, r.db).
 		Model(&model.UserOrganization{}).
-		Where("user_id = ? AND organization_id = ?", userID, orgID).
-		Update("role", role).Error
-}
-
-// FindUserOrganizations finds all organizations for a user
-func (r *organizationRepository) FindUserOrganizations(ctx context.Context, userID uuid.UUID) ([]model.UserOrganization, error) {
-	var userOrgs []model.UserOrganization
-	err := r.db.WithContext(ctx).
-		Preload("Organization").
-		Preload("User").
-		Where("user_id = ?", userID).
-		Find(&userOrgs).Error
-	return userOrgs, err
-}
-
-// FindOrganizationUsers finds all users for an organization
-func (r *organizationRepository) FindOrganizationUsers(ctx context.Context, orgID uuid.UUID) ([]model.UserOrganization, error) {
-	var userOrgs []model.UserOrganization
-	err := r.db.WithContext(ctx).
-		Preload("Organization").
-		Preload("User").
-		Where("organization_id = ?", orgID).
-		Find(&userOrgs).Error
-	return userOrgs, err
+		Distinct("role_id").
+		Where("organization_id IN ? AND is_active = ? AND role_id IS NOT NULL", orgIDs, true).
+		Pluck("role_id", &roleIDs).Error
+	return roleIDs, err
 }
 
-// FindActiveUserOrganizations finds all active organizations for a user
-func (r *organizationRepository) FindActiveUserOrganizations(ctx context.Context, userID uuid.UUID) ([]model.UserOrganization, error) {
-	var userOrgs []model.UserOrganization
-	err := r.db.WithContext(ctx).
-		Preload("Organization").
-		Preload("User").
-		Where("user_id = ? AND status = ?", userID, "active").
-		Find(&userOrgs).Error
-	return userOrgs, err
+// CountActiveMembers counts the active members of an organization
+func (r *organizationRepository) CountActiveMembers(ctx context.Context, orgID uuid.UUID) (int64, error) {
+	var count int64
+	err := dbFromContext(ctx, r.db).
+		Model(&model.UserOrganization{}).
+		Where("organization_id = ? AND is_active = ?", orgID, true).
+		Count(&count).Error
+	return count, err
 }
 
-// FindActiveOrganizationUsers finds all active users for an organization
-func (r *organizationRepository) FindActiveOrganizationUsers(ctx context.Context, orgID uuid.UUID) ([]model.UserOrganization, error) {
-	var userOrgs []model.UserOrganization
-	err := r.db.WithContext(ctx).
-		Preload("Organization").
-		Preload("User").
-		Where("organization_id = ? AND status = ?", orgID, "active").
-		Find(&userOrgs).Error
-	return userOrgs, err
+// CountChildren counts the direct child organizations of an organization, archived ones included
+func (r *organizationRepository) CountChildren(ctx context.Context, orgID uuid.UUID) (int64, error) {
+	var count int64
+	err := dbFromContext(ctx, r.db).
+		Model(&model.Organization{}).
+		Where("parent_organization_id = ?", orgID).
+		Count(&count).Error
+	return count, err
 }
 
-// CheckCodeExists checks if an organization code already exists
+// CheckCodeExists checks if an organization code already exists.
+// Soft-deleted organizations keep their code until they are purged, so they are included.
 func (r *organizationRepository) CheckCodeExists(ctx context.Context, code string) (bool, error) {
 	var count int64
-	err := r.db.WithContext(ctx).
+	err := dbFromContext(ctx, r.db).
+		Unscoped().
 		Model(&model.Organization{}).
 		Where("code = ?", code).
 		Count(&count).Error
@@ -385,20 +636,35 @@ func (r *organizationRepository) CheckCodeExists(ctx context.Context, code strin
 // CheckCodeExistsExcluding checks if an organization code exists excluding a specific ID
 func (r *organizationRepository) CheckCodeExistsExcluding(ctx context.Context, code string, excludeID uuid.UUID) (bool, error) {
 	var count int64
-	err := r.db.WithContext(ctx).
+	err := dbFromContext(ctx, r.db).
+		Unscoped().
 		Model(&model.Organization{}).
 		Where("code = ? AND id != ?", code, excludeID).
 		Count(&count).Error
 	return count > 0, err
 }
 
-// GetAllExistingCodes gets all existing organization codes
-func (r *organizationRepository) GetAllExistingCodes(ctx context.Context) ([]string, error) {
-	var codes []string
-	err := r.db.WithContext(ctx).
-		Model(&model.Organization{}).
-		Pluck("code", &codes).Error
-	return codes, err
+// UpdateCode replaces the code of an organization and records the audit entry in a single transaction.
+// The update only applies while no other organization, soft-deleted ones included, holds the code.
+// Callers make sure the organization exists, so no affected row means the code is taken.
+func (r *organizationRepository) UpdateCode(ctx context.Context, orgID uuid.UUID, code string, auditLog *model.OrganizationAuditLog) error {
+	return dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.Organization{}).
+			Where("id = ?", orgID).
+			Where("NOT EXISTS (SELECT 1 FROM organizations WHERE code = ?)", code).
+			Updates(map[string]interface{}{
+				"code":       code,
+				"updated_at": time.Now(),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrOrganizationCodeTaken
+		}
+
+		return tx.Omit(clause.Associations).Create(auditLog).Error
+	})
 }
 
 // GetOrganizationStats gets organization statistics
@@ -407,7 +673,7 @@ func (r *organizationRepository) GetOrganizationStats(ctx context.Context, orgID
 
 	// Get basic organization info
 	var org model.Organization
-	err := r.db.WithContext(ctx).Where("id = ?", orgID).First(&org).Error
+	err := dbFromContext(ctx, r.db).Where("id = ?", orgID).First(&org).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, errors.New("organization not found")
@@ -417,7 +683,7 @@ func (r *organizationRepository) GetOrganizationStats(ctx context.Context, orgID
 
 	// Count direct children
 	var directChildren int64
-	err = r.db.WithContext(ctx).
+	err = dbFromContext(ctx, r.db).
 		Model(&model.Organization{}).
 		Where("parent_organization_id = ?", orgID).
 		Count(&directChildren).Error
@@ -426,16 +692,20 @@ func (r *organizationRepository) GetOrganizationStats(ctx context.Context, orgID
 	}
 	stats.DirectChildren = int(directChildren)
 
-	// Count total descendants (recursive)
-	descendants, err := r.GetChildrenRecursive(ctx, orgID)
+	// Count total descendants
+	var totalDescendants int64
+	err = dbFromContext(ctx, r.db).
+		Model(&model.OrganizationClosure{}).
+		Where("ancestor_id = ? AND depth > 0", orgID).
+		Count(&totalDescendants).Error
 	if err != nil {
 		return nil, err
 	}
-	stats.TotalDescendants = len(descendants)
+	stats.TotalDescendants = int(totalDescendants)
 
 	// Count total members in organization
 	var totalMembers int64
-	err = r.db.WithContext(ctx).
+	err = dbFromContext(ctx, r.db).
 		Model(&model.UserOrganization{}).
 		Where("organization_id = ?", orgID).
 		Count(&totalMembers).Error
@@ -446,9 +716,9 @@ func (r *organizationRepository) GetOrganizationStats(ctx context.Context, orgID
 
 	// Count active members in organization
 	var activeMembers int64
-	err = r.db.WithContext(ctx).
+	err = dbFromContext(ctx, r.db).
 		Model(&model.UserOrganization{}).
-		Where("organization_id = ? AND status = ?", orgID, "active").
+		Where("organization_id = ? AND is_active = ?", orgID, true).
 		Count(&activeMembers).Error
 	if err != nil {
 		return nil, err
@@ -456,11 +726,11 @@ func (r *organizationRepository) GetOrganizationStats(ctx context.Context, orgID
 	stats.ActiveMembers = int(activeMembers)
 
 	// Calculate organization level (depth from root)
-	parentChain, err := r.GetParentChain(ctx, orgID)
+	level, err := r.GetDepth(ctx, orgID)
 	if err != nil {
 		return nil, err
 	}
-	stats.OrganizationLevel = len(parentChain)
+	stats.OrganizationLevel = level
 
 	return &stats, nil
 }
@@ -471,7 +741,7 @@ func (r *organizationRepository) GetHierarchyStats(ctx context.Context) (*dto.Hi
 
 	// Count total organizations
 	var totalOrgs int64
-	err := r.db.WithContext(ctx).
+	err := dbFromContext(ctx, r.db).
 		Model(&model.Organization{}).
 		Count(&totalOrgs).Error
 	if err != nil {
@@ -481,84 +751,48 @@ func (r *organizationRepository) GetHierarchyStats(ctx context.Context) (*dto.Hi
 
 	// Count active organizations
 	var activeOrgs int64
-	err = r.db.WithContext(ctx).
+	err = dbFromContext(ctx, r.db).
 		Model(&model.Organization{}).
-		Where("status = ?", "active").
+		Where("is_active = ?", true).
 		Count(&activeOrgs).Error
 	if err != nil {
 		return nil, err
 	}
 	stats.ActiveOrganizations = int(activeOrgs)
 
-	// Count by type
+	// Count by type from the registry, so types without organizations are reported with zero
 	var typeCounts []struct {
 		Type  string
 		Count int64
 	}
 
-	err = r.db.WithContext(ctx).
-		Model(&model.Organization{}).
-		Select("type, COUNT(*) as count").
-		Group("type").
+	err = dbFromContext(ctx, r.db).
+		Table("organization_types AS t").
+		Select("t.name AS type, COUNT(o.id) AS count").
+		Joins("LEFT JOIN organizations o ON o.organization_type = t.name AND o.deleted_at IS NULL").
+		Group("t.name").
 		Scan(&typeCounts).Error
 	if err != nil {
 		return nil, err
 	}
 
-	// Map type counts
+	stats.OrganizationsByType = make(map[string]int64, len(typeCounts))
 	for _, tc := range typeCounts {
-		switch tc.Type {
-		case "holding":
-			stats.HoldingCount = int(tc.Count)
-		case "company":
-			stats.CompanyCount = int(tc.Count)
-		case "store":
-			stats.StoreCount = int(tc.Count)
-		}
+		stats.OrganizationsByType[tc.Type] = tc.Count
 	}
 
-	// Calculate max depth
-	maxDepthInt64 := int64(0)
-	rootOrgs, err := r.FindRootOrganizations(ctx)
+	// Calculate max depth, counting root organizations as level 1
+	var maxDepth *int
+	err = dbFromContext(ctx, r.db).
+		Model(&model.OrganizationClosure{}).
+		Select("MAX(depth) + 1").
+		Scan(&maxDepth).Error
 	if err != nil {
 		return nil, err
 	}
-
-	for _, root := range rootOrgs {
-		depth, err := r.calculateMaxDepth(ctx, root.ID, 1)
-		if err != nil {
-			return nil, err
-		}
-		if depth > maxDepthInt64 {
-			maxDepthInt64 = depth
-		}
+	if maxDepth != nil {
+		stats.MaxDepth = *maxDepth
 	}
-	stats.MaxDepth = int(maxDepthInt64)
 
 	return &stats, nil
 }
-
-// calculateMaxDepth calculates the maximum depth in the hierarchy from a given root
-func (r *organizationRepository) calculateMaxDepth(ctx context.Context, orgID uuid.UUID, currentDepth int64) (int64, error) {
-	children, err := r.FindByParent(ctx, orgID)
-	if err != nil {
-		return 0, err
-	}
-
-	if len(children) == 0 {
-		return currentDepth, nil
-	}
-
-	maxChildDepth := currentDepth
-	for _, child := range children {
-		childDepth, err := r.calculateMaxDepth(ctx, child.ID, currentDepth+1)
-		if err != nil {
-			return 0, err
-		}
-		if childDepth > maxChildDepth {
-			maxChildDepth = childDepth
-		}
-	}
-
-	return maxChildDepth, nil
-}
@@ -4,47 +4,87 @@ import (
 	"go-base-project/internal/dto"
 	"go-base-project/internal/model"
 	"context"
+	"errors"
+	"time"
 
 	"github.com/google/uuid"
 )
 
+// ErrOrganizationCycle is returned when an organization would be moved under itself or one of its descendants.
+var ErrOrganizationCycle = errors.New("organization cannot be moved under itself or its descendants")
+
+// ErrOrganizationCodeTaken is returned when a generated organization code is already in use.
+// Callers generate a fresh code and try again.
+var ErrOrganizationCodeTaken = errors.New("organization code is already taken")
+
 // OrganizationRepositoryInterface defines the interface for organization data operations
+// OrganizationSearchHit is an organization matching a search term with its relevance; higher scores rank first.
+type OrganizationSearchHit struct {
+	Organization model.Organization
+	Score        float64
+}
+
 type OrganizationRepositoryInterface interface {
-	// Basic CRUD operations
+	// Basic CRUD operations. Create returns ErrOrganizationCodeTaken when the code is in use.
 	Create(ctx context.Context, org *model.Organization) (*model.Organization, error)
 	FindByID(ctx context.Context, id uuid.UUID) (*model.Organization, error)
 	FindByIDWithDetails(ctx context.Context, id uuid.UUID) (*model.Organization, error)
+	// FindByIDForUpdate finds an organization and locks its row until the surrounding transaction ends.
+	FindByIDForUpdate(ctx context.Context, id uuid.UUID) (*model.Organization, error)
 	FindByCode(ctx context.Context, code string) (*model.Organization, error)
 	Update(ctx context.Context, org *model.Organization) (*model.Organization, error)
-	Delete(ctx context.Context, id uuid.UUID) error
+	Delete(ctx context.Context, id uuid.UUID, auditLog *model.OrganizationAuditLog) error
+
+	// Lifecycle operations: soft-deleted organizations can be restored until they are purged,
+	// archived organizations stay visible but accept no new members.
+	FindDeletedByID(ctx context.Context, id uuid.UUID) (*model.Organization, error)
+	ListDeleted(ctx context.Context) ([]model.Organization, error)
+	Restore(ctx context.Context, id uuid.UUID, auditLog *model.OrganizationAuditLog) error
+	Archive(ctx context.Context, orgIDs []uuid.UUID, archivedBy uuid.UUID, auditLogs []model.OrganizationAuditLog) error
+	Unarchive(ctx context.Context, orgIDs []uuid.UUID, auditLogs []model.OrganizationAuditLog) error
+	PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int64, error)
 
 	// Query operations with enhanced functionality
 	FindAll(ctx context.Context, filters map[string]interface{}) ([]model.Organization, error)
-	List(ctx context.Context, page, limit int, search string, filters map[string]interface{}) ([]model.Organization, int64, error)
+	// List returns one page of the organizations matching the filters and search, ordered and paged by query,
+	// with the total number of matches.
+	List(ctx context.Context, search string, filters map[string]interface{}, query ListQuery) (*ListPage[model.Organization], int64, error)
 	Count(ctx context.Context, filters map[string]interface{}) (int64, error)
 	FindByType(ctx context.Context, orgType string) ([]model.Organization, error)
 	FindByParent(ctx context.Context, parentID uuid.UUID) ([]model.Organization, error)
 	FindRootOrganizations(ctx context.Context) ([]model.Organization, error)
 	FindActiveOrganizations(ctx context.Context) ([]model.Organization, error)
+	// Search ranks the organizations whose name, code or description match term. A nil organizationIDs
+	// searches every organization, otherwise only the listed ones.
+	Search(ctx context.Context, term string, organizationIDs []uuid.UUID, limit int) ([]OrganizationSearchHit, error)
 
 	// Hierarchy management operations
 	GetOrganizationHierarchy(ctx context.Context, orgID uuid.UUID) ([]model.Organization, error)
 	GetChildrenRecursive(ctx context.Context, parentID uuid.UUID) ([]model.Organization, error)
 	GetParentChain(ctx context.Context, orgID uuid.UUID) ([]model.Organization, error)
+	GetAncestors(ctx context.Context, orgID uuid.UUID) ([]model.OrganizationClosure, error)
+	GetDescendants(ctx context.Context, orgID uuid.UUID, maxDepth int) ([]model.OrganizationClosure, error)
+	GetSubtree(ctx context.Context, rootIDs []uuid.UUID) ([]model.Organization, error)
+	GetDepth(ctx context.Context, orgID uuid.UUID) (int, error)
+	GetSubtreeHeight(ctx context.Context, orgID uuid.UUID) (int, error)
+	IsAncestor(ctx context.Context, ancestorID, descendantID uuid.UUID) (bool, error)
+
+	// Move re-parents an organization and records the audit entry in a single transaction.
+	// The closure table follows the new parent through the organizations trigger.
+	Move(ctx context.Context, orgID uuid.UUID, newParentID *uuid.UUID, auditLog *model.OrganizationAuditLog) error
 
-	// User-Organization operations
-	AddUserToOrganization(ctx context.Context, userOrg *model.UserOrganization) error
-	RemoveUserFromOrganization(ctx context.Context, userID, orgID uuid.UUID) error
-	UpdateUserOrganizationRole(ctx context.Context, userID, orgID uuid.UUID, role string) error
-	FindUserOrganizations(ctx context.Context, userID uuid.UUID) ([]model.UserOrganization, error)
-	FindOrganizationUsers(ctx context.Context, orgID uuid.UUID) ([]model.UserOrganization, error)
-	FindActiveUserOrganizations(ctx context.Context, userID uuid.UUID) ([]model.UserOrganization, error)
-	FindActiveOrganizationUsers(ctx context.Context, orgID uuid.UUID) ([]model.UserOrganization, error)
+	// Membership aggregates; memberships themselves are managed by MembershipRepositoryInterface
+	FindMemberRoleIDs(ctx context.Context, orgIDs []uuid.UUID) ([]uuid.UUID, error)
+	CountActiveMembers(ctx context.Context, orgID uuid.UUID) (int64, error)
+	CountChildren(ctx context.Context, orgID uuid.UUID) (int64, error)
 
 	// Validation and utility operations
 	CheckCodeExists(ctx context.Context, code string) (bool, error)
 	CheckCodeExistsExcluding(ctx context.Context, code string, excludeID uuid.UUID) (bool, error)
-	GetAllExistingCodes(ctx context.Context) ([]string, error)
+
+	// UpdateCode replaces the code of an organization and records the audit entry in a single transaction.
+	// It returns ErrOrganizationCodeTaken when the new code is in use, including by soft-deleted organizations.
+	UpdateCode(ctx context.Context, orgID uuid.UUID, code string, auditLog *model.OrganizationAuditLog) error
 
 	// Organization statistics and analytics
 	GetOrganizationStats(ctx context.Context, orgID uuid.UUID) (*dto.OrganizationStatsResponse, error)
@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"go-base-project/internal/model"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type organizationSettingRepository struct {
+	db *gorm.DB
+}
+
+// NewOrganizationSettingRepository creates a new instance of OrganizationSettingRepository.
+func NewOrganizationSettingRepository(db *gorm.DB) OrganizationSettingRepositoryInterface {
+	return &organizationSettingRepository{db: db}
+}
+
+// FindByOrganization returns the settings set explicitly on an organization ordered by key.
+func (r *organizationSettingRepository) FindByOrganization(ctx context.Context, orgID uuid.UUID) ([]model.OrganizationSetting, error) {
+	var settings []model.OrganizationSetting
+	err := dbFromContext(ctx, r.db).
+		Where("organization_id = ?", orgID).
+		Order("key ASC").
+		Find(&settings).Error
+	return settings, err
+}
+
+// FindByOrganizations returns the settings set explicitly on the given organizations ordered by key.
+func (r *organizationSettingRepository) FindByOrganizations(ctx context.Context, orgIDs []uuid.UUID) ([]model.OrganizationSetting, error) {
+	var settings []model.OrganizationSetting
+	if len(orgIDs) == 0 {
+		return settings, nil
+	}
+
+	err := dbFromContext(ctx, r.db).
+		Where("organization_id IN ?", orgIDs).
+		Order("key ASC").
+		Find(&settings).Error
+	return settings, err
+}
+
+// FindInheritanceChain returns the settings of an organization and its ancestors, nearest organization first.
+func (r *organizationSettingRepository) FindInheritanceChain(ctx context.Context, orgID uuid.UUID) ([]model.OrganizationSetting, error) {
+	var settings []model.OrganizationSetting
+	err := dbFromContext(ctx, r.db).
+		Joins("JOIN organization_closures c ON c.ancestor_id = organization_settings.organization_id").
+		Where("c.descendant_id = ?", orgID).
+		Order("c.depth ASC, organization_settings.key ASC").
+		Find(&settings).Error
+	return settings, err
+}
+
+// Save upserts and removes settings of an organization together with its audit log entry.
+func (r *organizationSettingRepository) Save(ctx context.Context, orgID uuid.UUID, upserts []model.OrganizationSetting, removedKeys []string, auditLog *model.OrganizationAuditLog) error {
+	return dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if len(upserts) > 0 {
+			if err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "organization_id"}, {Name: "key"}},
+				DoUpdates: clause.AssignmentColumns([]string{"value", "updated_by", "updated_at"}),
+			}).Create(&upserts).Error; err != nil {
+				return err
+			}
+		}
+
+		if len(removedKeys) > 0 {
+			if err := tx.Where("organization_id = ? AND key IN ?", orgID, removedKeys).
+				Delete(&model.OrganizationSetting{}).Error; err != nil {
+				return err
+			}
+		}
+
+		if auditLog == nil {
+			return nil
+		}
+		return tx.Omit(clause.Associations).Create(auditLog).Error
+	})
+}
@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+	"go-base-project/internal/model"
+
+	"github.com/google/uuid"
+)
+
+// OrganizationSettingRepositoryInterface defines the data operations for per-organization settings
+type OrganizationSettingRepositoryInterface interface {
+	// FindByOrganization returns the settings set explicitly on an organization.
+	FindByOrganization(ctx context.Context, orgID uuid.UUID) ([]model.OrganizationSetting, error)
+	// FindByOrganizations returns the settings set explicitly on any of the given organizations.
+	FindByOrganizations(ctx context.Context, orgIDs []uuid.UUID) ([]model.OrganizationSetting, error)
+	// FindInheritanceChain returns the settings set on an organization and all its ancestors, nearest
+	// organization first, so the first value found for a key is the effective one.
+	FindInheritanceChain(ctx context.Context, orgID uuid.UUID) ([]model.OrganizationSetting, error)
+	// Save upserts and removes settings of an organization and records the audit log in one transaction.
+	Save(ctx context.Context, orgID uuid.UUID, upserts []model.OrganizationSetting, removedKeys []string, auditLog *model.OrganizationAuditLog) error
+}
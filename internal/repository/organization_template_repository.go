@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"go-base-project/internal/model"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type organizationTemplateRepository struct {
+	db *gorm.DB
+}
+
+// NewOrganizationTemplateRepository creates a new instance of OrganizationTemplateRepository.
+func NewOrganizationTemplateRepository(db *gorm.DB) OrganizationTemplateRepositoryInterface {
+	return &organizationTemplateRepository{db: db}
+}
+
+// Create stores a new template; the name is claimed atomically through its unique index.
+func (r *organizationTemplateRepository) Create(ctx context.Context, template *model.OrganizationTemplate) error {
+	result := dbFromContext(ctx, r.db).
+		Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "name"}}, DoNothing: true}).
+		Create(template)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrOrganizationTemplateNameTaken
+	}
+	return nil
+}
+
+// Update saves a template, refusing a name that another template already uses.
+func (r *organizationTemplateRepository) Update(ctx context.Context, template *model.OrganizationTemplate) error {
+	return dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&model.OrganizationTemplate{}).
+			Where("name = ? AND id != ?", template.Name, template.ID).
+			Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return ErrOrganizationTemplateNameTaken
+		}
+
+		result := tx.Model(&model.OrganizationTemplate{}).
+			Where("id = ?", template.ID).
+			Updates(map[string]interface{}{
+				"name":        template.Name,
+				"description": template.Description,
+				"definition":  template.Definition,
+				"updated_by":  template.UpdatedBy,
+				"updated_at":  template.UpdatedAt,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+}
+
+// FindByID finds a saved template.
+func (r *organizationTemplateRepository) FindByID(ctx context.Context, id uuid.UUID) (*model.OrganizationTemplate, error) {
+	var template model.OrganizationTemplate
+	if err := dbFromContext(ctx, r.db).Where("id = ?", id).First(&template).Error; err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+// FindAll returns the saved templates ordered by name.
+func (r *organizationTemplateRepository) FindAll(ctx context.Context) ([]model.OrganizationTemplate, error) {
+	var templates []model.OrganizationTemplate
+	err := dbFromContext(ctx, r.db).Order("name ASC").Find(&templates).Error
+	return templates, err
+}
+
+// Delete removes a saved template. Organizations created from it are not affected.
+func (r *organizationTemplateRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result := dbFromContext(ctx, r.db).Where("id = ?", id).Delete(&model.OrganizationTemplate{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
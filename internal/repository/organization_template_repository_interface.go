@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"go-base-project/internal/model"
+
+	"github.com/google/uuid"
+)
+
+// ErrOrganizationTemplateNameTaken is returned when another saved template already uses the name.
+var ErrOrganizationTemplateNameTaken = errors.New("organization template name is already taken")
+
+// OrganizationTemplateRepositoryInterface defines the data operations for saved organization templates
+type OrganizationTemplateRepositoryInterface interface {
+	// Create and Update return ErrOrganizationTemplateNameTaken when the name is in use.
+	Create(ctx context.Context, template *model.OrganizationTemplate) error
+	Update(ctx context.Context, template *model.OrganizationTemplate) error
+	FindByID(ctx context.Context, id uuid.UUID) (*model.OrganizationTemplate, error)
+	FindAll(ctx context.Context) ([]model.OrganizationTemplate, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"context"
+	"go-base-project/internal/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type organizationTypeRepository struct {
+	db *gorm.DB
+}
+
+// NewOrganizationTypeRepository creates a new instance of OrganizationTypeRepository.
+func NewOrganizationTypeRepository(db *gorm.DB) OrganizationTypeRepositoryInterface {
+	return &organizationTypeRepository{db: db}
+}
+
+// FindAll returns the registered organization types with their allowed parents.
+func (r *organizationTypeRepository) FindAll(ctx context.Context, includeInactive bool) ([]model.OrganizationType, error) {
+	var orgTypes []model.OrganizationType
+	query := dbFromContext(ctx, r.db).Preload("AllowedParents")
+	if !includeInactive {
+		query = query.Where("is_active = ?", true)
+	}
+	err := query.Order("name ASC").Find(&orgTypes).Error
+	return orgTypes, err
+}
+
+// FindByName finds an organization type, active or not, with its allowed parents.
+func (r *organizationTypeRepository) FindByName(ctx context.Context, name string) (*model.OrganizationType, error) {
+	var orgType model.OrganizationType
+	if err := dbFromContext(ctx, r.db).
+		Preload("AllowedParents").
+		Where("name = ?", name).
+		First(&orgType).Error; err != nil {
+		return nil, err
+	}
+	return &orgType, nil
+}
+
+// Exists checks whether an organization type is registered.
+func (r *organizationTypeRepository) Exists(ctx context.Context, name string) (bool, error) {
+	var count int64
+	err := dbFromContext(ctx, r.db).Model(&model.OrganizationType{}).Where("name = ?", name).Count(&count).Error
+	return count > 0, err
+}
+
+// Create stores a new organization type and its allowed parent types.
+func (r *organizationTypeRepository) Create(ctx context.Context, orgType *model.OrganizationType, parentTypes []string) error {
+	return dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(orgType).Error; err != nil {
+			return err
+		}
+		return replaceOrganizationTypeParents(tx, orgType.Name, parentTypes)
+	})
+}
+
+// Update saves an organization type and replaces its allowed parent types.
+func (r *organizationTypeRepository) Update(ctx context.Context, orgType *model.OrganizationType, parentTypes []string) error {
+	return dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Save(orgType).Error; err != nil {
+			return err
+		}
+		return replaceOrganizationTypeParents(tx, orgType.Name, parentTypes)
+	})
+}
+
+// CountOrganizations counts the organizations of a type.
+func (r *organizationTypeRepository) CountOrganizations(ctx context.Context, name string) (int64, error) {
+	var count int64
+	err := dbFromContext(ctx, r.db).Model(&model.Organization{}).Where("organization_type = ?", name).Count(&count).Error
+	return count, err
+}
+
+// FindAttributes returns the custom attribute schema of an organization type in listing order.
+func (r *organizationTypeRepository) FindAttributes(ctx context.Context, name string) ([]model.OrganizationTypeAttribute, error) {
+	var attributes []model.OrganizationTypeAttribute
+	err := dbFromContext(ctx, r.db).
+		Where("organization_type = ?", name).
+		Order("position ASC, key ASC").
+		Find(&attributes).Error
+	return attributes, err
+}
+
+// ReplaceAttributes rewrites the custom attribute schema of an organization type.
+func (r *organizationTypeRepository) ReplaceAttributes(ctx context.Context, name string, attributes []model.OrganizationTypeAttribute) error {
+	return dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("organization_type = ?", name).Delete(&model.OrganizationTypeAttribute{}).Error; err != nil {
+			return err
+		}
+		if len(attributes) == 0 {
+			return nil
+		}
+		return tx.Create(&attributes).Error
+	})
+}
+
+// replaceOrganizationTypeParents rewrites the allowed parent types of an organization type within tx.
+func replaceOrganizationTypeParents(tx *gorm.DB, name string, parentTypes []string) error {
+	if err := tx.Where("organization_type = ?", name).Delete(&model.OrganizationTypeParent{}).Error; err != nil {
+		return err
+	}
+	if len(parentTypes) == 0 {
+		return nil
+	}
+
+	parents := make([]model.OrganizationTypeParent, len(parentTypes))
+	for i, parentType := range parentTypes {
+		parents[i] = model.OrganizationTypeParent{OrganizationType: name, ParentType: parentType}
+	}
+	return tx.Create(&parents).Error
+}
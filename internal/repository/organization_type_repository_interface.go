@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+	"go-base-project/internal/model"
+)
+
+// OrganizationTypeRepositoryInterface defines the data operations for the organization type registry
+type OrganizationTypeRepositoryInterface interface {
+	FindAll(ctx context.Context, includeInactive bool) ([]model.OrganizationType, error)
+	FindByName(ctx context.Context, name string) (*model.OrganizationType, error)
+	Exists(ctx context.Context, name string) (bool, error)
+
+	// Create and Update store the type together with its allowed parent types in a single transaction.
+	Create(ctx context.Context, orgType *model.OrganizationType, parentTypes []string) error
+	Update(ctx context.Context, orgType *model.OrganizationType, parentTypes []string) error
+
+	CountOrganizations(ctx context.Context, name string) (int64, error)
+
+	// Custom attribute schema
+	FindAttributes(ctx context.Context, name string) ([]model.OrganizationTypeAttribute, error)
+	// ReplaceAttributes rewrites the attribute schema of a type in a single transaction.
+	ReplaceAttributes(ctx context.Context, name string, attributes []model.OrganizationTypeAttribute) error
+}
@@ -0,0 +1,159 @@
+package repository
+
+import (
+	"context"
+	"go-base-project/internal/constant"
+	"go-base-project/internal/model"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type personalDataRepository struct {
+	db *gorm.DB
+}
+
+// NewPersonalDataRepository creates a new instance of PersonalDataRepository.
+func NewPersonalDataRepository(db *gorm.DB) PersonalDataRepositoryInterface {
+	return &personalDataRepository{db: db}
+}
+
+// FindUserIncludingDeleted finds a user with their role, including soft-deleted users.
+func (r *personalDataRepository) FindUserIncludingDeleted(ctx context.Context, id uuid.UUID) (*model.User, error) {
+	var user model.User
+	if err := dbFromContext(ctx, r.db).Unscoped().Preload("Role").Where("id = ?", id).First(&user).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// FindRecords collects every row that refers to the user, oldest first.
+func (r *personalDataRepository) FindRecords(ctx context.Context, user *model.User) (*PersonalDataRecords, error) {
+	db := dbFromContext(ctx, r.db)
+	records := &PersonalDataRecords{}
+
+	if err := db.Preload("Organization").Preload("Role").
+		Where("user_id = ?", user.ID).
+		Order("joined_at ASC").
+		Find(&records.Memberships).Error; err != nil {
+		return nil, err
+	}
+	if err := db.Where("user_id = ? OR action_by = ?", user.ID, user.ID).
+		Order("action_at ASC").
+		Find(&records.OrganizationHistory).Error; err != nil {
+		return nil, err
+	}
+	if err := db.Where("user_id = ? OR action_by = ?", user.ID, user.ID).
+		Order("action_at ASC").
+		Find(&records.StatusHistory).Error; err != nil {
+		return nil, err
+	}
+	if err := db.Where("actor_id = ?", user.ID).
+		Order("created_at ASC").
+		Find(&records.OrganizationAuditLogs).Error; err != nil {
+		return nil, err
+	}
+	if err := db.Where("requested_by = ?", user.ID).
+		Order("created_at ASC").
+		Find(&records.ChangeRequests).Error; err != nil {
+		return nil, err
+	}
+	if err := db.Where("approver_id = ?", user.ID).
+		Order("created_at ASC").
+		Find(&records.ChangeRequestApprovals).Error; err != nil {
+		return nil, err
+	}
+	if err := db.Where("requested_by = ? OR approver_id = ?", user.ID, user.ID).
+		Order("created_at ASC").
+		Find(&records.RoleApprovals).Error; err != nil {
+		return nil, err
+	}
+	if err := db.Where("user_id = ? OR decided_by = ?", user.ID, user.ID).
+		Order("created_at ASC").
+		Find(&records.JoinRequests).Error; err != nil {
+		return nil, err
+	}
+	invitations := db.Where("invited_user_id = ? OR invited_by = ?", user.ID, user.ID)
+	if user.Email != "" {
+		invitations = db.Where("invited_user_id = ? OR invited_by = ? OR LOWER(email) = LOWER(?)", user.ID, user.ID, user.Email)
+	}
+	if err := invitations.Order("created_at ASC").Find(&records.Invitations).Error; err != nil {
+		return nil, err
+	}
+	if err := db.Where("submitted_by = ?", user.ID).
+		Order("created_at ASC").
+		Find(&records.Jobs).Error; err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+// Erase anonymises a user in place and records the erasure in a single transaction. The users row is kept,
+// soft-deleted, so history entries and organizations created by the user keep a valid reference.
+func (r *personalDataRepository) Erase(ctx context.Context, user *model.User, originalEmail string, erasure *model.UserErasure) error {
+	return dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		result := tx.Unscoped().Model(&model.User{}).
+			Where("id = ? AND erased_at IS NULL", user.ID).
+			Updates(map[string]interface{}{
+				"username":        user.Username,
+				"email":           user.Email,
+				"password":        user.Password,
+				"google_id":       user.GoogleID,
+				"avatar_url":      user.AvatarURL,
+				"avatar_key":      user.AvatarKey,
+				"display_name":    user.DisplayName,
+				"phone":           user.Phone,
+				"locale":          user.Locale,
+				"timezone":        user.Timezone,
+				"job_title":       user.JobTitle,
+				"role_id":         user.RoleID,
+				"status_reason":   user.StatusReason,
+				"suspended_until": user.SuspendedUntil,
+				"erased_at":       user.ErasedAt,
+				"deleted_at":      gorm.Expr("COALESCE(deleted_at, NOW())"),
+				"updated_at":      gorm.Expr("NOW()"),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrUserAlreadyErased
+		}
+
+		removed, err := removeUserMemberships(tx, user.ID, MembershipChange{ActionBy: erasure.ErasedBy, Reason: "Personal data erased"})
+		if err != nil {
+			return err
+		}
+		erasure.MembershipsRemoved = removed
+
+		if err := tx.Where("user_id = ? AND status = ?", user.ID, constant.JoinRequestStatusPending).
+			Delete(&model.OrganizationJoinRequest{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&model.OrganizationJoinRequest{}).
+			Where("user_id = ?", user.ID).
+			Update("message", "").Error; err != nil {
+			return err
+		}
+
+		invitations := tx.Model(&model.OrganizationInvitation{}).Where("status = ?", constant.InvitationStatusPending)
+		if originalEmail != "" {
+			invitations = invitations.Where("invited_user_id = ? OR LOWER(email) = LOWER(?)", user.ID, originalEmail)
+		} else {
+			invitations = invitations.Where("invited_user_id = ?", user.ID)
+		}
+		if err := invitations.
+			Updates(map[string]interface{}{
+				"status":     constant.InvitationStatusRevoked,
+				"email":      "",
+				"revoked_at": gorm.Expr("NOW()"),
+				"updated_at": gorm.Expr("NOW()"),
+			}).Error; err != nil {
+			return err
+		}
+
+		return tx.Omit(clause.Associations).Create(erasure).Error
+	})
+}
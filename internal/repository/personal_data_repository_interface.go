@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"go-base-project/internal/model"
+
+	"github.com/google/uuid"
+)
+
+// ErrUserAlreadyErased is returned when erasing a user whose personal data has already been erased
+var ErrUserAlreadyErased = errors.New("user personal data has already been erased")
+
+// PersonalDataRecords holds the rows that refer to a user, either as the subject or as the actor
+type PersonalDataRecords struct {
+	Memberships            []model.UserOrganization        // With organization and role
+	OrganizationHistory    []model.UserOrganizationHistory // About the user or performed by them
+	StatusHistory          []model.UserStatusHistory       // About the user or performed by them
+	OrganizationAuditLogs  []model.OrganizationAuditLog    // Performed by the user
+	ChangeRequests         []model.ChangeRequest           // Requested by the user
+	ChangeRequestApprovals []model.ChangeRequestApproval   // Decided by the user
+	RoleApprovals          []model.RoleApproval            // Requested or decided by the user
+	JoinRequests           []model.OrganizationJoinRequest // Made or decided by the user
+	Invitations            []model.OrganizationInvitation  // Addressed to or sent by the user
+	Jobs                   []model.Job                     // Submitted by the user
+}
+
+// PersonalDataRepositoryInterface defines the data operations behind personal data exports and erasure
+type PersonalDataRepositoryInterface interface {
+	// FindUserIncludingDeleted finds a user with their role, including soft-deleted users.
+	FindUserIncludingDeleted(ctx context.Context, id uuid.UUID) (*model.User, error)
+	// FindRecords collects every row that refers to the user.
+	FindRecords(ctx context.Context, user *model.User) (*PersonalDataRecords, error)
+	// Erase overwrites the users row with the anonymised fields of user and soft-deletes it, removes the
+	// memberships with their history entries, pending join requests and pending invitations addressed to
+	// the user or originalEmail, and records erasure, all in a single transaction. It returns ErrUserAlreadyErased for erased users.
+	Erase(ctx context.Context, user *model.User, originalEmail string, erasure *model.UserErasure) error
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type roleRepository struct {
@@ -16,7 +17,7 @@ type roleRepository struct {
 
 // Create implements RoleRepository.
 func (r *roleRepository) Create(ctx context.Context, role *model.Role) (*model.Role, error) {
-	if err := r.db.WithContext(ctx).Create(role).Error; err != nil {
+	if err := dbFromContext(ctx, r.db).Create(role).Error; err != nil {
 		return nil, err
 	}
 	return role, nil
@@ -25,7 +26,7 @@ func (r *roleRepository) Create(ctx context.Context, role *model.Role) (*model.R
 // FindAll returns all roles with their permissions.
 func (r *roleRepository) FindAll(ctx context.Context) ([]model.Role, error) {
 	var roles []model.Role
-	if err := r.db.WithContext(ctx).Preload("Permissions").Find(&roles).Error; err != nil {
+	if err := dbFromContext(ctx, r.db).Preload("Permissions").Find(&roles).Error; err != nil {
 		return nil, err
 	}
 	return roles, nil
@@ -34,7 +35,7 @@ func (r *roleRepository) FindAll(ctx context.Context) ([]model.Role, error) {
 // FindAllActive returns all active roles with their permissions.
 func (r *roleRepository) FindAllActive(ctx context.Context) ([]model.Role, error) {
 	var roles []model.Role
-	if err := r.db.WithContext(ctx).Preload("Permissions").Where("is_active = true").Find(&roles).Error; err != nil {
+	if err := dbFromContext(ctx, r.db).Preload("Permissions").Where("is_active = true").Find(&roles).Error; err != nil {
 		return nil, err
 	}
 	return roles, nil
@@ -43,7 +44,7 @@ func (r *roleRepository) FindAllActive(ctx context.Context) ([]model.Role, error
 // FindSystemRoles returns all system roles with their permissions.
 func (r *roleRepository) FindSystemRoles(ctx context.Context) ([]model.Role, error) {
 	var roles []model.Role
-	if err := r.db.WithContext(ctx).Preload("Permissions").Where("is_system_role = true").Find(&roles).Error; err != nil {
+	if err := dbFromContext(ctx, r.db).Preload("Permissions").Where("is_system_role = true").Find(&roles).Error; err != nil {
 		return nil, err
 	}
 	return roles, nil
@@ -56,7 +57,7 @@ func NewRoleRepository(db *gorm.DB) RoleRepositoryInterface {
 // FindByName finds a role by name (renamed from FindRoleByName for consistency).
 func (r *roleRepository) FindByName(ctx context.Context, name string) (*model.Role, error) {
 	var role model.Role
-	if err := r.db.WithContext(ctx).Where("name = ?", name).First(&role).Error; err != nil {
+	if err := dbFromContext(ctx, r.db).Where("name = ?", name).First(&role).Error; err != nil {
 		return nil, err
 	}
 	return &role, nil
@@ -65,7 +66,7 @@ func (r *roleRepository) FindByName(ctx context.Context, name string) (*model.Ro
 // FindByNameWithPermissions finds a role by name with permissions preloaded.
 func (r *roleRepository) FindByNameWithPermissions(ctx context.Context, name string) (*model.Role, error) {
 	var role model.Role
-	if err := r.db.WithContext(ctx).Preload("Permissions").Where("name = ?", name).First(&role).Error; err != nil {
+	if err := dbFromContext(ctx, r.db).Preload("Permissions").Where("name = ?", name).First(&role).Error; err != nil {
 		return nil, err
 	}
 	return &role, nil
@@ -73,7 +74,7 @@ func (r *roleRepository) FindByNameWithPermissions(ctx context.Context, name str
 
 func (r *roleRepository) FindByID(ctx context.Context, id uuid.UUID) (*model.Role, error) {
 	var role model.Role
-	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&role).Error; err != nil {
+	if err := dbFromContext(ctx, r.db).Where("id = ?", id).First(&role).Error; err != nil {
 		return nil, err
 	}
 	return &role, nil
@@ -82,7 +83,7 @@ func (r *roleRepository) FindByID(ctx context.Context, id uuid.UUID) (*model.Rol
 // FindByIDWithPermissions finds a role by ID with permissions preloaded.
 func (r *roleRepository) FindByIDWithPermissions(ctx context.Context, id uuid.UUID) (*model.Role, error) {
 	var role model.Role
-	if err := r.db.WithContext(ctx).Preload("Permissions").Where("id = ?", id).First(&role).Error; err != nil {
+	if err := dbFromContext(ctx, r.db).Preload("Permissions").Where("id = ?", id).First(&role).Error; err != nil {
 		return nil, err
 	}
 	return &role, nil
@@ -91,7 +92,7 @@ func (r *roleRepository) FindByIDWithPermissions(ctx context.Context, id uuid.UU
 // List returns paginated roles with search functionality.
 func (r *roleRepository) List(ctx context.Context, offset, limit int, search string) ([]model.Role, error) {
 	var roles []model.Role
-	query := r.db.WithContext(ctx).Preload("Permissions")
+	query := dbFromContext(ctx, r.db).Preload("Permissions")
 
 	if search != "" {
 		searchPattern := "%" + search + "%"
@@ -105,7 +106,7 @@ func (r *roleRepository) List(ctx context.Context, offset, limit int, search str
 // Count returns the total count of roles with search filter.
 func (r *roleRepository) Count(ctx context.Context, search string) (int64, error) {
 	var count int64
-	query := r.db.WithContext(ctx).Model(&model.Role{})
+	query := dbFromContext(ctx, r.db).Model(&model.Role{})
 
 	if search != "" {
 		searchPattern := "%" + search + "%"
@@ -118,7 +119,7 @@ func (r *roleRepository) Count(ctx context.Context, search string) (int64, error
 
 // Update updates an existing role.
 func (r *roleRepository) Update(ctx context.Context, role *model.Role) (*model.Role, error) {
-	if err := r.db.WithContext(ctx).Save(role).Error; err != nil {
+	if err := dbFromContext(ctx, r.db).Save(role).Error; err != nil {
 		return nil, err
 	}
 	return role, nil
@@ -126,12 +127,12 @@ func (r *roleRepository) Update(ctx context.Context, role *model.Role) (*model.R
 
 // Delete soft deletes a role by ID.
 func (r *roleRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Delete(&model.Role{}, id).Error
+	return dbFromContext(ctx, r.db).Delete(&model.Role{}, id).Error
 }
 
 func (r *roleRepository) FindPermissionsByRoleID(ctx context.Context, roleID uuid.UUID) ([]string, error) {
 	var permissions []string
-	err := r.db.WithContext(ctx).Table("permissions").
+	err := dbFromContext(ctx, r.db).Table("permissions").
 		Select("permissions.name").
 		Joins("join role_permissions on role_permissions.permission_id = permissions.id").
 		Where("role_permissions.role_id = ?", roleID).
@@ -140,7 +141,7 @@ func (r *roleRepository) FindPermissionsByRoleID(ctx context.Context, roleID uui
 }
 
 func (r *roleRepository) UpdateRolePermissions(ctx context.Context, roleID uuid.UUID, permissionNames []string) error {
-	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+	return dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
 		var role model.Role
 		if err := tx.First(&role, roleID).Error; err != nil {
 			if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -168,7 +169,7 @@ func (r *roleRepository) UpdateRolePermissions(ctx context.Context, roleID uuid.
 
 // CreateRoleApproval creates a new role approval request.
 func (r *roleRepository) CreateRoleApproval(ctx context.Context, approval *model.RoleApproval) (*model.RoleApproval, error) {
-	if err := r.db.WithContext(ctx).Create(approval).Error; err != nil {
+	if err := dbFromContext(ctx, r.db).Create(approval).Error; err != nil {
 		return nil, err
 	}
 	return approval, nil
@@ -177,10 +178,11 @@ func (r *roleRepository) CreateRoleApproval(ctx context.Context, approval *model
 // FindAllRoleApprovals returns all role approval requests with user information.
 func (r *roleRepository) FindAllRoleApprovals(ctx context.Context) ([]model.RoleApproval, error) {
 	var approvals []model.RoleApproval
-	if err := r.db.WithContext(ctx).
+	if err := dbFromContext(ctx, r.db).
 		Preload("RequestedByUser").
 		Preload("Approver").
 		Preload("Organization").
+		Order("created_at DESC").
 		Find(&approvals).Error; err != nil {
 		return nil, err
 	}
@@ -190,11 +192,12 @@ func (r *roleRepository) FindAllRoleApprovals(ctx context.Context) ([]model.Role
 // FindRoleApprovalsByStatus finds role approvals by status.
 func (r *roleRepository) FindRoleApprovalsByStatus(ctx context.Context, status string) ([]model.RoleApproval, error) {
 	var approvals []model.RoleApproval
-	if err := r.db.WithContext(ctx).
+	if err := dbFromContext(ctx, r.db).
 		Preload("RequestedByUser").
 		Preload("Approver").
 		Preload("Organization").
 		Where("status = ?", status).
+		Order("created_at DESC").
 		Find(&approvals).Error; err != nil {
 		return nil, err
 	}
@@ -204,11 +207,12 @@ func (r *roleRepository) FindRoleApprovalsByStatus(ctx context.Context, status s
 // FindRoleApprovalsByRequester finds role approvals by requester ID.
 func (r *roleRepository) FindRoleApprovalsByRequester(ctx context.Context, requesterID uuid.UUID) ([]model.RoleApproval, error) {
 	var approvals []model.RoleApproval
-	if err := r.db.WithContext(ctx).
+	if err := dbFromContext(ctx, r.db).
 		Preload("RequestedByUser").
 		Preload("Approver").
 		Preload("Organization").
 		Where("requested_by = ?", requesterID).
+		Order("created_at DESC").
 		Find(&approvals).Error; err != nil {
 		return nil, err
 	}
@@ -218,7 +222,7 @@ func (r *roleRepository) FindRoleApprovalsByRequester(ctx context.Context, reque
 // FindRoleApprovalByID finds a role approval request by ID.
 func (r *roleRepository) FindRoleApprovalByID(ctx context.Context, id uuid.UUID) (*model.RoleApproval, error) {
 	var approval model.RoleApproval
-	if err := r.db.WithContext(ctx).
+	if err := dbFromContext(ctx, r.db).
 		Preload("RequestedByUser").
 		Preload("Approver").
 		Preload("Organization").
@@ -229,9 +233,26 @@ func (r *roleRepository) FindRoleApprovalByID(ctx context.Context, id uuid.UUID)
 	return &approval, nil
 }
 
+// FindRoleApprovalByIDForUpdate finds a role approval request and locks its row until the surrounding
+// transaction ends, so concurrent decisions on the same request run one after another.
+func (r *roleRepository) FindRoleApprovalByIDForUpdate(ctx context.Context, id uuid.UUID) (*model.RoleApproval, error) {
+	var approval model.RoleApproval
+	if err := dbFromContext(ctx, r.db).
+		Preload("RequestedByUser").
+		Preload("Approver").
+		Preload("Organization").
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", id).
+		First(&approval).Error; err != nil {
+		return nil, err
+	}
+	return &approval, nil
+}
+
 // UpdateRoleApproval updates an existing role approval request.
+// Preloaded associations are not written back, only the approval row itself.
 func (r *roleRepository) UpdateRoleApproval(ctx context.Context, approval *model.RoleApproval) (*model.RoleApproval, error) {
-	if err := r.db.WithContext(ctx).Save(approval).Error; err != nil {
+	if err := dbFromContext(ctx, r.db).Omit(clause.Associations).Save(approval).Error; err != nil {
 		return nil, err
 	}
 	return approval, nil
@@ -242,7 +263,7 @@ func (r *roleRepository) UpdateRoleApproval(ctx context.Context, approval *model
 // GetAllPermissions returns all permissions from the database.
 func (r *roleRepository) GetAllPermissions(ctx context.Context) ([]model.Permission, error) {
 	var permissions []model.Permission
-	if err := r.db.WithContext(ctx).Order("name ASC").Find(&permissions).Error; err != nil {
+	if err := dbFromContext(ctx, r.db).Order("name ASC").Find(&permissions).Error; err != nil {
 		return nil, err
 	}
 	return permissions, nil
@@ -250,7 +271,7 @@ func (r *roleRepository) GetAllPermissions(ctx context.Context) ([]model.Permiss
 
 // CreatePermission creates a new permission in the database.
 func (r *roleRepository) CreatePermission(ctx context.Context, permission *model.Permission) error {
-	if err := r.db.WithContext(ctx).Create(permission).Error; err != nil {
+	if err := dbFromContext(ctx, r.db).Create(permission).Error; err != nil {
 		return err
 	}
 	return nil
@@ -259,7 +280,7 @@ func (r *roleRepository) CreatePermission(ctx context.Context, permission *model
 // FindPermissionByName finds a permission by its name.
 func (r *roleRepository) FindPermissionByName(ctx context.Context, name string) (*model.Permission, error) {
 	var permission model.Permission
-	if err := r.db.WithContext(ctx).Where("name = ?", name).First(&permission).Error; err != nil {
+	if err := dbFromContext(ctx, r.db).Where("name = ?", name).First(&permission).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil // Return nil for not found instead of error
 		}
@@ -271,7 +292,7 @@ func (r *roleRepository) FindPermissionByName(ctx context.Context, name string)
 // FindPermissionByID finds a permission by its ID.
 func (r *roleRepository) FindPermissionByID(ctx context.Context, id uuid.UUID) (*model.Permission, error) {
 	var permission model.Permission
-	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&permission).Error; err != nil {
+	if err := dbFromContext(ctx, r.db).Where("id = ?", id).First(&permission).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil // Return nil for not found instead of error
 		}
@@ -282,7 +303,7 @@ func (r *roleRepository) FindPermissionByID(ctx context.Context, id uuid.UUID) (
 
 // UpdatePermission updates an existing permission.
 func (r *roleRepository) UpdatePermission(ctx context.Context, permission *model.Permission) error {
-	if err := r.db.WithContext(ctx).Save(permission).Error; err != nil {
+	if err := dbFromContext(ctx, r.db).Save(permission).Error; err != nil {
 		return err
 	}
 	return nil
@@ -292,7 +313,7 @@ func (r *roleRepository) UpdatePermission(ctx context.Context, permission *model
 func (r *roleRepository) DeletePermission(ctx context.Context, id uuid.UUID) error {
 	// First check if permission is being used by any roles
 	var count int64
-	if err := r.db.WithContext(ctx).Table("role_permissions").
+	if err := dbFromContext(ctx, r.db).Table("role_permissions").
 		Joins("JOIN permissions ON role_permissions.permission_id = permissions.id").
 		Where("permissions.id = ?", id).
 		Count(&count).Error; err != nil {
@@ -304,7 +325,7 @@ func (r *roleRepository) DeletePermission(ctx context.Context, id uuid.UUID) err
 	}
 
 	// Soft delete the permission
-	if err := r.db.WithContext(ctx).Delete(&model.Permission{}, id).Error; err != nil {
+	if err := dbFromContext(ctx, r.db).Delete(&model.Permission{}, id).Error; err != nil {
 		return err
 	}
 	return nil
@@ -313,7 +334,7 @@ func (r *roleRepository) DeletePermission(ctx context.Context, id uuid.UUID) err
 // CheckPermissionExists checks if a permission with the given name exists.
 func (r *roleRepository) CheckPermissionExists(ctx context.Context, name string) (bool, error) {
 	var count int64
-	if err := r.db.WithContext(ctx).Model(&model.Permission{}).Where("name = ?", name).Count(&count).Error; err != nil {
+	if err := dbFromContext(ctx, r.db).Model(&model.Permission{}).Where("name = ?", name).Count(&count).Error; err != nil {
 		return false, err
 	}
 	return count > 0, nil
@@ -325,7 +346,7 @@ func (r *roleRepository) CheckPermissionExists(ctx context.Context, name string)
 func (r *roleRepository) FindRolesByOrganizationType(ctx context.Context, organizationType string) ([]model.Role, error) {
 	var roles []model.Role
 
-	query := r.db.WithContext(ctx).
+	query := dbFromContext(ctx, r.db).
 		Preload("Permissions").
 		Preload("OrganizationTypes").
 		Joins("INNER JOIN role_organization_types rot ON roles.id = rot.role_id").
@@ -353,7 +374,7 @@ func (r *roleRepository) CreateRoleOrganizationTypes(ctx context.Context, roleID
 		})
 	}
 
-	if err := r.db.WithContext(ctx).Create(&roleOrgTypes).Error; err != nil {
+	if err := dbFromContext(ctx, r.db).Create(&roleOrgTypes).Error; err != nil {
 		return fmt.Errorf("failed to create role organization types: %w", err)
 	}
 
@@ -362,42 +383,34 @@ func (r *roleRepository) CreateRoleOrganizationTypes(ctx context.Context, roleID
 
 // UpdateRoleOrganizationTypes updates organization type mappings for a role.
 func (r *roleRepository) UpdateRoleOrganizationTypes(ctx context.Context, roleID uuid.UUID, organizationTypes []string) error {
-	// Start transaction
-	tx := r.db.WithContext(ctx).Begin()
-	defer func() {
-		if r := recover(); r != nil {
-			tx.Rollback()
-		}
-	}()
-
-	// Delete existing mappings
-	if err := tx.Where("role_id = ?", roleID).Delete(&model.RoleOrganizationType{}).Error; err != nil {
-		tx.Rollback()
-		return fmt.Errorf("failed to delete existing role organization types: %w", err)
-	}
-
-	// Create new mappings if any
-	if len(organizationTypes) > 0 {
-		var roleOrgTypes []model.RoleOrganizationType
-		for _, orgType := range organizationTypes {
-			roleOrgTypes = append(roleOrgTypes, model.RoleOrganizationType{
-				RoleID:           roleID,
-				OrganizationType: orgType,
-			})
+	return dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		// Delete existing mappings
+		if err := tx.Where("role_id = ?", roleID).Delete(&model.RoleOrganizationType{}).Error; err != nil {
+			return fmt.Errorf("failed to delete existing role organization types: %w", err)
 		}
 
-		if err := tx.Create(&roleOrgTypes).Error; err != nil {
-			tx.Rollback()
-			return fmt.Errorf("failed to create new role organization types: %w", err)
+		// Create new mappings if any
+		if len(organizationTypes) > 0 {
+			var roleOrgTypes []model.RoleOrganizationType
+			for _, orgType := range organizationTypes {
+				roleOrgTypes = append(roleOrgTypes, model.RoleOrganizationType{
+					RoleID:           roleID,
+					OrganizationType: orgType,
+				})
+			}
+
+			if err := tx.Create(&roleOrgTypes).Error; err != nil {
+				return fmt.Errorf("failed to create new role organization types: %w", err)
+			}
 		}
-	}
 
-	return tx.Commit().Error
+		return nil
+	})
 }
 
 // DeleteRoleOrganizationTypes deletes all organization type mappings for a role.
 func (r *roleRepository) DeleteRoleOrganizationTypes(ctx context.Context, roleID uuid.UUID) error {
-	if err := r.db.WithContext(ctx).Where("role_id = ?", roleID).Delete(&model.RoleOrganizationType{}).Error; err != nil {
+	if err := dbFromContext(ctx, r.db).Where("role_id = ?", roleID).Delete(&model.RoleOrganizationType{}).Error; err != nil {
 		return fmt.Errorf("failed to delete role organization types: %w", err)
 	}
 	return nil
@@ -407,7 +420,7 @@ func (r *roleRepository) DeleteRoleOrganizationTypes(ctx context.Context, roleID
 func (r *roleRepository) FindOrganizationTypesByRoleID(ctx context.Context, roleID uuid.UUID) ([]string, error) {
 	var roleOrgTypes []model.RoleOrganizationType
 
-	if err := r.db.WithContext(ctx).Where("role_id = ?", roleID).Find(&roleOrgTypes).Error; err != nil {
+	if err := dbFromContext(ctx, r.db).Where("role_id = ?", roleID).Find(&roleOrgTypes).Error; err != nil {
 		return nil, fmt.Errorf("failed to find organization types for role %s: %w", roleID, err)
 	}
 
@@ -424,7 +437,7 @@ func (r *roleRepository) FindOrganizationTypesByRoleID(ctx context.Context, role
 // or by having level >= 100 (platform level).
 func (r *roleRepository) IsRoleSuperAdmin(ctx context.Context, roleID uuid.UUID) (bool, error) {
 	var role model.Role
-	err := r.db.WithContext(ctx).
+	err := dbFromContext(ctx, r.db).
 		Select("name, predefined_name, level").
 		Where("id = ?", roleID).
 		First(&role).Error
@@ -444,3 +457,38 @@ func (r *roleRepository) IsRoleSuperAdmin(ctx context.Context, roleID uuid.UUID)
 		role.PredefinedName == "super_admin" ||
 		role.Level >= 100, nil
 }
+
+// FindDefaultRolesByOrganizationType returns the default role configuration of an organization type.
+func (r *roleRepository) FindDefaultRolesByOrganizationType(ctx context.Context, organizationType string) (*model.OrganizationTypeDefaultRole, error) {
+	var defaults model.OrganizationTypeDefaultRole
+	if err := dbFromContext(ctx, r.db).
+		Where("organization_type = ?", organizationType).
+		First(&defaults).Error; err != nil {
+		return nil, err
+	}
+	return &defaults, nil
+}
+
+// FindAllDefaultRoles returns the default role configuration of every organization type.
+func (r *roleRepository) FindAllDefaultRoles(ctx context.Context) ([]model.OrganizationTypeDefaultRole, error) {
+	var defaults []model.OrganizationTypeDefaultRole
+	if err := dbFromContext(ctx, r.db).
+		Preload("CreatorRole").
+		Preload("MemberRole").
+		Order("organization_type ASC").
+		Find(&defaults).Error; err != nil {
+		return nil, fmt.Errorf("failed to find default roles: %w", err)
+	}
+	return defaults, nil
+}
+
+// UpsertDefaultRoles creates or replaces the default role configuration of an organization type.
+func (r *roleRepository) UpsertDefaultRoles(ctx context.Context, defaults *model.OrganizationTypeDefaultRole) error {
+	return dbFromContext(ctx, r.db).
+		Omit(clause.Associations).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "organization_type"}},
+			DoUpdates: clause.AssignmentColumns([]string{"creator_role_id", "member_role_id", "updated_by", "updated_at"}),
+		}).
+		Create(defaults).Error
+}
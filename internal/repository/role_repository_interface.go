@@ -41,6 +41,8 @@ type RoleRepositoryInterface interface {
 	FindRoleApprovalsByStatus(ctx context.Context, status string) ([]model.RoleApproval, error)
 	FindRoleApprovalsByRequester(ctx context.Context, requesterID uuid.UUID) ([]model.RoleApproval, error)
 	FindRoleApprovalByID(ctx context.Context, id uuid.UUID) (*model.RoleApproval, error)
+	// FindRoleApprovalByIDForUpdate finds a role approval request and locks its row until the surrounding transaction ends.
+	FindRoleApprovalByIDForUpdate(ctx context.Context, id uuid.UUID) (*model.RoleApproval, error)
 	UpdateRoleApproval(ctx context.Context, approval *model.RoleApproval) (*model.RoleApproval, error)
 
 	// Organization-specific role methods
@@ -50,6 +52,11 @@ type RoleRepositoryInterface interface {
 	DeleteRoleOrganizationTypes(ctx context.Context, roleID uuid.UUID) error
 	FindOrganizationTypesByRoleID(ctx context.Context, roleID uuid.UUID) ([]string, error)
 
+	// Default roles per organization type
+	FindDefaultRolesByOrganizationType(ctx context.Context, organizationType string) (*model.OrganizationTypeDefaultRole, error)
+	FindAllDefaultRoles(ctx context.Context) ([]model.OrganizationTypeDefaultRole, error)
+	UpsertDefaultRoles(ctx context.Context, defaults *model.OrganizationTypeDefaultRole) error
+
 	// Super admin check
 	IsRoleSuperAdmin(ctx context.Context, roleID uuid.UUID) (bool, error)
 }
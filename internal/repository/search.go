@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// likeEscaper escapes the LIKE wildcards of a search term; PostgreSQL uses backslash as the default escape character.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// containsPattern returns an ILIKE pattern matching values that contain term literally.
+func containsPattern(term string) string {
+	return "%" + likeEscaper.Replace(term) + "%"
+}
+
+// searchScore is the ID and relevance of a search match, scanned before the matching rows are loaded.
+type searchScore struct {
+	ID    uuid.UUID
+	Score float64
+}
+
+// scoredIDs returns the IDs of scores in rank order.
+func scoredIDs(scores []searchScore) []uuid.UUID {
+	ids := make([]uuid.UUID, len(scores))
+	for i, score := range scores {
+		ids[i] = score.ID
+	}
+	return ids
+}
@@ -0,0 +1,26 @@
+package repository
+
+import "testing"
+
+func TestContainsPattern(t *testing.T) {
+	tests := []struct {
+		name string
+		term string
+		want string
+	}{
+		{name: "plain term", term: "jane", want: "%jane%"},
+		{name: "empty term matches everything", term: "", want: "%%"},
+		{name: "escapes percent", term: "50%", want: `%50\%%`},
+		{name: "escapes underscore", term: "jane_doe", want: `%jane\_doe%`},
+		{name: "escapes backslash before wildcards", term: `a\_b`, want: `%a\\\_b%`},
+		{name: "keeps other characters", term: "o'neil.*", want: "%o'neil.*%"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := containsPattern(tt.term); got != tt.want {
+				t.Errorf("containsPattern(%q) = %q, want %q", tt.term, got, tt.want)
+			}
+		})
+	}
+}
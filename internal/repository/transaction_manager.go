@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// txContextKey is the context key under which the running transaction is stored
+type txContextKey struct{}
+
+// afterCommitContextKey is the context key under which the hooks of the running transaction are stored
+type afterCommitContextKey struct{}
+
+// afterCommitHooks collects the functions to run once the outermost transaction has committed
+type afterCommitHooks struct {
+	fns []func(ctx context.Context)
+}
+
+type transactionManager struct {
+	db *gorm.DB
+}
+
+// NewTransactionManager creates a new transaction manager instance
+func NewTransactionManager(db *gorm.DB) TransactionManagerInterface {
+	return &transactionManager{db: db}
+}
+
+// RunInTx runs fn in a transaction, joining the one already carried by ctx if there is one
+func (m *transactionManager) RunInTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txContextKey{}).(*gorm.DB); ok {
+		return fn(ctx)
+	}
+
+	hooks := &afterCommitHooks{}
+	err := m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		txCtx := context.WithValue(ctx, txContextKey{}, tx)
+		return fn(context.WithValue(txCtx, afterCommitContextKey{}, hooks))
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, hook := range hooks.fns {
+		hook(ctx)
+	}
+	return nil
+}
+
+// AfterCommit defers fn until the transaction carried by ctx has committed, or runs it right away outside of one
+func (m *transactionManager) AfterCommit(ctx context.Context, fn func(ctx context.Context)) {
+	if hooks, ok := ctx.Value(afterCommitContextKey{}).(*afterCommitHooks); ok {
+		hooks.fns = append(hooks.fns, fn)
+		return
+	}
+	fn(ctx)
+}
+
+// dbFromContext returns the transaction carried by ctx, or db outside of RunInTx, bound to ctx.
+// Repositories use it for every query so their writes take part in a running unit of work;
+// transactions they open themselves become savepoints of it.
+func dbFromContext(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(txContextKey{}).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+	return db.WithContext(ctx)
+}
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+)
+
+// TransactionManagerInterface runs work spanning several repositories in a single database transaction.
+// Repositories pick up the transaction from the context passed to fn, so services never handle *gorm.DB.
+type TransactionManagerInterface interface {
+	// RunInTx commits when fn returns nil and rolls back otherwise. Calls nested inside fn join the
+	// outer transaction instead of starting a new one.
+	RunInTx(ctx context.Context, fn func(ctx context.Context) error) error
+
+	// AfterCommit defers fn until the transaction carried by ctx commits and drops it on rollback.
+	// Side effects outside the database, such as deleting files or revoking sessions, go here so a
+	// rolled back transaction leaves them undone. Outside of a transaction fn runs immediately.
+	AfterCommit(ctx context.Context, fn func(ctx context.Context))
+}
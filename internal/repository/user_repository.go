@@ -1,11 +1,16 @@
 package repository
 
 import (
+	"go-base-project/internal/constant"
 	"go-base-project/internal/model"
 	"context"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type userRepository struct {
@@ -19,17 +24,82 @@ func NewUserRepository(db *gorm.DB) UserRepositoryInterface {
 
 // Create implements UserRepository.
 func (r *userRepository) Create(ctx context.Context, user *model.User) error {
-	return r.db.WithContext(ctx).Create(user).Error
+	return dbFromContext(ctx, r.db).Create(user).Error
+}
+
+// CreateWithUniqueUsername implements UserRepository.
+func (r *userRepository) CreateWithUniqueUsername(ctx context.Context, user *model.User) error {
+	result := dbFromContext(ctx, r.db).
+		Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "username"}}, DoNothing: true}).
+		Create(user)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrUsernameTaken
+	}
+	return nil
+}
+
+// FindTakenUsernames implements UserRepository.
+func (r *userRepository) FindTakenUsernames(ctx context.Context, usernames []string) (map[string]bool, error) {
+	lowered := make([]string, len(usernames))
+	for i, username := range usernames {
+		lowered[i] = strings.ToLower(username)
+	}
+
+	var taken []string
+	err := dbFromContext(ctx, r.db).
+		Unscoped().
+		Model(&model.User{}).
+		Where("LOWER(username) IN ?", lowered).
+		Pluck("LOWER(username)", &taken).Error
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]bool, len(taken))
+	for _, username := range taken {
+		result[username] = true
+	}
+	return result, nil
 }
 
 // Update implements UserRepository.
 func (r *userRepository) Update(ctx context.Context, user *model.User) error {
-	return r.db.WithContext(ctx).Save(user).Error
+	return dbFromContext(ctx, r.db).Save(user).Error
+}
+
+// UpdateProfile implements UserRepository.
+func (r *userRepository) UpdateProfile(ctx context.Context, user *model.User) error {
+	return dbFromContext(ctx, r.db).
+		Model(user).
+		Select("display_name", "phone", "locale", "timezone", "job_title", "updated_at").
+		Updates(map[string]interface{}{
+			"display_name": user.DisplayName,
+			"phone":        user.Phone,
+			"locale":       user.Locale,
+			"timezone":     user.Timezone,
+			"job_title":    user.JobTitle,
+			"updated_at":   gorm.Expr("NOW()"),
+		}).Error
+}
+
+// UpdateAvatar implements UserRepository.
+func (r *userRepository) UpdateAvatar(ctx context.Context, userID uuid.UUID, avatarKey, avatarURL string) error {
+	return dbFromContext(ctx, r.db).
+		Model(&model.User{}).
+		Where("id = ?", userID).
+		Updates(map[string]interface{}{
+			"avatar_key": avatarKey,
+			"avatar_url": avatarURL,
+			"updated_at": gorm.Expr("NOW()"),
+		}).Error
 }
 
 func (r *userRepository) FindByEmail(ctx context.Context, email string) (*model.User, error) {
 	var user model.User
-	if err := r.db.WithContext(ctx).Where("email = ?", email).First(&user).Error; err != nil {
+	if err := dbFromContext(ctx, r.db).Where("email = ?", email).First(&user).Error; err != nil {
 		return nil, err
 	}
 	return &user, nil
@@ -37,7 +107,7 @@ func (r *userRepository) FindByEmail(ctx context.Context, email string) (*model.
 
 func (r *userRepository) FindByGoogleID(ctx context.Context, googleID string) (*model.User, error) {
 	var user model.User
-	if err := r.db.WithContext(ctx).Where("google_id = ?", googleID).First(&user).Error; err != nil {
+	if err := dbFromContext(ctx, r.db).Where("google_id = ?", googleID).First(&user).Error; err != nil {
 		return nil, err
 	}
 	return &user, nil
@@ -46,27 +116,27 @@ func (r *userRepository) FindByGoogleID(ctx context.Context, googleID string) (*
 // FindByUsernameWithRole mencari pengguna berdasarkan username dan memuat relasi Role.
 func (r *userRepository) FindByUsernameWithRole(ctx context.Context, username string) (*model.User, error) {
 	var user model.User
-	err := r.db.WithContext(ctx).Preload("Role").Where("username = ?", username).First(&user).Error
+	err := dbFromContext(ctx, r.db).Preload("Role").Where("username = ?", username).First(&user).Error
 	return &user, err
 }
 
 func (r *userRepository) FindByUsername(ctx context.Context, username string) (*model.User, error) {
 	var user model.User
-	err := r.db.WithContext(ctx).Where("username = ?", username).First(&user).Error
+	err := dbFromContext(ctx, r.db).Where("username = ?", username).First(&user).Error
 	return &user, err
 }
 
 // FindByIDWithRole mencari pengguna berdasarkan ID dan memuat relasi Role.
 func (r *userRepository) FindByIDWithRole(ctx context.Context, id uuid.UUID) (*model.User, error) {
 	var user model.User
-	err := r.db.WithContext(ctx).Preload("Role").Where("id = ?", id).First(&user).Error
+	err := dbFromContext(ctx, r.db).Preload("Role").Where("id = ?", id).First(&user).Error
 	return &user, err
 }
 
 // FindByIDWithRoleAndOrganizations mencari pengguna berdasarkan ID dan memuat relasi Role dan Organizations.
 func (r *userRepository) FindByIDWithRoleAndOrganizations(ctx context.Context, id uuid.UUID) (*model.User, error) {
 	var user model.User
-	err := r.db.WithContext(ctx).
+	err := dbFromContext(ctx, r.db).
 		Preload("Role").
 		Preload("Organizations").
 		Where("id = ?", id).
@@ -76,14 +146,14 @@ func (r *userRepository) FindByIDWithRoleAndOrganizations(ctx context.Context, i
 
 func (r *userRepository) FindByID(ctx context.Context, id uuid.UUID) (*model.User, error) {
 	var user model.User
-	err := r.db.WithContext(ctx).Where("id = ?", id).First(&user).Error
+	err := dbFromContext(ctx, r.db).Where("id = ?", id).First(&user).Error
 	return &user, err
 }
 
 // FindByOrganizationID mencari pengguna berdasarkan organization ID dengan paginasi.
 func (r *userRepository) FindByOrganizationID(ctx context.Context, organizationID uuid.UUID, offset, limit int) ([]model.User, error) {
 	var users []model.User
-	err := r.db.WithContext(ctx).
+	err := dbFromContext(ctx, r.db).
 		Preload("Role").
 		Joins("JOIN user_organizations ON users.id = user_organizations.user_id").
 		Where("user_organizations.organization_id = ? AND user_organizations.is_active = true", organizationID).
@@ -96,7 +166,7 @@ func (r *userRepository) FindByOrganizationID(ctx context.Context, organizationI
 // List mengambil daftar pengguna dengan paginasi.
 func (r *userRepository) List(ctx context.Context, offset, limit int, search string) ([]model.User, error) {
 	var users []model.User
-	query := r.db.WithContext(ctx).Preload("Role")
+	query := dbFromContext(ctx, r.db).Preload("Role")
 	if search != "" {
 		searchPattern := "%" + search + "%"
 		query = query.Where("username ILIKE ? OR email ILIKE ?", searchPattern, searchPattern)
@@ -108,7 +178,7 @@ func (r *userRepository) List(ctx context.Context, offset, limit int, search str
 // Count menghitung total jumlah pengguna dengan filter pencarian.
 func (r *userRepository) Count(ctx context.Context, search string) (int64, error) {
 	var count int64
-	query := r.db.WithContext(ctx).Model(&model.User{})
+	query := dbFromContext(ctx, r.db).Model(&model.User{})
 	if search != "" {
 		searchPattern := "%" + search + "%"
 		query = query.Where("username ILIKE ? OR email ILIKE ?", searchPattern, searchPattern)
@@ -119,13 +189,13 @@ func (r *userRepository) Count(ctx context.Context, search string) (int64, error
 
 // Delete menghapus pengguna berdasarkan ID.
 func (r *userRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Delete(&model.User{}, id).Error
+	return dbFromContext(ctx, r.db).Delete(&model.User{}, id).Error
 }
 
 // CountUsersByRoleLevel menghitung jumlah user dengan role level tertentu.
 func (r *userRepository) CountUsersByRoleLevel(ctx context.Context, level int) (int64, error) {
 	var count int64
-	err := r.db.WithContext(ctx).
+	err := dbFromContext(ctx, r.db).
 		Model(&model.User{}).
 		Joins("JOIN roles ON users.role_id = roles.id").
 		Where("roles.level = ?", level).
@@ -133,194 +203,198 @@ func (r *userRepository) CountUsersByRoleLevel(ctx context.Context, level int) (
 	return count, err
 }
 
-// User-Organization Management Implementation
-
-// CreateUserOrganization membuat user-organization relationship baru.
-func (r *userRepository) CreateUserOrganization(ctx context.Context, userOrg *model.UserOrganization) (*model.UserOrganization, error) {
-	err := r.db.WithContext(ctx).Create(userOrg).Error
+// userListColumns are the fields user listings can be sorted on.
+var userListColumns = listColumns[model.User]{
+	id: sortColumn[model.User]{expr: "users.id", cast: "uuid", value: func(u *model.User) string { return u.ID.String() }},
+	fields: map[string]sortColumn[model.User]{
+		"username": {expr: "users.username", cast: "text", value: func(u *model.User) string { return u.Username }},
+		"email":    {expr: "COALESCE(users.email, '')", cast: "text", value: func(u *model.User) string { return u.Email }},
+		"created_at": {expr: "users.created_at", cast: "timestamptz", value: func(u *model.User) string {
+			return u.CreatedAt.UTC().Format(time.RFC3339Nano)
+		}},
+		"updated_at": {expr: "users.updated_at", cast: "timestamptz", value: func(u *model.User) string {
+			return u.UpdatedAt.UTC().Format(time.RFC3339Nano)
+		}},
+		"level": {expr: "COALESCE(roles.level, 0)", cast: "integer", value: func(u *model.User) string {
+			if u.Role == nil {
+				return "0"
+			}
+			return strconv.Itoa(u.Role.Level)
+		}},
+	},
+}
+
+// ListWithFilters retrieves one page of the users matching the filter
+func (r *userRepository) ListWithFilters(ctx context.Context, filter UserListFilter, query ListQuery) (*ListPage[model.User], error) {
+	db, orders, err := applyListQuery(r.filterUsers(ctx, filter).Preload("Role"), query, userListColumns)
 	if err != nil {
 		return nil, err
 	}
-	return userOrg, nil
-}
 
-// FindUserOrganization mencari user-organization relationship spesifik.
-func (r *userRepository) FindUserOrganization(ctx context.Context, userID, organizationID uuid.UUID) (*model.UserOrganization, error) {
-	var userOrg model.UserOrganization
-	err := r.db.WithContext(ctx).
-		Preload("User").
-		Preload("Organization").
-		Where("user_id = ? AND organization_id = ?", userID, organizationID).
-		First(&userOrg).Error
-	return &userOrg, err
-}
-
-// FindUserOrganizationWithRole mencari user-organization relationship dengan role information.
-func (r *userRepository) FindUserOrganizationWithRole(ctx context.Context, userID, organizationID uuid.UUID) (*model.UserOrganization, error) {
-	var userOrg model.UserOrganization
-	err := r.db.WithContext(ctx).
-		Preload("User").
-		Preload("Organization").
-		Preload("Role").
-		Where("user_id = ? AND organization_id = ?", userID, organizationID).
-		First(&userOrg).Error
-	return &userOrg, err
-}
-
-// FindUserOrganizations mencari semua organization yang diikuti user dengan paginasi.
-func (r *userRepository) FindUserOrganizations(ctx context.Context, userID uuid.UUID, offset, limit int) ([]model.UserOrganization, error) {
-	var userOrgs []model.UserOrganization
-	err := r.db.WithContext(ctx).
-		Preload("User").
-		Preload("Organization").
-		Preload("Role").
-		Where("user_id = ? AND is_active = true", userID).
-		Offset(offset).
-		Limit(limit).
-		Find(&userOrgs).Error
-	return userOrgs, err
-}
-
-// FindOrganizationMembers mencari semua member dalam organization dengan paginasi.
-func (r *userRepository) FindOrganizationMembers(ctx context.Context, organizationID uuid.UUID, offset, limit int) ([]model.UserOrganization, error) {
-	var userOrgs []model.UserOrganization
-	err := r.db.WithContext(ctx).
-		Preload("User").
-		Preload("Organization").
-		Where("organization_id = ? AND is_active = true", organizationID).
-		Offset(offset).
-		Limit(limit).
-		Find(&userOrgs).Error
-	return userOrgs, err
-}
-
-// FindOrganizationMembersWithRoles mencari semua member dalam organization dengan role information.
-func (r *userRepository) FindOrganizationMembersWithRoles(ctx context.Context, organizationID uuid.UUID, offset, limit int) ([]model.UserOrganization, error) {
-	var userOrgs []model.UserOrganization
-	err := r.db.WithContext(ctx).
-		Preload("User").
-		Preload("Organization").
-		Preload("Role").
-		Where("organization_id = ? AND is_active = true", organizationID).
-		Offset(offset).
-		Limit(limit).
-		Find(&userOrgs).Error
-	return userOrgs, err
+	var users []model.User
+	if err := db.Find(&users).Error; err != nil {
+		return nil, err
+	}
+	return listPageFromRows(users, query.Limit, orders), nil
 }
 
-// CountUserOrganizations menghitung total organization yang diikuti user.
-func (r *userRepository) CountUserOrganizations(ctx context.Context, userID uuid.UUID) (int64, error) {
+// CountWithFilters counts the users matching the filter
+func (r *userRepository) CountWithFilters(ctx context.Context, filter UserListFilter) (int64, error) {
 	var count int64
-	err := r.db.WithContext(ctx).
-		Model(&model.UserOrganization{}).
-		Where("user_id = ? AND is_active = true", userID).
-		Count(&count).Error
+	err := r.filterUsers(ctx, filter).Count(&count).Error
 	return count, err
 }
 
-// CountOrganizationMembers menghitung total member dalam organization.
-func (r *userRepository) CountOrganizationMembers(ctx context.Context, organizationID uuid.UUID) (int64, error) {
-	var count int64
-	err := r.db.WithContext(ctx).
-		Model(&model.UserOrganization{}).
-		Where("organization_id = ? AND is_active = true", organizationID).
-		Count(&count).Error
-	return count, err
-}
+// Search ranks users by trigram similarity of their username or email to term, boosting exact and
+// substring matches. Fuzzy matches use the pg_trgm similarity threshold.
+func (r *userRepository) Search(ctx context.Context, filter UserListFilter, term string, limit int) ([]UserSearchHit, error) {
+	pattern := containsPattern(term)
 
-// UpdateUserOrganization memperbarui user-organization relationship.
-func (r *userRepository) UpdateUserOrganization(ctx context.Context, userOrg *model.UserOrganization) (*model.UserOrganization, error) {
-	err := r.db.WithContext(ctx).Save(userOrg).Error
-	if err != nil {
+	var scores []searchScore
+	err := r.filterUsers(ctx, filter).
+		Select(`users.id, GREATEST(similarity(users.username, ?), similarity(COALESCE(users.email, ''), ?)) +
+			CASE WHEN lower(users.username) = lower(?) OR lower(users.email) = lower(?) THEN 1
+				WHEN users.username ILIKE ? OR users.email ILIKE ? THEN 0.5
+				ELSE 0 END AS score`, term, term, term, term, pattern, pattern).
+		Where("(users.username ILIKE ? OR users.email ILIKE ? OR users.username % ? OR users.email % ?)", pattern, pattern, term, term).
+		Order("score DESC, users.username").
+		Limit(limit).
+		Scan(&scores).Error
+	if err != nil || len(scores) == 0 {
 		return nil, err
 	}
-	return userOrg, nil
-}
 
-// UpdateUserOrganizationRole memperbarui role user dalam organization tertentu.
-func (r *userRepository) UpdateUserOrganizationRole(ctx context.Context, userID, organizationID uuid.UUID, roleID *uuid.UUID) error {
-	return r.db.WithContext(ctx).
-		Model(&model.UserOrganization{}).
-		Where("user_id = ? AND organization_id = ?", userID, organizationID).
-		Update("role_id", roleID).Error
-}
+	var users []model.User
+	if err := dbFromContext(ctx, r.db).Preload("Role").Where("id IN ?", scoredIDs(scores)).Find(&users).Error; err != nil {
+		return nil, err
+	}
+	usersByID := make(map[uuid.UUID]model.User, len(users))
+	for _, user := range users {
+		usersByID[user.ID] = user
+	}
 
-// DeleteUserOrganization menghapus user-organization relationship.
-func (r *userRepository) DeleteUserOrganization(ctx context.Context, userID, organizationID uuid.UUID) error {
-	return r.db.WithContext(ctx).
-		Where("user_id = ? AND organization_id = ?", userID, organizationID).
-		Delete(&model.UserOrganization{}).Error
+	hits := make([]UserSearchHit, 0, len(scores))
+	for _, score := range scores {
+		if user, ok := usersByID[score.ID]; ok {
+			hits = append(hits, UserSearchHit{User: user, Score: score.Score})
+		}
+	}
+	return hits, nil
 }
 
-// BulkCreateUserOrganizations membuat multiple user-organization relationships sekaligus.
-func (r *userRepository) BulkCreateUserOrganizations(ctx context.Context, userOrgs []model.UserOrganization) ([]model.UserOrganization, []error) {
-	var created []model.UserOrganization
-	var errors []error
+// filterUsers builds the users query shared by ListWithFilters and CountWithFilters. Every user has at most
+// one global role, so joining roles never duplicates rows.
+func (r *userRepository) filterUsers(ctx context.Context, filter UserListFilter) *gorm.DB {
+	query := dbFromContext(ctx, r.db).
+		Model(&model.User{}).
+		Joins("LEFT JOIN roles ON users.role_id = roles.id")
 
-	// Use transaction for bulk operations
-	tx := r.db.WithContext(ctx).Begin()
-	defer func() {
-		if r := recover(); r != nil {
-			tx.Rollback()
-		}
-	}()
+	// Level filtering: only show users with roles having level < VisibleBelow
+	// This ensures Platform Admin (level 99) cannot see Super Admin (level 100)
+	query = query.Where("(roles.level < ? OR roles.level IS NULL)", filter.VisibleBelow)
 
-	for _, userOrg := range userOrgs {
-		if err := tx.Create(&userOrg).Error; err != nil {
-			errors = append(errors, err)
-		} else {
-			created = append(created, userOrg)
-		}
+	// Organization filtering for non-platform users; an empty list leaves only platform-level users
+	if filter.AccessibleOrgs != nil {
+		// For organization-level users, only show users from their accessible organizations
+		// PLUS platform-level users (who don't have organization assignments)
+		subQuery := dbFromContext(ctx, r.db).Table("user_organizations").
+			Select("user_id").
+			Where("organization_id IN ? AND is_active = true", filter.AccessibleOrgs)
+		query = query.Where("(users.id IN (?) OR roles.level >= 76)", subQuery)
 	}
 
-	if len(errors) > 0 {
-		tx.Rollback()
-		return nil, errors
+	if filter.ExcludeUserID != nil {
+		query = query.Where("users.id <> ?", *filter.ExcludeUserID)
 	}
-
-	if err := tx.Commit().Error; err != nil {
-		return nil, []error{err}
+	if filter.Search != "" {
+		searchPattern := containsPattern(filter.Search)
+		query = query.Where("(users.username ILIKE ? OR users.email ILIKE ? OR users.display_name ILIKE ?)", searchPattern, searchPattern, searchPattern)
 	}
-
-	return created, nil
-}
-
-// User Organization History Implementation
-
-// CreateUserOrganizationHistory creates a new user organization history record.
-func (r *userRepository) CreateUserOrganizationHistory(ctx context.Context, history *model.UserOrganizationHistory) (*model.UserOrganizationHistory, error) {
-	if err := r.db.WithContext(ctx).Create(history).Error; err != nil {
-		return nil, err
+	if len(filter.RoleIDs) > 0 {
+		query = query.Where("users.role_id IN ?", filter.RoleIDs)
 	}
-	return history, nil
-}
+	if filter.MinLevel != nil {
+		query = query.Where("COALESCE(roles.level, 0) >= ?", *filter.MinLevel)
+	}
+	if filter.MaxLevel != nil {
+		query = query.Where("COALESCE(roles.level, 0) <= ?", *filter.MaxLevel)
+	}
+	if len(filter.AuthProviders) > 0 {
+		query = query.Where("users.auth_provider IN ?", filter.AuthProviders)
+	}
+	if len(filter.Statuses) > 0 {
+		query = query.Where("users.status IN ?", filter.Statuses)
+	}
+	if len(filter.OrganizationIDs) > 0 {
+		members := dbFromContext(ctx, r.db).Table("user_organizations").
+			Select("user_id").
+			Where("organization_id IN ? AND is_active = true", filter.OrganizationIDs)
+		query = query.Where("users.id IN (?)", members)
+	}
+	if len(filter.Attributes) > 0 {
+		members := dbFromContext(ctx, r.db).Table("user_organizations").
+			Select("user_id").
+			Where("is_active = true")
+		if len(filter.OrganizationIDs) > 0 {
+			members = members.Where("organization_id IN ?", filter.OrganizationIDs)
+		}
+		for _, attribute := range filter.Attributes {
+			members = members.Where("attributes ->> ? = ?", attribute.Key, attribute.Value)
+		}
+		query = query.Where("users.id IN (?)", members)
+	}
+	if filter.CreatedAfter != nil {
+		query = query.Where("users.created_at >= ?", *filter.CreatedAfter)
+	}
+	if filter.CreatedBefore != nil {
+		query = query.Where("users.created_at < ?", *filter.CreatedBefore)
+	}
+	return query
+}
+
+// ChangeStatus saves the status of a user and records the transition in a single transaction
+func (r *userRepository) ChangeStatus(ctx context.Context, user *model.User, history *model.UserStatusHistory) (bool, error) {
+	changed := false
+	err := dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.User{}).
+			Where("id = ? AND status = ?", user.ID, history.PreviousStatus).
+			Updates(map[string]interface{}{
+				"status":          user.Status,
+				"status_reason":   user.StatusReason,
+				"suspended_until": user.SuspendedUntil,
+				"updated_at":      gorm.Expr("NOW()"),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		changed = true
 
-// FindUserOrganizationHistory retrieves user organization history with pagination.
-func (r *userRepository) FindUserOrganizationHistory(ctx context.Context, userID uuid.UUID, offset, limit int) ([]model.UserOrganizationHistory, error) {
-	var history []model.UserOrganizationHistory
+		return tx.Omit(clause.Associations).Create(history).Error
+	})
+	return changed, err
+}
 
-	err := r.db.WithContext(ctx).
-		Preload("User").
-		Preload("Organization").
-		Preload("Actor").
-		Where("user_id = ?", userID).
-		Order("action_at DESC").
-		Offset(offset).
+// FindExpiredSuspensions returns suspended users whose suspension ended before now, oldest first
+func (r *userRepository) FindExpiredSuspensions(ctx context.Context, now time.Time, limit int) ([]model.User, error) {
+	var users []model.User
+	err := dbFromContext(ctx, r.db).
+		Where("status = ? AND suspended_until IS NOT NULL AND suspended_until <= ?", constant.UserStatusSuspended, now).
+		Order("suspended_until ASC").
 		Limit(limit).
-		Find(&history).Error
-
-	return history, err
+		Find(&users).Error
+	return users, err
 }
 
-// FindOrganizationUserHistory retrieves organization user history with pagination.
-func (r *userRepository) FindOrganizationUserHistory(ctx context.Context, organizationID uuid.UUID, offset, limit int) ([]model.UserOrganizationHistory, error) {
-	var history []model.UserOrganizationHistory
+// FindUserStatusHistory retrieves the status transitions of a user, newest first.
+func (r *userRepository) FindUserStatusHistory(ctx context.Context, userID uuid.UUID, offset, limit int) ([]model.UserStatusHistory, error) {
+	var history []model.UserStatusHistory
 
-	err := r.db.WithContext(ctx).
-		Preload("User").
-		Preload("Organization").
+	err := dbFromContext(ctx, r.db).
 		Preload("Actor").
-		Where("organization_id = ?", organizationID).
+		Where("user_id = ?", userID).
 		Order("action_at DESC").
 		Offset(offset).
 		Limit(limit).
@@ -329,104 +403,12 @@ func (r *userRepository) FindOrganizationUserHistory(ctx context.Context, organi
 	return history, err
 }
 
-// CountUserOrganizationHistory counts total history records for a user.
-func (r *userRepository) CountUserOrganizationHistory(ctx context.Context, userID uuid.UUID) (int64, error) {
+// CountUserStatusHistory counts the status transitions of a user.
+func (r *userRepository) CountUserStatusHistory(ctx context.Context, userID uuid.UUID) (int64, error) {
 	var count int64
-	err := r.db.WithContext(ctx).
-		Model(&model.UserOrganizationHistory{}).
+	err := dbFromContext(ctx, r.db).
+		Model(&model.UserStatusHistory{}).
 		Where("user_id = ?", userID).
 		Count(&count).Error
 	return count, err
 }
-
-// CountOrganizationUserHistory counts total history records for an organization.
-func (r *userRepository) CountOrganizationUserHistory(ctx context.Context, organizationID uuid.UUID) (int64, error) {
-	var count int64
-	err := r.db.WithContext(ctx).
-		Model(&model.UserOrganizationHistory{}).
-		Where("organization_id = ?", organizationID).
-		Count(&count).Error
-	return count, err
-}
-
-// FindUserOrganizationHistoryByAction retrieves user organization history filtered by action.
-func (r *userRepository) FindUserOrganizationHistoryByAction(ctx context.Context, userID uuid.UUID, action string, offset, limit int) ([]model.UserOrganizationHistory, error) {
-	var history []model.UserOrganizationHistory
-
-	err := r.db.WithContext(ctx).
-		Preload("User").
-		Preload("Organization").
-		Preload("Actor").
-		Where("user_id = ? AND action = ?", userID, action).
-		Order("action_at DESC").
-		Offset(offset).
-		Limit(limit).
-		Find(&history).Error
-
-	return history, err
-}
-
-// ListWithFilters retrieves users with level and organization filtering
-func (r *userRepository) ListWithFilters(ctx context.Context, offset, limit int, search string, maxLevel int, organizationIDs []uuid.UUID) ([]model.User, error) {
-	var users []model.User
-	query := r.db.WithContext(ctx).
-		Preload("Role").
-		Select("DISTINCT users.*")
-
-	// Apply search filter if provided
-	if search != "" {
-		searchPattern := "%" + search + "%"
-		query = query.Where("users.username ILIKE ? OR users.email ILIKE ?", searchPattern, searchPattern)
-	}
-
-	// Level filtering: only show users with roles having level < maxLevel
-	// This ensures Platform Admin (level 99) cannot see Super Admin (level 100)
-	query = query.Joins("LEFT JOIN roles ON users.role_id = roles.id").
-		Where("roles.level < ? OR roles.level IS NULL", maxLevel)
-
-	// Organization filtering for non-platform users
-	if len(organizationIDs) > 0 {
-		// For organization-level users, only show users from their accessible organizations
-		// PLUS platform-level users (who don't have organization assignments)
-		subQuery := r.db.Table("user_organizations").
-			Select("DISTINCT user_id").
-			Where("organization_id IN ? AND is_active = true", organizationIDs)
-
-		query = query.Where("users.id IN (?) OR roles.level >= 76", subQuery)
-	}
-
-	err := query.Offset(offset).Limit(limit).Find(&users).Error
-	return users, err
-}
-
-// CountWithFilters counts users with level and organization filtering
-func (r *userRepository) CountWithFilters(ctx context.Context, search string, maxLevel int, organizationIDs []uuid.UUID) (int64, error) {
-	var count int64
-	query := r.db.WithContext(ctx).
-		Model(&model.User{}).
-		Select("COUNT(DISTINCT users.id)")
-
-	// Apply search filter if provided
-	if search != "" {
-		searchPattern := "%" + search + "%"
-		query = query.Where("users.username ILIKE ? OR users.email ILIKE ?", searchPattern, searchPattern)
-	}
-
-	// Level filtering: only show users with roles having level < maxLevel
-	query = query.Joins("LEFT JOIN roles ON users.role_id = roles.id").
-		Where("roles.level < ? OR roles.level IS NULL", maxLevel)
-
-	// Organization filtering for non-platform users
-	if len(organizationIDs) > 0 {
-		// For organization-level users, only show users from their accessible organizations
-		// PLUS platform-level users (who don't have organization assignments)
-		subQuery := r.db.Table("user_organizations").
-			Select("DISTINCT user_id").
-			Where("organization_id IN ? AND is_active = true", organizationIDs)
-
-		query = query.Where("users.id IN (?) OR roles.level >= 76", subQuery)
-	}
-
-	err := query.Count(&count).Error
-	return count, err
-}
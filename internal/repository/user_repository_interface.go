@@ -3,12 +3,57 @@ package repository
 import (
 	"go-base-project/internal/model"
 	"context"
+	"errors"
+	"time"
 
 	"github.com/google/uuid"
 )
 
+// UserListFilter narrows a user listing. Empty fields do not filter; level bounds apply to the global role,
+// users without one count as level 0.
+type UserListFilter struct {
+	Search         string
+	VisibleBelow   int         // Only users whose role level is below this level
+	AccessibleOrgs []uuid.UUID // When not nil, only members of these organizations plus platform-level users
+	ExcludeUserID  *uuid.UUID
+
+	RoleIDs         []uuid.UUID
+	MinLevel        *int
+	MaxLevel        *int
+	AuthProviders   []string
+	Statuses        []string
+	OrganizationIDs []uuid.UUID // Only active members of any of these organizations
+	CreatedAfter    *time.Time
+	CreatedBefore   *time.Time
+
+	// Only users with an active membership carrying all of these custom attribute values. With
+	// OrganizationIDs, the membership must be in one of those organizations.
+	Attributes []UserAttributeFilter
+}
+
+// UserAttributeFilter matches memberships whose custom attribute Key has Value, compared as text.
+type UserAttributeFilter struct {
+	Key   string
+	Value string
+}
+
+// UserSearchHit is a user matching a search term with its relevance; higher scores rank first.
+type UserSearchHit struct {
+	User  model.User
+	Score float64
+}
+
+// ErrUsernameTaken is returned when a username is already in use, including by a soft-deleted user.
+var ErrUsernameTaken = errors.New("username is already taken")
+
 type UserRepositoryInterface interface {
 	Create(ctx context.Context, user *model.User) error
+	// CreateWithUniqueUsername creates a user whose username is claimed atomically through the unique
+	// index. It returns ErrUsernameTaken when the username is in use.
+	CreateWithUniqueUsername(ctx context.Context, user *model.User) error
+	// FindTakenUsernames returns which of the usernames are in use, compared case-insensitively and
+	// including soft-deleted users. The result is keyed by lowercase username.
+	FindTakenUsernames(ctx context.Context, usernames []string) (map[string]bool, error)
 	FindByUsernameWithRole(ctx context.Context, username string) (*model.User, error)
 	FindByUsername(ctx context.Context, username string) (*model.User, error)
 	FindByEmail(ctx context.Context, email string) (*model.User, error)
@@ -19,31 +64,24 @@ type UserRepositoryInterface interface {
 	FindByOrganizationID(ctx context.Context, organizationID uuid.UUID, offset, limit int) ([]model.User, error)
 	List(ctx context.Context, offset, limit int, search string) ([]model.User, error)
 	Count(ctx context.Context, search string) (int64, error)
-	ListWithFilters(ctx context.Context, offset, limit int, search string, maxLevel int, organizationIDs []uuid.UUID) ([]model.User, error)
-	CountWithFilters(ctx context.Context, search string, maxLevel int, organizationIDs []uuid.UUID) (int64, error)
+	ListWithFilters(ctx context.Context, filter UserListFilter, query ListQuery) (*ListPage[model.User], error)
+	CountWithFilters(ctx context.Context, filter UserListFilter) (int64, error)
+	// Search ranks the users matching the filter whose username or email resembles term.
+	Search(ctx context.Context, filter UserListFilter, term string, limit int) ([]UserSearchHit, error)
 	Update(ctx context.Context, user *model.User) error
+	// UpdateProfile saves only the profile fields of user: display name, phone, locale, timezone and job title.
+	UpdateProfile(ctx context.Context, user *model.User) error
+	// UpdateAvatar saves the storage prefix and URL of a user's avatar; empty values remove it.
+	UpdateAvatar(ctx context.Context, userID uuid.UUID, avatarKey, avatarURL string) error
 	Delete(ctx context.Context, id uuid.UUID) error
 	CountUsersByRoleLevel(ctx context.Context, level int) (int64, error)
 
-	// User-Organization Management
-	CreateUserOrganization(ctx context.Context, userOrg *model.UserOrganization) (*model.UserOrganization, error)
-	FindUserOrganization(ctx context.Context, userID, organizationID uuid.UUID) (*model.UserOrganization, error)
-	FindUserOrganizationWithRole(ctx context.Context, userID, organizationID uuid.UUID) (*model.UserOrganization, error)
-	FindUserOrganizations(ctx context.Context, userID uuid.UUID, offset, limit int) ([]model.UserOrganization, error)
-	FindOrganizationMembers(ctx context.Context, organizationID uuid.UUID, offset, limit int) ([]model.UserOrganization, error)
-	FindOrganizationMembersWithRoles(ctx context.Context, organizationID uuid.UUID, offset, limit int) ([]model.UserOrganization, error)
-	CountUserOrganizations(ctx context.Context, userID uuid.UUID) (int64, error)
-	CountOrganizationMembers(ctx context.Context, organizationID uuid.UUID) (int64, error)
-	UpdateUserOrganization(ctx context.Context, userOrg *model.UserOrganization) (*model.UserOrganization, error)
-	UpdateUserOrganizationRole(ctx context.Context, userID, organizationID uuid.UUID, roleID *uuid.UUID) error
-	DeleteUserOrganization(ctx context.Context, userID, organizationID uuid.UUID) error
-	BulkCreateUserOrganizations(ctx context.Context, userOrgs []model.UserOrganization) ([]model.UserOrganization, []error)
-
-	// User Organization History methods
-	CreateUserOrganizationHistory(ctx context.Context, history *model.UserOrganizationHistory) (*model.UserOrganizationHistory, error)
-	FindUserOrganizationHistory(ctx context.Context, userID uuid.UUID, offset, limit int) ([]model.UserOrganizationHistory, error)
-	FindOrganizationUserHistory(ctx context.Context, organizationID uuid.UUID, offset, limit int) ([]model.UserOrganizationHistory, error)
-	CountUserOrganizationHistory(ctx context.Context, userID uuid.UUID) (int64, error)
-	CountOrganizationUserHistory(ctx context.Context, organizationID uuid.UUID) (int64, error)
-	FindUserOrganizationHistoryByAction(ctx context.Context, userID uuid.UUID, action string, offset, limit int) ([]model.UserOrganizationHistory, error)
+	// Account status
+	// ChangeStatus saves the status fields of user and records history in a single transaction. It only applies
+	// while the stored status still equals history.PreviousStatus and reports whether it did.
+	ChangeStatus(ctx context.Context, user *model.User, history *model.UserStatusHistory) (bool, error)
+	// FindExpiredSuspensions returns up to limit suspended users whose suspension ended before now.
+	FindExpiredSuspensions(ctx context.Context, now time.Time, limit int) ([]model.User, error)
+	FindUserStatusHistory(ctx context.Context, userID uuid.UUID, offset, limit int) ([]model.UserStatusHistory, error)
+	CountUserStatusHistory(ctx context.Context, userID uuid.UUID) (int64, error)
 }
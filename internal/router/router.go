@@ -71,6 +71,9 @@ func SetupRoutes(e *echo.Echo, cfg config.Config, handlers *bootstrap.Handlers,
 
 	e.GET("/swagger/*", echoSwagger.WrapHandler)
 
+	// Files of the local storage driver, e.g. avatars
+	e.GET("/media/*", handlers.Media.ServeMedia)
+
 	api := e.Group("/api")
 
 	healthRoutes := api.Group("/health")
@@ -87,14 +90,34 @@ func SetupRoutes(e *echo.Echo, cfg config.Config, handlers *bootstrap.Handlers,
 		authRoutes.GET("/google/login", handlers.Auth.GoogleLogin)
 		authRoutes.GET("/google/callback", handlers.Auth.GoogleCallback)
 		authRoutes.GET("/me", handlers.Auth.GetCurrentUser, m.JWT)
+		authRoutes.PATCH("/me", handlers.User.UpdateMyProfile, m.JWT)
+		authRoutes.GET("/me/profile", handlers.User.GetMyProfile, m.JWT)
+		authRoutes.GET("/me/export", handlers.PersonalData.ExportMyData, m.JWT)
+		authRoutes.PUT("/me/avatar", handlers.Avatar.UploadMyAvatar, m.JWT)
+		authRoutes.DELETE("/me/avatar", handlers.Avatar.DeleteMyAvatar, m.JWT)
 		authRoutes.POST("/switch-organization", handlers.Auth.SwitchOrganization, m.JWT)
 	}
 
+	// Stable avatar URLs, public so they work in image tags
+	api.GET("/avatars/:id", handlers.Avatar.GetAvatar)
+
+	// Search across users and organizations (results are limited to what the caller can see)
+	api.GET("/search", handlers.Search.Search, m.JWT)
+
+	// Background jobs, visible to the user who submitted them
+	jobRoutes := api.Group("/jobs", m.JWT)
+	{
+		jobRoutes.GET("/:id", handlers.Job.GetJob)
+		jobRoutes.POST("/:id/cancel", handlers.Job.CancelJob)
+		jobRoutes.POST("/:id/retry", handlers.Job.RetryJob)
+	}
+
 	// General role-related routes (accessible by authenticated users)
 	roleRoutes := api.Group("/roles", m.JWT)
 	{
-		// DISABLED: Role approval request functionality
-		// roleRoutes.POST("/approval-requests", handlers.Role.CreateRoleApprovalRequest)
+		roleRoutes.POST("/approval-requests", handlers.Role.CreateRoleApprovalRequest)
+		roleRoutes.GET("/approval-requests/me", handlers.Role.ListMyRoleApprovalRequests)
+		roleRoutes.POST("/approval-requests/:id/cancel", handlers.Role.CancelRoleApprovalRequest)
 		roleRoutes.GET("/predefined-options", handlers.Role.GetPredefinedRoleOptions)
 	}
 
@@ -103,16 +126,19 @@ func SetupRoutes(e *echo.Echo, cfg config.Config, handlers *bootstrap.Handlers,
 	{
 		orgRoutes.GET("", handlers.Organization.ListOrganizations)
 		orgRoutes.GET("/statistics", handlers.Organization.GetOrganizationStatistics)
+		orgRoutes.GET("/settings/schema", handlers.OrgSetting.GetSettingsSchema)
 		orgRoutes.GET("/:id", handlers.Organization.GetOrganization)
 		orgRoutes.GET("/code/:code", handlers.Organization.GetOrganizationByCode)
 		orgRoutes.POST("/join", handlers.Organization.JoinOrganization)
-		orgRoutes.DELETE("/:id/leave", handlers.Organization.LeaveOrganization)
+		orgRoutes.POST("/invitations/accept", handlers.Invitation.AcceptInvitation)
+		orgRoutes.GET("/join-requests/me", handlers.Organization.ListMyJoinRequests)
+		orgRoutes.DELETE("/:id/leave", handlers.Membership.LeaveOrganization)
 	}
 
 	// User-specific organization routes
 	userOrgRoutes := api.Group("/users", m.JWT)
 	{
-		userOrgRoutes.GET("/me/organizations", handlers.Organization.GetUserOrganizations)
+		userOrgRoutes.GET("/me/organizations", handlers.Membership.ListMyMemberships)
 	}
 
 	// Organization-scoped routes - require organization context
@@ -132,6 +158,26 @@ func SetupRoutes(e *echo.Echo, cfg config.Config, handlers *bootstrap.Handlers,
 		orgContextRoutes.POST("/users/:userId/assign-role", handlers.User.AssignRoleToUserInOrganization, m.RequirePermission("roles:assign"))
 		orgContextRoutes.GET("/users/:userId/role", handlers.User.GetUserRoleInOrganization, m.RequirePermission("users:read"))
 
+		// Organization invitations
+		orgContextRoutes.POST("/invitations", handlers.Invitation.CreateInvitation, m.RequirePermission("organizations:manage_members"))
+		orgContextRoutes.GET("/invitations", handlers.Invitation.ListInvitations, m.RequirePermission("organizations:manage_members"))
+		orgContextRoutes.DELETE("/invitations/:invitationId", handlers.Invitation.RevokeInvitation, m.RequirePermission("organizations:manage_members"))
+
+		// Organization hierarchy (ancestors, descendants, depth)
+		orgContextRoutes.GET("/hierarchy", handlers.Organization.GetOrganizationHierarchy, m.RequirePermission("organizations:read"))
+
+		// Organization settings, inherited down the hierarchy
+		orgContextRoutes.GET("/settings", handlers.OrgSetting.GetSettings, m.RequirePermission("organizations:read"))
+		orgContextRoutes.PUT("/settings", handlers.OrgSetting.UpdateSettings, m.RequirePermission("organizations:update"))
+
+		// Plan limits and their current usage
+		orgContextRoutes.GET("/quotas", handlers.OrgQuota.GetQuotaUsage, m.RequirePermission("organizations:read"))
+
+		// Join requests for approval-required organizations
+		orgContextRoutes.GET("/join-requests", handlers.Organization.ListJoinRequests, m.RequirePermission("organizations:manage_members"))
+		orgContextRoutes.POST("/join-requests/:requestId/approve", handlers.Organization.ApproveJoinRequest, m.RequirePermission("organizations:manage_members"))
+		orgContextRoutes.POST("/join-requests/:requestId/reject", handlers.Organization.RejectJoinRequest, m.RequirePermission("organizations:manage_members"))
+
 		orgContextRoutes.GET("/reports", func(c echo.Context) error {
 			orgID := c.Get(constant.OrganizationIDKey).(uuid.UUID)
 			return c.JSON(http.StatusOK, map[string]interface{}{
@@ -156,10 +202,12 @@ func SetupRoutes(e *echo.Echo, cfg config.Config, handlers *bootstrap.Handlers,
 
 			// Organization-specific role routes
 			roleRoutes.GET("/organization-types", handlers.Role.GetRolesForOrganizationType, m.RequirePermission("roles:read"))
+			roleRoutes.GET("/default-roles", handlers.Role.ListOrganizationTypeDefaultRoles, m.RequirePermission("roles:read"))
+			roleRoutes.PUT("/default-roles/:organizationType", handlers.Role.UpdateOrganizationTypeDefaultRoles, m.RequirePermission("roles:assign"))
 
-			// DISABLED: Role approval management routes
-			// roleRoutes.GET("/approval-requests", handlers.Role.ListRoleApprovalRequests, m.RequirePermission("roles:approve"))
-			// roleRoutes.PUT("/approval-requests/:id/decision", handlers.Role.ApproveRejectRoleRequest, m.RequirePermission("roles:approve"))
+			// Role approval management routes
+			roleRoutes.GET("/approval-requests", handlers.Role.ListRoleApprovalRequests, m.RequirePermission("roles:approve"))
+			roleRoutes.PUT("/approval-requests/:id/decision", handlers.Role.ApproveRejectRoleRequest, m.RequirePermission("roles:approve"))
 		}
 
 		// Permission management routes
@@ -176,30 +224,81 @@ func SetupRoutes(e *echo.Echo, cfg config.Config, handlers *bootstrap.Handlers,
 			// Basic user management
 			userRoutes.POST("", handlers.User.CreateUser, m.RequirePermission("users:create"))
 			userRoutes.GET("", handlers.User.ListUsers, m.RequirePermission("users:read"))
+			userRoutes.POST("/import", handlers.UserImport.ImportUsers, m.RequirePermission("users:create"))
+			userRoutes.GET("/import/:importId/result", handlers.UserImport.GetImportResult, m.RequirePermission("users:create"))
 			userRoutes.GET("/:id", handlers.User.GetUserByID, m.RequirePermission("users:read"))
 			userRoutes.PUT("/:id", handlers.User.UpdateUser, m.RequirePermission("users:update"))
+			userRoutes.GET("/:id/profile", handlers.User.GetUserProfile, m.RequirePermission("users:read"))
+			userRoutes.PATCH("/:id/profile", handlers.User.UpdateUserProfile, m.RequirePermission("users:update"))
+			userRoutes.DELETE("/:id/avatar", handlers.Avatar.DeleteUserAvatar, m.RequirePermission("users:update"))
 			userRoutes.DELETE("/:id", handlers.User.DeleteUser, m.RequirePermission("users:delete"))
+			userRoutes.POST("/:id/suspend", handlers.User.SuspendUser, m.RequirePermission("users:suspend"))
+			userRoutes.POST("/:id/reactivate", handlers.User.ReactivateUser, m.RequirePermission("users:suspend"))
+			userRoutes.GET("/:id/status-history", handlers.User.GetUserStatusHistory, m.RequirePermission("users:read-history"))
+			userRoutes.POST("/:id/erase", handlers.PersonalData.RequestUserErasure, m.RequirePermission("users:erase"))
 
 			// User-Organization Management
-			userRoutes.POST("/assign-organization", handlers.User.AssignUserToOrganization, m.RequirePermission("users:assign-organization"))
-			userRoutes.POST("/bulk-assign-organization", handlers.User.BulkAssignUsersToOrganization, m.RequirePermission("users:bulk-assign-organization"))
-			userRoutes.POST("/log-organization-action", handlers.User.LogUserOrganizationAction, m.RequirePermission("users:log-actions"))
-			userRoutes.GET("/:userId/organizations", handlers.User.GetUserOrganizations, m.RequirePermission("users:read"))
-			userRoutes.GET("/:userId/organization-history", handlers.User.GetUserOrganizationHistory, m.RequirePermission("users:read-history"))
-			userRoutes.PUT("/:userId/organizations/:organizationId", handlers.User.UpdateUserOrganizationRole, m.RequirePermission("users:update-organization-role"))
-			userRoutes.DELETE("/:userId/organizations/:organizationId", handlers.User.RemoveUserFromOrganization, m.RequirePermission("users:remove-organization"))
+			userRoutes.POST("/assign-organization", handlers.Membership.AddMember, m.RequirePermission("users:assign-organization"))
+			userRoutes.POST("/bulk-assign-organization", handlers.Membership.BulkAddMembers, m.RequirePermission("users:bulk-assign-organization"))
+			userRoutes.GET("/:userId/organizations", handlers.Membership.ListUserMemberships, m.RequirePermission("users:read"))
+			userRoutes.GET("/:userId/organization-history", handlers.Membership.GetUserHistory, m.RequirePermission("users:read-history"))
+			userRoutes.PUT("/:userId/organizations/:organizationId", handlers.Membership.UpdateMember, m.RequirePermission("users:update-organization-role"))
+			userRoutes.PUT("/:userId/organizations/:organizationId/attributes", handlers.Membership.UpdateMemberAttributes, m.RequirePermission("users:update-organization-role"))
+			userRoutes.DELETE("/:userId/organizations/:organizationId", handlers.Membership.RemoveMember, m.RequirePermission("users:remove-organization"))
 		}
 
 		// Admin organization management routes
 		organizationRoutes := adminRoutes.Group("/organizations")
 		{
 			organizationRoutes.POST("", handlers.Organization.CreateOrganization, m.RequirePermission("organizations:create"))
+			organizationRoutes.POST("/import", handlers.OrgTransfer.ImportOrganizationTree, m.RequirePermission("organizations:create"))
 			organizationRoutes.PUT("/:id", handlers.Organization.UpdateOrganization, m.RequirePermission("organizations:update"))
 			organizationRoutes.DELETE("/:id", handlers.Organization.DeleteOrganization, m.RequirePermission("organizations:delete"))
-			organizationRoutes.GET("/:id/members", handlers.Organization.GetOrganizationMembers, m.RequirePermission("organizations:read"))
-			organizationRoutes.GET("/:organizationId/members", handlers.User.GetOrganizationMembers, m.RequirePermission("organizations:read-members"))
-			organizationRoutes.GET("/:organizationId/user-history", handlers.User.GetOrganizationUserHistory, m.RequirePermission("organizations:read-history"))
+			organizationRoutes.GET("/:id/members", handlers.Membership.ListOrganizationMembers, m.RequirePermission("organizations:read-members"))
+			organizationRoutes.GET("/:id/user-history", handlers.Membership.GetOrganizationHistory, m.RequirePermission("organizations:read-history"))
+			organizationRoutes.POST("/:id/move", handlers.Organization.MoveOrganization, m.RequirePermission("organizations:update"))
+			organizationRoutes.GET("/:id/audit-logs", handlers.Organization.ListOrganizationAuditLogs, m.RequirePermission("organizations:read"))
+			organizationRoutes.POST("/:id/archive", handlers.Organization.ArchiveOrganization, m.RequirePermission("organizations:update"))
+			organizationRoutes.POST("/:id/unarchive", handlers.Organization.UnarchiveOrganization, m.RequirePermission("organizations:update"))
+			organizationRoutes.POST("/:id/rotate-code", handlers.Organization.RotateOrganizationCode, m.RequirePermission("organizations:update"))
+			organizationRoutes.GET("/:id/export", handlers.OrgTransfer.ExportOrganizationTree, m.RequirePermission("organizations:read"))
+			organizationRoutes.POST("/:id/export", handlers.OrgTransfer.SubmitOrganizationExport, m.RequirePermission("organizations:read"))
+			organizationRoutes.PUT("/:id/quotas", handlers.OrgQuota.UpdateQuotas, m.RequirePermission("organizations:manage_quotas"))
+			organizationRoutes.GET("/deleted", handlers.Organization.ListDeletedOrganizations, m.RequirePermission("organizations:delete"))
+			organizationRoutes.POST("/:id/restore", handlers.Organization.RestoreOrganization, m.RequirePermission("organizations:delete"))
 			organizationRoutes.POST("/complete-structure", handlers.Organization.CreateCompleteOrganizationStructure, m.RequirePermission("organizations:create"))
 		}
+
+		// Organization type registry routes
+		orgTypeRoutes := adminRoutes.Group("/organization-types")
+		{
+			orgTypeRoutes.GET("", handlers.OrgType.ListOrganizationTypes, m.RequirePermission("organizations:read"))
+			orgTypeRoutes.GET("/:name", handlers.OrgType.GetOrganizationType, m.RequirePermission("organizations:read"))
+			orgTypeRoutes.POST("", handlers.OrgType.CreateOrganizationType, m.RequirePermission("organizations:create"))
+			orgTypeRoutes.PUT("/:name", handlers.OrgType.UpdateOrganizationType, m.RequirePermission("organizations:update"))
+			orgTypeRoutes.GET("/:name/attributes", handlers.OrgType.GetOrganizationTypeAttributes, m.RequirePermission("organizations:read"))
+			orgTypeRoutes.PUT("/:name/attributes", handlers.OrgType.UpdateOrganizationTypeAttributes, m.RequirePermission("organizations:update"))
+		}
+
+		// Organization structure template routes (reusable onboarding layouts)
+		orgTemplateRoutes := adminRoutes.Group("/organization-templates")
+		{
+			orgTemplateRoutes.GET("", handlers.OrgTemplate.ListTemplates, m.RequirePermission("organizations:read"))
+			orgTemplateRoutes.POST("", handlers.OrgTemplate.CreateTemplate, m.RequirePermission("organizations:create"))
+			orgTemplateRoutes.POST("/validate", handlers.OrgTemplate.ValidateTemplate, m.RequirePermission("organizations:create"))
+			orgTemplateRoutes.POST("/apply", handlers.OrgTemplate.ApplyTemplate, m.RequirePermission("organizations:create"))
+			orgTemplateRoutes.GET("/:id", handlers.OrgTemplate.GetTemplate, m.RequirePermission("organizations:read"))
+			orgTemplateRoutes.PUT("/:id", handlers.OrgTemplate.UpdateTemplate, m.RequirePermission("organizations:create"))
+			orgTemplateRoutes.DELETE("/:id", handlers.OrgTemplate.DeleteTemplate, m.RequirePermission("organizations:create"))
+		}
+
+		// Admin change request approval routes (four-eyes workflow for sensitive actions)
+		approvalRoutes := adminRoutes.Group("/approvals")
+		{
+			approvalRoutes.GET("", handlers.Approval.ListChangeRequests, m.RequirePermission("approvals:read"))
+			approvalRoutes.GET("/:id", handlers.Approval.GetChangeRequest, m.RequirePermission("approvals:read"))
+			approvalRoutes.POST("/:id/decision", handlers.Approval.DecideChangeRequest, m.RequirePermission("approvals:decide"))
+			approvalRoutes.POST("/:id/cancel", handlers.Approval.CancelChangeRequest)
+		}
 	}
 }
@@ -1,6 +1,7 @@
 package seeder
 
 import (
+	"go-base-project/internal/constant"
 	"go-base-project/internal/model"
 
 	"github.com/rs/zerolog/log"
@@ -16,6 +17,8 @@ func SeedRolesAndPermissions(db *gorm.DB) error {
 		{Name: "users:read", Description: "Can read user data"},
 		{Name: "users:update", Description: "Can update user data"},
 		{Name: "users:delete", Description: "Can delete users"},
+		{Name: "users:suspend", Description: "Can suspend and reactivate user accounts"},
+		{Name: "users:erase", Description: "Can request the erasure of a user's personal data"},
 		{Name: "users:assign-organization", Description: "Can assign users to organizations"},
 		{Name: "users:remove-organization", Description: "Can remove users from organizations"},
 		{Name: "users:bulk-assign-organization", Description: "Can bulk assign users to organizations"},
@@ -23,6 +26,8 @@ func SeedRolesAndPermissions(db *gorm.DB) error {
 		{Name: "roles:assign", Description: "Can assign roles to users"},
 		{Name: "roles:create", Description: "Can create new roles"},
 		{Name: "roles:approve", Description: "Can approve role creation requests"},
+		{Name: "approvals:read", Description: "Can view change requests for sensitive actions"},
+		{Name: "approvals:decide", Description: "Can approve or reject change requests for sensitive actions"},
 		{Name: "dashboard:view", Description: "Can view the main dashboard"},
 		{Name: "scanned_data:create", Description: "Can create new scanned data entries"},
 		// Shipping Management Permissions
@@ -34,6 +39,7 @@ func SeedRolesAndPermissions(db *gorm.DB) error {
 		{Name: "organizations:update", Description: "Can update organization data"},
 		{Name: "organizations:delete", Description: "Can delete organizations"},
 		{Name: "organizations:manage_members", Description: "Can manage organization members"},
+		{Name: "organizations:manage_quotas", Description: "Can set organization plan limits"},
 	}
 
 	// Seed all permissions
@@ -45,14 +51,19 @@ func SeedRolesAndPermissions(db *gorm.DB) error {
 	}
 	log.Info().Msg("Permissions seeded successfully.")
 
-	// 2. Define system roles with hierarchy
+	// 2. Define system roles with hierarchy, plus the default organization roles.
+	// Non-system roles are only initialized on first creation so admin changes survive restarts.
+	memberPermissions := []string{"organizations:read", "users:read", "dashboard:view"}
+	ownerPermissions := []string{"organizations:read", "organizations:update", "organizations:manage_members", "users:read", "dashboard:view"}
+
 	rolesToSeed := []struct {
-		Name           string
-		Description    string
-		Level          int
-		IsSystemRole   bool
-		PredefinedName string
-		Permissions    []string
+		Name              string
+		Description       string
+		Level             int
+		IsSystemRole      bool
+		PredefinedName    string
+		Permissions       []string
+		OrganizationTypes []string
 	}{
 		{
 			Name:           "super_admin",
@@ -62,13 +73,65 @@ func SeedRolesAndPermissions(db *gorm.DB) error {
 			PredefinedName: "Nexus",
 			Permissions:    []string{}, // EMPTY - Access bypassed via backend logic
 		},
+		{
+			Name:              "holding_admin",
+			Description:       "Holding Administrator - Default role for holding creators",
+			Level:             constant.RoleLevelHoldingOwner,
+			PredefinedName:    "holding_admin",
+			Permissions:       ownerPermissions,
+			OrganizationTypes: []string{constant.OrganizationTypeHolding},
+		},
+		{
+			Name:              "holding_staff",
+			Description:       "Holding Staff - Default role for holding members",
+			Level:             constant.RoleLevelHoldingManager,
+			PredefinedName:    "holding_staff",
+			Permissions:       memberPermissions,
+			OrganizationTypes: []string{constant.OrganizationTypeHolding},
+		},
+		{
+			Name:              "company_admin",
+			Description:       "Company Administrator - Default role for company creators",
+			Level:             constant.RoleLevelCompanyOwner,
+			PredefinedName:    "company_admin",
+			Permissions:       ownerPermissions,
+			OrganizationTypes: []string{constant.OrganizationTypeCompany},
+		},
+		{
+			Name:              "company_staff",
+			Description:       "Company Staff - Default role for company members",
+			Level:             constant.RoleLevelCompanyManager,
+			PredefinedName:    "company_staff",
+			Permissions:       memberPermissions,
+			OrganizationTypes: []string{constant.OrganizationTypeCompany},
+		},
+		{
+			Name:              "store_admin",
+			Description:       "Store Administrator - Default role for store creators",
+			Level:             constant.RoleLevelStoreManager,
+			PredefinedName:    "store_admin",
+			Permissions:       ownerPermissions,
+			OrganizationTypes: []string{constant.OrganizationTypeStore},
+		},
+		{
+			Name:              "store_staff",
+			Description:       "Store Staff - Default role for store members",
+			Level:             constant.RoleLevelStoreStaff,
+			PredefinedName:    "store_staff",
+			Permissions:       memberPermissions,
+			OrganizationTypes: []string{constant.OrganizationTypeStore},
+		},
 	}
 
 	for _, roleData := range rolesToSeed {
 		var role model.Role
-		if err := db.FirstOrCreate(&role, model.Role{Name: roleData.Name}).Error; err != nil {
-			log.Error().Err(err).Msgf("Failed to seed role: %s", roleData.Name)
-			return err
+		result := db.FirstOrCreate(&role, model.Role{Name: roleData.Name})
+		if result.Error != nil {
+			log.Error().Err(result.Error).Msgf("Failed to seed role: %s", roleData.Name)
+			return result.Error
+		}
+		if !roleData.IsSystemRole && result.RowsAffected == 0 {
+			continue
 		}
 
 		// Update role with hierarchy information
@@ -101,6 +164,45 @@ func SeedRolesAndPermissions(db *gorm.DB) error {
 				return err
 			}
 		}
+
+		for _, orgType := range roleData.OrganizationTypes {
+			mapping := model.RoleOrganizationType{RoleID: role.ID, OrganizationType: orgType}
+			if err := db.FirstOrCreate(&mapping, mapping).Error; err != nil {
+				log.Error().Err(err).Msgf("Failed to map role %s to organization type %s", roleData.Name, orgType)
+				return err
+			}
+		}
+	}
+
+	// 3. Default creator and member roles per organization type, only where none is configured yet
+	defaultRolesToSeed := []struct {
+		OrganizationType string
+		CreatorRole      string
+		MemberRole       string
+	}{
+		{OrganizationType: constant.OrganizationTypeHolding, CreatorRole: "holding_admin", MemberRole: "holding_staff"},
+		{OrganizationType: constant.OrganizationTypeCompany, CreatorRole: "company_admin", MemberRole: "company_staff"},
+		{OrganizationType: constant.OrganizationTypeStore, CreatorRole: "store_admin", MemberRole: "store_staff"},
+	}
+
+	for _, defaultsData := range defaultRolesToSeed {
+		var creatorRole, memberRole model.Role
+		if err := db.Where("name = ?", defaultsData.CreatorRole).First(&creatorRole).Error; err != nil {
+			return err
+		}
+		if err := db.Where("name = ?", defaultsData.MemberRole).First(&memberRole).Error; err != nil {
+			return err
+		}
+
+		defaults := model.OrganizationTypeDefaultRole{
+			OrganizationType: defaultsData.OrganizationType,
+			CreatorRoleID:    &creatorRole.ID,
+			MemberRoleID:     &memberRole.ID,
+		}
+		if err := db.FirstOrCreate(&defaults, model.OrganizationTypeDefaultRole{OrganizationType: defaultsData.OrganizationType}).Error; err != nil {
+			log.Error().Err(err).Msgf("Failed to seed default roles for organization type: %s", defaultsData.OrganizationType)
+			return err
+		}
 	}
 
 	log.Info().Msg("Roles and permission associations seeded successfully.")
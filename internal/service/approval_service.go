@@ -0,0 +1,406 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-base-project/internal/apperror"
+	"go-base-project/internal/config"
+	"go-base-project/internal/constant"
+	"go-base-project/internal/dto"
+	"go-base-project/internal/model"
+	"go-base-project/internal/repository"
+	"go-base-project/internal/util"
+	"math"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+// organizationScopedActions are the change request actions whose target is an organization. Approvers below
+// platform level must have access to that organization.
+var organizationScopedActions = map[string]bool{
+	constant.ChangeRequestActionDeleteOrganization: true,
+	constant.ChangeRequestActionBulkAssignUsers:    true,
+}
+
+// approvalService implements ApprovalServiceInterface.
+type approvalService struct {
+	changeRequestRepo   repository.ChangeRequestRepositoryInterface
+	userRepo            repository.UserRepositoryInterface
+	txManager           repository.TransactionManagerInterface
+	orgService          OrganizationServiceInterface
+	requiredApprovals   int
+	minApproverLevel    int
+	expiry              time.Duration
+	bulkAssignThreshold int
+
+	mu        sync.RWMutex
+	executors map[string]ChangeRequestExecutor
+}
+
+// NewApprovalService creates a new instance of approvalService.
+func NewApprovalService(changeRequestRepo repository.ChangeRequestRepositoryInterface, userRepo repository.UserRepositoryInterface, txManager repository.TransactionManagerInterface, orgService OrganizationServiceInterface, cfg config.Config) ApprovalServiceInterface {
+	return &approvalService{
+		changeRequestRepo:   changeRequestRepo,
+		userRepo:            userRepo,
+		txManager:           txManager,
+		orgService:          orgService,
+		requiredApprovals:   cfg.ApprovalRequiredApprovals,
+		minApproverLevel:    cfg.ApprovalMinApproverLevel,
+		expiry:              cfg.ApprovalExpiry,
+		bulkAssignThreshold: cfg.ApprovalBulkAssignThreshold,
+		executors:           make(map[string]ChangeRequestExecutor),
+	}
+}
+
+// RegisterExecutor binds an action to the function that performs it once approved.
+func (s *approvalService) RegisterExecutor(action string, executor ChangeRequestExecutor) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.executors[action] = executor
+}
+
+// RequiresBulkAssignApproval reports whether a bulk assignment of userCount users must go through approval.
+func (s *approvalService) RequiresBulkAssignApproval(userCount int) bool {
+	return userCount > s.bulkAssignThreshold
+}
+
+// SubmitChangeRequest queues a sensitive operation until enough approvers sign off.
+func (s *approvalService) SubmitChangeRequest(ctx context.Context, action string, targetID *uuid.UUID, summary string, payload interface{}, requestedBy uuid.UUID) (*dto.ChangeRequestResponse, error) {
+	if s.executor(action) == nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("no executor registered for change request action %q", action))
+	}
+
+	if targetID != nil {
+		existing, err := s.changeRequestRepo.FindPendingByActionAndTarget(ctx, action, *targetID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.NewInternalError(fmt.Errorf("failed to check pending change requests: %w", err))
+		}
+		if existing != nil {
+			if existing.ExpiresAt.After(time.Now()) {
+				return nil, apperror.NewConflictError(fmt.Sprintf("A pending change request (%s) already exists for this operation", existing.ID))
+			}
+			// The overdue request still holds the pending slot until the expiry job records it
+			if _, err := s.ExpireChangeRequests(ctx); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	serialized, err := json.Marshal(payload)
+	if err != nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to serialize change request payload: %w", err))
+	}
+
+	changeRequest := &model.ChangeRequest{
+		Action:            action,
+		TargetID:          targetID,
+		Summary:           summary,
+		Payload:           string(serialized),
+		RequestedBy:       requestedBy,
+		Status:            constant.ChangeRequestStatusPending,
+		RequiredApprovals: s.requiredApprovals,
+		MinApproverLevel:  s.minApproverLevel,
+		ExpiresAt:         time.Now().Add(s.expiry),
+	}
+
+	created, err := s.changeRequestRepo.Create(ctx, changeRequest)
+	if err != nil {
+		if errors.Is(err, repository.ErrChangeRequestAlreadyPending) {
+			return nil, apperror.NewConflictError("A pending change request already exists for this operation")
+		}
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to create change request: %w", err))
+	}
+
+	log.Info().
+		Str("change_request_id", created.ID.String()).
+		Str("action", action).
+		Str("requested_by", requestedBy.String()).
+		Msg("Change request submitted for approval")
+
+	return s.GetChangeRequest(ctx, created.ID)
+}
+
+// ListChangeRequests returns change requests, optionally filtered by status.
+func (s *approvalService) ListChangeRequests(ctx context.Context, status string, page, limit int) (*dto.PagedChangeRequestResponse, error) {
+	switch status {
+	case "", constant.ChangeRequestStatusPending, constant.ChangeRequestStatusExecuting, constant.ChangeRequestStatusExecuted,
+		constant.ChangeRequestStatusRejected, constant.ChangeRequestStatusCancelled, constant.ChangeRequestStatusExpired:
+	default:
+		return nil, apperror.NewValidationError(fmt.Sprintf("Invalid change request status '%s'", status))
+	}
+
+	page, limit, offset := util.ValidateAndSetPaginationParams(page, limit)
+
+	changeRequests, err := s.changeRequestRepo.List(ctx, status, offset, limit)
+	if err != nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to list change requests: %w", err))
+	}
+
+	total, err := s.changeRequestRepo.Count(ctx, status)
+	if err != nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to count change requests: %w", err))
+	}
+
+	responses := make([]dto.ChangeRequestResponse, len(changeRequests))
+	for i := range changeRequests {
+		responses[i] = *s.mapChangeRequestToResponse(&changeRequests[i])
+	}
+
+	return &dto.PagedChangeRequestResponse{
+		ChangeRequests: responses,
+		Page:           page,
+		Limit:          limit,
+		Total:          total,
+		TotalPages:     int(math.Ceil(float64(total) / float64(limit))),
+	}, nil
+}
+
+// GetChangeRequest returns a single change request with its decisions.
+func (s *approvalService) GetChangeRequest(ctx context.Context, id uuid.UUID) (*dto.ChangeRequestResponse, error) {
+	changeRequest, err := s.findChangeRequest(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.mapChangeRequestToResponse(changeRequest), nil
+}
+
+// DecideChangeRequest records an approval or rejection. The approval that reaches the required
+// count executes the operation; a single rejection closes the request.
+//
+// The decision, the operation and the final status commit in one transaction. When the operation
+// fails everything rolls back, including the decision, so the request stays pending and can be
+// approved again once the cause is fixed. The transaction runs on a context detached from the
+// caller, so a client disconnect cannot abort an operation halfway.
+func (s *approvalService) DecideChangeRequest(ctx context.Context, id uuid.UUID, req dto.ChangeRequestDecisionRequest, approverID uuid.UUID) (*dto.ChangeRequestResponse, error) {
+	changeRequest, err := s.findChangeRequest(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if status := effectiveStatus(changeRequest, time.Now()); status != constant.ChangeRequestStatusPending {
+		return nil, apperror.NewConflictError(fmt.Sprintf("Change request is already %s", status))
+	}
+	if changeRequest.RequestedBy == approverID {
+		return nil, apperror.NewForbiddenError("You cannot decide on your own change request")
+	}
+	for _, decision := range changeRequest.Approvals {
+		if decision.ApproverID == approverID {
+			return nil, apperror.NewConflictError("You have already decided on this change request")
+		}
+	}
+
+	approver, err := s.userRepo.FindByIDWithRole(ctx, approverID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.NewNotFoundError("approver")
+		}
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to fetch approver: %w", err))
+	}
+	if approver.Role == nil || approver.Role.Level < changeRequest.MinApproverLevel {
+		return nil, apperror.NewForbiddenError(fmt.Sprintf("Approvers must have at least level %d", changeRequest.MinApproverLevel))
+	}
+	if err := s.ensureApproverCanAccessTarget(ctx, changeRequest, approverID, approver.Role.Level); err != nil {
+		return nil, err
+	}
+
+	var updated *model.ChangeRequest
+	err = s.txManager.RunInTx(context.WithoutCancel(ctx), func(ctx context.Context) error {
+		var err error
+		updated, err = s.changeRequestRepo.RecordDecision(ctx, &model.ChangeRequestApproval{
+			ChangeRequestID: id,
+			ApproverID:      approverID,
+			Decision:        req.Decision,
+			Comment:         req.Comment,
+		})
+		if err != nil {
+			if errors.Is(err, repository.ErrChangeRequestNotPending) {
+				return apperror.NewConflictError("Change request is no longer pending")
+			}
+			return apperror.NewInternalError(fmt.Errorf("failed to record decision: %w", err))
+		}
+
+		if updated.Status != constant.ChangeRequestStatusExecuting {
+			return nil
+		}
+		result, err := s.execute(ctx, updated)
+		if err != nil {
+			return err
+		}
+		if err := s.changeRequestRepo.MarkExecuted(ctx, id, time.Now(), result); err != nil {
+			return apperror.NewInternalError(fmt.Errorf("failed to record change request outcome: %w", err))
+		}
+		updated.Status = constant.ChangeRequestStatusExecuted
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info().
+		Str("change_request_id", id.String()).
+		Str("approver_id", approverID.String()).
+		Str("decision", req.Decision).
+		Str("status", updated.Status).
+		Msg("Change request decision recorded")
+
+	return s.GetChangeRequest(ctx, id)
+}
+
+// CancelChangeRequest withdraws a pending change request. Only the requester can cancel it.
+func (s *approvalService) CancelChangeRequest(ctx context.Context, id uuid.UUID, requestedBy uuid.UUID) (*dto.ChangeRequestResponse, error) {
+	changeRequest, err := s.findChangeRequest(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if changeRequest.RequestedBy != requestedBy {
+		return nil, apperror.NewForbiddenError("Only the requester can cancel a change request")
+	}
+	if status := effectiveStatus(changeRequest, time.Now()); status != constant.ChangeRequestStatusPending {
+		return nil, apperror.NewConflictError(fmt.Sprintf("Change request is already %s", status))
+	}
+
+	cancelled, err := s.changeRequestRepo.UpdateStatus(ctx, id, constant.ChangeRequestStatusPending, constant.ChangeRequestStatusCancelled)
+	if err != nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to cancel change request: %w", err))
+	}
+	if !cancelled {
+		return nil, apperror.NewConflictError(fmt.Sprintf("Change request is already %s", changeRequest.Status))
+	}
+
+	return s.GetChangeRequest(ctx, id)
+}
+
+// ExpireChangeRequests marks every overdue pending change request as expired.
+func (s *approvalService) ExpireChangeRequests(ctx context.Context) (int64, error) {
+	expired, err := s.changeRequestRepo.ExpirePending(ctx, time.Now())
+	if err != nil {
+		return 0, apperror.NewInternalError(fmt.Errorf("failed to expire change requests: %w", err))
+	}
+	return expired, nil
+}
+
+// ensureApproverCanAccessTarget checks that an approver below platform level has access to the organization an
+// organization-scoped change request targets.
+func (s *approvalService) ensureApproverCanAccessTarget(ctx context.Context, changeRequest *model.ChangeRequest, approverID uuid.UUID, approverLevel int) error {
+	if !organizationScopedActions[changeRequest.Action] || changeRequest.TargetID == nil || approverLevel >= constant.RoleLevelPlatformManager {
+		return nil
+	}
+
+	accessibleOrgIDs, err := s.orgService.GetAccessibleOrganizationIDs(ctx, approverID, approverLevel)
+	if err != nil {
+		return apperror.NewInternalError(fmt.Errorf("failed to resolve approver organizations: %w", err))
+	}
+	if !slices.Contains(accessibleOrgIDs, *changeRequest.TargetID) {
+		return apperror.NewForbiddenError("You do not have access to the organization of this change request")
+	}
+	return nil
+}
+
+// effectiveStatus reports an overdue pending change request as expired before the expiry job records it.
+func effectiveStatus(changeRequest *model.ChangeRequest, now time.Time) string {
+	if changeRequest.Status == constant.ChangeRequestStatusPending && !changeRequest.ExpiresAt.After(now) {
+		return constant.ChangeRequestStatusExpired
+	}
+	return changeRequest.Status
+}
+
+// execute runs the executor of a change request that reached its required approvals and returns its
+// serialized result. Failures the approver can act on keep their message; anything else is reported as
+// an internal error.
+func (s *approvalService) execute(ctx context.Context, changeRequest *model.ChangeRequest) (*string, error) {
+	executor := s.executor(changeRequest.Action)
+	if executor == nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("no executor registered for change request action %q", changeRequest.Action))
+	}
+
+	result, err := executor(ctx, []byte(changeRequest.Payload))
+	if err != nil {
+		log.Error().
+			Err(err).
+			Str("change_request_id", changeRequest.ID.String()).
+			Str("action", changeRequest.Action).
+			Msg("Approved change request failed to execute, decision rolled back")
+
+		var appErr *apperror.AppError
+		if errors.As(err, &appErr) {
+			return nil, appErr
+		}
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to execute change request: %w", err))
+	}
+
+	if result == nil {
+		return nil, nil
+	}
+	serialized, err := json.Marshal(result)
+	if err != nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to serialize change request result: %w", err))
+	}
+	value := string(serialized)
+	return &value, nil
+}
+
+// executor returns the registered executor for an action, or nil.
+func (s *approvalService) executor(action string) ChangeRequestExecutor {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.executors[action]
+}
+
+// findChangeRequest loads a change request and translates repository errors.
+func (s *approvalService) findChangeRequest(ctx context.Context, id uuid.UUID) (*model.ChangeRequest, error) {
+	changeRequest, err := s.changeRequestRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.NewNotFoundError("change request")
+		}
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to fetch change request: %w", err))
+	}
+	return changeRequest, nil
+}
+
+// mapChangeRequestToResponse converts a ChangeRequest model to its response DTO.
+func (s *approvalService) mapChangeRequestToResponse(changeRequest *model.ChangeRequest) *dto.ChangeRequestResponse {
+	response := &dto.ChangeRequestResponse{
+		ID:                changeRequest.ID,
+		Action:            changeRequest.Action,
+		TargetID:          changeRequest.TargetID,
+		Summary:           changeRequest.Summary,
+		Payload:           json.RawMessage(changeRequest.Payload),
+		RequestedBy:       changeRequest.RequestedBy,
+		RequestedByUser:   changeRequest.Requester.Username,
+		Status:            effectiveStatus(changeRequest, time.Now()),
+		RequiredApprovals: changeRequest.RequiredApprovals,
+		MinApproverLevel:  changeRequest.MinApproverLevel,
+		ExpiresAt:         changeRequest.ExpiresAt,
+		ExecutedAt:        changeRequest.ExecutedAt,
+		Decisions:         make([]dto.ChangeRequestDecisionResponse, len(changeRequest.Approvals)),
+		CreatedAt:         changeRequest.CreatedAt,
+		UpdatedAt:         changeRequest.UpdatedAt,
+	}
+
+	if changeRequest.Result != nil {
+		response.Result = json.RawMessage(*changeRequest.Result)
+	}
+
+	for i, decision := range changeRequest.Approvals {
+		if decision.Decision == constant.ChangeRequestDecisionApproved {
+			response.ApprovalCount++
+		}
+		response.Decisions[i] = dto.ChangeRequestDecisionResponse{
+			ApproverID: decision.ApproverID,
+			Approver:   decision.Approver.Username,
+			Decision:   decision.Decision,
+			Comment:    decision.Comment,
+			CreatedAt:  decision.CreatedAt,
+		}
+	}
+
+	return response
+}
@@ -0,0 +1,27 @@
+package service
+
+import (
+	"context"
+	"go-base-project/internal/dto"
+
+	"github.com/google/uuid"
+)
+
+// ChangeRequestExecutor runs the operation behind an approved change request from its serialized payload.
+// The returned value, if any, is stored as the change request result.
+type ChangeRequestExecutor func(ctx context.Context, payload []byte) (interface{}, error)
+
+// ApprovalServiceInterface defines the contract for four-eyes approval of sensitive admin actions.
+type ApprovalServiceInterface interface {
+	// RegisterExecutor binds an action to the function that performs it once approved.
+	RegisterExecutor(action string, executor ChangeRequestExecutor)
+	// RequiresBulkAssignApproval reports whether a bulk assignment of userCount users must go through approval.
+	RequiresBulkAssignApproval(userCount int) bool
+
+	SubmitChangeRequest(ctx context.Context, action string, targetID *uuid.UUID, summary string, payload interface{}, requestedBy uuid.UUID) (*dto.ChangeRequestResponse, error)
+	ListChangeRequests(ctx context.Context, status string, page, limit int) (*dto.PagedChangeRequestResponse, error)
+	GetChangeRequest(ctx context.Context, id uuid.UUID) (*dto.ChangeRequestResponse, error)
+	DecideChangeRequest(ctx context.Context, id uuid.UUID, req dto.ChangeRequestDecisionRequest, approverID uuid.UUID) (*dto.ChangeRequestResponse, error)
+	CancelChangeRequest(ctx context.Context, id uuid.UUID, requestedBy uuid.UUID) (*dto.ChangeRequestResponse, error)
+	ExpireChangeRequests(ctx context.Context) (int64, error)
+}
@@ -0,0 +1,295 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"go-base-project/internal/apperror"
+	"go-base-project/internal/config"
+	"go-base-project/internal/constant"
+	"go-base-project/internal/dto"
+	"go-base-project/internal/model"
+
+	"github.com/google/uuid"
+)
+
+func newTestApprovalService(repo *fakeChangeRequestRepo, tx *fakeTxManager) *approvalService {
+	return NewApprovalService(repo, &fakeUserRepo{level: 100}, tx, nil, config.Config{
+		ApprovalRequiredApprovals: 1,
+		ApprovalMinApproverLevel:  50,
+		ApprovalExpiry:            time.Hour,
+	}).(*approvalService)
+}
+
+func newPendingChangeRequest(action string) *model.ChangeRequest {
+	return &model.ChangeRequest{
+		ID:                uuid.New(),
+		Action:            action,
+		Payload:           `{}`,
+		RequestedBy:       uuid.New(),
+		Status:            constant.ChangeRequestStatusPending,
+		RequiredApprovals: 1,
+		MinApproverLevel:  50,
+		ExpiresAt:         time.Now().Add(time.Hour),
+	}
+}
+
+func TestDecideChangeRequestExecution(t *testing.T) {
+	const action = "test.action"
+
+	tests := []struct {
+		name           string
+		executorErr    error
+		wantCode       int
+		wantStatus     string
+		wantDecisions  int
+		wantRollbacks  int
+		wantMarkedOnce bool
+	}{
+		{
+			name:           "successful execution commits decision and outcome",
+			wantStatus:     constant.ChangeRequestStatusExecuted,
+			wantDecisions:  1,
+			wantMarkedOnce: true,
+		},
+		{
+			name:          "client error rolls back and keeps request pending",
+			executorErr:   apperror.NewConflictError("Organization has active children"),
+			wantCode:      http.StatusConflict,
+			wantStatus:    constant.ChangeRequestStatusPending,
+			wantRollbacks: 1,
+		},
+		{
+			name:          "unexpected error rolls back as internal error",
+			executorErr:   errors.New("connection reset"),
+			wantCode:      http.StatusInternalServerError,
+			wantStatus:    constant.ChangeRequestStatusPending,
+			wantRollbacks: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changeRequest := newPendingChangeRequest(action)
+			repo := newFakeChangeRequestRepo(changeRequest)
+			tx := &fakeTxManager{repo: repo}
+			svc := newTestApprovalService(repo, tx)
+
+			executed := 0
+			svc.RegisterExecutor(action, func(ctx context.Context, payload []byte) (interface{}, error) {
+				executed++
+				return nil, tt.executorErr
+			})
+
+			_, err := svc.DecideChangeRequest(context.Background(), changeRequest.ID, dto.ChangeRequestDecisionRequest{Decision: constant.ChangeRequestDecisionApproved}, uuid.New())
+
+			if tt.wantCode == 0 {
+				if err != nil {
+					t.Fatalf("DecideChangeRequest() error = %v, want nil", err)
+				}
+			} else {
+				var appErr *apperror.AppError
+				if !errors.As(err, &appErr) || appErr.Code != tt.wantCode {
+					t.Fatalf("DecideChangeRequest() error = %v, want AppError with code %d", err, tt.wantCode)
+				}
+			}
+
+			stored := repo.requests[changeRequest.ID]
+			if executed != 1 {
+				t.Errorf("executor ran %d times, want 1", executed)
+			}
+			if stored.Status != tt.wantStatus {
+				t.Errorf("status = %q, want %q", stored.Status, tt.wantStatus)
+			}
+			if len(stored.Approvals) != tt.wantDecisions {
+				t.Errorf("decisions = %d, want %d", len(stored.Approvals), tt.wantDecisions)
+			}
+			if tx.rollbacks != tt.wantRollbacks {
+				t.Errorf("rollbacks = %d, want %d", tx.rollbacks, tt.wantRollbacks)
+			}
+			if (repo.markedCalls == 1) != tt.wantMarkedOnce {
+				t.Errorf("MarkExecuted calls = %d, want marked once = %v", repo.markedCalls, tt.wantMarkedOnce)
+			}
+		})
+	}
+}
+
+func TestDecideChangeRequestRetryAfterFailedExecution(t *testing.T) {
+	const action = "test.action"
+	changeRequest := newPendingChangeRequest(action)
+	repo := newFakeChangeRequestRepo(changeRequest)
+	svc := newTestApprovalService(repo, &fakeTxManager{repo: repo})
+
+	fail := true
+	svc.RegisterExecutor(action, func(ctx context.Context, payload []byte) (interface{}, error) {
+		if fail {
+			return nil, apperror.NewConflictError("not yet")
+		}
+		return nil, nil
+	})
+
+	approverID := uuid.New()
+	decision := dto.ChangeRequestDecisionRequest{Decision: constant.ChangeRequestDecisionApproved}
+	if _, err := svc.DecideChangeRequest(context.Background(), changeRequest.ID, decision, approverID); err == nil {
+		t.Fatal("first DecideChangeRequest() error = nil, want executor error")
+	}
+
+	// The same approver can try again because the failed decision was rolled back
+	fail = false
+	if _, err := svc.DecideChangeRequest(context.Background(), changeRequest.ID, decision, approverID); err != nil {
+		t.Fatalf("second DecideChangeRequest() error = %v, want nil", err)
+	}
+	if status := repo.requests[changeRequest.ID].Status; status != constant.ChangeRequestStatusExecuted {
+		t.Errorf("status = %q, want %q", status, constant.ChangeRequestStatusExecuted)
+	}
+}
+
+func TestDecideChangeRequestDetachesExecutionFromCaller(t *testing.T) {
+	const action = "test.action"
+	changeRequest := newPendingChangeRequest(action)
+	repo := newFakeChangeRequestRepo(changeRequest)
+	svc := newTestApprovalService(repo, &fakeTxManager{repo: repo})
+
+	var executorCtxErr error
+	svc.RegisterExecutor(action, func(ctx context.Context, payload []byte) (interface{}, error) {
+		executorCtxErr = ctx.Err()
+		return nil, nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := svc.DecideChangeRequest(ctx, changeRequest.ID, dto.ChangeRequestDecisionRequest{Decision: constant.ChangeRequestDecisionApproved}, uuid.New()); err != nil {
+		t.Fatalf("DecideChangeRequest() error = %v, want nil", err)
+	}
+	if executorCtxErr != nil {
+		t.Errorf("executor context error = %v, want nil after the caller disconnected", executorCtxErr)
+	}
+}
+
+func TestDecideChangeRequestRejectionSkipsExecution(t *testing.T) {
+	const action = "test.action"
+	changeRequest := newPendingChangeRequest(action)
+	repo := newFakeChangeRequestRepo(changeRequest)
+	svc := newTestApprovalService(repo, &fakeTxManager{repo: repo})
+
+	executed := false
+	svc.RegisterExecutor(action, func(ctx context.Context, payload []byte) (interface{}, error) {
+		executed = true
+		return nil, nil
+	})
+
+	if _, err := svc.DecideChangeRequest(context.Background(), changeRequest.ID, dto.ChangeRequestDecisionRequest{Decision: constant.ChangeRequestDecisionRejected}, uuid.New()); err != nil {
+		t.Fatalf("DecideChangeRequest() error = %v, want nil", err)
+	}
+	if executed {
+		t.Error("executor ran for a rejected change request")
+	}
+	if status := repo.requests[changeRequest.ID].Status; status != constant.ChangeRequestStatusRejected {
+		t.Errorf("status = %q, want %q", status, constant.ChangeRequestStatusRejected)
+	}
+}
+
+func TestOverdueChangeRequestIsExpiredWithoutWrites(t *testing.T) {
+	const action = "test.action"
+	changeRequest := newPendingChangeRequest(action)
+	changeRequest.ExpiresAt = time.Now().Add(-time.Minute)
+	repo := newFakeChangeRequestRepo(changeRequest)
+	svc := newTestApprovalService(repo, &fakeTxManager{repo: repo})
+	svc.RegisterExecutor(action, func(ctx context.Context, payload []byte) (interface{}, error) {
+		t.Fatal("executor ran for an expired change request")
+		return nil, nil
+	})
+
+	response, err := svc.GetChangeRequest(context.Background(), changeRequest.ID)
+	if err != nil {
+		t.Fatalf("GetChangeRequest() error = %v", err)
+	}
+	if response.Status != constant.ChangeRequestStatusExpired {
+		t.Errorf("reported status = %q, want %q", response.Status, constant.ChangeRequestStatusExpired)
+	}
+
+	_, err = svc.DecideChangeRequest(context.Background(), changeRequest.ID, dto.ChangeRequestDecisionRequest{Decision: constant.ChangeRequestDecisionApproved}, uuid.New())
+	var appErr *apperror.AppError
+	if !errors.As(err, &appErr) || appErr.Code != http.StatusConflict {
+		t.Fatalf("DecideChangeRequest() error = %v, want a conflict", err)
+	}
+	if repo.recordedCalls != 0 || changeRequest.Status != constant.ChangeRequestStatusPending {
+		t.Errorf("decision recorded %d times and status = %q, want no writes", repo.recordedCalls, changeRequest.Status)
+	}
+}
+
+func TestDecideChangeRequestRequiresAccessToTargetOrganization(t *testing.T) {
+	targetID := uuid.New()
+
+	tests := []struct {
+		name          string
+		action        string
+		approverLevel int
+		accessible    []uuid.UUID
+		wantCode      int
+	}{
+		{name: "organization member may approve", action: constant.ChangeRequestActionDeleteOrganization, approverLevel: 60, accessible: []uuid.UUID{targetID}},
+		{name: "outsider is refused", action: constant.ChangeRequestActionDeleteOrganization, approverLevel: 60, wantCode: http.StatusForbidden},
+		{name: "bulk assignment is organization scoped", action: constant.ChangeRequestActionBulkAssignUsers, approverLevel: 60, wantCode: http.StatusForbidden},
+		{name: "platform level reaches every organization", action: constant.ChangeRequestActionDeleteOrganization, approverLevel: constant.RoleLevelPlatformManager},
+		{name: "actions without an organization target are not scoped", action: constant.ChangeRequestActionDeleteUser, approverLevel: 60},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changeRequest := newPendingChangeRequest(tt.action)
+			changeRequest.TargetID = &targetID
+			repo := newFakeChangeRequestRepo(changeRequest)
+			svc := NewApprovalService(repo, &fakeUserRepo{level: tt.approverLevel}, &fakeTxManager{repo: repo}, &fakeAccessibleOrgService{accessible: tt.accessible}, config.Config{
+				ApprovalRequiredApprovals: 1,
+				ApprovalMinApproverLevel:  50,
+				ApprovalExpiry:            time.Hour,
+			})
+			svc.RegisterExecutor(tt.action, func(ctx context.Context, payload []byte) (interface{}, error) {
+				return nil, nil
+			})
+
+			_, err := svc.DecideChangeRequest(context.Background(), changeRequest.ID, dto.ChangeRequestDecisionRequest{Decision: constant.ChangeRequestDecisionApproved}, uuid.New())
+
+			if tt.wantCode == 0 {
+				if err != nil {
+					t.Fatalf("DecideChangeRequest() error = %v, want nil", err)
+				}
+				return
+			}
+			var appErr *apperror.AppError
+			if !errors.As(err, &appErr) || appErr.Code != tt.wantCode {
+				t.Fatalf("DecideChangeRequest() error = %v, want AppError with code %d", err, tt.wantCode)
+			}
+			if repo.recordedCalls != 0 {
+				t.Errorf("decision recorded %d times, want none", repo.recordedCalls)
+			}
+		})
+	}
+}
+
+func TestSubmitChangeRequestRejectsConcurrentDuplicate(t *testing.T) {
+	const action = "test.action"
+	// The fake lookup never finds the existing request, as when two submissions race past the read check
+	existing := newPendingChangeRequest(action)
+	targetID := uuid.New()
+	existing.TargetID = &targetID
+	repo := newFakeChangeRequestRepo(existing)
+	svc := newTestApprovalService(repo, &fakeTxManager{repo: repo})
+	svc.RegisterExecutor(action, func(ctx context.Context, payload []byte) (interface{}, error) {
+		return nil, nil
+	})
+
+	_, err := svc.SubmitChangeRequest(context.Background(), action, &targetID, "duplicate", struct{}{}, uuid.New())
+
+	var appErr *apperror.AppError
+	if !errors.As(err, &appErr) || appErr.Code != http.StatusConflict {
+		t.Fatalf("SubmitChangeRequest() error = %v, want a conflict", err)
+	}
+	if len(repo.requests) != 1 {
+		t.Errorf("stored %d change requests, want only the existing one", len(repo.requests))
+	}
+}
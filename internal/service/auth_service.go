@@ -7,7 +7,6 @@ import (
 	"go-base-project/internal/model"
 	"go-base-project/internal/repository"
 	"go-base-project/internal/util"
-	"go-base-project/pkg/generator"
 	"context"
 	"errors"
 	"fmt"
@@ -27,16 +26,18 @@ type authService struct {
 	userRepo             repository.UserRepositoryInterface
 	roleRepo             repository.RoleRepositoryInterface
 	authorizationService AuthorizationServiceInterface
+	usernameService      UsernameServiceInterface
 	redis                *redis.Client
 	jwtSecret            string
 }
 
 // NewAuthService creates a new instance of authService.
-func NewAuthService(userRepo repository.UserRepositoryInterface, roleRepo repository.RoleRepositoryInterface, authorizationService AuthorizationServiceInterface, redis *redis.Client, jwtSecret string) AuthServiceInterface {
+func NewAuthService(userRepo repository.UserRepositoryInterface, roleRepo repository.RoleRepositoryInterface, authorizationService AuthorizationServiceInterface, usernameService UsernameServiceInterface, redis *redis.Client, jwtSecret string) AuthServiceInterface {
 	return &authService{
 		userRepo:             userRepo,
 		roleRepo:             roleRepo,
 		authorizationService: authorizationService,
+		usernameService:      usernameService,
 		redis:                redis,
 		jwtSecret:            jwtSecret,
 	}
@@ -91,7 +92,13 @@ func (s *authService) Login(ctx context.Context, username, password string) (*dt
 		return nil, apperror.NewUnauthorizedError(constant.ErrMsgInvalidCredentials)
 	}
 
-	// 4. Create comprehensive login result using repository data
+	// 4. Only active accounts may sign in; checked after the password so the status is not disclosed
+	if err := checkUserStatus(user, time.Now()); err != nil {
+		log.Warn().Str("username", username).Str("user_id", user.ID.String()).Str("status", user.Status).Msg("Login attempt on inactive account")
+		return nil, err
+	}
+
+	// 5. Create comprehensive login result using repository data
 	loginResult, err := s.createLoginResultForUser(ctx, user)
 	if err != nil {
 		log.Error().Err(err).Str("username", username).Str("user_id", user.ID.String()).Msg("Failed to create login result")
@@ -145,7 +152,7 @@ func (s *authService) RefreshToken(ctx context.Context, tokenString string) (str
 		return "", "", apperror.NewUnauthorizedError("invalid user id format")
 	}
 
-	// 4. Cek apakah user masih ada di database
+	// 4. Cek apakah user masih ada di database dan akunnya masih aktif
 	user, err := s.userRepo.FindByIDWithRoleAndOrganizations(ctx, userID)
 	if err != nil {
 		// Jika user tidak ditemukan, kembalikan error unauthorized, bukan not found
@@ -154,6 +161,9 @@ func (s *authService) RefreshToken(ctx context.Context, tokenString string) (str
 		}
 		return "", "", apperror.NewInternalError(err)
 	}
+	if err := checkUserStatus(user, time.Now()); err != nil {
+		return "", "", err
+	}
 	if user.RoleID == nil {
 		return "", "", apperror.NewInternalError(fmt.Errorf("user %s has no role assigned", user.ID))
 	}
@@ -176,7 +186,10 @@ func (s *authService) LoginWithGoogle(ctx context.Context, userInfo dto.GoogleUs
 	// 1. Cek apakah user sudah ada dengan Google ID
 	user, err := s.userRepo.FindByGoogleID(ctx, userInfo.ID)
 	if err == nil {
-		// User ditemukan, langsung login
+		// User ditemukan, langsung login jika akunnya aktif
+		if err := checkUserStatus(user, time.Now()); err != nil {
+			return nil, err
+		}
 		user, _ = s.userRepo.FindByIDWithRoleAndOrganizations(ctx, user.ID) // Muat ulang dengan role dan organizations
 		return s.createLoginResultForUser(ctx, user)
 	}
@@ -189,9 +202,14 @@ func (s *authService) LoginWithGoogle(ctx context.Context, userInfo dto.GoogleUs
 	// 2. User belum ada, cek berdasarkan email (mungkin sudah daftar manual)
 	user, err = s.userRepo.FindByEmail(ctx, userInfo.Email)
 	if err == nil {
-		// User dengan email yang sama ditemukan, tautkan akunnya
+		// User dengan email yang sama ditemukan, tautkan akunnya jika akunnya aktif
+		if err := checkUserStatus(user, time.Now()); err != nil {
+			return nil, err
+		}
 		user.GoogleID = &userInfo.ID
-		user.AvatarURL = userInfo.Picture
+		if user.AvatarKey == "" { // Avatar yang diunggah sendiri tidak ditimpa foto Google
+			user.AvatarURL = userInfo.Picture
+		}
 		user.AuthProvider = "google"
 		if err := s.userRepo.Update(ctx, user); err != nil {
 			return nil, apperror.NewInternalError(fmt.Errorf("failed to link google account: %w", err))
@@ -203,7 +221,6 @@ func (s *authService) LoginWithGoogle(ctx context.Context, userInfo dto.GoogleUs
 	// 3. User benar-benar baru, buat akun baru TANPA role dan organization
 	newUser := &model.User{
 		Email:        userInfo.Email,
-		Username:     generator.GenerateFromEmail(userInfo.Email), // Pastikan username unik
 		GoogleID:     &userInfo.ID,
 		AvatarURL:    userInfo.Picture,
 		AuthProvider: "google",
@@ -211,7 +228,8 @@ func (s *authService) LoginWithGoogle(ctx context.Context, userInfo dto.GoogleUs
 		// RoleID akan tetap nil, user harus request role sendiri
 	}
 
-	if err := s.userRepo.Create(ctx, newUser); err != nil {
+	// Username dibuat dari email dan dijamin unik oleh username service
+	if err := s.usernameService.CreateUserWithGeneratedUsername(ctx, newUser, userInfo.Email); err != nil {
 		return nil, apperror.NewInternalError(fmt.Errorf("failed to create user from google info: %w", err))
 	}
 
@@ -1,29 +1,37 @@
 package service
 
 import (
+	"go-base-project/internal/apperror"
 	"go-base-project/internal/cache"
+	"go-base-project/internal/constant"
+	"go-base-project/internal/model"
 	"go-base-project/internal/repository"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/go-redis/redis/v8"
 	"github.com/google/uuid"
 	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
 )
 
 type authorizationService struct {
-	roleRepo repository.RoleRepositoryInterface
-	userRepo repository.UserRepositoryInterface
-	redis    *redis.Client
+	roleRepo       repository.RoleRepositoryInterface
+	userRepo       repository.UserRepositoryInterface
+	membershipRepo repository.MembershipRepositoryInterface
+	redis          *redis.Client
 }
 
 // NewAuthorizationService creates a new authorization service instance
-func NewAuthorizationService(roleRepo repository.RoleRepositoryInterface, userRepo repository.UserRepositoryInterface, redis *redis.Client) AuthorizationServiceInterface {
+func NewAuthorizationService(roleRepo repository.RoleRepositoryInterface, userRepo repository.UserRepositoryInterface, membershipRepo repository.MembershipRepositoryInterface, redis *redis.Client) AuthorizationServiceInterface {
 	return &authorizationService{
-		roleRepo: roleRepo,
-		userRepo: userRepo,
-		redis:    redis,
+		roleRepo:       roleRepo,
+		userRepo:       userRepo,
+		membershipRepo: membershipRepo,
+		redis:          redis,
 	}
 }
 
@@ -61,7 +69,7 @@ func (s *authorizationService) GetAndCachePermissionsForRole(ctx context.Context
 	// Super admin gets ALL permissions automatically
 	if isSuperAdmin {
 		allPermissions := []string{
-			"users:create", "users:read", "users:update", "users:delete",
+			"users:create", "users:read", "users:update", "users:delete", "users:suspend", "users:erase",
 			"roles:assign", "roles:create", "roles:approve", "roles:update", "roles:read",
 			"permissions:create", "permissions:read", "permissions:update", "permissions:delete",
 			"dashboard:view", "scanned_data:create",
@@ -132,22 +140,56 @@ func (s *authorizationService) IsRoleSuperAdmin(ctx context.Context, roleID uuid
 	return s.roleRepo.IsRoleSuperAdmin(ctx, roleID)
 }
 
+// EnsureUserActive checks that a user still exists and may use the application. Only active accounts are
+// cached, so suspensions take effect on the next request once the user service drops the cache entry.
+func (s *authorizationService) EnsureUserActive(ctx context.Context, userID uuid.UUID) error {
+	cacheKey := cache.GetUserStatusCacheKey(userID)
+	status, err := s.redis.Get(ctx, cacheKey).Result()
+	if err == nil && status == constant.UserStatusActive {
+		return nil
+	}
+	if err != nil && err != redis.Nil {
+		log.Warn().Err(err).Str("user_id", userID.String()).Msg("Failed to read user status cache")
+	}
+
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apperror.NewUnauthorizedError("user for this token no longer exists")
+		}
+		return apperror.NewInternalError(fmt.Errorf("failed to find user: %w", err))
+	}
+	if err := checkUserStatus(user, time.Now()); err != nil {
+		return err
+	}
+
+	if err := s.redis.Set(ctx, cacheKey, constant.UserStatusActive, cache.UserStatusCacheDuration).Err(); err != nil {
+		log.Warn().Err(err).Str("user_id", userID.String()).Msg("Failed to cache user status")
+	}
+	return nil
+}
+
 // CheckUserOrganizationAccess checks if a user has access to a specific organization.
-// This method validates that there's an active user-organization relationship.
+// This method validates that there's an active user-organization relationship with an organization
+// that is neither archived nor deleted.
 func (s *authorizationService) CheckUserOrganizationAccess(ctx context.Context, userID, organizationID uuid.UUID) (bool, error) {
 	// Find the user-organization relationship
-	userOrg, err := s.userRepo.FindUserOrganization(ctx, userID, organizationID)
+	userOrg, err := s.membershipRepo.Find(ctx, userID, organizationID)
 	if err != nil {
 		// If no relationship found, user doesn't have access
 		return false, nil
 	}
 
-	// Check if the relationship is active
-	if userOrg != nil && userOrg.IsActive {
-		return true, nil
-	}
+	return membershipGrantsAccess(userOrg), nil
+}
 
-	return false, nil
+// membershipGrantsAccess reports whether a membership is usable: active, in an organization that is
+// not archived. Soft-deleted organizations are not preloaded, which leaves the organization empty.
+func membershipGrantsAccess(userOrg *model.UserOrganization) bool {
+	if userOrg == nil || !userOrg.IsActive {
+		return false
+	}
+	return userOrg.Organization.ID != uuid.Nil && !userOrg.Organization.IsArchived()
 }
 
 // CheckPermissionInOrganization checks if a user has a specific permission within an organization context.
@@ -178,12 +220,12 @@ func (s *authorizationService) CheckPermissionInOrganization(ctx context.Context
 
 // GetUserRoleInOrganization retrieves the user's role ID within a specific organization.
 func (s *authorizationService) GetUserRoleInOrganization(ctx context.Context, userID, organizationID uuid.UUID) (*uuid.UUID, error) {
-	userOrg, err := s.userRepo.FindUserOrganization(ctx, userID, organizationID)
+	userOrg, err := s.membershipRepo.Find(ctx, userID, organizationID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find user organization relationship: %w", err)
 	}
 
-	if userOrg == nil || !userOrg.IsActive {
+	if !membershipGrantsAccess(userOrg) {
 		return nil, nil
 	}
 
@@ -232,3 +274,27 @@ func (s *authorizationService) ValidateRoleAccessibleInOrganization(ctx context.
 
 	return false, nil
 }
+
+// GetUserEffectiveLevel returns the highest role level a user holds, either globally or through
+// an active membership in the given organization.
+func (s *authorizationService) GetUserEffectiveLevel(ctx context.Context, userID, organizationID uuid.UUID) (int, error) {
+	user, err := s.userRepo.FindByIDWithRole(ctx, userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find user: %w", err)
+	}
+
+	level := 0
+	if user.Role != nil {
+		level = user.Role.Level
+	}
+
+	userOrg, err := s.membershipRepo.Find(ctx, userID, organizationID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, fmt.Errorf("failed to find user organization relationship: %w", err)
+	}
+	if err == nil && membershipGrantsAccess(userOrg) && userOrg.Role != nil && userOrg.Role.Level > level {
+		level = userOrg.Role.Level
+	}
+
+	return level, nil
+}
@@ -13,10 +13,13 @@ type AuthorizationServiceInterface interface {
 	GetAndCachePermissionsForRole(ctx context.Context, roleID uuid.UUID) ([]string, error)
 	CheckUserOrganizationAccess(ctx context.Context, userID, organizationID uuid.UUID) (bool, error)
 	IsRoleSuperAdmin(ctx context.Context, roleID uuid.UUID) (bool, error)
+	// EnsureUserActive returns an error unless the user still exists and their account is active.
+	EnsureUserActive(ctx context.Context, userID uuid.UUID) error
 
 	// Multi-tenant role isolation methods
 	CheckPermissionInOrganization(ctx context.Context, userID, organizationID uuid.UUID, requiredPermission string) (bool, error)
 	GetUserRoleInOrganization(ctx context.Context, userID, organizationID uuid.UUID) (*uuid.UUID, error)
 	GetUserPermissionsInOrganization(ctx context.Context, userID, organizationID uuid.UUID) ([]string, error)
 	ValidateRoleAccessibleInOrganization(ctx context.Context, roleID, organizationID uuid.UUID, organizationType string) (bool, error)
+	GetUserEffectiveLevel(ctx context.Context, userID, organizationID uuid.UUID) (int, error)
 }
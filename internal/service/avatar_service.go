@@ -0,0 +1,190 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-base-project/internal/apperror"
+	"go-base-project/internal/config"
+	"go-base-project/internal/dto"
+	"go-base-project/internal/repository"
+	"go-base-project/pkg/imaging"
+	"go-base-project/platform/storage"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+const (
+	// avatarMinSize is the smallest accepted width and height of an uploaded avatar
+	avatarMinSize = 64
+	// imageVariantQuality is the JPEG quality of stored image variants
+	imageVariantQuality = 85
+)
+
+// avatarSizes are the standard avatar sizes in pixels, ascending; the largest one is the default
+var avatarSizes = []int{64, 128, 256}
+
+type avatarService struct {
+	userRepo  repository.UserRepositoryInterface
+	store     storage.BlobStore
+	cfg       config.Config
+	avatarURL string // Base of the stable avatar URLs that redirect to a fresh signed URL
+}
+
+// NewAvatarService creates a new instance of AvatarService
+func NewAvatarService(userRepo repository.UserRepositoryInterface, store storage.BlobStore, cfg config.Config) AvatarServiceInterface {
+	return &avatarService{
+		userRepo:  userRepo,
+		store:     store,
+		cfg:       cfg,
+		avatarURL: strings.TrimRight(cfg.BackendURL, "/") + "/api/avatars/",
+	}
+}
+
+// UploadAvatar stores every standard size under a new versioned prefix, so cached URLs of the previous
+// avatar never serve the new image, and deletes the previous avatar once the user points at the new one.
+func (s *avatarService) UploadAvatar(ctx context.Context, userID uuid.UUID, data []byte) (*dto.UserProfileResponse, error) {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.NewNotFoundError("user")
+		}
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to find user: %w", err))
+	}
+
+	img, err := imaging.Decode(data, avatarMinSize)
+	if err != nil {
+		return nil, apperror.NewValidationError(err.Error())
+	}
+
+	version := strconv.FormatInt(time.Now().UnixMilli(), 36)
+	prefix := "avatars/" + userID.String() + "/" + version
+	if err := storeImageVariants(ctx, s.store, prefix, img, avatarSizes); err != nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to store avatar: %w", err))
+	}
+
+	avatarURL := s.store.PublicURL(imageVariantKey(prefix, avatarSizes[len(avatarSizes)-1]))
+	if s.cfg.StorageSignedURLs {
+		avatarURL = s.avatarURL + userID.String() + "?" + url.Values{"v": {version}}.Encode()
+	}
+	if err := s.userRepo.UpdateAvatar(ctx, userID, prefix, avatarURL); err != nil {
+		deleteImageVariants(ctx, s.store, prefix, avatarSizes)
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to update avatar: %w", err))
+	}
+	if user.AvatarKey != "" {
+		deleteImageVariants(ctx, s.store, user.AvatarKey, avatarSizes)
+	}
+
+	log.Info().Str("user_id", userID.String()).Str("avatar_key", prefix).Msg("Avatar uploaded")
+	user.AvatarKey = prefix
+	user.AvatarURL = avatarURL
+	return mapUserProfileToResponse(user), nil
+}
+
+// DeleteAvatar clears the avatar of a user, including an external picture, and deletes the stored images.
+func (s *avatarService) DeleteAvatar(ctx context.Context, userID, deletedBy uuid.UUID) (*dto.UserProfileResponse, error) {
+	user, err := s.userRepo.FindByIDWithRole(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.NewNotFoundError("user")
+		}
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to find user: %w", err))
+	}
+	if userID != deletedBy {
+		if err := checkAuthorityOverUser(ctx, s.userRepo, user, deletedBy); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.userRepo.UpdateAvatar(ctx, userID, "", ""); err != nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to remove avatar: %w", err))
+	}
+	if user.AvatarKey != "" {
+		deleteImageVariants(ctx, s.store, user.AvatarKey, avatarSizes)
+	}
+
+	log.Info().Str("user_id", userID.String()).Str("deleted_by", deletedBy.String()).Msg("Avatar removed")
+	user.AvatarKey = ""
+	user.AvatarURL = ""
+	return mapUserProfileToResponse(user), nil
+}
+
+// GetAvatarURL resolves the avatar of a user for the stable avatar URL, which redirects to the result.
+func (s *avatarService) GetAvatarURL(ctx context.Context, userID uuid.UUID, size int) (string, error) {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", apperror.NewNotFoundError("avatar")
+		}
+		return "", apperror.NewInternalError(fmt.Errorf("failed to find user: %w", err))
+	}
+	if user.AvatarKey == "" {
+		if user.AvatarURL == "" {
+			return "", apperror.NewNotFoundError("avatar")
+		}
+		return user.AvatarURL, nil
+	}
+
+	key := imageVariantKey(user.AvatarKey, nearestImageSize(avatarSizes, size))
+	if !s.cfg.StorageSignedURLs {
+		return s.store.PublicURL(key), nil
+	}
+	signedURL, err := s.store.SignedURL(ctx, key, s.cfg.StorageURLExpiry)
+	if err != nil {
+		return "", apperror.NewInternalError(fmt.Errorf("failed to sign avatar URL: %w", err))
+	}
+	return signedURL, nil
+}
+
+// storeImageVariants crops img to a square and stores it as a JPEG per size under prefix. It is shared by
+// every image kept in standard sizes, such as avatars and organization logos. Variants stored before a
+// failure are deleted again.
+func storeImageVariants(ctx context.Context, store storage.BlobStore, prefix string, img image.Image, sizes []int) error {
+	for i, size := range sizes {
+		data, err := imaging.EncodeJPEG(imaging.SquareThumbnail(img, size), imageVariantQuality)
+		if err == nil {
+			err = store.Put(ctx, imageVariantKey(prefix, size), bytes.NewReader(data), int64(len(data)), imaging.ContentTypeJPEG)
+		}
+		if err != nil {
+			deleteImageVariants(ctx, store, prefix, sizes[:i])
+			return fmt.Errorf("failed to store %dpx variant: %w", size, err)
+		}
+	}
+	return nil
+}
+
+// deleteImageVariants deletes the variants stored under prefix. Failures only leave orphaned objects
+// behind, so they are logged rather than returned.
+func deleteImageVariants(ctx context.Context, store storage.BlobStore, prefix string, sizes []int) {
+	for _, size := range sizes {
+		key := imageVariantKey(prefix, size)
+		if err := store.Delete(ctx, key); err != nil {
+			log.Warn().Err(err).Str("key", key).Msg("Failed to delete image variant")
+		}
+	}
+}
+
+// imageVariantKey is the storage key of the variant of the given size, e.g. avatars/<user id>/<version>/128.jpg
+func imageVariantKey(prefix string, size int) string {
+	return prefix + "/" + strconv.Itoa(size) + ".jpg"
+}
+
+// nearestImageSize picks the smallest of the ascending sizes that is at least size, or the largest one
+// when size is not positive or exceeds them all.
+func nearestImageSize(sizes []int, size int) int {
+	for _, candidate := range sizes {
+		if size > 0 && candidate >= size {
+			return candidate
+		}
+	}
+	return sizes[len(sizes)-1]
+}
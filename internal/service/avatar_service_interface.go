@@ -0,0 +1,20 @@
+package service
+
+import (
+	"context"
+	"go-base-project/internal/dto"
+
+	"github.com/google/uuid"
+)
+
+// AvatarServiceInterface defines uploading, removing and resolving user avatars
+type AvatarServiceInterface interface {
+	// UploadAvatar decodes an uploaded image, crops and scales it to the standard avatar sizes and makes it
+	// the user's avatar, replacing any previous one.
+	UploadAvatar(ctx context.Context, userID uuid.UUID, data []byte) (*dto.UserProfileResponse, error)
+	// DeleteAvatar removes a user's avatar. Removing someone else's avatar requires authority over them.
+	DeleteAvatar(ctx context.Context, userID, deletedBy uuid.UUID) (*dto.UserProfileResponse, error)
+	// GetAvatarURL returns where the avatar of a user in the smallest standard size of at least size pixels
+	// can be fetched: a signed or public storage URL, or the external picture of the user.
+	GetAvatarURL(ctx context.Context, userID uuid.UUID, size int) (string, error)
+}
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"go-base-project/internal/constant"
+	"go-base-project/internal/dto"
+)
+
+// RegisterChangeRequestExecutors wires each approval-gated action to the service call that performs it.
+func RegisterChangeRequestExecutors(approvalService ApprovalServiceInterface, orgService OrganizationServiceInterface, userService UserServiceInterface, roleService RoleServiceInterface, personalDataService PersonalDataServiceInterface, jobService JobServiceInterface) {
+	approvalService.RegisterExecutor(constant.ChangeRequestActionDeleteOrganization, func(ctx context.Context, payload []byte) (interface{}, error) {
+		var p dto.DeleteOrganizationPayload
+		if err := json.Unmarshal(payload, &p); err != nil {
+			return nil, fmt.Errorf("invalid %s payload: %w", constant.ChangeRequestActionDeleteOrganization, err)
+		}
+		return nil, orgService.DeleteOrganization(ctx, p.OrganizationID, p.RequestedBy)
+	})
+
+	approvalService.RegisterExecutor(constant.ChangeRequestActionDeleteUser, func(ctx context.Context, payload []byte) (interface{}, error) {
+		var p dto.DeleteUserPayload
+		if err := json.Unmarshal(payload, &p); err != nil {
+			return nil, fmt.Errorf("invalid %s payload: %w", constant.ChangeRequestActionDeleteUser, err)
+		}
+		return nil, userService.DeleteUser(ctx, p.UserID)
+	})
+
+	approvalService.RegisterExecutor(constant.ChangeRequestActionEraseUser, func(ctx context.Context, payload []byte) (interface{}, error) {
+		var p dto.EraseUserPayload
+		if err := json.Unmarshal(payload, &p); err != nil {
+			return nil, fmt.Errorf("invalid %s payload: %w", constant.ChangeRequestActionEraseUser, err)
+		}
+		return nil, personalDataService.EraseUser(ctx, p.UserID, p.Reason, p.RequestedBy)
+	})
+
+	approvalService.RegisterExecutor(constant.ChangeRequestActionUpdateRolePermissions, func(ctx context.Context, payload []byte) (interface{}, error) {
+		var p dto.UpdateRolePermissionsPayload
+		if err := json.Unmarshal(payload, &p); err != nil {
+			return nil, fmt.Errorf("invalid %s payload: %w", constant.ChangeRequestActionUpdateRolePermissions, err)
+		}
+		return nil, roleService.UpdateRolePermissions(ctx, p.RoleID, p.PermissionNames)
+	})
+
+	approvalService.RegisterExecutor(constant.ChangeRequestActionBulkAssignUsers, func(ctx context.Context, payload []byte) (interface{}, error) {
+		var p dto.BulkAssignUsersPayload
+		if err := json.Unmarshal(payload, &p); err != nil {
+			return nil, fmt.Errorf("invalid %s payload: %w", constant.ChangeRequestActionBulkAssignUsers, err)
+		}
+		// The batch runs as a background job on behalf of the requester, who can follow its progress.
+		// Queueing it commits together with the approval.
+		job, err := jobService.SubmitJob(ctx, constant.JobTypeBulkAssignUsers, p.BulkAssignUsersToOrganizationRequest, p.RequestedBy)
+		if err != nil {
+			return nil, err
+		}
+		return dto.ChangeRequestJobResult{JobID: job.ID}, nil
+	})
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"go-base-project/internal/apperror"
+	"go-base-project/internal/constant"
+	"go-base-project/internal/dto"
+	"go-base-project/internal/model"
+
+	"github.com/google/uuid"
+)
+
+func newBulkAssignChangeRequest(t *testing.T, requestedBy uuid.UUID, userCount int) (*model.ChangeRequest, dto.BulkAssignUsersToOrganizationRequest) {
+	t.Helper()
+	req := dto.BulkAssignUsersToOrganizationRequest{OrganizationID: uuid.New()}
+	for i := 0; i < userCount; i++ {
+		req.UserIDs = append(req.UserIDs, uuid.New())
+	}
+	payload, err := json.Marshal(dto.BulkAssignUsersPayload{BulkAssignUsersToOrganizationRequest: req, RequestedBy: requestedBy})
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+
+	changeRequest := newPendingChangeRequest(constant.ChangeRequestActionBulkAssignUsers)
+	changeRequest.RequestedBy = requestedBy
+	changeRequest.Payload = string(payload)
+	return changeRequest, req
+}
+
+func TestBulkAssignExecutorQueuesJob(t *testing.T) {
+	requestedBy := uuid.New()
+	changeRequest, req := newBulkAssignChangeRequest(t, requestedBy, 3)
+	repo := newFakeChangeRequestRepo(changeRequest)
+	svc := newTestApprovalService(repo, &fakeTxManager{repo: repo})
+	jobs := &fakeJobService{}
+	RegisterChangeRequestExecutors(svc, nil, nil, nil, nil, jobs)
+
+	response, err := svc.DecideChangeRequest(context.Background(), changeRequest.ID, dto.ChangeRequestDecisionRequest{Decision: constant.ChangeRequestDecisionApproved}, uuid.New())
+	if err != nil {
+		t.Fatalf("DecideChangeRequest() error = %v, want nil", err)
+	}
+
+	if len(jobs.submitted) != 1 {
+		t.Fatalf("submitted jobs = %d, want 1", len(jobs.submitted))
+	}
+	job := jobs.submitted[0]
+	if job.jobType != constant.JobTypeBulkAssignUsers {
+		t.Errorf("job type = %q, want %q", job.jobType, constant.JobTypeBulkAssignUsers)
+	}
+	if job.submittedBy != requestedBy {
+		t.Errorf("job submitted by %s, want the requester %s", job.submittedBy, requestedBy)
+	}
+	jobPayload, ok := job.payload.(dto.BulkAssignUsersToOrganizationRequest)
+	if !ok || jobPayload.OrganizationID != req.OrganizationID || len(jobPayload.UserIDs) != len(req.UserIDs) {
+		t.Errorf("job payload = %#v, want the requested bulk assignment %#v", job.payload, req)
+	}
+
+	var result dto.ChangeRequestJobResult
+	if err := json.Unmarshal(response.Result, &result); err != nil {
+		t.Fatalf("change request result %q is not a job result: %v", response.Result, err)
+	}
+	if result.JobID != job.id {
+		t.Errorf("result job ID = %s, want %s", result.JobID, job.id)
+	}
+	if response.Status != constant.ChangeRequestStatusExecuted {
+		t.Errorf("status = %q, want %q", response.Status, constant.ChangeRequestStatusExecuted)
+	}
+}
+
+func TestBulkAssignExecutorQueueFailureKeepsRequestPending(t *testing.T) {
+	changeRequest, _ := newBulkAssignChangeRequest(t, uuid.New(), 3)
+	repo := newFakeChangeRequestRepo(changeRequest)
+	tx := &fakeTxManager{repo: repo}
+	svc := newTestApprovalService(repo, tx)
+	jobs := &fakeJobService{submitErr: apperror.NewInternalError(errors.New("failed to queue job"))}
+	RegisterChangeRequestExecutors(svc, nil, nil, nil, nil, jobs)
+
+	if _, err := svc.DecideChangeRequest(context.Background(), changeRequest.ID, dto.ChangeRequestDecisionRequest{Decision: constant.ChangeRequestDecisionApproved}, uuid.New()); err == nil {
+		t.Fatal("DecideChangeRequest() error = nil, want the queueing error")
+	}
+
+	stored := repo.requests[changeRequest.ID]
+	if stored.Status != constant.ChangeRequestStatusPending {
+		t.Errorf("status = %q, want %q", stored.Status, constant.ChangeRequestStatusPending)
+	}
+	if len(stored.Approvals) != 0 {
+		t.Errorf("decisions = %d, want the approval rolled back", len(stored.Approvals))
+	}
+	if stored.Result != nil {
+		t.Errorf("result = %q, want none", *stored.Result)
+	}
+	if tx.rollbacks != 1 {
+		t.Errorf("rollbacks = %d, want 1", tx.rollbacks)
+	}
+}
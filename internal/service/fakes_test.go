@@ -0,0 +1,257 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"go-base-project/internal/constant"
+	"go-base-project/internal/dto"
+	"go-base-project/internal/model"
+	"go-base-project/internal/repository"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Fakes shared by the service tests. Fakes that embed a repository or service interface implement only the
+// methods the tests reach; any other call panics on the nil embedded interface, so a test that strays into
+// code it did not set up fails loudly instead of passing on zero values.
+
+// fakeChangeRequestRepo keeps change requests in memory and follows the status rules of the real repository
+type fakeChangeRequestRepo struct {
+	requests      map[uuid.UUID]*model.ChangeRequest
+	markedCalls   int
+	recordedCalls int
+}
+
+func newFakeChangeRequestRepo(requests ...*model.ChangeRequest) *fakeChangeRequestRepo {
+	repo := &fakeChangeRequestRepo{requests: make(map[uuid.UUID]*model.ChangeRequest)}
+	for _, request := range requests {
+		repo.requests[request.ID] = request
+	}
+	return repo
+}
+
+// snapshot copies every change request so a rolled back transaction can restore them
+func (r *fakeChangeRequestRepo) snapshot() map[uuid.UUID]model.ChangeRequest {
+	copies := make(map[uuid.UUID]model.ChangeRequest, len(r.requests))
+	for id, request := range r.requests {
+		copied := *request
+		copied.Approvals = append([]model.ChangeRequestApproval(nil), request.Approvals...)
+		copies[id] = copied
+	}
+	return copies
+}
+
+func (r *fakeChangeRequestRepo) restore(copies map[uuid.UUID]model.ChangeRequest) {
+	for id, request := range copies {
+		restored := request
+		r.requests[id] = &restored
+	}
+}
+
+func (r *fakeChangeRequestRepo) Create(ctx context.Context, changeRequest *model.ChangeRequest) (*model.ChangeRequest, error) {
+	for _, request := range r.requests {
+		if request.Status == constant.ChangeRequestStatusPending && request.Action == changeRequest.Action &&
+			request.TargetID != nil && changeRequest.TargetID != nil && *request.TargetID == *changeRequest.TargetID {
+			return nil, repository.ErrChangeRequestAlreadyPending
+		}
+	}
+	if changeRequest.ID == uuid.Nil {
+		changeRequest.ID = uuid.New()
+	}
+	r.requests[changeRequest.ID] = changeRequest
+	return changeRequest, nil
+}
+
+func (r *fakeChangeRequestRepo) FindByID(ctx context.Context, id uuid.UUID) (*model.ChangeRequest, error) {
+	request, ok := r.requests[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	copied := *request
+	return &copied, nil
+}
+
+func (r *fakeChangeRequestRepo) FindPendingByActionAndTarget(ctx context.Context, action string, targetID uuid.UUID) (*model.ChangeRequest, error) {
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *fakeChangeRequestRepo) List(ctx context.Context, status string, offset, limit int) ([]model.ChangeRequest, error) {
+	return nil, nil
+}
+
+func (r *fakeChangeRequestRepo) Count(ctx context.Context, status string) (int64, error) {
+	return 0, nil
+}
+
+func (r *fakeChangeRequestRepo) UpdateStatus(ctx context.Context, id uuid.UUID, fromStatus, toStatus string) (bool, error) {
+	request, ok := r.requests[id]
+	if !ok || request.Status != fromStatus {
+		return false, nil
+	}
+	request.Status = toStatus
+	return true, nil
+}
+
+func (r *fakeChangeRequestRepo) MarkExecuted(ctx context.Context, id uuid.UUID, executedAt time.Time, result *string) error {
+	r.markedCalls++
+	request := r.requests[id]
+	if request.Status != constant.ChangeRequestStatusExecuting {
+		return nil
+	}
+	request.Status = constant.ChangeRequestStatusExecuted
+	request.ExecutedAt = &executedAt
+	request.Result = result
+	return nil
+}
+
+func (r *fakeChangeRequestRepo) RecordDecision(ctx context.Context, decision *model.ChangeRequestApproval) (*model.ChangeRequest, error) {
+	r.recordedCalls++
+	request, ok := r.requests[decision.ChangeRequestID]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	if request.Status != constant.ChangeRequestStatusPending {
+		return nil, repository.ErrChangeRequestNotPending
+	}
+
+	request.Approvals = append(request.Approvals, *decision)
+	if decision.Decision == constant.ChangeRequestDecisionRejected {
+		request.Status = constant.ChangeRequestStatusRejected
+	} else {
+		approvals := 0
+		for _, approval := range request.Approvals {
+			if approval.Decision == constant.ChangeRequestDecisionApproved {
+				approvals++
+			}
+		}
+		if approvals >= request.RequiredApprovals {
+			request.Status = constant.ChangeRequestStatusExecuting
+		}
+	}
+
+	copied := *request
+	return &copied, nil
+}
+
+func (r *fakeChangeRequestRepo) ExpirePending(ctx context.Context, now time.Time) (int64, error) {
+	return 0, nil
+}
+
+// fakeTxManager runs fn directly and restores the change requests when it fails, like a rollback would.
+// After-commit hooks run once fn succeeds and are dropped when it fails.
+type fakeTxManager struct {
+	repo      *fakeChangeRequestRepo
+	rollbacks int
+	hooks     []func(ctx context.Context)
+	inTx      bool
+}
+
+func (m *fakeTxManager) RunInTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if m.inTx {
+		return fn(ctx)
+	}
+
+	var saved map[uuid.UUID]model.ChangeRequest
+	if m.repo != nil {
+		saved = m.repo.snapshot()
+	}
+	m.inTx = true
+	err := fn(ctx)
+	m.inTx = false
+	hooks := m.hooks
+	m.hooks = nil
+
+	if err != nil {
+		m.rollbacks++
+		if m.repo != nil {
+			m.repo.restore(saved)
+		}
+		return err
+	}
+	for _, hook := range hooks {
+		hook(ctx)
+	}
+	return nil
+}
+
+func (m *fakeTxManager) AfterCommit(ctx context.Context, fn func(ctx context.Context)) {
+	if m.inTx {
+		m.hooks = append(m.hooks, fn)
+		return
+	}
+	fn(ctx)
+}
+
+// fakeUserRepo answers the user lookups of the approval and username tests. Approvers get a role of level;
+// usernames in taken are in use and those in raced are claimed by a concurrent sign-up right after the
+// availability check.
+type fakeUserRepo struct {
+	repository.UserRepositoryInterface
+	level   int
+	taken   map[string]bool
+	raced   map[string]bool
+	created []string
+}
+
+func (r *fakeUserRepo) FindByIDWithRole(ctx context.Context, id uuid.UUID) (*model.User, error) {
+	return &model.User{ID: id, Role: &model.Role{Level: r.level}}, nil
+}
+
+func (r *fakeUserRepo) FindTakenUsernames(ctx context.Context, usernames []string) (map[string]bool, error) {
+	taken := make(map[string]bool)
+	for _, username := range usernames {
+		if r.taken[username] {
+			taken[username] = true
+		}
+	}
+	return taken, nil
+}
+
+func (r *fakeUserRepo) CreateWithUniqueUsername(ctx context.Context, user *model.User) error {
+	if r.raced[user.Username] {
+		delete(r.raced, user.Username)
+		r.taken[user.Username] = true
+		return repository.ErrUsernameTaken
+	}
+	if r.taken[user.Username] {
+		return repository.ErrUsernameTaken
+	}
+	r.taken[user.Username] = true
+	r.created = append(r.created, user.Username)
+	return nil
+}
+
+// fakeAccessibleOrgService only answers which organizations a user can access
+type fakeAccessibleOrgService struct {
+	OrganizationServiceInterface
+	accessible []uuid.UUID
+}
+
+func (s *fakeAccessibleOrgService) GetAccessibleOrganizationIDs(ctx context.Context, currentUserID uuid.UUID, currentUserLevel int) ([]uuid.UUID, error) {
+	return s.accessible, nil
+}
+
+// fakeJobService records submitted jobs
+type fakeJobService struct {
+	JobServiceInterface
+	submitErr error
+	submitted []fakeSubmittedJob
+}
+
+type fakeSubmittedJob struct {
+	id          uuid.UUID
+	jobType     string
+	payload     interface{}
+	submittedBy uuid.UUID
+}
+
+func (s *fakeJobService) SubmitJob(ctx context.Context, jobType string, payload interface{}, submittedBy uuid.UUID) (*dto.JobResponse, error) {
+	if s.submitErr != nil {
+		return nil, s.submitErr
+	}
+	job := fakeSubmittedJob{id: uuid.New(), jobType: jobType, payload: payload, submittedBy: submittedBy}
+	s.submitted = append(s.submitted, job)
+	return &dto.JobResponse{ID: job.id, Type: jobType, Status: constant.JobStatusQueued}, nil
+}
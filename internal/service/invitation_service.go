@@ -0,0 +1,345 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-base-project/internal/apperror"
+	"go-base-project/internal/config"
+	"go-base-project/internal/constant"
+	"go-base-project/internal/dto"
+	"go-base-project/internal/model"
+	"go-base-project/internal/repository"
+	"go-base-project/internal/util"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+// invitationService implements InvitationServiceInterface.
+type invitationService struct {
+	invitationRepo       repository.InvitationRepositoryInterface
+	orgRepo              repository.OrganizationRepositoryInterface
+	userRepo             repository.UserRepositoryInterface
+	roleRepo             repository.RoleRepositoryInterface
+	membershipRepo       repository.MembershipRepositoryInterface
+	txManager            repository.TransactionManagerInterface
+	authorizationService AuthorizationServiceInterface
+	membershipService    MembershipServiceInterface
+	tokenSecret          string
+	frontendURL          string
+	defaultExpiry        time.Duration
+	maxUses              int
+}
+
+// NewInvitationService creates a new instance of invitationService.
+func NewInvitationService(
+	invitationRepo repository.InvitationRepositoryInterface,
+	orgRepo repository.OrganizationRepositoryInterface,
+	userRepo repository.UserRepositoryInterface,
+	roleRepo repository.RoleRepositoryInterface,
+	membershipRepo repository.MembershipRepositoryInterface,
+	txManager repository.TransactionManagerInterface,
+	authorizationService AuthorizationServiceInterface,
+	membershipService MembershipServiceInterface,
+	cfg config.Config,
+) InvitationServiceInterface {
+	return &invitationService{
+		invitationRepo:       invitationRepo,
+		orgRepo:              orgRepo,
+		userRepo:             userRepo,
+		roleRepo:             roleRepo,
+		membershipRepo:       membershipRepo,
+		txManager:            txManager,
+		authorizationService: authorizationService,
+		membershipService:    membershipService,
+		tokenSecret:          cfg.JWTSecret,
+		frontendURL:          cfg.FrontendURL,
+		defaultExpiry:        cfg.InvitationExpiry,
+		maxUses:              cfg.InvitationMaxUses,
+	}
+}
+
+// CreateInvitation issues a signed invitation token for an organization with a pre-selected role.
+func (s *invitationService) CreateInvitation(ctx context.Context, organizationID uuid.UUID, req dto.CreateInvitationRequest, invitedBy uuid.UUID) (*dto.CreateInvitationResponse, error) {
+	org, err := s.orgRepo.FindByID(ctx, organizationID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.NewNotFoundError("organization")
+		}
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to find organization: %w", err))
+	}
+	if !org.IsActive {
+		return nil, apperror.NewValidationError("Cannot invite members to an inactive organization")
+	}
+	if err := ensureOrganizationNotArchived(org); err != nil {
+		return nil, err
+	}
+
+	role, err := s.roleRepo.FindByID(ctx, req.RoleID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.NewNotFoundError("role")
+		}
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to find role: %w", err))
+	}
+	if !role.IsActive {
+		return nil, apperror.NewValidationError("Cannot invite with an inactive role")
+	}
+
+	accessible, err := s.authorizationService.ValidateRoleAccessibleInOrganization(ctx, role.ID, org.ID, org.OrganizationType)
+	if err != nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to validate role for organization: %w", err))
+	}
+	if !accessible {
+		return nil, apperror.NewValidationError(fmt.Sprintf("Role '%s' is not available for %s organizations", role.Name, org.OrganizationType))
+	}
+
+	inviterLevel, err := s.authorizationService.GetUserEffectiveLevel(ctx, invitedBy, org.ID)
+	if err != nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to determine inviter level: %w", err))
+	}
+	if inviterLevel < constant.RoleLevelSuperAdmin && role.Level >= inviterLevel {
+		return nil, apperror.NewForbiddenError("You can only invite members with a role below your own level")
+	}
+
+	email := strings.ToLower(strings.TrimSpace(req.Email))
+	if email != "" && req.UserID != nil {
+		return nil, apperror.NewValidationError("Specify either an email or a user, not both")
+	}
+
+	maxUses := req.MaxUses
+	if maxUses == 0 {
+		maxUses = 1
+	}
+	if email != "" || req.UserID != nil {
+		// Targeted invitations are personal, so they can only be redeemed once
+		if maxUses != 1 {
+			return nil, apperror.NewValidationError("Invitations for a specific email or user can only be used once")
+		}
+	} else if maxUses > s.maxUses {
+		return nil, apperror.NewValidationError(fmt.Sprintf("max_uses cannot exceed %d", s.maxUses))
+	}
+
+	if req.UserID != nil {
+		if _, err := s.userRepo.FindByID(ctx, *req.UserID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, apperror.NewNotFoundError("user")
+			}
+			return nil, apperror.NewInternalError(fmt.Errorf("failed to find invited user: %w", err))
+		}
+		if isMember, err := s.isActiveMember(ctx, *req.UserID, org.ID); err != nil {
+			return nil, err
+		} else if isMember {
+			return nil, apperror.NewConflictError("User is already a member of this organization")
+		}
+	}
+
+	expiry := s.defaultExpiry
+	if req.ExpiresInHours > 0 {
+		expiry = time.Duration(req.ExpiresInHours) * time.Hour
+	}
+
+	// The ID is generated up front because it is embedded in the signed token
+	invitationID, err := uuid.NewV7()
+	if err != nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to generate invitation ID: %w", err))
+	}
+	token, err := util.GenerateInvitationToken(invitationID, s.tokenSecret)
+	if err != nil {
+		return nil, apperror.NewInternalError(err)
+	}
+
+	invitation := &model.OrganizationInvitation{
+		ID:             invitationID,
+		OrganizationID: org.ID,
+		RoleID:         role.ID,
+		Email:          email,
+		InvitedUserID:  req.UserID,
+		TokenHash:      util.HashInvitationToken(token),
+		Status:         constant.InvitationStatusPending,
+		MaxUses:        maxUses,
+		ExpiresAt:      time.Now().Add(expiry),
+		InvitedBy:      invitedBy,
+	}
+	if _, err := s.invitationRepo.Create(ctx, invitation); err != nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to create invitation: %w", err))
+	}
+
+	log.Info().
+		Str("invitation_id", invitation.ID.String()).
+		Str("organization_id", org.ID.String()).
+		Str("role", role.Name).
+		Str("invited_by", invitedBy.String()).
+		Msg("Organization invitation created")
+
+	created, err := s.invitationRepo.FindByID(ctx, invitation.ID)
+	if err != nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to fetch created invitation: %w", err))
+	}
+
+	return &dto.CreateInvitationResponse{
+		InvitationResponse: *s.mapInvitationToResponse(created),
+		Token:              token,
+		AcceptURL:          fmt.Sprintf("%s/invitations/accept?token=%s", strings.TrimRight(s.frontendURL, "/"), url.QueryEscape(token)),
+	}, nil
+}
+
+// ListPendingInvitations returns the invitations of an organization that can still be accepted.
+func (s *invitationService) ListPendingInvitations(ctx context.Context, organizationID uuid.UUID) ([]dto.InvitationResponse, error) {
+	invitations, err := s.invitationRepo.ListPendingByOrganization(ctx, organizationID, time.Now())
+	if err != nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to list invitations: %w", err))
+	}
+
+	responses := make([]dto.InvitationResponse, len(invitations))
+	for i := range invitations {
+		responses[i] = *s.mapInvitationToResponse(&invitations[i])
+	}
+	return responses, nil
+}
+
+// RevokeInvitation revokes a pending invitation of the organization.
+func (s *invitationService) RevokeInvitation(ctx context.Context, organizationID, invitationID, revokedBy uuid.UUID) error {
+	invitation, err := s.invitationRepo.FindByID(ctx, invitationID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apperror.NewNotFoundError("invitation")
+		}
+		return apperror.NewInternalError(fmt.Errorf("failed to find invitation: %w", err))
+	}
+	// Invitations of other organizations are reported as missing so IDs cannot be probed
+	if invitation.OrganizationID != organizationID {
+		return apperror.NewNotFoundError("invitation")
+	}
+
+	revoked, err := s.invitationRepo.Revoke(ctx, invitationID, revokedBy, time.Now())
+	if err != nil {
+		return apperror.NewInternalError(fmt.Errorf("failed to revoke invitation: %w", err))
+	}
+	if !revoked {
+		return apperror.NewConflictError(fmt.Sprintf("Invitation is already %s", invitation.Status))
+	}
+
+	log.Info().
+		Str("invitation_id", invitationID.String()).
+		Str("revoked_by", revokedBy.String()).
+		Msg("Organization invitation revoked")
+	return nil
+}
+
+// AcceptInvitation verifies the token and adds the user to the organization with the invited role.
+func (s *invitationService) AcceptInvitation(ctx context.Context, req dto.AcceptInvitationRequest, userID uuid.UUID) (*dto.UserOrganizationResponse, error) {
+	invitationID, err := util.ParseInvitationToken(req.Token, s.tokenSecret)
+	if err != nil {
+		return nil, apperror.NewValidationError("Invalid invitation token")
+	}
+
+	invitation, err := s.invitationRepo.FindByID(ctx, invitationID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.NewValidationError("Invalid invitation token")
+		}
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to find invitation: %w", err))
+	}
+	if invitation.TokenHash != util.HashInvitationToken(req.Token) {
+		return nil, apperror.NewValidationError("Invalid invitation token")
+	}
+
+	switch {
+	case invitation.Status == constant.InvitationStatusRevoked:
+		return nil, apperror.NewValidationError("This invitation has been revoked")
+	case invitation.Status == constant.InvitationStatusAccepted || invitation.UseCount >= invitation.MaxUses:
+		return nil, apperror.NewValidationError("This invitation has already been used")
+	case !invitation.ExpiresAt.After(time.Now()):
+		return nil, apperror.NewValidationError("This invitation has expired")
+	case !invitation.Organization.IsActive:
+		return nil, apperror.NewValidationError("Organization is not active")
+	case invitation.Organization.IsArchived():
+		return nil, apperror.NewConflictError("Organization is archived")
+	}
+
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.NewNotFoundError("user")
+		}
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to find user: %w", err))
+	}
+	if invitation.InvitedUserID != nil && *invitation.InvitedUserID != user.ID {
+		return nil, apperror.NewForbiddenError("This invitation was issued to another user")
+	}
+	if invitation.Email != "" && !strings.EqualFold(invitation.Email, user.Email) {
+		return nil, apperror.NewForbiddenError("This invitation was issued to another email address")
+	}
+
+	// The use is given back when the membership cannot be granted, e.g. because the organization is full
+	var membership *dto.UserOrganizationResponse
+	err = s.txManager.RunInTx(ctx, func(ctx context.Context) error {
+		if err := s.invitationRepo.Redeem(ctx, invitation.ID, time.Now()); err != nil {
+			if errors.Is(err, repository.ErrInvitationNotRedeemable) {
+				return apperror.NewConflictError("This invitation is no longer valid")
+			}
+			return apperror.NewInternalError(fmt.Errorf("failed to accept invitation: %w", err))
+		}
+
+		roleID := invitation.RoleID
+		var err error
+		membership, err = s.membershipService.AddMember(ctx, dto.AssignUserToOrganizationRequest{
+			UserID:         user.ID,
+			OrganizationID: invitation.OrganizationID,
+			RoleID:         &roleID,
+			IsActive:       true,
+			Reason:         fmt.Sprintf("Accepted invitation %s", invitation.ID),
+		}, user.ID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info().
+		Str("invitation_id", invitation.ID.String()).
+		Str("user_id", user.ID.String()).
+		Str("organization_id", invitation.OrganizationID.String()).
+		Msg("Organization invitation accepted")
+
+	return membership, nil
+}
+
+// isActiveMember reports whether the user already has an active membership in the organization.
+func (s *invitationService) isActiveMember(ctx context.Context, userID, organizationID uuid.UUID) (bool, error) {
+	membership, err := s.membershipRepo.Find(ctx, userID, organizationID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, nil
+		}
+		return false, apperror.NewInternalError(fmt.Errorf("failed to check membership: %w", err))
+	}
+	return membership.IsActive, nil
+}
+
+// mapInvitationToResponse converts an OrganizationInvitation model to its response DTO.
+func (s *invitationService) mapInvitationToResponse(invitation *model.OrganizationInvitation) *dto.InvitationResponse {
+	return &dto.InvitationResponse{
+		ID:               invitation.ID,
+		OrganizationID:   invitation.OrganizationID,
+		OrganizationName: invitation.Organization.Name,
+		RoleID:           invitation.RoleID,
+		RoleName:         invitation.Role.Name,
+		Email:            invitation.Email,
+		InvitedUserID:    invitation.InvitedUserID,
+		Status:           invitation.Status,
+		MaxUses:          invitation.MaxUses,
+		UseCount:         invitation.UseCount,
+		ExpiresAt:        invitation.ExpiresAt,
+		InvitedBy:        invitation.InvitedBy,
+		InvitedByUser:    invitation.Inviter.Username,
+		RevokedAt:        invitation.RevokedAt,
+		CreatedAt:        invitation.CreatedAt,
+	}
+}
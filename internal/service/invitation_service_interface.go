@@ -0,0 +1,16 @@
+package service
+
+import (
+	"context"
+	"go-base-project/internal/dto"
+
+	"github.com/google/uuid"
+)
+
+// InvitationServiceInterface defines the contract for organization invitations.
+type InvitationServiceInterface interface {
+	CreateInvitation(ctx context.Context, organizationID uuid.UUID, req dto.CreateInvitationRequest, invitedBy uuid.UUID) (*dto.CreateInvitationResponse, error)
+	ListPendingInvitations(ctx context.Context, organizationID uuid.UUID) ([]dto.InvitationResponse, error)
+	RevokeInvitation(ctx context.Context, organizationID, invitationID, revokedBy uuid.UUID) error
+	AcceptInvitation(ctx context.Context, req dto.AcceptInvitationRequest, userID uuid.UUID) (*dto.UserOrganizationResponse, error)
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"go-base-project/internal/constant"
+	"go-base-project/internal/dto"
+
+	"github.com/google/uuid"
+)
+
+// bulkAssignJobChunkSize is the number of users a bulk assignment job assigns between progress updates
+const bulkAssignJobChunkSize = 100
+
+// RegisterJobHandlers wires each background job type to the service calls that perform it.
+func RegisterJobHandlers(
+	jobService JobServiceInterface,
+	membershipService MembershipServiceInterface,
+	userImportService UserImportServiceInterface,
+	orgService OrganizationServiceInterface,
+	transferService OrganizationTransferServiceInterface,
+) {
+	jobService.RegisterHandler(constant.JobTypeBulkAssignUsers, func(ctx context.Context, payload []byte, submittedBy uuid.UUID) (interface{}, error) {
+		var req dto.BulkAssignUsersToOrganizationRequest
+		if err := json.Unmarshal(payload, &req); err != nil {
+			return nil, fmt.Errorf("invalid %s payload: %w", constant.JobTypeBulkAssignUsers, err)
+		}
+
+		// Chunks are assigned one after another, so a stopped or failed job keeps the chunks it completed
+		result := &dto.BulkAssignResponse{}
+		for start := 0; start < len(req.UserIDs); start += bulkAssignJobChunkSize {
+			end := start + bulkAssignJobChunkSize
+			if end > len(req.UserIDs) {
+				end = len(req.UserIDs)
+			}
+			chunk := req
+			chunk.UserIDs = req.UserIDs[start:end]
+			response, err := membershipService.BulkAddMembers(ctx, chunk, submittedBy)
+			if err != nil {
+				return nil, err
+			}
+
+			result.SuccessCount += response.SuccessCount
+			result.FailureCount += response.FailureCount
+			result.Assignments = append(result.Assignments, response.Assignments...)
+			result.Errors = append(result.Errors, response.Errors...)
+			result.Message = fmt.Sprintf("%d users assigned successfully, %d failed", result.SuccessCount, result.FailureCount)
+			if err := reportJobProgress(ctx, end, len(req.UserIDs), result); err != nil {
+				return nil, err
+			}
+		}
+		return result, nil
+	})
+
+	jobService.RegisterHandler(constant.JobTypeImportUsers, func(ctx context.Context, payload []byte, submittedBy uuid.UUID) (interface{}, error) {
+		var p dto.ImportUsersJobPayload
+		if err := json.Unmarshal(payload, &p); err != nil {
+			return nil, fmt.Errorf("invalid %s payload: %w", constant.JobTypeImportUsers, err)
+		}
+		return userImportService.ImportUsers(ctx, p.Filename, p.Data, false, submittedBy)
+	})
+
+	jobService.RegisterHandler(constant.JobTypeArchiveOrganization, func(ctx context.Context, payload []byte, submittedBy uuid.UUID) (interface{}, error) {
+		var p dto.ArchiveOrganizationJobPayload
+		if err := json.Unmarshal(payload, &p); err != nil {
+			return nil, fmt.Errorf("invalid %s payload: %w", constant.JobTypeArchiveOrganization, err)
+		}
+		return orgService.ArchiveOrganization(ctx, p.OrganizationID, p.Request, submittedBy)
+	})
+
+	jobService.RegisterHandler(constant.JobTypeExportOrganization, func(ctx context.Context, payload []byte, submittedBy uuid.UUID) (interface{}, error) {
+		var p dto.ExportOrganizationJobPayload
+		if err := json.Unmarshal(payload, &p); err != nil {
+			return nil, fmt.Errorf("invalid %s payload: %w", constant.JobTypeExportOrganization, err)
+		}
+
+		result := &dto.OrganizationExportJobResult{Format: p.Format}
+		if p.Format == "csv" {
+			data, err := transferService.ExportOrganizationTreeCSV(ctx, p.OrganizationID)
+			if err != nil {
+				return nil, err
+			}
+			result.CSV = string(data)
+		} else {
+			document, err := transferService.ExportOrganizationTree(ctx, p.OrganizationID)
+			if err != nil {
+				return nil, err
+			}
+			result.Document = document
+		}
+		return result, nil
+	})
+}
@@ -0,0 +1,424 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go-base-project/internal/apperror"
+	"go-base-project/internal/config"
+	"go-base-project/internal/constant"
+	"go-base-project/internal/dto"
+	"go-base-project/internal/model"
+	"go-base-project/internal/repository"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+const (
+	// jobLeaseDuration is how long a claimed job stays with its worker without a heartbeat; a job whose
+	// worker died is claimed again once its lease runs out
+	jobLeaseDuration = time.Minute
+	// jobHeartbeatInterval is how often a worker renews its lease and checks for cancellation
+	jobHeartbeatInterval = 10 * time.Second
+	// jobRetryBackoff is multiplied by the square of the attempt count to delay automatic retries
+	jobRetryBackoff = 30 * time.Second
+)
+
+// jobRunContextKey carries the running job in the context handed to a JobHandler
+type jobRunContextKey struct{}
+
+type jobService struct {
+	jobRepo      repository.JobRepositoryInterface
+	maxAttempts  int
+	pollInterval time.Duration
+
+	mu       sync.RWMutex
+	handlers map[string]JobHandler
+}
+
+// NewJobService creates a new instance of JobService
+func NewJobService(jobRepo repository.JobRepositoryInterface, cfg config.Config) JobServiceInterface {
+	return &jobService{
+		jobRepo:      jobRepo,
+		maxAttempts:  cfg.JobMaxAttempts,
+		pollInterval: cfg.JobPollInterval,
+		handlers:     make(map[string]JobHandler),
+	}
+}
+
+// RegisterHandler binds a job type to the function that performs it.
+func (s *jobService) RegisterHandler(jobType string, handler JobHandler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handlers[jobType] = handler
+}
+
+// SubmitJob serializes the payload and queues the job for the workers.
+func (s *jobService) SubmitJob(ctx context.Context, jobType string, payload interface{}, submittedBy uuid.UUID) (*dto.JobResponse, error) {
+	if s.handler(jobType) == nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("no handler registered for job type %q", jobType))
+	}
+
+	serialized, err := json.Marshal(payload)
+	if err != nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to serialize job payload: %w", err))
+	}
+	job := &model.Job{
+		Type:        jobType,
+		Payload:     string(serialized),
+		Status:      constant.JobStatusQueued,
+		SubmittedBy: submittedBy,
+		MaxAttempts: s.maxAttempts,
+		RunAfter:    time.Now(),
+	}
+	if err := s.jobRepo.Create(ctx, job); err != nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to queue job: %w", err))
+	}
+
+	log.Info().
+		Str("job_id", job.ID.String()).
+		Str("type", jobType).
+		Str("submitted_by", submittedBy.String()).
+		Msg("Job queued")
+	return mapJobToResponse(job), nil
+}
+
+// GetJob returns a job; jobs of other users are reported as not found.
+func (s *jobService) GetJob(ctx context.Context, id, requestedBy uuid.UUID) (*dto.JobResponse, error) {
+	job, err := s.findOwnJob(ctx, id, requestedBy)
+	if err != nil {
+		return nil, err
+	}
+	return mapJobToResponse(job), nil
+}
+
+// CancelJob cancels a queued job right away. A running job is flagged and stops at its next progress update
+// or heartbeat, keeping its partial result.
+func (s *jobService) CancelJob(ctx context.Context, id, requestedBy uuid.UUID) (*dto.JobResponse, error) {
+	job, err := s.findOwnJob(ctx, id, requestedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	switch job.Status {
+	case constant.JobStatusQueued, constant.JobStatusRunning:
+		cancelled, err := s.jobRepo.CancelQueued(ctx, id, time.Now())
+		if err != nil {
+			return nil, apperror.NewInternalError(fmt.Errorf("failed to cancel job: %w", err))
+		}
+		// The job may have been claimed in the meantime
+		if !cancelled {
+			requested, err := s.jobRepo.RequestCancellation(ctx, id)
+			if err != nil {
+				return nil, apperror.NewInternalError(fmt.Errorf("failed to request job cancellation: %w", err))
+			}
+			if !requested {
+				return nil, apperror.NewConflictError("Job has already finished")
+			}
+		}
+	default:
+		return nil, apperror.NewConflictError("Job has already finished")
+	}
+
+	log.Info().Str("job_id", id.String()).Str("cancelled_by", requestedBy.String()).Msg("Job cancellation requested")
+	return s.GetJob(ctx, id, requestedBy)
+}
+
+// RetryJob queues a failed or cancelled job again from the start with a fresh set of attempts.
+func (s *jobService) RetryJob(ctx context.Context, id, requestedBy uuid.UUID) (*dto.JobResponse, error) {
+	if _, err := s.findOwnJob(ctx, id, requestedBy); err != nil {
+		return nil, err
+	}
+
+	requeued, err := s.jobRepo.Requeue(ctx, id, time.Now())
+	if err != nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to retry job: %w", err))
+	}
+	if !requeued {
+		return nil, apperror.NewConflictError("Only failed or cancelled jobs can be retried")
+	}
+
+	log.Info().Str("job_id", id.String()).Str("retried_by", requestedBy.String()).Msg("Job queued for retry")
+	return s.GetJob(ctx, id, requestedBy)
+}
+
+// RunWorkers starts the workers and blocks until all of them have stopped after ctx is cancelled.
+func (s *jobService) RunWorkers(ctx context.Context, workers int) {
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.work(ctx)
+		}()
+	}
+	log.Info().Int("workers", workers).Msg("Job workers started")
+	wg.Wait()
+}
+
+// PurgeFinishedJobs removes finished jobs, with their payloads and results, past the retention period.
+func (s *jobService) PurgeFinishedJobs(ctx context.Context, retention time.Duration) (int64, error) {
+	purged, err := s.jobRepo.DeleteFinishedBefore(ctx, time.Now().Add(-retention))
+	if err != nil {
+		return purged, apperror.NewInternalError(fmt.Errorf("failed to purge finished jobs: %w", err))
+	}
+	return purged, nil
+}
+
+// work runs jobs until the queue is empty, then waits for the next poll.
+func (s *jobService) work(ctx context.Context) {
+	for {
+		for ctx.Err() == nil && s.runNext(ctx) {
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(s.pollInterval):
+		}
+	}
+}
+
+// runNext claims and runs one job and reports whether there was one.
+func (s *jobService) runNext(ctx context.Context) bool {
+	now := time.Now()
+	job, err := s.jobRepo.ClaimNext(ctx, now, now.Add(jobLeaseDuration))
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) && ctx.Err() == nil {
+			log.Error().Err(err).Msg("Failed to claim job")
+		}
+		return false
+	}
+	s.run(ctx, job)
+	return true
+}
+
+// run performs a claimed job and records its outcome. Failures are retried with backoff until the job runs
+// out of attempts, except for client errors, which would fail the same way again.
+func (s *jobService) run(workerCtx context.Context, job *model.Job) {
+	// Recording the outcome must not fail because the job or the worker was cancelled
+	storeCtx := context.WithoutCancel(workerCtx)
+	logger := log.With().Str("job_id", job.ID.String()).Str("type", job.Type).Int("attempt", job.Attempts).Logger()
+
+	handler := s.handler(job.Type)
+	if handler == nil {
+		s.finish(storeCtx, job, constant.JobStatusFailed, nil, fmt.Sprintf("No handler registered for job type %q", job.Type))
+		return
+	}
+	// Only a job whose worker died without recording the outcome can get here
+	if job.Attempts > job.MaxAttempts {
+		s.finish(storeCtx, job, constant.JobStatusFailed, nil, "Job was interrupted too often")
+		return
+	}
+
+	jobCtx, cancel := context.WithCancel(workerCtx)
+	defer cancel()
+	run := &jobRun{service: s, jobID: job.ID, storeCtx: storeCtx, cancel: cancel}
+	if job.CancelRequested {
+		run.stop()
+	}
+	stopHeartbeat := run.keepAlive(jobCtx)
+
+	logger.Info().Msg("Job started")
+	result, err := handler(context.WithValue(jobCtx, jobRunContextKey{}, run), []byte(job.Payload), job.SubmittedBy)
+	stopHeartbeat()
+
+	switch {
+	case err == nil:
+		s.finish(storeCtx, job, constant.JobStatusSucceeded, result, "")
+		logger.Info().Msg("Job succeeded")
+	case run.cancelled.Load():
+		s.finish(storeCtx, job, constant.JobStatusCancelled, result, "Cancelled by user")
+		logger.Info().Msg("Job cancelled")
+	case workerCtx.Err() != nil:
+		// The worker is shutting down; another worker picks the job up again
+		if err := s.jobRepo.Release(storeCtx, job.ID); err != nil {
+			logger.Error().Err(err).Msg("Failed to release interrupted job")
+		}
+		logger.Info().Msg("Job released on shutdown")
+	case isPermanentJobError(err) || job.Attempts >= job.MaxAttempts:
+		s.finish(storeCtx, job, constant.JobStatusFailed, result, jobErrorMessage(err))
+		logger.Error().Err(err).Msg("Job failed")
+	default:
+		runAfter := time.Now().Add(time.Duration(job.Attempts*job.Attempts) * jobRetryBackoff)
+		if err := s.jobRepo.Reschedule(storeCtx, job.ID, runAfter, jobErrorMessage(err)); err != nil {
+			logger.Error().Err(err).Msg("Failed to reschedule job")
+		}
+		logger.Warn().Err(err).Time("run_after", runAfter).Msg("Job attempt failed, retrying")
+	}
+}
+
+// finish records the final status of a job; a nil result keeps the partial result reported last.
+func (s *jobService) finish(ctx context.Context, job *model.Job, status string, result interface{}, errorMessage string) {
+	serialized, err := serializeJobResult(result)
+	if err != nil {
+		status = constant.JobStatusFailed
+		errorMessage = "Failed to store the job result"
+		log.Error().Err(err).Str("job_id", job.ID.String()).Msg("Failed to serialize job result")
+	}
+	if err := s.jobRepo.Finish(ctx, job.ID, status, serialized, errorMessage, time.Now()); err != nil {
+		log.Error().Err(err).Str("job_id", job.ID.String()).Str("status", status).Msg("CRITICAL: job finished but outcome was not recorded")
+	}
+}
+
+// handler returns the registered handler for a job type, or nil.
+func (s *jobService) handler(jobType string) JobHandler {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.handlers[jobType]
+}
+
+// findOwnJob loads a job and hides it from anyone but its submitter.
+func (s *jobService) findOwnJob(ctx context.Context, id, requestedBy uuid.UUID) (*model.Job, error) {
+	job, err := s.jobRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.NewNotFoundError("job")
+		}
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to fetch job: %w", err))
+	}
+	if job.SubmittedBy != requestedBy {
+		return nil, apperror.NewNotFoundError("job")
+	}
+	return job, nil
+}
+
+// jobRun is the state of a job while a worker performs it
+type jobRun struct {
+	service   *jobService
+	jobID     uuid.UUID
+	storeCtx  context.Context
+	cancel    context.CancelFunc
+	cancelled atomic.Bool
+}
+
+// stop cancels the context of the handler after a cancellation request
+func (r *jobRun) stop() {
+	r.cancelled.Store(true)
+	r.cancel()
+}
+
+// keepAlive renews the lease of the job until the returned function is called or ctx is done, and stops
+// the job when its cancellation is requested in the meantime.
+func (r *jobRun) keepAlive(ctx context.Context) func() {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(jobHeartbeatInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				cancelRequested, err := r.service.jobRepo.Heartbeat(r.storeCtx, r.jobID, time.Now().Add(jobLeaseDuration))
+				if err != nil {
+					log.Warn().Err(err).Str("job_id", r.jobID.String()).Msg("Failed to renew job lease")
+					continue
+				}
+				if cancelRequested {
+					r.stop()
+				}
+			}
+		}
+	}()
+	return func() {
+		close(done)
+		<-stopped
+	}
+}
+
+// progress records the progress and partial result of the job.
+func (r *jobRun) progress(processed, total int, partial interface{}) {
+	serialized, err := serializeJobResult(partial)
+	if err != nil {
+		log.Warn().Err(err).Str("job_id", r.jobID.String()).Msg("Failed to serialize partial job result")
+	}
+	cancelRequested, err := r.service.jobRepo.UpdateProgress(r.storeCtx, r.jobID, processed, total, serialized, time.Now().Add(jobLeaseDuration))
+	if err != nil {
+		log.Warn().Err(err).Str("job_id", r.jobID.String()).Msg("Failed to record job progress")
+		return
+	}
+	if cancelRequested {
+		r.stop()
+	}
+}
+
+// reportJobProgress publishes the progress of the job running in ctx: processed of total items are done,
+// and partial is the result so far. Outside of a job it does nothing. It returns an error once the job has
+// to stop, because it was cancelled or its worker is shutting down.
+func reportJobProgress(ctx context.Context, processed, total int, partial interface{}) error {
+	if run, ok := ctx.Value(jobRunContextKey{}).(*jobRun); ok {
+		run.progress(processed, total, partial)
+	}
+	return ctx.Err()
+}
+
+// isPermanentJobError reports whether a job failed on its input rather than on a transient condition
+func isPermanentJobError(err error) bool {
+	var appErr *apperror.AppError
+	return errors.As(err, &appErr) && appErr.Code < http.StatusInternalServerError
+}
+
+// jobErrorMessage returns the message of a job failure that is safe to show to the submitter
+func jobErrorMessage(err error) string {
+	var appErr *apperror.AppError
+	if errors.As(err, &appErr) {
+		return appErr.Message
+	}
+	return "an unexpected error occurred"
+}
+
+func serializeJobResult(result interface{}) (*string, error) {
+	if result == nil {
+		return nil, nil
+	}
+	serialized, err := json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+	value := string(serialized)
+	return &value, nil
+}
+
+// mapJobToResponse converts a Job model to its response DTO.
+func mapJobToResponse(job *model.Job) *dto.JobResponse {
+	response := &dto.JobResponse{
+		ID:     job.ID,
+		Type:   job.Type,
+		Status: job.Status,
+		Progress: dto.JobProgressResponse{
+			Processed: job.ProcessedItems,
+			Total:     job.TotalItems,
+		},
+		Error:           job.Error,
+		Attempts:        job.Attempts,
+		MaxAttempts:     job.MaxAttempts,
+		CancelRequested: job.CancelRequested,
+		StatusURL:       fmt.Sprintf("/api/jobs/%s", job.ID),
+		CreatedAt:       job.CreatedAt,
+		StartedAt:       job.StartedAt,
+		FinishedAt:      job.FinishedAt,
+	}
+	if job.TotalItems > 0 {
+		response.Progress.Percent = math.Round(float64(job.ProcessedItems)*10000/float64(job.TotalItems)) / 100
+	} else if job.Status == constant.JobStatusSucceeded {
+		response.Progress.Percent = 100
+	}
+	if job.Result != nil {
+		response.Result = json.RawMessage(*job.Result)
+	}
+	return response
+}
@@ -0,0 +1,34 @@
+package service
+
+import (
+	"context"
+	"go-base-project/internal/dto"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// JobHandler performs a background job of one type from its serialized payload, on behalf of the user who
+// submitted it. The returned value is stored as the job result. Handlers of long jobs publish progress and
+// partial results with reportJobProgress and stop when their context is cancelled.
+type JobHandler func(ctx context.Context, payload []byte, submittedBy uuid.UUID) (interface{}, error)
+
+// JobServiceInterface defines the background job queue and its workers.
+type JobServiceInterface interface {
+	// RegisterHandler binds a job type to the function that performs it.
+	RegisterHandler(jobType string, handler JobHandler)
+	// SubmitJob queues a job of a registered type.
+	SubmitJob(ctx context.Context, jobType string, payload interface{}, submittedBy uuid.UUID) (*dto.JobResponse, error)
+
+	// GetJob returns a job to the user who submitted it.
+	GetJob(ctx context.Context, id, requestedBy uuid.UUID) (*dto.JobResponse, error)
+	// CancelJob cancels a queued job, or asks the worker of a running job to stop.
+	CancelJob(ctx context.Context, id, requestedBy uuid.UUID) (*dto.JobResponse, error)
+	// RetryJob queues a failed or cancelled job again.
+	RetryJob(ctx context.Context, id, requestedBy uuid.UUID) (*dto.JobResponse, error)
+
+	// RunWorkers processes queued jobs with the given number of workers until ctx is cancelled.
+	RunWorkers(ctx context.Context, workers int)
+	// PurgeFinishedJobs removes jobs that finished longer than retention ago.
+	PurgeFinishedJobs(ctx context.Context, retention time.Duration) (int64, error)
+}
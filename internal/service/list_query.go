@@ -0,0 +1,110 @@
+package service
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"go-base-project/internal/apperror"
+	"go-base-project/internal/repository"
+)
+
+// maxListSortFields bounds the number of fields a listing may be sorted on
+const maxListSortFields = 4
+
+// listCursor is the opaque cursor handed to clients. It carries the sort it was issued for so a cursor
+// cannot be replayed against a different order.
+type listCursor struct {
+	Sort   string   `json:"s"`
+	Values []string `json:"v"`
+}
+
+// buildListQuery turns the sort, cursor and pagination parameters of a listing into a repository query.
+// Sort is a comma separated list of fields, each optionally prefixed with '-' for descending order;
+// defaultSort applies when it is empty. A cursor takes precedence over the page.
+func buildListQuery(sort, defaultSort, cursor string, offset, limit int) (repository.ListQuery, error) {
+	if strings.TrimSpace(sort) == "" {
+		sort = defaultSort
+	}
+
+	query := repository.ListQuery{Limit: limit, Offset: offset}
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(sort, ",") {
+		part = strings.TrimSpace(part)
+		field := sortFieldName(part)
+		if field == "" {
+			return query, apperror.NewValidationError("Sort fields must not be empty")
+		}
+		if seen[field] {
+			return query, apperror.NewValidationError("Sort field " + field + " is listed more than once")
+		}
+		seen[field] = true
+		query.Sort = append(query.Sort, repository.SortField{Field: field, Descending: strings.HasPrefix(part, "-")})
+	}
+	if len(query.Sort) > maxListSortFields {
+		return query, apperror.NewValidationError(fmt.Sprintf("A listing can be sorted on at most %d fields", maxListSortFields))
+	}
+
+	if cursor != "" {
+		decoded, err := decodeListCursor(cursor)
+		if err != nil || decoded.Sort != canonicalSort(query.Sort) {
+			return query, apperror.NewValidationError("Invalid cursor, it may belong to a listing with a different sort")
+		}
+		query.After = decoded.Values
+		query.Offset = 0
+	}
+	return query, nil
+}
+
+// sortFieldName strips the direction prefix of a sort field.
+func sortFieldName(part string) string {
+	return strings.TrimPrefix(strings.TrimPrefix(part, "-"), "+")
+}
+
+// listQueryError maps the sort and cursor errors of a repository listing to validation errors.
+func listQueryError(err error) error {
+	switch {
+	case errors.Is(err, repository.ErrInvalidSortField):
+		return apperror.NewValidationError("Cannot sort on " + strings.TrimPrefix(err.Error(), repository.ErrInvalidSortField.Error()+": "))
+	case errors.Is(err, repository.ErrInvalidCursor):
+		return apperror.NewValidationError("Invalid cursor, it may belong to a listing with a different sort")
+	}
+	return nil
+}
+
+// encodeListCursor renders the next cursor of a page, or an empty string on the last page.
+func encodeListCursor(query repository.ListQuery, values []string) string {
+	if values == nil {
+		return ""
+	}
+	encoded, err := json.Marshal(listCursor{Sort: canonicalSort(query.Sort), Values: values})
+	if err != nil {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString(encoded)
+}
+
+func decodeListCursor(cursor string) (*listCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, err
+	}
+	var decoded listCursor
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		return nil, err
+	}
+	return &decoded, nil
+}
+
+func canonicalSort(fields []repository.SortField) string {
+	parts := make([]string, len(fields))
+	for i, field := range fields {
+		parts[i] = field.Field
+		if field.Descending {
+			parts[i] = "-" + field.Field
+		}
+	}
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"go-base-project/internal/apperror"
+	"go-base-project/internal/repository"
+)
+
+func TestBuildListQuerySort(t *testing.T) {
+	tests := []struct {
+		name        string
+		sort        string
+		defaultSort string
+		want        []repository.SortField
+	}{
+		{name: "falls back to the default", sort: " ", defaultSort: "-created_at", want: []repository.SortField{{Field: "created_at", Descending: true}}},
+		{name: "parses directions", sort: "-level, +username,email", want: []repository.SortField{
+			{Field: "level", Descending: true}, {Field: "username"}, {Field: "email"},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := buildListQuery(tt.sort, tt.defaultSort, "", 0, 10)
+			if err != nil {
+				t.Fatalf("buildListQuery() error = %v", err)
+			}
+			if !reflect.DeepEqual(query.Sort, tt.want) {
+				t.Errorf("sort = %#v, want %#v", query.Sort, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildListQueryRejectsInvalidSort(t *testing.T) {
+	for _, sort := range []string{"username,", "username,-username", "a,b,c,d,e"} {
+		if _, err := buildListQuery(sort, "", "", 0, 10); !isValidationError(err) {
+			t.Errorf("buildListQuery(%q) error = %v, want a validation error", sort, err)
+		}
+	}
+}
+
+func TestListCursorRoundTrip(t *testing.T) {
+	query, err := buildListQuery("-level,username", "", "", 0, 10)
+	if err != nil {
+		t.Fatalf("buildListQuery() error = %v", err)
+	}
+	values := []string{"50", "jane", "0190f3a2-7c41-7d2e-9b1a-3c4d5e6f7a8b"}
+
+	cursor := encodeListCursor(query, values)
+	if cursor == "" {
+		t.Fatal("encodeListCursor() = \"\", want a cursor")
+	}
+
+	// A cursor replaces the offset and is only accepted for the sort it was issued for
+	next, err := buildListQuery("-level, username", "", cursor, 40, 10)
+	if err != nil {
+		t.Fatalf("buildListQuery() with cursor error = %v", err)
+	}
+	if !reflect.DeepEqual(next.After, values) {
+		t.Errorf("after = %v, want %v", next.After, values)
+	}
+	if next.Offset != 0 {
+		t.Errorf("offset = %d, want 0 when a cursor is given", next.Offset)
+	}
+
+	if _, err := buildListQuery("level,username", "", cursor, 0, 10); !isValidationError(err) {
+		t.Errorf("buildListQuery() with a cursor of another sort error = %v, want a validation error", err)
+	}
+}
+
+func TestEncodeListCursorOnLastPage(t *testing.T) {
+	if cursor := encodeListCursor(repository.ListQuery{}, nil); cursor != "" {
+		t.Errorf("encodeListCursor() = %q, want \"\" on the last page", cursor)
+	}
+}
+
+func TestBuildListQueryRejectsMalformedCursor(t *testing.T) {
+	for _, cursor := range []string{"not*base64", base64.RawURLEncoding.EncodeToString([]byte("not json"))} {
+		if _, err := buildListQuery("username", "", cursor, 0, 10); !isValidationError(err) {
+			t.Errorf("buildListQuery() with cursor %q error = %v, want a validation error", cursor, err)
+		}
+	}
+}
+
+func isValidationError(err error) bool {
+	var appErr *apperror.AppError
+	return errors.As(err, &appErr) && appErr.Code == http.StatusBadRequest
+}
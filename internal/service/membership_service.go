@@ -0,0 +1,437 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-base-project/internal/apperror"
+	"go-base-project/internal/constant"
+	"go-base-project/internal/dto"
+	"go-base-project/internal/model"
+	"go-base-project/internal/repository"
+	"go-base-project/internal/util"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+// membershipService implements MembershipServiceInterface.
+type membershipService struct {
+	membershipRepo repository.MembershipRepositoryInterface
+	userRepo       repository.UserRepositoryInterface
+	roleRepo       repository.RoleRepositoryInterface
+	orgRepo        repository.OrganizationRepositoryInterface
+	orgTypeRepo    repository.OrganizationTypeRepositoryInterface
+	txManager      repository.TransactionManagerInterface
+	quotaService   OrganizationQuotaServiceInterface
+}
+
+// NewMembershipService creates a new membership service instance
+func NewMembershipService(
+	membershipRepo repository.MembershipRepositoryInterface,
+	userRepo repository.UserRepositoryInterface,
+	roleRepo repository.RoleRepositoryInterface,
+	orgRepo repository.OrganizationRepositoryInterface,
+	orgTypeRepo repository.OrganizationTypeRepositoryInterface,
+	txManager repository.TransactionManagerInterface,
+	quotaService OrganizationQuotaServiceInterface,
+) MembershipServiceInterface {
+	return &membershipService{
+		membershipRepo: membershipRepo,
+		userRepo:       userRepo,
+		roleRepo:       roleRepo,
+		orgRepo:        orgRepo,
+		orgTypeRepo:    orgTypeRepo,
+		txManager:      txManager,
+		quotaService:   quotaService,
+	}
+}
+
+// membershipChange describes a membership change made by actor; uuid.Nil marks a change made by the system.
+func membershipChange(actor uuid.UUID, reason string) repository.MembershipChange {
+	change := repository.MembershipChange{Reason: reason}
+	if actor != uuid.Nil {
+		change.ActionBy = &actor
+	}
+	return change
+}
+
+// AddMember adds a user to an organization with an optional role.
+func (s *membershipService) AddMember(ctx context.Context, req dto.AssignUserToOrganizationRequest, addedBy uuid.UUID) (*dto.UserOrganizationResponse, error) {
+	if _, err := s.userRepo.FindByID(ctx, req.UserID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.NewNotFoundError("user")
+		}
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to find user: %w", err))
+	}
+	if err := s.ensureRoleExists(ctx, req.RoleID); err != nil {
+		return nil, err
+	}
+
+	err := s.txManager.RunInTx(ctx, func(ctx context.Context) error {
+		if err := s.lockOrganizationForMembers(ctx, req.OrganizationID); err != nil {
+			return err
+		}
+
+		// Only active memberships count against the member limit
+		if req.IsActive {
+			if err := s.quotaService.EnsureQuota(ctx, req.OrganizationID, constant.OrganizationQuotaMembers, 1); err != nil {
+				return err
+			}
+		}
+
+		membership := &model.UserOrganization{
+			UserID:         req.UserID,
+			OrganizationID: req.OrganizationID,
+			RoleID:         req.RoleID,
+			IsActive:       req.IsActive,
+			JoinedAt:       time.Now(),
+		}
+		if err := s.membershipRepo.Create(ctx, membership, membershipChange(addedBy, req.Reason)); err != nil {
+			if errors.Is(err, repository.ErrAlreadyOrganizationMember) {
+				return apperror.NewConflictError("User is already assigned to this organization")
+			}
+			return apperror.NewInternalError(fmt.Errorf("failed to assign user to organization: %w", err))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := s.membershipRepo.Find(ctx, req.UserID, req.OrganizationID)
+	if err != nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to fetch created membership: %w", err))
+	}
+	return mapMembershipToResponse(created), nil
+}
+
+// BulkAddMembers adds several users to an organization as active members. Users that do not exist or are
+// already members are reported as failures; the whole batch is rejected when it exceeds the member limit.
+// The batch is applied in one transaction, so it is added with its history in full or not at all.
+func (s *membershipService) BulkAddMembers(ctx context.Context, req dto.BulkAssignUsersToOrganizationRequest, addedBy uuid.UUID) (*dto.BulkAssignResponse, error) {
+	if err := s.ensureRoleExists(ctx, req.RoleID); err != nil {
+		return nil, err
+	}
+
+	var assignments []dto.UserOrganizationResponse
+	var failures []dto.BulkAssignError
+	err := s.txManager.RunInTx(ctx, func(ctx context.Context) error {
+		assignments, failures = nil, nil
+		if err := s.lockOrganizationForMembers(ctx, req.OrganizationID); err != nil {
+			return err
+		}
+
+		var candidates []uuid.UUID
+		for _, userID := range req.UserIDs {
+			if _, err := s.userRepo.FindByID(ctx, userID); err != nil {
+				failures = append(failures, dto.BulkAssignError{UserID: userID, Error: "User not found"})
+				continue
+			}
+			if existing, err := s.membershipRepo.Find(ctx, userID, req.OrganizationID); err == nil && existing.IsActive {
+				failures = append(failures, dto.BulkAssignError{UserID: userID, Error: "User already assigned to organization"})
+				continue
+			}
+			candidates = append(candidates, userID)
+		}
+
+		if len(candidates) > 0 {
+			if err := s.quotaService.EnsureQuota(ctx, req.OrganizationID, constant.OrganizationQuotaMembers, len(candidates)); err != nil {
+				return err
+			}
+		}
+
+		change := membershipChange(addedBy, "Bulk assignment")
+		assignments = make([]dto.UserOrganizationResponse, 0, len(candidates))
+		for _, userID := range candidates {
+			membership := &model.UserOrganization{
+				UserID:         userID,
+				OrganizationID: req.OrganizationID,
+				RoleID:         req.RoleID,
+				IsActive:       true,
+				JoinedAt:       time.Now(),
+			}
+			if err := s.membershipRepo.Create(ctx, membership, change); err != nil {
+				if errors.Is(err, repository.ErrAlreadyOrganizationMember) {
+					failures = append(failures, dto.BulkAssignError{UserID: userID, Error: "User already assigned to organization"})
+					continue
+				}
+				return apperror.NewInternalError(fmt.Errorf("failed to assign user %s to organization: %w", userID, err))
+			}
+			assignments = append(assignments, *mapMembershipToResponse(membership))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	successCount := len(assignments)
+	failureCount := len(failures)
+	return &dto.BulkAssignResponse{
+		SuccessCount: successCount,
+		FailureCount: failureCount,
+		Assignments:  assignments,
+		Errors:       failures,
+		Message:      fmt.Sprintf("%d users assigned successfully, %d failed", successCount, failureCount),
+	}, nil
+}
+
+// UpdateMember sets the role and status of a membership. Reactivating a membership counts against the
+// member limit like adding one.
+func (s *membershipService) UpdateMember(ctx context.Context, userID, organizationID uuid.UUID, req dto.UpdateUserOrganizationRequest, updatedBy uuid.UUID) (*dto.UserOrganizationResponse, error) {
+	if err := s.ensureRoleExists(ctx, req.RoleID); err != nil {
+		return nil, err
+	}
+
+	var membership *model.UserOrganization
+	err := s.txManager.RunInTx(ctx, func(ctx context.Context) error {
+		if err := s.lockOrganizationForMembers(ctx, organizationID); err != nil {
+			return err
+		}
+
+		current, err := s.membershipRepo.Find(ctx, userID, organizationID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return apperror.NewNotFoundError("user organization assignment")
+			}
+			return apperror.NewInternalError(fmt.Errorf("failed to find membership: %w", err))
+		}
+		if req.IsActive && !current.IsActive {
+			if err := s.quotaService.EnsureQuota(ctx, organizationID, constant.OrganizationQuotaMembers, 1); err != nil {
+				return err
+			}
+		}
+
+		update := repository.MembershipUpdate{RoleID: req.RoleID, IsActive: req.IsActive}
+		membership, err = s.membershipRepo.Update(ctx, userID, organizationID, update, membershipChange(updatedBy, req.Reason))
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return apperror.NewNotFoundError("user organization assignment")
+			}
+			return apperror.NewInternalError(fmt.Errorf("failed to update membership: %w", err))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mapMembershipToResponse(membership), nil
+}
+
+// UpdateMemberAttributes replaces the custom attributes of a membership after validating them against the
+// attribute schema of the organization's type.
+func (s *membershipService) UpdateMemberAttributes(ctx context.Context, userID, organizationID uuid.UUID, req dto.UpdateUserOrganizationAttributesRequest, updatedBy uuid.UUID) (*dto.UserOrganizationResponse, error) {
+	membership, err := s.membershipRepo.Find(ctx, userID, organizationID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.NewNotFoundError("user organization assignment")
+		}
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to find membership: %w", err))
+	}
+
+	schema, err := s.orgTypeRepo.FindAttributes(ctx, membership.Organization.OrganizationType)
+	if err != nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to fetch organization type attributes: %w", err))
+	}
+	attributes, err := normalizeMembershipAttributes(schema, req.Attributes)
+	if err != nil {
+		return nil, apperror.NewValidationError(err.Error())
+	}
+
+	if err := s.membershipRepo.UpdateAttributes(ctx, userID, organizationID, attributes, membershipChange(updatedBy, "")); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.NewNotFoundError("user organization assignment")
+		}
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to update membership attributes: %w", err))
+	}
+	membership.Attributes = attributes
+
+	log.Info().
+		Str("user_id", userID.String()).
+		Str("organization_id", organizationID.String()).
+		Str("updated_by", updatedBy.String()).
+		Msg("Membership attributes updated")
+	return mapMembershipToResponse(membership), nil
+}
+
+// RemoveMember removes a user from an organization.
+func (s *membershipService) RemoveMember(ctx context.Context, userID, organizationID uuid.UUID, removedBy uuid.UUID) error {
+	return s.removeMember(ctx, userID, organizationID, membershipChange(removedBy, ""))
+}
+
+// LeaveOrganization removes the calling user from an organization.
+func (s *membershipService) LeaveOrganization(ctx context.Context, userID, organizationID uuid.UUID) error {
+	return s.removeMember(ctx, userID, organizationID, membershipChange(userID, "Left the organization"))
+}
+
+func (s *membershipService) removeMember(ctx context.Context, userID, organizationID uuid.UUID, change repository.MembershipChange) error {
+	if err := s.membershipRepo.Delete(ctx, userID, organizationID, change); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apperror.NewNotFoundError("user organization assignment")
+		}
+		return apperror.NewInternalError(fmt.Errorf("failed to remove user from organization: %w", err))
+	}
+	return nil
+}
+
+// ListUserMemberships returns the active memberships of a user with their organization and role.
+func (s *membershipService) ListUserMemberships(ctx context.Context, userID uuid.UUID, page, limit int) (*dto.PagedUserOrganizationResponse, error) {
+	page, limit, offset := util.ValidateAndSetPaginationParams(page, limit)
+
+	memberships, err := s.membershipRepo.FindByUser(ctx, userID, offset, limit)
+	if err != nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to get user organizations: %w", err))
+	}
+	total, err := s.membershipRepo.CountByUser(ctx, userID)
+	if err != nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to count user organizations: %w", err))
+	}
+	return pagedMembershipResponse(memberships, page, limit, total), nil
+}
+
+// ListOrganizationMembers returns the active members of an organization with their user and role.
+func (s *membershipService) ListOrganizationMembers(ctx context.Context, organizationID uuid.UUID, page, limit int) (*dto.PagedUserOrganizationResponse, error) {
+	page, limit, offset := util.ValidateAndSetPaginationParams(page, limit)
+
+	memberships, err := s.membershipRepo.FindByOrganization(ctx, organizationID, offset, limit)
+	if err != nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to get organization members: %w", err))
+	}
+	total, err := s.membershipRepo.CountByOrganization(ctx, organizationID)
+	if err != nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to count organization members: %w", err))
+	}
+	return pagedMembershipResponse(memberships, page, limit, total), nil
+}
+
+// GetUserHistory returns the membership history of a user, newest first.
+func (s *membershipService) GetUserHistory(ctx context.Context, userID uuid.UUID, page, limit int) (*dto.PagedUserOrganizationHistoryResponse, error) {
+	page, limit, offset := util.ValidateAndSetPaginationParams(page, limit)
+
+	history, err := s.membershipRepo.FindHistoryByUser(ctx, userID, offset, limit)
+	if err != nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to get user organization history: %w", err))
+	}
+	total, err := s.membershipRepo.CountHistoryByUser(ctx, userID)
+	if err != nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to count user organization history: %w", err))
+	}
+	return pagedMembershipHistoryResponse(history, page, limit, total), nil
+}
+
+// GetOrganizationHistory returns the membership history of an organization, newest first.
+func (s *membershipService) GetOrganizationHistory(ctx context.Context, organizationID uuid.UUID, page, limit int) (*dto.PagedUserOrganizationHistoryResponse, error) {
+	page, limit, offset := util.ValidateAndSetPaginationParams(page, limit)
+
+	history, err := s.membershipRepo.FindHistoryByOrganization(ctx, organizationID, offset, limit)
+	if err != nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to get organization user history: %w", err))
+	}
+	total, err := s.membershipRepo.CountHistoryByOrganization(ctx, organizationID)
+	if err != nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to count organization user history: %w", err))
+	}
+	return pagedMembershipHistoryResponse(history, page, limit, total), nil
+}
+
+// lockOrganizationForMembers locks the organization row for the running transaction and ensures it accepts
+// members. Additions to the same organization wait for each other, so the member limit they check
+// includes the members added by a concurrent request.
+func (s *membershipService) lockOrganizationForMembers(ctx context.Context, organizationID uuid.UUID) error {
+	org, err := s.orgRepo.FindByIDForUpdate(ctx, organizationID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apperror.NewNotFoundError("organization")
+		}
+		return apperror.NewInternalError(fmt.Errorf("failed to lock organization: %w", err))
+	}
+	return ensureOrganizationNotArchived(org)
+}
+
+// ensureRoleExists validates an optional role of a membership
+func (s *membershipService) ensureRoleExists(ctx context.Context, roleID *uuid.UUID) error {
+	if roleID == nil {
+		return nil
+	}
+	if _, err := s.roleRepo.FindByID(ctx, *roleID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apperror.NewNotFoundError("role")
+		}
+		return apperror.NewInternalError(fmt.Errorf("failed to find role: %w", err))
+	}
+	return nil
+}
+
+// pagedMembershipResponse wraps a page of memberships in the paged response DTO
+func pagedMembershipResponse(memberships []model.UserOrganization, page, limit int, total int64) *dto.PagedUserOrganizationResponse {
+	responses := make([]dto.UserOrganizationResponse, len(memberships))
+	for i := range memberships {
+		responses[i] = *mapMembershipToResponse(&memberships[i])
+	}
+	return &dto.PagedUserOrganizationResponse{
+		UserOrganizations: responses,
+		Page:              page,
+		Limit:             limit,
+		Total:             total,
+		TotalPages:        int(math.Ceil(float64(total) / float64(limit))),
+	}
+}
+
+// pagedMembershipHistoryResponse wraps a page of history entries in the paged response DTO
+func pagedMembershipHistoryResponse(history []model.UserOrganizationHistory, page, limit int, total int64) *dto.PagedUserOrganizationHistoryResponse {
+	responses := make([]dto.UserOrganizationHistoryResponse, len(history))
+	for i := range history {
+		responses[i] = *mapMembershipHistoryToResponse(&history[i])
+	}
+	return &dto.PagedUserOrganizationHistoryResponse{
+		History:    responses,
+		Page:       page,
+		Limit:      limit,
+		Total:      total,
+		TotalPages: int(math.Ceil(float64(total) / float64(limit))),
+	}
+}
+
+// mapMembershipToResponse converts a membership and the relationships loaded with it to the response DTO
+func mapMembershipToResponse(membership *model.UserOrganization) *dto.UserOrganizationResponse {
+	response := &dto.UserOrganizationResponse{
+		UserID:         membership.UserID,
+		OrganizationID: membership.OrganizationID,
+		RoleID:         membership.RoleID,
+		Role:           util.MapRoleToResponse(membership.Role),
+		JoinedAt:       membership.JoinedAt,
+		IsActive:       membership.IsActive,
+	}
+	if membership.User.ID != uuid.Nil {
+		response.User = util.MapUserToResponse(&membership.User)
+	}
+	if membership.Organization.ID != uuid.Nil {
+		response.Organization = *util.MapOrganizationToResponse(&membership.Organization)
+	}
+	if membership.Attributes != "" && membership.Attributes != "{}" {
+		response.Attributes = json.RawMessage(membership.Attributes)
+	}
+	return response
+}
+
+// mapMembershipHistoryToResponse converts a membership history entry to the response DTO
+func mapMembershipHistoryToResponse(history *model.UserOrganizationHistory) *dto.UserOrganizationHistoryResponse {
+	return &dto.UserOrganizationHistoryResponse{
+		ID:             history.ID,
+		UserID:         history.UserID,
+		OrganizationID: history.OrganizationID,
+		Action:         history.Action,
+		PreviousRole:   history.PreviousRole,
+		NewRole:        history.NewRole,
+		PreviousStatus: history.PreviousStatus,
+		NewStatus:      history.NewStatus,
+		ActionBy:       history.ActionBy,
+		ActionAt:       history.ActionAt.Format(time.RFC3339),
+		Reason:         history.Reason,
+	}
+}
@@ -0,0 +1,36 @@
+package service
+
+import (
+	"context"
+	"go-base-project/internal/dto"
+
+	"github.com/google/uuid"
+)
+
+// MembershipServiceInterface defines the business logic for user-organization memberships.
+// Every change is recorded in the membership history together with the user who made it.
+type MembershipServiceInterface interface {
+	// AddMember adds a user to an organization, or reactivates an inactive membership.
+	AddMember(ctx context.Context, req dto.AssignUserToOrganizationRequest, addedBy uuid.UUID) (*dto.UserOrganizationResponse, error)
+	// BulkAddMembers adds several users to an organization; users that cannot be added are reported per user.
+	BulkAddMembers(ctx context.Context, req dto.BulkAssignUsersToOrganizationRequest, addedBy uuid.UUID) (*dto.BulkAssignResponse, error)
+	// UpdateMember sets the role and status of a membership.
+	UpdateMember(ctx context.Context, userID, organizationID uuid.UUID, req dto.UpdateUserOrganizationRequest, updatedBy uuid.UUID) (*dto.UserOrganizationResponse, error)
+	// UpdateMemberAttributes replaces the custom attributes of a membership, validated against the
+	// attribute schema of the organization's type.
+	UpdateMemberAttributes(ctx context.Context, userID, organizationID uuid.UUID, req dto.UpdateUserOrganizationAttributesRequest, updatedBy uuid.UUID) (*dto.UserOrganizationResponse, error)
+	// RemoveMember removes a user from an organization.
+	RemoveMember(ctx context.Context, userID, organizationID uuid.UUID, removedBy uuid.UUID) error
+	// LeaveOrganization removes the calling user from an organization.
+	LeaveOrganization(ctx context.Context, userID, organizationID uuid.UUID) error
+
+	// ListUserMemberships returns the active memberships of a user.
+	ListUserMemberships(ctx context.Context, userID uuid.UUID, page, limit int) (*dto.PagedUserOrganizationResponse, error)
+	// ListOrganizationMembers returns the active members of an organization.
+	ListOrganizationMembers(ctx context.Context, organizationID uuid.UUID, page, limit int) (*dto.PagedUserOrganizationResponse, error)
+
+	// GetUserHistory returns the membership history of a user, newest first.
+	GetUserHistory(ctx context.Context, userID uuid.UUID, page, limit int) (*dto.PagedUserOrganizationHistoryResponse, error)
+	// GetOrganizationHistory returns the membership history of an organization, newest first.
+	GetOrganizationHistory(ctx context.Context, organizationID uuid.UUID, page, limit int) (*dto.PagedUserOrganizationHistoryResponse, error)
+}
@@ -0,0 +1,188 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-base-project/internal/apperror"
+	"go-base-project/internal/dto"
+	"go-base-project/internal/model"
+	"go-base-project/internal/repository"
+	"go-base-project/internal/util"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+// organizationDefaultRoles are the roles granted automatically in an organization of a given type.
+type organizationDefaultRoles struct {
+	Creator *model.Role
+	Member  *model.Role
+}
+
+// resolveOrganizationDefaultRoles resolves the creator and member roles for an organization type.
+// Only active roles mapped to the type (FindRolesByOrganizationType) are eligible. When a role is not
+// configured, or the configured one is no longer eligible, the highest-level eligible role is used for
+// creators and the lowest-level one for members. Either role is nil when the type has no eligible roles.
+func resolveOrganizationDefaultRoles(ctx context.Context, roleRepo repository.RoleRepositoryInterface, organizationType string) (*organizationDefaultRoles, error) {
+	eligible, err := roleRepo.FindRolesByOrganizationType(ctx, organizationType)
+	if err != nil {
+		return nil, err
+	}
+	if len(eligible) == 0 {
+		log.Warn().Str("organization_type", organizationType).Msg("No roles are mapped to organization type; memberships will have no default role")
+		return &organizationDefaultRoles{}, nil
+	}
+
+	defaults, err := roleRepo.FindDefaultRolesByOrganizationType(ctx, organizationType)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to find default roles for organization type %s: %w", organizationType, err)
+	}
+
+	var creatorRoleID, memberRoleID *uuid.UUID
+	if defaults != nil {
+		creatorRoleID = defaults.CreatorRoleID
+		memberRoleID = defaults.MemberRoleID
+	}
+
+	// Eligible roles are ordered by level ascending
+	return &organizationDefaultRoles{
+		Creator: pickEligibleRole(eligible, creatorRoleID, &eligible[len(eligible)-1], organizationType, "creator"),
+		Member:  pickEligibleRole(eligible, memberRoleID, &eligible[0], organizationType, "member"),
+	}, nil
+}
+
+// pickEligibleRole returns the configured role when it is eligible, otherwise the fallback.
+func pickEligibleRole(eligible []model.Role, configuredID *uuid.UUID, fallback *model.Role, organizationType, kind string) *model.Role {
+	if configuredID == nil {
+		return fallback
+	}
+	for i := range eligible {
+		if eligible[i].ID == *configuredID {
+			return &eligible[i]
+		}
+	}
+	log.Warn().
+		Str("organization_type", organizationType).
+		Str("role_id", configuredID.String()).
+		Msgf("Configured default %s role is not eligible for organization type; falling back to %s", kind, fallback.Name)
+	return fallback
+}
+
+// ListOrganizationTypeDefaultRoles returns the configured and effective default roles of every registered organization type.
+func (s *roleService) ListOrganizationTypeDefaultRoles(ctx context.Context) ([]dto.OrganizationTypeDefaultRolesResponse, error) {
+	orgTypes, err := s.orgTypeRepo.FindAll(ctx, false)
+	if err != nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to fetch organization types: %w", err))
+	}
+
+	configured, err := s.roleRepo.FindAllDefaultRoles(ctx)
+	if err != nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to fetch default roles: %w", err))
+	}
+
+	configuredByType := make(map[string]*model.OrganizationTypeDefaultRole, len(configured))
+	for i := range configured {
+		configuredByType[configured[i].OrganizationType] = &configured[i]
+	}
+
+	responses := make([]dto.OrganizationTypeDefaultRolesResponse, 0, len(orgTypes))
+	for _, orgType := range orgTypes {
+		response, err := s.buildOrganizationTypeDefaultRolesResponse(ctx, orgType.Name, configuredByType[orgType.Name])
+		if err != nil {
+			return nil, err
+		}
+		responses = append(responses, *response)
+	}
+
+	return responses, nil
+}
+
+// UpdateOrganizationTypeDefaultRoles configures the creator and member roles of an organization type.
+func (s *roleService) UpdateOrganizationTypeDefaultRoles(ctx context.Context, organizationType string, req dto.UpdateOrganizationTypeDefaultRolesRequest, updatedBy uuid.UUID) (*dto.OrganizationTypeDefaultRolesResponse, error) {
+	if _, err := findActiveOrganizationType(ctx, s.orgTypeRepo, organizationType); err != nil {
+		return nil, err
+	}
+
+	if err := saveOrganizationDefaultRoles(ctx, s.roleRepo, organizationType, req.CreatorRoleID, req.MemberRoleID, updatedBy); err != nil {
+		return nil, err
+	}
+
+	saved, err := s.roleRepo.FindDefaultRolesByOrganizationType(ctx, organizationType)
+	if err != nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to fetch default roles: %w", err))
+	}
+	return s.buildOrganizationTypeDefaultRolesResponse(ctx, organizationType, saved)
+}
+
+// saveOrganizationDefaultRoles validates and stores the default roles of an organization type.
+// Both roles must be active and mapped to the type, and the member role may not outrank the creator role.
+func saveOrganizationDefaultRoles(ctx context.Context, roleRepo repository.RoleRepositoryInterface, organizationType string, creatorRoleID, memberRoleID, updatedBy uuid.UUID) error {
+	eligible, err := roleRepo.FindRolesByOrganizationType(ctx, organizationType)
+	if err != nil {
+		return apperror.NewInternalError(fmt.Errorf("failed to fetch roles for organization type: %w", err))
+	}
+
+	var creatorRole, memberRole *model.Role
+	for i := range eligible {
+		if eligible[i].ID == creatorRoleID {
+			creatorRole = &eligible[i]
+		}
+		if eligible[i].ID == memberRoleID {
+			memberRole = &eligible[i]
+		}
+	}
+	if creatorRole == nil {
+		return apperror.NewValidationError(fmt.Sprintf("Creator role is not an active role for organization type '%s'", organizationType))
+	}
+	if memberRole == nil {
+		return apperror.NewValidationError(fmt.Sprintf("Member role is not an active role for organization type '%s'", organizationType))
+	}
+	if memberRole.Level > creatorRole.Level {
+		return apperror.NewValidationError("Member role level cannot be higher than the creator role level")
+	}
+
+	defaults := &model.OrganizationTypeDefaultRole{
+		OrganizationType: organizationType,
+		CreatorRoleID:    &creatorRole.ID,
+		MemberRoleID:     &memberRole.ID,
+		UpdatedBy:        &updatedBy,
+	}
+	if err := roleRepo.UpsertDefaultRoles(ctx, defaults); err != nil {
+		return apperror.NewInternalError(fmt.Errorf("failed to save default roles: %w", err))
+	}
+
+	log.Info().
+		Str("organization_type", organizationType).
+		Str("creator_role_id", creatorRole.ID.String()).
+		Str("member_role_id", memberRole.ID.String()).
+		Str("updated_by", updatedBy.String()).
+		Msg("Default roles for organization type updated")
+	return nil
+}
+
+// buildOrganizationTypeDefaultRolesResponse combines the configured defaults with the roles that are actually granted.
+func (s *roleService) buildOrganizationTypeDefaultRolesResponse(ctx context.Context, organizationType string, configured *model.OrganizationTypeDefaultRole) (*dto.OrganizationTypeDefaultRolesResponse, error) {
+	effective, err := resolveOrganizationDefaultRoles(ctx, s.roleRepo, organizationType)
+	if err != nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to resolve default roles: %w", err))
+	}
+
+	response := &dto.OrganizationTypeDefaultRolesResponse{OrganizationType: organizationType}
+	if configured != nil {
+		response.CreatorRoleID = configured.CreatorRoleID
+		response.MemberRoleID = configured.MemberRoleID
+		response.UpdatedBy = configured.UpdatedBy
+		updatedAt := configured.UpdatedAt.Format(time.RFC3339)
+		response.UpdatedAt = &updatedAt
+	}
+	if effective.Creator != nil {
+		response.CreatorRole = util.MapRoleToResponse(effective.Creator)
+	}
+	if effective.Member != nil {
+		response.MemberRole = util.MapRoleToResponse(effective.Member)
+	}
+	return response, nil
+}
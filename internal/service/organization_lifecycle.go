@@ -0,0 +1,268 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"go-base-project/internal/apperror"
+	"go-base-project/internal/constant"
+	"go-base-project/internal/dto"
+	"go-base-project/internal/model"
+	"go-base-project/internal/util"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+// organizationArchiveBatchSize is the number of organizations archived per transaction
+const organizationArchiveBatchSize = 100
+
+// ensureOrganizationNotArchived rejects changes that would add members or grant roles in an archived organization
+func ensureOrganizationNotArchived(org *model.Organization) error {
+	if org.IsArchived() {
+		return apperror.NewConflictError(fmt.Sprintf("Organization %s is archived", org.Name))
+	}
+	return nil
+}
+
+// ArchiveOrganization archives an organization, and with cascade its whole subtree. Archived organizations
+// keep their data but block joins, organization-context access and new role grants until unarchived.
+func (s *organizationService) ArchiveOrganization(ctx context.Context, id uuid.UUID, req dto.ArchiveOrganizationRequest, archivedBy uuid.UUID) (*dto.ArchiveOrganizationResponse, error) {
+	org, err := s.orgRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.NewNotFoundError("organization")
+		}
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to find organization: %w", err))
+	}
+	if org.IsArchived() && !req.Cascade {
+		return nil, apperror.NewConflictError("Organization is already archived")
+	}
+
+	targets, err := s.collectLifecycleTargets(ctx, org, req.Cascade, func(o *model.Organization) bool { return !o.IsArchived() })
+	if err != nil {
+		return nil, err
+	}
+	if len(targets) == 0 {
+		return nil, apperror.NewConflictError("Organization and its subtree are already archived")
+	}
+
+	auditLogs, err := buildLifecycleAuditLogs(targets, org.ID, constant.OrganizationAuditActionArchived, req, archivedBy)
+	if err != nil {
+		return nil, err
+	}
+	// Large subtrees are archived in batches, so a background job reports progress and can stop between
+	// them; archiving the subtree again picks up the organizations a stopped run left out
+	for start := 0; start < len(targets); start += organizationArchiveBatchSize {
+		end := start + organizationArchiveBatchSize
+		if end > len(targets) {
+			end = len(targets)
+		}
+		if err := s.orgRepo.Archive(ctx, targets[start:end], archivedBy, auditLogs[start:end]); err != nil {
+			return nil, apperror.NewInternalError(fmt.Errorf("failed to archive organization: %w", err))
+		}
+		if err := reportJobProgress(ctx, end, len(targets), &dto.ArchiveOrganizationResponse{AffectedOrganizationIDs: targets[:end]}); err != nil {
+			return nil, err
+		}
+	}
+
+	log.Info().
+		Str("organization_id", org.ID.String()).
+		Str("archived_by", archivedBy.String()).
+		Bool("cascade", req.Cascade).
+		Int("affected_organizations", len(targets)).
+		Msg("Organization archived")
+
+	return s.buildArchiveResponse(ctx, org.ID, targets)
+}
+
+// UnarchiveOrganization lifts the archive of an organization, and with cascade of its whole subtree
+func (s *organizationService) UnarchiveOrganization(ctx context.Context, id uuid.UUID, req dto.ArchiveOrganizationRequest, unarchivedBy uuid.UUID) (*dto.ArchiveOrganizationResponse, error) {
+	org, err := s.orgRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.NewNotFoundError("organization")
+		}
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to find organization: %w", err))
+	}
+	if !org.IsArchived() && !req.Cascade {
+		return nil, apperror.NewConflictError("Organization is not archived")
+	}
+
+	targets, err := s.collectLifecycleTargets(ctx, org, req.Cascade, func(o *model.Organization) bool { return o.IsArchived() })
+	if err != nil {
+		return nil, err
+	}
+	if len(targets) == 0 {
+		return nil, apperror.NewConflictError("Organization and its subtree are not archived")
+	}
+
+	auditLogs, err := buildLifecycleAuditLogs(targets, org.ID, constant.OrganizationAuditActionUnarchived, req, unarchivedBy)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.orgRepo.Unarchive(ctx, targets, auditLogs); err != nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to unarchive organization: %w", err))
+	}
+
+	log.Info().
+		Str("organization_id", org.ID.String()).
+		Str("unarchived_by", unarchivedBy.String()).
+		Bool("cascade", req.Cascade).
+		Int("affected_organizations", len(targets)).
+		Msg("Organization unarchived")
+
+	return s.buildArchiveResponse(ctx, org.ID, targets)
+}
+
+// collectLifecycleTargets returns the organization, plus its descendants when cascading, that match the filter
+func (s *organizationService) collectLifecycleTargets(ctx context.Context, org *model.Organization, cascade bool, include func(*model.Organization) bool) ([]uuid.UUID, error) {
+	var targets []uuid.UUID
+	if include(org) {
+		targets = append(targets, org.ID)
+	}
+	if !cascade {
+		return targets, nil
+	}
+
+	descendants, err := s.orgRepo.GetDescendants(ctx, org.ID, 0)
+	if err != nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to find descendant organizations: %w", err))
+	}
+	for _, descendant := range descendants {
+		if descendant.Descendant != nil && include(descendant.Descendant) {
+			targets = append(targets, descendant.DescendantID)
+		}
+	}
+	return targets, nil
+}
+
+// buildLifecycleAuditLogs creates one audit entry per affected organization, pointing back at the organization
+// the operation was requested for so cascaded entries can be traced to their origin
+func buildLifecycleAuditLogs(orgIDs []uuid.UUID, rootID uuid.UUID, action string, req dto.ArchiveOrganizationRequest, actorID uuid.UUID) ([]model.OrganizationAuditLog, error) {
+	details, err := json.Marshal(map[string]interface{}{
+		"cascade":                req.Cascade,
+		"root_organization_id":   rootID,
+		"affected_organizations": len(orgIDs),
+	})
+	if err != nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to serialize audit details: %w", err))
+	}
+
+	auditLogs := make([]model.OrganizationAuditLog, len(orgIDs))
+	for i, orgID := range orgIDs {
+		auditLogs[i] = model.OrganizationAuditLog{
+			OrganizationID: orgID,
+			Action:         action,
+			ActorID:        &actorID,
+			Details:        string(details),
+			Reason:         req.Reason,
+		}
+	}
+	return auditLogs, nil
+}
+
+// buildArchiveResponse reloads the requested organization after an archive state change
+func (s *organizationService) buildArchiveResponse(ctx context.Context, orgID uuid.UUID, affected []uuid.UUID) (*dto.ArchiveOrganizationResponse, error) {
+	org, err := s.orgRepo.FindByID(ctx, orgID)
+	if err != nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to reload organization: %w", err))
+	}
+	return &dto.ArchiveOrganizationResponse{
+		Organization:            util.MapOrganizationToResponse(org),
+		AffectedOrganizationIDs: affected,
+	}, nil
+}
+
+// RestoreOrganization brings back a soft-deleted organization that has not been purged yet.
+// Its parent must still exist, and the organization must fit its parent under the current type registry.
+func (s *organizationService) RestoreOrganization(ctx context.Context, id uuid.UUID, req dto.RestoreOrganizationRequest, restoredBy uuid.UUID) (*dto.OrganizationResponse, error) {
+	org, err := s.orgRepo.FindDeletedByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.NewNotFoundError("deleted organization")
+		}
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to find deleted organization: %w", err))
+	}
+
+	var parent *model.Organization
+	if org.ParentOrganizationID != nil {
+		parent, err = s.orgRepo.FindByID(ctx, *org.ParentOrganizationID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, apperror.NewValidationError("The parent organization is deleted; restore it first")
+			}
+			return nil, apperror.NewInternalError(fmt.Errorf("failed to find parent organization: %w", err))
+		}
+	}
+
+	orgType, err := s.orgTypeRepo.FindByName(ctx, org.OrganizationType)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.NewValidationError(fmt.Sprintf("Organization type %s no longer exists", org.OrganizationType))
+		}
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to find organization type: %w", err))
+	}
+	if _, err := s.validateOrganizationPlacement(ctx, orgType, parent); err != nil {
+		return nil, err
+	}
+
+	details, err := json.Marshal(map[string]interface{}{
+		"deleted_at": org.DeletedAt.Time,
+	})
+	if err != nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to serialize audit details: %w", err))
+	}
+
+	auditLog := &model.OrganizationAuditLog{
+		OrganizationID: org.ID,
+		Action:         constant.OrganizationAuditActionRestored,
+		ActorID:        &restoredBy,
+		Details:        string(details),
+		Reason:         req.Reason,
+	}
+	if err := s.orgRepo.Restore(ctx, org.ID, auditLog); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.NewNotFoundError("deleted organization")
+		}
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to restore organization: %w", err))
+	}
+
+	log.Info().
+		Str("organization_id", org.ID.String()).
+		Str("restored_by", restoredBy.String()).
+		Msg("Organization restored")
+
+	restored, err := s.orgRepo.FindByID(ctx, org.ID)
+	if err != nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to reload organization: %w", err))
+	}
+	return util.MapOrganizationToResponse(restored), nil
+}
+
+// ListDeletedOrganizations lists soft-deleted organizations that can still be restored
+func (s *organizationService) ListDeletedOrganizations(ctx context.Context) ([]dto.OrganizationResponse, error) {
+	orgs, err := s.orgRepo.ListDeleted(ctx)
+	if err != nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to list deleted organizations: %w", err))
+	}
+
+	responses := make([]dto.OrganizationResponse, len(orgs))
+	for i := range orgs {
+		responses[i] = *util.MapOrganizationToResponse(&orgs[i])
+	}
+	return responses, nil
+}
+
+// PurgeDeletedOrganizations permanently removes organizations that were soft-deleted longer than retention ago
+func (s *organizationService) PurgeDeletedOrganizations(ctx context.Context, retention time.Duration) (int64, error) {
+	purged, err := s.orgRepo.PurgeDeleted(ctx, time.Now().Add(-retention))
+	if err != nil {
+		return purged, fmt.Errorf("failed to purge deleted organizations: %w", err)
+	}
+	return purged, nil
+}
@@ -0,0 +1,251 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-base-project/internal/apperror"
+	"go-base-project/internal/constant"
+	"go-base-project/internal/dto"
+	"go-base-project/internal/model"
+	"go-base-project/internal/repository"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// organizationQuotaDefinition describes a quota and how its usage is counted.
+type organizationQuotaDefinition struct {
+	Name        string
+	Description string
+	Unit        string // Plural noun used in error messages
+	Usage       func(repository.OrganizationRepositoryInterface, context.Context, uuid.UUID) (int64, error)
+}
+
+// organizationQuotaRegistry lists the quotas an organization can be limited on, in the order they are reported.
+var organizationQuotaRegistry = []organizationQuotaDefinition{
+	{
+		Name:        constant.OrganizationQuotaMembers,
+		Description: "Active members of the organization",
+		Unit:        "members",
+		Usage:       repository.OrganizationRepositoryInterface.CountActiveMembers,
+	},
+	{
+		Name:        constant.OrganizationQuotaChildOrganizations,
+		Description: "Direct child organizations, archived ones included",
+		Unit:        "child organizations",
+		Usage:       repository.OrganizationRepositoryInterface.CountChildren,
+	},
+}
+
+func findOrganizationQuotaDefinition(name string) (*organizationQuotaDefinition, bool) {
+	for i := range organizationQuotaRegistry {
+		if organizationQuotaRegistry[i].Name == name {
+			return &organizationQuotaRegistry[i], true
+		}
+	}
+	return nil, false
+}
+
+type organizationQuotaService struct {
+	quotaRepo repository.OrganizationQuotaRepositoryInterface
+	orgRepo   repository.OrganizationRepositoryInterface
+}
+
+// NewOrganizationQuotaService creates a new instance of OrganizationQuotaService
+func NewOrganizationQuotaService(
+	quotaRepo repository.OrganizationQuotaRepositoryInterface,
+	orgRepo repository.OrganizationRepositoryInterface,
+) OrganizationQuotaServiceInterface {
+	return &organizationQuotaService{
+		quotaRepo: quotaRepo,
+		orgRepo:   orgRepo,
+	}
+}
+
+// GetUsage reports the usage of every quota of an organization against its effective limit.
+func (s *organizationQuotaService) GetUsage(ctx context.Context, orgID uuid.UUID) (*dto.OrganizationQuotaUsageResponse, error) {
+	if err := s.ensureOrganizationExists(ctx, orgID); err != nil {
+		return nil, err
+	}
+
+	limits, err := s.resolveLimits(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.OrganizationQuotaUsageResponse{
+		OrganizationID: orgID,
+		Quotas:         make([]dto.OrganizationQuotaUsage, 0, len(organizationQuotaRegistry)),
+	}
+	for i := range organizationQuotaRegistry {
+		definition := &organizationQuotaRegistry[i]
+		used, err := definition.Usage(s.orgRepo, ctx, orgID)
+		if err != nil {
+			return nil, apperror.NewInternalError(fmt.Errorf("failed to count %s usage: %w", definition.Name, err))
+		}
+
+		usage := dto.OrganizationQuotaUsage{
+			Quota:       definition.Name,
+			Description: definition.Description,
+			Used:        used,
+			Source:      constant.OrganizationSettingSourceDefault,
+		}
+		if quota, ok := limits[definition.Name]; ok {
+			usage.Source = constant.OrganizationSettingSourceInherited
+			if quota.OrganizationID == orgID {
+				usage.Source = constant.OrganizationSettingSourceOrganization
+			}
+			sourceOrgID := quota.OrganizationID
+			updatedAt := quota.UpdatedAt
+			usage.SourceOrganizationID = &sourceOrgID
+			usage.UpdatedAt = &updatedAt
+			usage.Limit = quota.MaxValue
+		}
+		if usage.Limit != nil {
+			remaining := int64(*usage.Limit) - used
+			usage.Exceeded = remaining < 0
+			if remaining < 0 {
+				remaining = 0
+			}
+			usage.Remaining = &remaining
+		}
+		response.Quotas = append(response.Quotas, usage)
+	}
+	return response, nil
+}
+
+// UpdateQuotas validates the requested limits and stores them. Lowering a limit below the current usage
+// is allowed; it only blocks further growth.
+func (s *organizationQuotaService) UpdateQuotas(ctx context.Context, orgID uuid.UUID, req dto.UpdateOrganizationQuotasRequest, updatedBy uuid.UUID) (*dto.OrganizationQuotaUsageResponse, error) {
+	if len(req.Limits) == 0 && len(req.Inherit) == 0 {
+		return nil, apperror.NewValidationError("At least one limit to set or inherit is required")
+	}
+	if err := s.ensureOrganizationExists(ctx, orgID); err != nil {
+		return nil, err
+	}
+
+	var issues []string
+	names := make([]string, 0, len(req.Limits))
+	for name := range req.Limits {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	now := time.Now()
+	var upserts []model.OrganizationQuota
+	changes := make(map[string]interface{})
+	for _, name := range names {
+		if _, ok := findOrganizationQuotaDefinition(name); !ok {
+			issues = append(issues, fmt.Sprintf("%s: unknown quota", name))
+			continue
+		}
+		limit := req.Limits[name]
+		if limit != nil && *limit < 0 {
+			issues = append(issues, fmt.Sprintf("%s: limit must not be negative", name))
+			continue
+		}
+		upserts = append(upserts, model.OrganizationQuota{
+			OrganizationID: orgID,
+			Quota:          name,
+			MaxValue:       limit,
+			UpdatedBy:      &updatedBy,
+			UpdatedAt:      now,
+		})
+		changes[name] = limit
+	}
+
+	var removed []string
+	for _, name := range req.Inherit {
+		if _, ok := findOrganizationQuotaDefinition(name); !ok {
+			issues = append(issues, fmt.Sprintf("%s: unknown quota", name))
+			continue
+		}
+		if _, ok := req.Limits[name]; ok {
+			issues = append(issues, fmt.Sprintf("%s: cannot be both set and inherited", name))
+			continue
+		}
+		removed = append(removed, name)
+		changes[name] = "inherit"
+	}
+
+	if len(issues) > 0 {
+		return nil, apperror.NewValidationError("Invalid organization quotas: " + strings.Join(issues, "; "))
+	}
+
+	details, err := json.Marshal(map[string]interface{}{"limits": changes})
+	if err != nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to serialize audit details: %w", err))
+	}
+	auditLog := &model.OrganizationAuditLog{
+		OrganizationID: orgID,
+		Action:         constant.OrganizationAuditActionQuotas,
+		ActorID:        &updatedBy,
+		Details:        string(details),
+		Reason:         req.Reason,
+	}
+	if err := s.quotaRepo.Save(ctx, orgID, upserts, removed, auditLog); err != nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to save organization quotas: %w", err))
+	}
+
+	return s.GetUsage(ctx, orgID)
+}
+
+// EnsureQuota checks that the organization has room for additional units of a quota.
+func (s *organizationQuotaService) EnsureQuota(ctx context.Context, orgID uuid.UUID, quota string, additional int) error {
+	definition, ok := findOrganizationQuotaDefinition(quota)
+	if !ok {
+		return apperror.NewInternalError(fmt.Errorf("unknown organization quota %q", quota))
+	}
+
+	limits, err := s.resolveLimits(ctx, orgID)
+	if err != nil {
+		return err
+	}
+	limit, ok := limits[quota]
+	if !ok || limit.MaxValue == nil {
+		return nil
+	}
+
+	used, err := definition.Usage(s.orgRepo, ctx, orgID)
+	if err != nil {
+		return apperror.NewInternalError(fmt.Errorf("failed to count %s usage: %w", quota, err))
+	}
+	if used+int64(additional) > int64(*limit.MaxValue) {
+		return apperror.NewQuotaExceededError(fmt.Sprintf(
+			"Organization has reached its limit of %d %s (%d in use, %d requested)",
+			*limit.MaxValue, definition.Unit, used, additional,
+		))
+	}
+	return nil
+}
+
+// resolveLimits returns the nearest limit of every quota set on the organization or its ancestors.
+func (s *organizationQuotaService) resolveLimits(ctx context.Context, orgID uuid.UUID) (map[string]model.OrganizationQuota, error) {
+	chain, err := s.quotaRepo.FindInheritanceChain(ctx, orgID)
+	if err != nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to load organization quotas: %w", err))
+	}
+
+	limits := make(map[string]model.OrganizationQuota, len(chain))
+	for _, quota := range chain {
+		if _, ok := limits[quota.Quota]; !ok {
+			limits[quota.Quota] = quota
+		}
+	}
+	return limits, nil
+}
+
+func (s *organizationQuotaService) ensureOrganizationExists(ctx context.Context, orgID uuid.UUID) error {
+	if _, err := s.orgRepo.FindByID(ctx, orgID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apperror.NewNotFoundError("organization")
+		}
+		return apperror.NewInternalError(fmt.Errorf("failed to find organization: %w", err))
+	}
+	return nil
+}
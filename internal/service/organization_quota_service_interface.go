@@ -0,0 +1,19 @@
+package service
+
+import (
+	"context"
+	"go-base-project/internal/dto"
+
+	"github.com/google/uuid"
+)
+
+// OrganizationQuotaServiceInterface defines the business logic for organization plan limits
+type OrganizationQuotaServiceInterface interface {
+	// GetUsage reports the usage of an organization against the limits that apply to it.
+	GetUsage(ctx context.Context, orgID uuid.UUID) (*dto.OrganizationQuotaUsageResponse, error)
+	// UpdateQuotas sets or removes limits of an organization and returns its usage.
+	UpdateQuotas(ctx context.Context, orgID uuid.UUID, req dto.UpdateOrganizationQuotasRequest, updatedBy uuid.UUID) (*dto.OrganizationQuotaUsageResponse, error)
+	// EnsureQuota returns a QUOTA_EXCEEDED error when adding the given amount to the usage of an
+	// organization would exceed its limit for the quota.
+	EnsureQuota(ctx context.Context, orgID uuid.UUID, quota string, additional int) error
+}
@@ -8,50 +8,95 @@ import (
 	"go-base-project/internal/repository"
 	"go-base-project/internal/util"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
 	"gorm.io/gorm"
 )
 
 type organizationService struct {
-	orgRepo  repository.OrganizationRepositoryInterface
-	userRepo repository.UserRepositoryInterface
+	orgRepo              repository.OrganizationRepositoryInterface
+	userRepo             repository.UserRepositoryInterface
+	membershipRepo       repository.MembershipRepositoryInterface
+	roleRepo             repository.RoleRepositoryInterface
+	joinRequestRepo      repository.JoinRequestRepositoryInterface
+	auditRepo            repository.OrganizationAuditRepositoryInterface
+	orgTypeRepo          repository.OrganizationTypeRepositoryInterface
+	txManager            repository.TransactionManagerInterface
+	authorizationService AuthorizationServiceInterface
+	settingService       OrganizationSettingServiceInterface
+	quotaService         OrganizationQuotaServiceInterface
+	membershipService    MembershipServiceInterface
+	codeGenerator        *util.OrganizationCodeGenerator
 }
 
+// maxOrganizationCodeAttempts bounds how often a colliding generated code is replaced before giving up
+const maxOrganizationCodeAttempts = 5
+
 // NewOrganizationService creates a new organization service instance
-func NewOrganizationService(orgRepo repository.OrganizationRepositoryInterface, userRepo repository.UserRepositoryInterface) OrganizationServiceInterface {
+func NewOrganizationService(
+	orgRepo repository.OrganizationRepositoryInterface,
+	userRepo repository.UserRepositoryInterface,
+	membershipRepo repository.MembershipRepositoryInterface,
+	roleRepo repository.RoleRepositoryInterface,
+	joinRequestRepo repository.JoinRequestRepositoryInterface,
+	auditRepo repository.OrganizationAuditRepositoryInterface,
+	orgTypeRepo repository.OrganizationTypeRepositoryInterface,
+	txManager repository.TransactionManagerInterface,
+	authorizationService AuthorizationServiceInterface,
+	settingService OrganizationSettingServiceInterface,
+	quotaService OrganizationQuotaServiceInterface,
+	membershipService MembershipServiceInterface,
+	codeGenerator *util.OrganizationCodeGenerator,
+) OrganizationServiceInterface {
 	return &organizationService{
-		orgRepo:  orgRepo,
-		userRepo: userRepo,
+		orgRepo:              orgRepo,
+		userRepo:             userRepo,
+		membershipRepo:       membershipRepo,
+		roleRepo:             roleRepo,
+		joinRequestRepo:      joinRequestRepo,
+		auditRepo:            auditRepo,
+		orgTypeRepo:          orgTypeRepo,
+		txManager:            txManager,
+		authorizationService: authorizationService,
+		settingService:       settingService,
+		quotaService:         quotaService,
+		membershipService:    membershipService,
+		codeGenerator:        codeGenerator,
 	}
 }
 
 // CreateOrganization creates a new organization with enhanced validation and error handling
 func (s *organizationService) CreateOrganization(ctx context.Context, req dto.CreateOrganizationRequest, createdBy uuid.UUID) (*dto.OrganizationResponse, error) {
 	// Validate organization type
-	if err := s.validateOrganizationType(req.OrganizationType); err != nil {
-		return nil, err
-	}
-
-	// Generate unique code using business logic in service layer
-	code, err := s.GenerateUniqueCode(ctx, req.Name)
+	orgType, err := findActiveOrganizationType(ctx, s.orgTypeRepo, req.OrganizationType)
 	if err != nil {
-		return nil, apperror.NewInternalError(fmt.Errorf("failed to generate organization code: %w", err))
+		return nil, err
 	}
 
-	// Validate parent organization if specified
+	// Validate parent organization and placement rules of the type
+	var parent *model.Organization
 	if req.ParentOrganizationID != nil {
-		if err := s.validateParentOrganization(ctx, *req.ParentOrganizationID); err != nil {
+		parent, err = s.findParentOrganization(ctx, *req.ParentOrganizationID)
+		if err != nil {
 			return nil, err
 		}
+		if err := ensureOrganizationNotArchived(parent); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := s.validateOrganizationPlacement(ctx, orgType, parent); err != nil {
+		return nil, err
 	}
 
-	// Create organization using repository
+	// Create organization using repository; the code is allocated on insert
 	org := &model.Organization{
 		Name:                 req.Name,
-		Code:                 code,
 		OrganizationType:     req.OrganizationType,
 		ParentOrganizationID: req.ParentOrganizationID,
 		Description:          req.Description,
@@ -59,24 +104,43 @@ func (s *organizationService) CreateOrganization(ctx context.Context, req dto.Cr
 		IsActive:             true,
 	}
 
-	createdOrg, err := s.orgRepo.Create(ctx, org)
-	if err != nil {
-		return nil, apperror.NewInternalError(fmt.Errorf("failed to create organization: %w", err))
-	}
+	// The organization and its creator's membership are created together, so an organization never
+	// ends up without its owner
+	var createdOrg *model.Organization
+	err = s.txManager.RunInTx(ctx, func(ctx context.Context) error {
+		if parent != nil {
+			if err := reserveChildOrganization(ctx, s.orgRepo, s.quotaService, parent.ID); err != nil {
+				return err
+			}
+		}
 
-	// Auto-join creator to organization as Organization Creator using repository method
-	// TODO: Replace with proper role ID lookup for "Organization Creator" role
-	userOrg := &model.UserOrganization{
-		UserID:         createdBy,
-		OrganizationID: createdOrg.ID,
-		RoleID:         nil, // TODO: Set to Organization Creator role ID
-		IsActive:       true,
-	}
+		var err error
+		createdOrg, err = s.createWithGeneratedCode(ctx, org)
+		if err != nil {
+			return apperror.NewInternalError(fmt.Errorf("failed to create organization: %w", err))
+		}
 
-	if err := s.orgRepo.AddUserToOrganization(ctx, userOrg); err != nil {
-		// Log the error but don't fail the organization creation
-		// TODO: Replace with proper structured logging
-		fmt.Printf("Warning: Failed to auto-join creator to organization %s: %v\n", createdOrg.ID, err)
+		// Auto-join creator to organization with the creator role of its type
+		userOrg := &model.UserOrganization{
+			UserID:         createdBy,
+			OrganizationID: createdOrg.ID,
+			IsActive:       true,
+		}
+
+		defaultRoles, err := resolveOrganizationDefaultRoles(ctx, s.roleRepo, createdOrg.OrganizationType)
+		if err != nil {
+			log.Error().Err(err).Str("organization_id", createdOrg.ID.String()).Msg("Failed to resolve default creator role")
+		} else if defaultRoles.Creator != nil {
+			userOrg.RoleID = &defaultRoles.Creator.ID
+		}
+
+		if err := s.membershipRepo.Create(ctx, userOrg, membershipChange(createdBy, "Created the organization")); err != nil {
+			return apperror.NewInternalError(fmt.Errorf("failed to add creator to organization: %w", err))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return util.MapOrganizationToResponse(createdOrg), nil
@@ -129,18 +193,41 @@ func (s *organizationService) UpdateOrganization(ctx context.Context, id uuid.UU
 		org.IsActive = *req.IsActive
 	}
 
-	updatedOrg, err := s.orgRepo.Update(ctx, org)
+	var updatedOrg *model.Organization
+	err = s.txManager.RunInTx(ctx, func(ctx context.Context) error {
+		var err error
+		updatedOrg, err = s.orgRepo.Update(ctx, org)
+		if err != nil {
+			return apperror.NewInternalError(fmt.Errorf("failed to update organization: %w", err))
+		}
+
+		// The join policy lives in the settings store and is inherited by child organizations
+		if req.JoinPolicy != "" {
+			joinPolicy, err := json.Marshal(req.JoinPolicy)
+			if err != nil {
+				return apperror.NewInternalError(fmt.Errorf("failed to encode join policy: %w", err))
+			}
+			settingsReq := dto.UpdateOrganizationSettingsRequest{
+				Settings: map[string]json.RawMessage{constant.OrganizationSettingJoinPolicy: joinPolicy},
+			}
+			if _, err := s.settingService.UpdateSettings(ctx, org.ID, settingsReq, updatedBy); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 	if err != nil {
-		return nil, apperror.NewInternalError(fmt.Errorf("failed to update organization: %w", err))
+		return nil, err
 	}
 
 	return util.MapOrganizationToResponse(updatedOrg), nil
 }
 
-// DeleteOrganization deletes an organization
+// DeleteOrganization soft deletes an organization. It can be restored until the purge job removes it
+// after the retention period.
 func (s *organizationService) DeleteOrganization(ctx context.Context, id uuid.UUID, deletedBy uuid.UUID) error {
 	// Check if organization exists
-	_, err := s.orgRepo.FindByID(ctx, id)
+	org, err := s.orgRepo.FindByID(ctx, id)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return apperror.NewNotFoundError("organization")
@@ -158,13 +245,33 @@ func (s *organizationService) DeleteOrganization(ctx context.Context, id uuid.UU
 		return apperror.NewAppError(http.StatusBadRequest, "Cannot delete organization with child organizations", nil)
 	}
 
-	if err := s.orgRepo.Delete(ctx, id); err != nil {
+	details, err := json.Marshal(map[string]interface{}{
+		"code":                   org.Code,
+		"parent_organization_id": org.ParentOrganizationID,
+	})
+	if err != nil {
+		return apperror.NewInternalError(fmt.Errorf("failed to serialize audit details: %w", err))
+	}
+
+	auditLog := &model.OrganizationAuditLog{
+		OrganizationID: org.ID,
+		Action:         constant.OrganizationAuditActionDeleted,
+		ActorID:        &deletedBy,
+		Details:        string(details),
+	}
+	if err := s.orgRepo.Delete(ctx, id, auditLog); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apperror.NewNotFoundError("organization")
+		}
 		return apperror.NewInternalError(fmt.Errorf("failed to delete organization: %w", err))
 	}
 
 	return nil
 }
 
+// defaultOrganizationListSort is the order of organization listings that do not ask for one
+const defaultOrganizationListSort = "name"
+
 // ListOrganizations retrieves organizations with filters using repository's enhanced List method
 func (s *organizationService) ListOrganizations(ctx context.Context, req dto.ListOrganizationsRequest) (*dto.PaginatedOrganizationsResponse, error) {
 	// Get current user from context for hierarchical filtering
@@ -203,15 +310,23 @@ func (s *organizationService) ListOrganizations(ctx context.Context, req dto.Lis
 		filters["accessible_org_ids"] = accessibleOrgIDs
 	}
 
+	query, err := buildListQuery(req.Sort, defaultOrganizationListSort, req.Cursor, (req.Page-1)*req.Limit, req.Limit)
+	if err != nil {
+		return nil, err
+	}
+
 	// Use repository List method with pagination and hierarchical filtering
-	orgs, total, err := s.orgRepo.List(ctx, req.Page, req.Limit, req.Search, filters)
+	result, total, err := s.orgRepo.List(ctx, req.Search, filters, query)
 	if err != nil {
+		if queryErr := listQueryError(err); queryErr != nil {
+			return nil, queryErr
+		}
 		return nil, apperror.NewInternalError(fmt.Errorf("failed to list organizations: %w", err))
 	}
 
-	response := make([]dto.OrganizationResponse, len(orgs))
-	for i, org := range orgs {
-		response[i] = *util.MapOrganizationToResponse(&org)
+	response := make([]dto.OrganizationResponse, len(result.Items))
+	for i := range result.Items {
+		response[i] = *util.MapOrganizationToResponse(&result.Items[i])
 	}
 
 	// Calculate pagination info
@@ -225,6 +340,7 @@ func (s *organizationService) ListOrganizations(ctx context.Context, req dto.Lis
 			Total:      int(total),
 			TotalPages: totalPages,
 		},
+		NextCursor: encodeListCursor(query, result.NextCursor),
 	}, nil
 } // GetOrganizationsByType retrieves organizations by type
 func (s *organizationService) GetOrganizationsByType(ctx context.Context, orgType string) ([]dto.OrganizationResponse, error) {
@@ -256,12 +372,286 @@ func (s *organizationService) GetChildOrganizations(ctx context.Context, parentI
 	return response, nil
 }
 
-// JoinOrganization allows user to join an organization with enhanced validation
-func (s *organizationService) JoinOrganization(ctx context.Context, userID uuid.UUID, req dto.JoinOrganizationRequest) (*dto.UserOrganizationResponse, error) {
+// MoveOrganization re-parents an organization together with its whole subtree.
+// The new parent must have a type the organization may sit under and must not be inside the moved subtree.
+func (s *organizationService) MoveOrganization(ctx context.Context, id uuid.UUID, req dto.MoveOrganizationRequest, movedBy uuid.UUID) (*dto.OrganizationResponse, error) {
+	org, err := s.orgRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.NewNotFoundError("organization")
+		}
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to find organization: %w", err))
+	}
+
+	if uuidPtrEqual(org.ParentOrganizationID, req.NewParentOrganizationID) {
+		return nil, apperror.NewValidationError("Organization is already under the requested parent")
+	}
+
+	var newParent *model.Organization
+	if req.NewParentOrganizationID != nil {
+		if *req.NewParentOrganizationID == org.ID {
+			return nil, apperror.NewValidationError("Organization cannot be moved under itself")
+		}
+
+		newParent, err = s.findParentOrganization(ctx, *req.NewParentOrganizationID)
+		if err != nil {
+			return nil, err
+		}
+		if err := ensureOrganizationNotArchived(newParent); err != nil {
+			return nil, err
+		}
+
+		isDescendant, err := s.orgRepo.IsAncestor(ctx, org.ID, newParent.ID)
+		if err != nil {
+			return nil, apperror.NewInternalError(fmt.Errorf("failed to check organization hierarchy: %w", err))
+		}
+		if isDescendant {
+			return nil, apperror.NewValidationError("Organization cannot be moved under one of its own descendants")
+		}
+	}
+
+	orgType, err := findActiveOrganizationType(ctx, s.orgTypeRepo, org.OrganizationType)
+	if err != nil {
+		return nil, err
+	}
+	newDepth, err := s.validateOrganizationPlacement(ctx, orgType, newParent)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.validateSubtreeDepth(ctx, org.ID, newDepth); err != nil {
+		return nil, err
+	}
+
+	// Members of the old ancestors lose access to the subtree, members of the new ones gain it
+	affectedOrgIDs, err := s.collectHierarchyAffectedOrganizations(ctx, org.ID, req.NewParentOrganizationID)
+	if err != nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to collect affected organizations: %w", err))
+	}
+
+	details, err := json.Marshal(map[string]interface{}{
+		"from_parent_organization_id": org.ParentOrganizationID,
+		"to_parent_organization_id":   req.NewParentOrganizationID,
+		"affected_organizations":      len(affectedOrgIDs),
+	})
+	if err != nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to serialize audit details: %w", err))
+	}
+
+	auditLog := &model.OrganizationAuditLog{
+		OrganizationID: org.ID,
+		Action:         constant.OrganizationAuditActionMoved,
+		ActorID:        &movedBy,
+		Details:        string(details),
+		Reason:         req.Reason,
+	}
+	err = s.txManager.RunInTx(ctx, func(ctx context.Context) error {
+		if newParent != nil {
+			if err := reserveChildOrganization(ctx, s.orgRepo, s.quotaService, newParent.ID); err != nil {
+				return err
+			}
+		}
+
+		if err := s.orgRepo.Move(ctx, org.ID, req.NewParentOrganizationID, auditLog); err != nil {
+			if errors.Is(err, repository.ErrOrganizationCycle) {
+				return apperror.NewValidationError("Organization cannot be moved under one of its own descendants")
+			}
+			return apperror.NewInternalError(fmt.Errorf("failed to move organization: %w", err))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.invalidateHierarchyCaches(ctx, affectedOrgIDs)
+	// The subtree now inherits its settings from the new ancestors
+	s.settingService.InvalidateSettingsCache(ctx, []uuid.UUID{org.ID})
+
+	log.Info().
+		Str("organization_id", org.ID.String()).
+		Str("moved_by", movedBy.String()).
+		Int("affected_organizations", len(affectedOrgIDs)).
+		Msg("Organization moved")
+
+	movedOrg, err := s.orgRepo.FindByID(ctx, org.ID)
+	if err != nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to reload organization: %w", err))
+	}
+	return util.MapOrganizationToResponse(movedOrg), nil
+}
+
+// collectHierarchyAffectedOrganizations returns the organizations whose access is affected when the
+// subtree rooted at orgID changes parent: the subtree itself, its current ancestors and, if given,
+// the new parent with its ancestors.
+func (s *organizationService) collectHierarchyAffectedOrganizations(ctx context.Context, orgID uuid.UUID, newParentID *uuid.UUID) ([]uuid.UUID, error) {
+	seen := make(map[uuid.UUID]bool)
+	var orgIDs []uuid.UUID
+	add := func(id uuid.UUID) {
+		if !seen[id] {
+			seen[id] = true
+			orgIDs = append(orgIDs, id)
+		}
+	}
+
+	subtree, err := s.orgRepo.GetSubtree(ctx, []uuid.UUID{orgID})
+	if err != nil {
+		return nil, err
+	}
+	for _, org := range subtree {
+		add(org.ID)
+	}
+
+	oldAncestors, err := s.orgRepo.GetAncestors(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	for _, ancestor := range oldAncestors {
+		add(ancestor.AncestorID)
+	}
+
+	if newParentID != nil {
+		add(*newParentID)
+		newAncestors, err := s.orgRepo.GetAncestors(ctx, *newParentID)
+		if err != nil {
+			return nil, err
+		}
+		for _, ancestor := range newAncestors {
+			add(ancestor.AncestorID)
+		}
+	}
+
+	return orgIDs, nil
+}
+
+// invalidateHierarchyCaches drops the cached permissions of every role held by members of the given
+// organizations. Accessible organization IDs are read from the closure table on every request and need
+// no invalidation. Failures are logged only; cached entries expire on their own.
+func (s *organizationService) invalidateHierarchyCaches(ctx context.Context, orgIDs []uuid.UUID) {
+	roleIDs, err := s.orgRepo.FindMemberRoleIDs(ctx, orgIDs)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to find member roles for cache invalidation")
+		return
+	}
+
+	for _, roleID := range roleIDs {
+		if err := s.authorizationService.InvalidateRolePermissionsCache(ctx, roleID); err != nil {
+			log.Warn().Err(err).Str("role_id", roleID.String()).Msg("Failed to invalidate role permissions cache")
+		}
+	}
+}
+
+// ListOrganizationAuditLogs retrieves the most recent audit trail entries of an organization.
+func (s *organizationService) ListOrganizationAuditLogs(ctx context.Context, id uuid.UUID, limit int) ([]dto.OrganizationAuditLogResponse, error) {
+	if _, err := s.orgRepo.FindByID(ctx, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.NewNotFoundError("organization")
+		}
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to find organization: %w", err))
+	}
+
+	if limit <= 0 || limit > 100 {
+		limit = 50
+	}
+
+	auditLogs, err := s.auditRepo.ListByOrganization(ctx, id, limit)
+	if err != nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to list organization audit logs: %w", err))
+	}
+
+	responses := make([]dto.OrganizationAuditLogResponse, len(auditLogs))
+	for i, auditLog := range auditLogs {
+		responses[i] = dto.OrganizationAuditLogResponse{
+			ID:             auditLog.ID,
+			OrganizationID: auditLog.OrganizationID,
+			Action:         auditLog.Action,
+			ActorID:        auditLog.ActorID,
+			Details:        json.RawMessage(auditLog.Details),
+			Reason:         auditLog.Reason,
+			CreatedAt:      auditLog.CreatedAt,
+		}
+		if auditLog.Actor != nil {
+			responses[i].ActorUsername = auditLog.Actor.Username
+		}
+	}
+
+	return responses, nil
+}
+
+// uuidPtrEqual reports whether two optional IDs are both nil or hold the same value
+func uuidPtrEqual(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// GetOrganizationHierarchy retrieves the ancestors, descendants and depth of an organization.
+// maxDepth limits how many levels of descendants are returned; zero or less means unlimited.
+func (s *organizationService) GetOrganizationHierarchy(ctx context.Context, orgID uuid.UUID, maxDepth int) (*dto.OrganizationHierarchyResponse, error) {
+	org, err := s.orgRepo.FindByID(ctx, orgID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.NewNotFoundError("organization")
+		}
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to find organization: %w", err))
+	}
+
+	ancestors, err := s.orgRepo.GetAncestors(ctx, orgID)
+	if err != nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to find ancestor organizations: %w", err))
+	}
+
+	descendants, err := s.orgRepo.GetDescendants(ctx, orgID, maxDepth)
+	if err != nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to find descendant organizations: %w", err))
+	}
+
+	height, err := s.orgRepo.GetSubtreeHeight(ctx, orgID)
+	if err != nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to calculate subtree height: %w", err))
+	}
+
+	response := &dto.OrganizationHierarchyResponse{
+		Organization:  *util.MapOrganizationToResponse(org),
+		Depth:         len(ancestors),
+		SubtreeHeight: height,
+		Ancestors:     make([]dto.OrganizationNodeResponse, 0, len(ancestors)),
+		Descendants:   make([]dto.OrganizationNodeResponse, 0, len(descendants)),
+	}
+	for _, ancestor := range ancestors {
+		if ancestor.Ancestor == nil {
+			continue
+		}
+		response.Ancestors = append(response.Ancestors, dto.OrganizationNodeResponse{
+			OrganizationResponse: *util.MapOrganizationToResponse(ancestor.Ancestor),
+			Distance:             ancestor.Depth,
+		})
+	}
+	for _, descendant := range descendants {
+		if descendant.Descendant == nil {
+			continue
+		}
+		response.Descendants = append(response.Descendants, dto.OrganizationNodeResponse{
+			OrganizationResponse: *util.MapOrganizationToResponse(descendant.Descendant),
+			Distance:             descendant.Depth,
+		})
+	}
+
+	return response, nil
+}
+
+// JoinOrganization allows user to join an organization by code according to its join policy.
+// Open organizations grant membership immediately; approval-required organizations record a join request.
+func (s *organizationService) JoinOrganization(ctx context.Context, userID uuid.UUID, req dto.JoinOrganizationRequest) (*dto.JoinOrganizationResponse, error) {
 	// Validate organization exists and is active
-	org, err := s.orgRepo.FindByCode(ctx, req.OrganizationCode)
+	code := s.codeGenerator.Normalize(req.OrganizationCode)
+	org, err := s.orgRepo.FindByCode(ctx, code)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
+			// A code failing the check character was most likely mistyped rather than unknown
+			if !s.codeGenerator.Valid(code) {
+				return nil, apperror.NewValidationError("Organization code is invalid, please check it for typos")
+			}
 			return nil, apperror.NewNotFoundError("organization with code")
 		}
 		return nil, apperror.NewInternalError(fmt.Errorf("failed to find organization: %w", err))
@@ -270,6 +660,17 @@ func (s *organizationService) JoinOrganization(ctx context.Context, userID uuid.
 	if !org.IsActive {
 		return nil, apperror.NewAppError(http.StatusBadRequest, "Organization is not active", nil)
 	}
+	if err := ensureOrganizationNotArchived(org); err != nil {
+		return nil, err
+	}
+
+	var joinPolicy string
+	if err := s.settingService.GetEffectiveValue(ctx, org.ID, constant.OrganizationSettingJoinPolicy, &joinPolicy); err != nil {
+		return nil, err
+	}
+	if joinPolicy == constant.JoinPolicyInviteOnly {
+		return nil, apperror.NewForbiddenError("This organization only accepts members through invitations")
+	}
 
 	// Check if user already in organization using repository method
 	if exists, err := s.checkUserOrganizationMembership(ctx, userID, org.ID); err != nil {
@@ -278,75 +679,250 @@ func (s *organizationService) JoinOrganization(ctx context.Context, userID uuid.
 		return nil, apperror.NewConflictError("User already member of this organization")
 	}
 
-	// Add user to organization with Member role
-	// TODO: Replace with proper role ID lookup for "Member" role
-	userOrg := &model.UserOrganization{
+	if joinPolicy == constant.JoinPolicyApprovalRequired {
+		// A full organization accepts no join requests either; the limit is enforced again on approval
+		if err := s.quotaService.EnsureQuota(ctx, org.ID, constant.OrganizationQuotaMembers, 1); err != nil {
+			return nil, err
+		}
+		joinRequest, err := s.createJoinRequest(ctx, org, userID, req.Message)
+		if err != nil {
+			return nil, err
+		}
+		return &dto.JoinOrganizationResponse{Status: "pending", JoinRequest: joinRequest}, nil
+	}
+
+	// Add user to organization with the member role of its type
+	defaultRoles, err := resolveOrganizationDefaultRoles(ctx, s.roleRepo, org.OrganizationType)
+	if err != nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to resolve default member role: %w", err))
+	}
+
+	membershipReq := dto.AssignUserToOrganizationRequest{
 		UserID:         userID,
 		OrganizationID: org.ID,
-		RoleID:         nil, // TODO: Set to Member role ID
 		IsActive:       true,
+		Reason:         "Joined the organization",
+	}
+	if defaultRoles.Member != nil {
+		membershipReq.RoleID = &defaultRoles.Member.ID
 	}
 
-	if err := s.orgRepo.AddUserToOrganization(ctx, userOrg); err != nil {
-		return nil, apperror.NewInternalError(fmt.Errorf("failed to join organization: %w", err))
+	membership, err := s.membershipService.AddMember(ctx, membershipReq, userID)
+	if err != nil {
+		return nil, err
 	}
 
-	return s.mapToUserOrganizationResponse(userOrg, org), nil
+	return &dto.JoinOrganizationResponse{Status: "joined", Membership: membership}, nil
 }
 
-// LeaveOrganization allows user to leave an organization
-func (s *organizationService) LeaveOrganization(ctx context.Context, userID, orgID uuid.UUID) error {
-	if err := s.orgRepo.RemoveUserFromOrganization(ctx, userID, orgID); err != nil {
-		return apperror.NewInternalError(fmt.Errorf("failed to leave organization: %w", err))
+// createJoinRequest records a pending join request for an approval-required organization.
+func (s *organizationService) createJoinRequest(ctx context.Context, org *model.Organization, userID uuid.UUID, message string) (*dto.JoinRequestResponse, error) {
+	if _, err := s.joinRequestRepo.FindPendingByOrganizationAndUser(ctx, org.ID, userID); err == nil {
+		return nil, apperror.NewConflictError("You already have a pending request to join this organization")
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to check pending join requests: %w", err))
 	}
-	return nil
+
+	joinRequest := &model.OrganizationJoinRequest{
+		OrganizationID: org.ID,
+		UserID:         userID,
+		Status:         constant.JoinRequestStatusPending,
+		Message:        message,
+	}
+	if _, err := s.joinRequestRepo.Create(ctx, joinRequest); err != nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to create join request: %w", err))
+	}
+
+	created, err := s.joinRequestRepo.FindByID(ctx, joinRequest.ID)
+	if err != nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to fetch created join request: %w", err))
+	}
+	return s.mapJoinRequestToResponse(created), nil
 }
 
-// GetUserOrganizations retrieves all organizations for a user
-func (s *organizationService) GetUserOrganizations(ctx context.Context, userID uuid.UUID) ([]dto.UserOrganizationResponse, error) {
-	userOrgs, err := s.orgRepo.FindUserOrganizations(ctx, userID)
+// ListJoinRequests returns the join requests of an organization, optionally filtered by status.
+func (s *organizationService) ListJoinRequests(ctx context.Context, orgID uuid.UUID, status string) ([]dto.JoinRequestResponse, error) {
+	if err := validateJoinRequestStatusFilter(status); err != nil {
+		return nil, err
+	}
+
+	joinRequests, err := s.joinRequestRepo.ListByOrganization(ctx, orgID, status)
 	if err != nil {
-		return nil, apperror.NewInternalError(fmt.Errorf("failed to find user organizations: %w", err))
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to list join requests: %w", err))
 	}
+	return s.mapJoinRequestsToResponse(joinRequests), nil
+}
 
-	response := make([]dto.UserOrganizationResponse, len(userOrgs))
-	for i, userOrg := range userOrgs {
-		response[i] = *s.mapToUserOrganizationResponse(&userOrg, &userOrg.Organization)
+// ListMyJoinRequests returns the join requests made by a user, optionally filtered by status.
+func (s *organizationService) ListMyJoinRequests(ctx context.Context, userID uuid.UUID, status string) ([]dto.JoinRequestResponse, error) {
+	if err := validateJoinRequestStatusFilter(status); err != nil {
+		return nil, err
 	}
 
-	return response, nil
+	joinRequests, err := s.joinRequestRepo.ListByUser(ctx, userID, status)
+	if err != nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to list join requests: %w", err))
+	}
+	return s.mapJoinRequestsToResponse(joinRequests), nil
 }
 
-// GetOrganizationMembers retrieves all members of an organization
-func (s *organizationService) GetOrganizationMembers(ctx context.Context, orgID uuid.UUID) ([]dto.UserOrganizationResponse, error) {
-	userOrgs, err := s.orgRepo.FindOrganizationUsers(ctx, orgID)
+// ApproveJoinRequest grants membership to the requester with the role chosen by the approver.
+func (s *organizationService) ApproveJoinRequest(ctx context.Context, orgID, requestID uuid.UUID, req dto.ApproveJoinRequestRequest, approvedBy uuid.UUID) (*dto.UserOrganizationResponse, error) {
+	joinRequest, err := s.findOrganizationJoinRequest(ctx, orgID, requestID)
+	if err != nil {
+		return nil, err
+	}
+	if joinRequest.Status != constant.JoinRequestStatusPending {
+		return nil, apperror.NewConflictError(fmt.Sprintf("Join request is already %s", joinRequest.Status))
+	}
+	if err := ensureOrganizationNotArchived(&joinRequest.Organization); err != nil {
+		return nil, err
+	}
+
+	role, err := s.roleRepo.FindByID(ctx, req.RoleID)
 	if err != nil {
-		return nil, apperror.NewInternalError(fmt.Errorf("failed to find organization members: %w", err))
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.NewNotFoundError("role")
+		}
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to find role: %w", err))
+	}
+	if !role.IsActive {
+		return nil, apperror.NewValidationError("Cannot grant an inactive role")
 	}
 
-	response := make([]dto.UserOrganizationResponse, len(userOrgs))
-	for i, userOrg := range userOrgs {
-		org, _ := s.orgRepo.FindByID(ctx, userOrg.OrganizationID)
-		response[i] = *s.mapToUserOrganizationResponse(&userOrg, org)
+	accessible, err := s.authorizationService.ValidateRoleAccessibleInOrganization(ctx, role.ID, orgID, joinRequest.Organization.OrganizationType)
+	if err != nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to validate role for organization: %w", err))
+	}
+	if !accessible {
+		return nil, apperror.NewValidationError(fmt.Sprintf("Role '%s' is not available for %s organizations", role.Name, joinRequest.Organization.OrganizationType))
 	}
 
-	return response, nil
+	approverLevel, err := s.authorizationService.GetUserEffectiveLevel(ctx, approvedBy, orgID)
+	if err != nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to determine approver level: %w", err))
+	}
+	if approverLevel < constant.RoleLevelSuperAdmin && role.Level >= approverLevel {
+		return nil, apperror.NewForbiddenError("You can only grant a role below your own level")
+	}
+
+	// The join request is only marked approved together with the membership, which checks the member limit
+	var membership *dto.UserOrganizationResponse
+	err = s.txManager.RunInTx(ctx, func(ctx context.Context) error {
+		if err := s.joinRequestRepo.Approve(ctx, joinRequest.ID, approvedBy, role.ID, time.Now()); err != nil {
+			if errors.Is(err, repository.ErrJoinRequestNotPending) {
+				return apperror.NewConflictError("Join request is no longer pending")
+			}
+			return apperror.NewInternalError(fmt.Errorf("failed to approve join request: %w", err))
+		}
+
+		var err error
+		membership, err = s.membershipService.AddMember(ctx, dto.AssignUserToOrganizationRequest{
+			UserID:         joinRequest.UserID,
+			OrganizationID: orgID,
+			RoleID:         &role.ID,
+			IsActive:       true,
+			Reason:         fmt.Sprintf("Approved join request %s", joinRequest.ID),
+		}, approvedBy)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return membership, nil
 }
 
-// checkUserOrganizationMembership checks if user is already a member of the organization
-func (s *organizationService) checkUserOrganizationMembership(ctx context.Context, userID, orgID uuid.UUID) (bool, error) {
-	userOrgs, err := s.orgRepo.FindUserOrganizations(ctx, userID)
+// RejectJoinRequest closes a pending join request without granting membership.
+func (s *organizationService) RejectJoinRequest(ctx context.Context, orgID, requestID uuid.UUID, req dto.RejectJoinRequestRequest, rejectedBy uuid.UUID) (*dto.JoinRequestResponse, error) {
+	joinRequest, err := s.findOrganizationJoinRequest(ctx, orgID, requestID)
 	if err != nil {
-		return false, err
+		return nil, err
 	}
 
-	for _, userOrg := range userOrgs {
-		if userOrg.OrganizationID == orgID && userOrg.IsActive {
-			return true, nil
+	rejected, err := s.joinRequestRepo.Reject(ctx, joinRequest.ID, rejectedBy, req.Reason, time.Now())
+	if err != nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to reject join request: %w", err))
+	}
+	if !rejected {
+		return nil, apperror.NewConflictError(fmt.Sprintf("Join request is already %s", joinRequest.Status))
+	}
+
+	updated, err := s.joinRequestRepo.FindByID(ctx, joinRequest.ID)
+	if err != nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to fetch join request: %w", err))
+	}
+	return s.mapJoinRequestToResponse(updated), nil
+}
+
+// findOrganizationJoinRequest loads a join request and makes sure it belongs to the organization.
+func (s *organizationService) findOrganizationJoinRequest(ctx context.Context, orgID, requestID uuid.UUID) (*model.OrganizationJoinRequest, error) {
+	joinRequest, err := s.joinRequestRepo.FindByID(ctx, requestID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.NewNotFoundError("join request")
+		}
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to find join request: %w", err))
+	}
+	// Requests of other organizations are reported as missing so IDs cannot be probed
+	if joinRequest.OrganizationID != orgID {
+		return nil, apperror.NewNotFoundError("join request")
+	}
+	return joinRequest, nil
+}
+
+// validateJoinRequestStatusFilter validates the optional status filter for join request listings.
+func validateJoinRequestStatusFilter(status string) error {
+	switch status {
+	case "", constant.JoinRequestStatusPending, constant.JoinRequestStatusApproved, constant.JoinRequestStatusRejected:
+		return nil
+	}
+	return apperror.NewValidationError(fmt.Sprintf("Invalid join request status '%s'", status))
+}
+
+// mapJoinRequestsToResponse converts join request models to response DTOs.
+func (s *organizationService) mapJoinRequestsToResponse(joinRequests []model.OrganizationJoinRequest) []dto.JoinRequestResponse {
+	responses := make([]dto.JoinRequestResponse, len(joinRequests))
+	for i := range joinRequests {
+		responses[i] = *s.mapJoinRequestToResponse(&joinRequests[i])
+	}
+	return responses
+}
+
+// mapJoinRequestToResponse converts an OrganizationJoinRequest model to its response DTO.
+func (s *organizationService) mapJoinRequestToResponse(joinRequest *model.OrganizationJoinRequest) *dto.JoinRequestResponse {
+	response := &dto.JoinRequestResponse{
+		ID:               joinRequest.ID,
+		OrganizationID:   joinRequest.OrganizationID,
+		OrganizationName: joinRequest.Organization.Name,
+		OrganizationCode: joinRequest.Organization.Code,
+		UserID:           joinRequest.UserID,
+		Username:         joinRequest.User.Username,
+		Email:            joinRequest.User.Email,
+		Status:           joinRequest.Status,
+		Message:          joinRequest.Message,
+		RoleID:           joinRequest.RoleID,
+		DecidedBy:        joinRequest.DecidedBy,
+		DecidedAt:        joinRequest.DecidedAt,
+		Reason:           joinRequest.Reason,
+		CreatedAt:        joinRequest.CreatedAt,
+	}
+	if joinRequest.Role != nil {
+		response.RoleName = joinRequest.Role.Name
+	}
+	return response
+}
+
+// checkUserOrganizationMembership checks if user is already a member of the organization
+func (s *organizationService) checkUserOrganizationMembership(ctx context.Context, userID, orgID uuid.UUID) (bool, error) {
+	userOrg, err := s.membershipRepo.Find(ctx, userID, orgID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, nil
 		}
+		return false, err
 	}
 
-	return false, nil
+	return userOrg.IsActive, nil
 }
 
 // buildOrganizationFilters constructs filters map from request parameters
@@ -362,54 +938,187 @@ func (s *organizationService) buildOrganizationFilters(req dto.ListOrganizations
 	if req.IsActive != nil {
 		filters["is_active"] = *req.IsActive
 	}
+	if req.IsArchived != nil {
+		filters["archived"] = *req.IsArchived
+	}
 
 	return filters
 }
 
-// validateOrganizationType validates if the organization type is valid
-func (s *organizationService) validateOrganizationType(orgType string) error {
-	validTypes := []string{
-		constant.OrganizationTypeHolding,
-		constant.OrganizationTypeCompany,
-		constant.OrganizationTypeStore,
+// findParentOrganization loads the organization an organization is being placed under
+func (s *organizationService) findParentOrganization(ctx context.Context, parentID uuid.UUID) (*model.Organization, error) {
+	parent, err := s.orgRepo.FindByID(ctx, parentID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.NewNotFoundError("parent organization")
+		}
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to validate parent organization: %w", err))
 	}
+	return parent, nil
+}
 
-	for _, validType := range validTypes {
-		if orgType == validType {
-			return nil
+// validateOrganizationPlacement checks the registry rules for placing an organization of orgType under
+// parent (nil for a root organization) and returns the depth the organization would sit at.
+func (s *organizationService) validateOrganizationPlacement(ctx context.Context, orgType *model.OrganizationType, parent *model.Organization) (int, error) {
+	parentType, depth := "", 0
+	if parent != nil {
+		parentDepth, err := s.orgRepo.GetDepth(ctx, parent.ID)
+		if err != nil {
+			return 0, apperror.NewInternalError(fmt.Errorf("failed to calculate parent depth: %w", err))
 		}
+		parentType, depth = parent.OrganizationType, parentDepth+1
 	}
 
-	return apperror.NewAppError(http.StatusBadRequest, "Invalid organization type", nil)
+	if !orgType.AllowsParent(parentType) {
+		if parentType == "" {
+			return 0, apperror.NewValidationError(fmt.Sprintf("A %s must have a parent organization", orgType.Name))
+		}
+		return 0, apperror.NewValidationError(fmt.Sprintf("A %s cannot be placed under a %s", orgType.Name, parentType))
+	}
+	if !orgType.AllowsDepth(depth) {
+		return 0, apperror.NewValidationError(fmt.Sprintf("A %s cannot be placed deeper than level %d", orgType.Name, *orgType.MaxDepth))
+	}
+	return depth, nil
 }
 
-// validateParentOrganization validates if parent organization exists and hierarchy is valid
-func (s *organizationService) validateParentOrganization(ctx context.Context, parentID uuid.UUID) error {
-	// Check if parent organization exists
-	_, err := s.orgRepo.FindByID(ctx, parentID)
+// validateSubtreeDepth checks that every descendant of orgID still fits the maximum depth of its type
+// once orgID sits at newDepth.
+func (s *organizationService) validateSubtreeDepth(ctx context.Context, orgID uuid.UUID, newDepth int) error {
+	descendants, err := s.orgRepo.GetDescendants(ctx, orgID, 0)
 	if err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return apperror.NewNotFoundError("parent organization")
+		return apperror.NewInternalError(fmt.Errorf("failed to find descendant organizations: %w", err))
+	}
+	if len(descendants) == 0 {
+		return nil
+	}
+
+	orgTypes, err := loadOrganizationTypes(ctx, s.orgTypeRepo)
+	if err != nil {
+		return apperror.NewInternalError(err)
+	}
+
+	for _, descendant := range descendants {
+		if descendant.Descendant == nil {
+			continue
+		}
+		orgType, ok := orgTypes[descendant.Descendant.OrganizationType]
+		if ok && !orgType.AllowsDepth(newDepth+descendant.Depth) {
+			return apperror.NewValidationError(fmt.Sprintf("Moving would place %s (%s) deeper than level %d", descendant.Descendant.Name, orgType.Name, *orgType.MaxDepth))
 		}
-		return apperror.NewInternalError(fmt.Errorf("failed to validate parent organization: %w", err))
 	}
 	return nil
 }
 
+// loadOrganizationTypes loads the whole organization type registry keyed by type name
+func loadOrganizationTypes(ctx context.Context, orgTypeRepo repository.OrganizationTypeRepositoryInterface) (map[string]*model.OrganizationType, error) {
+	orgTypes, err := orgTypeRepo.FindAll(ctx, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch organization types: %w", err)
+	}
+
+	byName := make(map[string]*model.OrganizationType, len(orgTypes))
+	for i := range orgTypes {
+		byName[orgTypes[i].Name] = &orgTypes[i]
+	}
+	return byName, nil
+}
+
 // buildOrganizationFilters constructs filters map from request parameters
 
-// GenerateUniqueCode generates a unique organization code using business logic in service layer
-func (s *organizationService) GenerateUniqueCode(ctx context.Context, organizationName string) (string, error) {
-	// Get all existing codes from repository (data access only)
-	existingCodes, err := s.orgRepo.GetAllExistingCodes(ctx)
+// createWithGeneratedCode assigns a freshly generated code to the organization and creates it
+func (s *organizationService) createWithGeneratedCode(ctx context.Context, org *model.Organization) (*model.Organization, error) {
+	return createOrganizationWithGeneratedCode(ctx, s.orgRepo, s.codeGenerator, org)
+}
+
+// reserveChildOrganization locks the parent organization for the running transaction and checks that it has
+// room for another child. Creates and moves under the same parent wait for each other, so the limit they
+// check includes the children added by a concurrent request.
+func reserveChildOrganization(ctx context.Context, orgRepo repository.OrganizationRepositoryInterface, quotaService OrganizationQuotaServiceInterface, parentID uuid.UUID) error {
+	if _, err := orgRepo.FindByIDForUpdate(ctx, parentID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apperror.NewNotFoundError("parent organization")
+		}
+		return apperror.NewInternalError(fmt.Errorf("failed to lock parent organization: %w", err))
+	}
+	return quotaService.EnsureQuota(ctx, parentID, constant.OrganizationQuotaChildOrganizations, 1)
+}
+
+// createOrganizationWithGeneratedCode assigns a freshly generated code to the organization and creates it.
+// The database claims the code atomically; on a collision a new code is generated and the insert retried.
+func createOrganizationWithGeneratedCode(ctx context.Context, orgRepo repository.OrganizationRepositoryInterface, codeGenerator *util.OrganizationCodeGenerator, org *model.Organization) (*model.Organization, error) {
+	for attempt := 1; attempt <= maxOrganizationCodeAttempts; attempt++ {
+		code, err := codeGenerator.Generate(org.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate organization code: %w", err)
+		}
+		org.Code = code
+
+		createdOrg, err := orgRepo.Create(ctx, org)
+		if err == nil {
+			return createdOrg, nil
+		}
+		if !errors.Is(err, repository.ErrOrganizationCodeTaken) {
+			return nil, err
+		}
+		log.Warn().Str("code", code).Int("attempt", attempt).Msg("Generated organization code already taken, retrying")
+	}
+	return nil, fmt.Errorf("no free organization code after %d attempts", maxOrganizationCodeAttempts)
+}
+
+// RotateOrganizationCode replaces the join code of an organization, for example after it leaked.
+// The old code stops working immediately; pending invitations and memberships are not affected.
+func (s *organizationService) RotateOrganizationCode(ctx context.Context, id uuid.UUID, req dto.RotateOrganizationCodeRequest, rotatedBy uuid.UUID) (*dto.OrganizationResponse, error) {
+	org, err := s.orgRepo.FindByID(ctx, id)
 	if err != nil {
-		return "", fmt.Errorf("failed to fetch existing codes: %w", err)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.NewNotFoundError("organization")
+		}
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to find organization: %w", err))
 	}
 
-	// Use utility function for business logic (code generation)
-	uniqueCode := util.GenerateUniqueOrganizationCode(organizationName, existingCodes)
+	for attempt := 1; attempt <= maxOrganizationCodeAttempts; attempt++ {
+		code, err := s.codeGenerator.Generate(org.Name)
+		if err != nil {
+			return nil, apperror.NewInternalError(fmt.Errorf("failed to generate organization code: %w", err))
+		}
+
+		details, err := json.Marshal(map[string]interface{}{
+			"old_code": org.Code,
+			"new_code": code,
+		})
+		if err != nil {
+			return nil, apperror.NewInternalError(fmt.Errorf("failed to serialize audit details: %w", err))
+		}
+
+		auditLog := &model.OrganizationAuditLog{
+			OrganizationID: org.ID,
+			Action:         constant.OrganizationAuditActionCodeRotated,
+			ActorID:        &rotatedBy,
+			Details:        string(details),
+			Reason:         req.Reason,
+		}
+		err = s.orgRepo.UpdateCode(ctx, org.ID, code, auditLog)
+		if errors.Is(err, repository.ErrOrganizationCodeTaken) {
+			log.Warn().Str("code", code).Int("attempt", attempt).Msg("Generated organization code already taken, retrying")
+			continue
+		}
+		if err != nil {
+			return nil, apperror.NewInternalError(fmt.Errorf("failed to rotate organization code: %w", err))
+		}
+
+		log.Info().
+			Str("organization_id", org.ID.String()).
+			Str("rotated_by", rotatedBy.String()).
+			Msg("Organization code rotated")
 
-	return uniqueCode, nil
+		rotatedOrg, err := s.orgRepo.FindByID(ctx, org.ID)
+		if err != nil {
+			return nil, apperror.NewInternalError(fmt.Errorf("failed to reload organization: %w", err))
+		}
+		return util.MapOrganizationToResponse(rotatedOrg), nil
+	}
+
+	return nil, apperror.NewInternalError(fmt.Errorf("no free organization code after %d attempts", maxOrganizationCodeAttempts))
 }
 
 // ValidateOrganizationAccess checks if user has access to organization using repository methods
@@ -417,20 +1126,6 @@ func (s *organizationService) ValidateOrganizationAccess(ctx context.Context, us
 	return s.checkUserOrganizationMembership(ctx, userID, orgID)
 }
 
-// mapToUserOrganizationResponse converts UserOrganization model to response DTO using utility function
-func (s *organizationService) mapToUserOrganizationResponse(userOrg *model.UserOrganization, org *model.Organization) *dto.UserOrganizationResponse {
-	orgResponse := util.MapOrganizationToResponse(org)
-
-	return &dto.UserOrganizationResponse{
-		UserID:         userOrg.UserID,
-		OrganizationID: userOrg.OrganizationID,
-		Organization:   *orgResponse,
-		RoleID:         userOrg.RoleID,
-		JoinedAt:       userOrg.JoinedAt,
-		IsActive:       userOrg.IsActive,
-	}
-}
-
 // CreateCompleteOrganizationStructure creates the complete organization structure (Holding->Company->Store)
 // This is used by Platform Admins to onboard new users with complete business structure
 func (s *organizationService) CreateCompleteOrganizationStructure(ctx context.Context, req dto.CreateCompleteStructureRequest, createdBy uuid.UUID) (*dto.CompleteOrganizationStructureResponse, error) {
@@ -452,92 +1147,92 @@ func (s *organizationService) CreateCompleteOrganizationStructure(ctx context.Co
 		return nil, apperror.NewNotFoundError("target user not found")
 	}
 
-	// Step 1: Create Holding Company (root level)
-	holdingCode, err := s.GenerateUniqueCode(ctx, req.HoldingName)
-	if err != nil {
-		return nil, apperror.NewInternalError(fmt.Errorf("failed to generate holding code: %w", err))
-	}
-
-	holding := &model.Organization{
-		Name:             req.HoldingName,
-		Code:             holdingCode,
-		OrganizationType: constant.OrganizationTypeHolding,
-		Description:      req.HoldingDescription,
-		CreatedBy:        createdBy,
-		IsActive:         true,
-	}
-
-	createdHolding, err := s.orgRepo.Create(ctx, holding)
-	if err != nil {
-		return nil, apperror.NewInternalError(fmt.Errorf("failed to create holding organization: %w", err))
-	}
+	// All organizations and memberships are created in one transaction so a failing step leaves nothing behind
+	var createdHolding, createdCompany, createdStore *model.Organization
+	var userMemberships []dto.UserOrganizationResponse
+	err = s.txManager.RunInTx(ctx, func(ctx context.Context) error {
+		// Step 1: Create Holding Company (root level)
+		holding := &model.Organization{
+			Name:             req.HoldingName,
+			OrganizationType: constant.OrganizationTypeHolding,
+			Description:      req.HoldingDescription,
+			CreatedBy:        createdBy,
+			IsActive:         true,
+		}
 
-	// Step 2: Create Company under Holding
-	companyCode, err := s.GenerateUniqueCode(ctx, req.CompanyName)
-	if err != nil {
-		return nil, apperror.NewInternalError(fmt.Errorf("failed to generate company code: %w", err))
-	}
+		createdHolding, err = s.createWithGeneratedCode(ctx, holding)
+		if err != nil {
+			return apperror.NewInternalError(fmt.Errorf("failed to create holding organization: %w", err))
+		}
 
-	company := &model.Organization{
-		Name:                 req.CompanyName,
-		Code:                 companyCode,
-		OrganizationType:     constant.OrganizationTypeCompany,
-		ParentOrganizationID: &createdHolding.ID,
-		Description:          req.CompanyDescription,
-		CreatedBy:            createdBy,
-		IsActive:             true,
-	}
+		// Step 2: Create Company under Holding
+		company := &model.Organization{
+			Name:                 req.CompanyName,
+			OrganizationType:     constant.OrganizationTypeCompany,
+			ParentOrganizationID: &createdHolding.ID,
+			Description:          req.CompanyDescription,
+			CreatedBy:            createdBy,
+			IsActive:             true,
+		}
 
-	createdCompany, err := s.orgRepo.Create(ctx, company)
-	if err != nil {
-		return nil, apperror.NewInternalError(fmt.Errorf("failed to create company organization: %w", err))
-	}
+		createdCompany, err = s.createWithGeneratedCode(ctx, company)
+		if err != nil {
+			return apperror.NewInternalError(fmt.Errorf("failed to create company organization: %w", err))
+		}
 
-	// Step 3: Create Store under Company
-	storeCode, err := s.GenerateUniqueCode(ctx, req.StoreName)
-	if err != nil {
-		return nil, apperror.NewInternalError(fmt.Errorf("failed to generate store code: %w", err))
-	}
+		// Step 3: Create Store under Company
+		store := &model.Organization{
+			Name:                 req.StoreName,
+			OrganizationType:     constant.OrganizationTypeStore,
+			ParentOrganizationID: &createdCompany.ID,
+			Description:          req.StoreDescription,
+			CreatedBy:            createdBy,
+			IsActive:             true,
+		}
 
-	store := &model.Organization{
-		Name:                 req.StoreName,
-		Code:                 storeCode,
-		OrganizationType:     constant.OrganizationTypeStore,
-		ParentOrganizationID: &createdCompany.ID,
-		Description:          req.StoreDescription,
-		CreatedBy:            createdBy,
-		IsActive:             true,
-	}
+		createdStore, err = s.createWithGeneratedCode(ctx, store)
+		if err != nil {
+			return apperror.NewInternalError(fmt.Errorf("failed to create store organization: %w", err))
+		}
 
-	createdStore, err := s.orgRepo.Create(ctx, store)
-	if err != nil {
-		return nil, apperror.NewInternalError(fmt.Errorf("failed to create store organization: %w", err))
-	}
+		// Step 4: Assign user to all organizations (typically they'll be holding owner)
+		organizations := []*model.Organization{createdHolding, createdCompany, createdStore}
+		for _, org := range organizations {
+			userOrg := &model.UserOrganization{
+				UserID:         req.UserID,
+				OrganizationID: org.ID,
+				IsActive:       true,
+			}
 
-	// Step 4: Assign user to all organizations (typically they'll be holding owner)
-	organizations := []*model.Organization{createdHolding, createdCompany, createdStore}
-	var userMemberships []dto.UserOrganizationResponse
+			// The structure owner gets the creator role of each organization type
+			defaultRoles, err := resolveOrganizationDefaultRoles(ctx, s.roleRepo, org.OrganizationType)
+			if err != nil {
+				return apperror.NewInternalError(fmt.Errorf("failed to resolve default creator role: %w", err))
+			}
+			if defaultRoles.Creator != nil {
+				userOrg.RoleID = &defaultRoles.Creator.ID
+			}
 
-	for _, org := range organizations {
-		userOrg := &model.UserOrganization{
-			UserID:         req.UserID,
-			OrganizationID: org.ID,
-			IsActive:       true,
-		}
+			if err := s.membershipRepo.Create(ctx, userOrg, membershipChange(createdBy, "Created the organization structure")); err != nil {
+				return apperror.NewInternalError(fmt.Errorf("failed to add user to organization %s: %w", org.Name, err))
+			}
 
-		if err := s.orgRepo.AddUserToOrganization(ctx, userOrg); err != nil {
-			return nil, apperror.NewInternalError(fmt.Errorf("failed to add user to organization %s: %w", org.Name, err))
+			// Add to response
+			membership := dto.UserOrganizationResponse{
+				UserID:         req.UserID,
+				OrganizationID: org.ID,
+				Organization:   *util.MapOrganizationToResponse(org),
+				RoleID:         userOrg.RoleID,
+				JoinedAt:       userOrg.JoinedAt,
+				IsActive:       true,
+			}
+			userMemberships = append(userMemberships, membership)
 		}
 
-		// Add to response
-		membership := dto.UserOrganizationResponse{
-			UserID:         req.UserID,
-			OrganizationID: org.ID,
-			Organization:   *util.MapOrganizationToResponse(org),
-			JoinedAt:       userOrg.JoinedAt,
-			IsActive:       true,
-		}
-		userMemberships = append(userMemberships, membership)
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	// Build response
@@ -645,7 +1340,7 @@ func (s *organizationService) GetAccessibleOrganizationIDs(ctx context.Context,
 	}
 
 	// Get user's direct organization assignments
-	userOrgs, err := s.userRepo.FindUserOrganizations(ctx, currentUserID, 0, 100)
+	userOrgs, err := s.membershipRepo.FindByUser(ctx, currentUserID, 0, 100)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user organizations: %w", err)
 	}
@@ -654,90 +1349,74 @@ func (s *organizationService) GetAccessibleOrganizationIDs(ctx context.Context,
 		return []uuid.UUID{}, nil
 	}
 
-	var accessibleOrgIDs []uuid.UUID
-	processedOrgIDs := make(map[uuid.UUID]bool)
-
-	// For each organization the user belongs to, get all organizations they can access
+	rootIDs := make([]uuid.UUID, 0, len(userOrgs))
 	for _, userOrg := range userOrgs {
-		if processedOrgIDs[userOrg.OrganizationID] {
-			continue
-		}
-		processedOrgIDs[userOrg.OrganizationID] = true
-
-		// Get the organization with its details
-		org, err := s.orgRepo.FindByIDWithDetails(ctx, userOrg.OrganizationID)
-		if err != nil {
-			continue // Skip if organization not found
-		}
-
-		// Add current organization
-		accessibleOrgIDs = append(accessibleOrgIDs, org.ID)
-
-		// Based on organization type and user level, determine accessible child organizations
-		childOrgIDs, err := s.getAccessibleChildOrganizations(ctx, org, currentUserLevel)
-		if err != nil {
-			continue // Skip if error getting children
-		}
-
-		for _, childID := range childOrgIDs {
-			if !processedOrgIDs[childID] {
-				accessibleOrgIDs = append(accessibleOrgIDs, childID)
-				processedOrgIDs[childID] = true
-			}
-		}
+		rootIDs = append(rootIDs, userOrg.OrganizationID)
 	}
 
-	return accessibleOrgIDs, nil
-}
-
-// getAccessibleChildOrganizations recursively gets all child organizations that the user can access
-func (s *organizationService) getAccessibleChildOrganizations(ctx context.Context, org *model.Organization, userLevel int) ([]uuid.UUID, error) {
-	var childIDs []uuid.UUID
+	// Load every membership organization and its descendants in one query,
+	// then walk the subtrees in memory applying the hierarchy access rules
+	subtree, err := s.orgRepo.GetSubtree(ctx, rootIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get organization subtrees: %w", err)
+	}
 
-	// Get direct children of current organization
-	children, err := s.orgRepo.FindByParent(ctx, org.ID)
+	orgTypes, err := loadOrganizationTypes(ctx, s.orgTypeRepo)
 	if err != nil {
-		return childIDs, err
+		return nil, err
 	}
 
-	for _, child := range children {
-		// Determine if user can access this child based on organization hierarchy rules
-		if s.canAccessChildOrganization(org.OrganizationType, child.OrganizationType, userLevel) {
-			childIDs = append(childIDs, child.ID)
+	orgsByID := make(map[uuid.UUID]*model.Organization, len(subtree))
+	childrenByParent := make(map[uuid.UUID][]*model.Organization)
+	for i := range subtree {
+		org := &subtree[i]
+		orgsByID[org.ID] = org
+		if org.ParentOrganizationID != nil {
+			childrenByParent[*org.ParentOrganizationID] = append(childrenByParent[*org.ParentOrganizationID], org)
+		}
+	}
 
-			// Recursively get children of this child
-			grandChildIDs, err := s.getAccessibleChildOrganizations(ctx, &child, userLevel)
-			if err != nil {
-				continue // Skip if error, but continue with other children
+	var accessibleOrgIDs []uuid.UUID
+	processedOrgIDs := make(map[uuid.UUID]bool)
+
+	for _, rootID := range rootIDs {
+		root, ok := orgsByID[rootID]
+		if !ok || processedOrgIDs[rootID] {
+			continue // Skip if organization not found or already reached
+		}
+		processedOrgIDs[rootID] = true
+		accessibleOrgIDs = append(accessibleOrgIDs, rootID)
+
+		queue := []*model.Organization{root}
+		for len(queue) > 0 {
+			parent := queue[0]
+			queue = queue[1:]
+
+			for _, child := range childrenByParent[parent.ID] {
+				if !s.canAccessChildOrganization(parent.OrganizationType, child.OrganizationType, orgTypes) {
+					continue
+				}
+				if !processedOrgIDs[child.ID] {
+					accessibleOrgIDs = append(accessibleOrgIDs, child.ID)
+					processedOrgIDs[child.ID] = true
+				}
+				queue = append(queue, child)
 			}
-			childIDs = append(childIDs, grandChildIDs...)
 		}
 	}
 
-	return childIDs, nil
+	return accessibleOrgIDs, nil
 }
 
-// canAccessChildOrganization determines if a user can access a child organization
-// based on organization hierarchy and user level
-func (s *organizationService) canAccessChildOrganization(parentType, childType string, userLevel int) bool {
-	// Organization hierarchy rules:
-	// - Holding (level 51-75) can access Company and Store
-	// - Company (level 26-50) can access Store only
-	// - Store (level 1-25) cannot access other organizations
-
-	switch parentType {
-	case constant.OrganizationTypeHolding:
-		// Holding users can access Company and Store children
-		return childType == constant.OrganizationTypeCompany || childType == constant.OrganizationTypeStore
-	case constant.OrganizationTypeCompany:
-		// Company users can access Store children only
-		return childType == constant.OrganizationTypeStore
-	case constant.OrganizationTypeStore:
-		// Store users cannot access child organizations (stores don't have children typically)
-		return false
-	default:
+// canAccessChildOrganization determines if members of a parent organization can access a child
+// organization. Access follows the placement rules of the type registry: members see children whose
+// type may be placed under the parent's type (a holding sees its companies, a company its stores).
+func (s *organizationService) canAccessChildOrganization(parentType, childType string, orgTypes map[string]*model.OrganizationType) bool {
+	orgType, ok := orgTypes[childType]
+	if !ok {
 		return false
 	}
+	return orgType.AllowsParent(parentType)
 }
 
 // getAllOrganizationIDs returns all organization IDs for platform users
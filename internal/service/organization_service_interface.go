@@ -3,6 +3,7 @@ package service
 import (
 	"go-base-project/internal/dto"
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -15,20 +16,33 @@ type OrganizationServiceInterface interface {
 	GetOrganizationByCode(ctx context.Context, code string) (*dto.OrganizationResponse, error)
 	UpdateOrganization(ctx context.Context, id uuid.UUID, req dto.UpdateOrganizationRequest, updatedBy uuid.UUID) (*dto.OrganizationResponse, error)
 	DeleteOrganization(ctx context.Context, id uuid.UUID, deletedBy uuid.UUID) error
+	MoveOrganization(ctx context.Context, id uuid.UUID, req dto.MoveOrganizationRequest, movedBy uuid.UUID) (*dto.OrganizationResponse, error)
+	ListOrganizationAuditLogs(ctx context.Context, id uuid.UUID, limit int) ([]dto.OrganizationAuditLogResponse, error)
+
+	// Organization lifecycle: archive state and restoring or purging soft-deleted organizations
+	ArchiveOrganization(ctx context.Context, id uuid.UUID, req dto.ArchiveOrganizationRequest, archivedBy uuid.UUID) (*dto.ArchiveOrganizationResponse, error)
+	UnarchiveOrganization(ctx context.Context, id uuid.UUID, req dto.ArchiveOrganizationRequest, unarchivedBy uuid.UUID) (*dto.ArchiveOrganizationResponse, error)
+	RestoreOrganization(ctx context.Context, id uuid.UUID, req dto.RestoreOrganizationRequest, restoredBy uuid.UUID) (*dto.OrganizationResponse, error)
+	ListDeletedOrganizations(ctx context.Context) ([]dto.OrganizationResponse, error)
+	PurgeDeletedOrganizations(ctx context.Context, retention time.Duration) (int64, error)
 
 	// Organization queries
 	ListOrganizations(ctx context.Context, req dto.ListOrganizationsRequest) (*dto.PaginatedOrganizationsResponse, error)
 	GetOrganizationsByType(ctx context.Context, orgType string) ([]dto.OrganizationResponse, error)
 	GetChildOrganizations(ctx context.Context, parentID uuid.UUID) ([]dto.OrganizationResponse, error)
+	GetOrganizationHierarchy(ctx context.Context, orgID uuid.UUID, maxDepth int) (*dto.OrganizationHierarchyResponse, error)
+
+	// Joining; memberships themselves are managed by MembershipServiceInterface
+	JoinOrganization(ctx context.Context, userID uuid.UUID, req dto.JoinOrganizationRequest) (*dto.JoinOrganizationResponse, error)
 
-	// User-Organization management
-	JoinOrganization(ctx context.Context, userID uuid.UUID, req dto.JoinOrganizationRequest) (*dto.UserOrganizationResponse, error)
-	LeaveOrganization(ctx context.Context, userID, orgID uuid.UUID) error
-	GetUserOrganizations(ctx context.Context, userID uuid.UUID) ([]dto.UserOrganizationResponse, error)
-	GetOrganizationMembers(ctx context.Context, orgID uuid.UUID) ([]dto.UserOrganizationResponse, error)
+	// Join requests for organizations whose join policy requires approval
+	ListJoinRequests(ctx context.Context, orgID uuid.UUID, status string) ([]dto.JoinRequestResponse, error)
+	ListMyJoinRequests(ctx context.Context, userID uuid.UUID, status string) ([]dto.JoinRequestResponse, error)
+	ApproveJoinRequest(ctx context.Context, orgID, requestID uuid.UUID, req dto.ApproveJoinRequestRequest, approvedBy uuid.UUID) (*dto.UserOrganizationResponse, error)
+	RejectJoinRequest(ctx context.Context, orgID, requestID uuid.UUID, req dto.RejectJoinRequestRequest, rejectedBy uuid.UUID) (*dto.JoinRequestResponse, error)
 
 	// Organization code utilities
-	GenerateUniqueCode(ctx context.Context, organizationName string) (string, error)
+	RotateOrganizationCode(ctx context.Context, id uuid.UUID, req dto.RotateOrganizationCodeRequest, rotatedBy uuid.UUID) (*dto.OrganizationResponse, error)
 	ValidateOrganizationAccess(ctx context.Context, userID, orgID uuid.UUID) (bool, error)
 
 	// Complete organization structure creation (Platform admin feature)
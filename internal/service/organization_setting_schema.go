@@ -0,0 +1,205 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"go-base-project/internal/constant"
+	"go-base-project/internal/dto"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Setting value types of the settings schema
+const (
+	organizationSettingTypeString     = "string"
+	organizationSettingTypeBoolean    = "boolean"
+	organizationSettingTypeStringList = "string_list"
+	organizationSettingTypeJSON       = "json"
+)
+
+// maxCustomSettingSize bounds the encoded size of a custom setting value
+const maxCustomSettingSize = 4096
+
+var (
+	customSettingKeyPattern = regexp.MustCompile(`^custom\.[a-z0-9_-]+(\.[a-z0-9_-]+)*$`)
+	languageTagPattern      = regexp.MustCompile(`^[a-z]{2,3}(-[A-Za-z0-9]{2,8})*$`)
+)
+
+// organizationSettingDefinition describes one setting of the schema: its value type, default and the
+// values it accepts. Every setting is inherited by descendant organizations unless they override it.
+type organizationSettingDefinition struct {
+	Key           string
+	Type          string
+	Description   string
+	Default       interface{}
+	AllowedValues []string           // Accepted values of string and string list settings; empty accepts any
+	Validate      func(string) error // Additional check of string values
+}
+
+// organizationSettingSchema is the registry of known settings, in the order they are listed.
+var organizationSettingSchema = []organizationSettingDefinition{
+	{
+		Key:           constant.OrganizationSettingJoinPolicy,
+		Type:          organizationSettingTypeString,
+		Description:   "How joining by organization code is handled",
+		Default:       constant.JoinPolicyOpen,
+		AllowedValues: []string{constant.JoinPolicyOpen, constant.JoinPolicyApprovalRequired, constant.JoinPolicyInviteOnly},
+	},
+	{
+		Key:         constant.OrganizationSettingTimezone,
+		Type:        organizationSettingTypeString,
+		Description: "Default IANA time zone of the organization",
+		Default:     "UTC",
+		Validate: func(value string) error {
+			if _, err := time.LoadLocation(value); err != nil || value == "" || value == "Local" {
+				return fmt.Errorf("%q is not a valid IANA time zone", value)
+			}
+			return nil
+		},
+	},
+	{
+		Key:         constant.OrganizationSettingLocale,
+		Type:        organizationSettingTypeString,
+		Description: "Default locale of the organization as a language tag",
+		Default:     "en",
+		Validate: func(value string) error {
+			if !languageTagPattern.MatchString(value) {
+				return fmt.Errorf("%q is not a valid language tag", value)
+			}
+			return nil
+		},
+	},
+	{
+		Key:           constant.OrganizationSettingAllowedLoginProviders,
+		Type:          organizationSettingTypeStringList,
+		Description:   "Login providers members of the organization may sign in with",
+		Default:       []string{"local", "google"},
+		AllowedValues: []string{"local", "google"},
+	},
+	{
+		Key:         constant.OrganizationSettingRequireMFA,
+		Type:        organizationSettingTypeBoolean,
+		Description: "Whether members of the organization must use multi-factor authentication",
+		Default:     false,
+	},
+}
+
+// customOrganizationSettingDefinition covers the free-form custom.* keys applications store their own
+// configuration under.
+var customOrganizationSettingDefinition = organizationSettingDefinition{
+	Key:         constant.OrganizationSettingCustomPrefix + "*",
+	Type:        organizationSettingTypeJSON,
+	Description: fmt.Sprintf("Application specific value of any JSON type, at most %d bytes", maxCustomSettingSize),
+}
+
+// findOrganizationSettingDefinition returns the schema definition of a setting key.
+func findOrganizationSettingDefinition(key string) (*organizationSettingDefinition, bool) {
+	if strings.HasPrefix(key, constant.OrganizationSettingCustomPrefix) {
+		if !customSettingKeyPattern.MatchString(key) || len(key) > 100 {
+			return nil, false
+		}
+		return &customOrganizationSettingDefinition, true
+	}
+	for i := range organizationSettingSchema {
+		if organizationSettingSchema[i].Key == key {
+			return &organizationSettingSchema[i], true
+		}
+	}
+	return nil, false
+}
+
+// normalizeValue validates a JSON value against the definition and returns it in compact form.
+func (d *organizationSettingDefinition) normalizeValue(raw json.RawMessage) (string, error) {
+	switch d.Type {
+	case organizationSettingTypeString:
+		var value string
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return "", fmt.Errorf("must be a string")
+		}
+		if err := d.checkAllowed(value); err != nil {
+			return "", err
+		}
+		if d.Validate != nil {
+			if err := d.Validate(value); err != nil {
+				return "", err
+			}
+		}
+		return marshalSettingValue(value)
+	case organizationSettingTypeBoolean:
+		var value bool
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return "", fmt.Errorf("must be a boolean")
+		}
+		return marshalSettingValue(value)
+	case organizationSettingTypeStringList:
+		var values []string
+		if err := json.Unmarshal(raw, &values); err != nil || values == nil {
+			return "", fmt.Errorf("must be a list of strings")
+		}
+		if len(values) == 0 {
+			return "", fmt.Errorf("must contain at least one value")
+		}
+		seen := make(map[string]bool, len(values))
+		for _, value := range values {
+			if seen[value] {
+				return "", fmt.Errorf("contains %q more than once", value)
+			}
+			seen[value] = true
+			if err := d.checkAllowed(value); err != nil {
+				return "", err
+			}
+		}
+		return marshalSettingValue(values)
+	default:
+		var value interface{}
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return "", fmt.Errorf("must be valid JSON")
+		}
+		normalized, err := marshalSettingValue(value)
+		if err != nil {
+			return "", err
+		}
+		if len(normalized) > maxCustomSettingSize {
+			return "", fmt.Errorf("must not exceed %d bytes", maxCustomSettingSize)
+		}
+		return normalized, nil
+	}
+}
+
+// checkAllowed reports whether a string value is one of the allowed values of the definition.
+func (d *organizationSettingDefinition) checkAllowed(value string) error {
+	if len(d.AllowedValues) == 0 {
+		return nil
+	}
+	for _, allowed := range d.AllowedValues {
+		if value == allowed {
+			return nil
+		}
+	}
+	return fmt.Errorf("%q is not one of %s", value, strings.Join(d.AllowedValues, ", "))
+}
+
+// mapToResponse converts the definition into its API representation.
+func (d *organizationSettingDefinition) mapToResponse() dto.OrganizationSettingDefinitionResponse {
+	response := dto.OrganizationSettingDefinitionResponse{
+		Key:           d.Key,
+		Type:          d.Type,
+		Description:   d.Description,
+		AllowedValues: d.AllowedValues,
+	}
+	if d.Default != nil {
+		if encoded, err := json.Marshal(d.Default); err == nil {
+			response.Default = encoded
+		}
+	}
+	return response
+}
+
+func marshalSettingValue(value interface{}) (string, error) {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf("could not be encoded: %w", err)
+	}
+	return string(encoded), nil
+}
@@ -0,0 +1,300 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-base-project/internal/apperror"
+	"go-base-project/internal/cache"
+	"go-base-project/internal/constant"
+	"go-base-project/internal/dto"
+	"go-base-project/internal/model"
+	"go-base-project/internal/repository"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+type organizationSettingService struct {
+	settingRepo repository.OrganizationSettingRepositoryInterface
+	orgRepo     repository.OrganizationRepositoryInterface
+	redis       *redis.Client
+}
+
+// NewOrganizationSettingService creates a new instance of OrganizationSettingService
+func NewOrganizationSettingService(
+	settingRepo repository.OrganizationSettingRepositoryInterface,
+	orgRepo repository.OrganizationRepositoryInterface,
+	redis *redis.Client,
+) OrganizationSettingServiceInterface {
+	return &organizationSettingService{
+		settingRepo: settingRepo,
+		orgRepo:     orgRepo,
+		redis:       redis,
+	}
+}
+
+// GetSchema lists the known settings followed by the custom.* keys.
+func (s *organizationSettingService) GetSchema() []dto.OrganizationSettingDefinitionResponse {
+	schema := make([]dto.OrganizationSettingDefinitionResponse, 0, len(organizationSettingSchema)+1)
+	for i := range organizationSettingSchema {
+		schema = append(schema, organizationSettingSchema[i].mapToResponse())
+	}
+	return append(schema, customOrganizationSettingDefinition.mapToResponse())
+}
+
+// GetSettings returns the effective settings of an organization.
+func (s *organizationSettingService) GetSettings(ctx context.Context, orgID uuid.UUID) (*dto.OrganizationSettingsResponse, error) {
+	if err := s.ensureOrganizationExists(ctx, orgID); err != nil {
+		return nil, err
+	}
+	return s.resolveSettings(ctx, orgID)
+}
+
+// UpdateSettings validates the requested values against the schema and stores them. Values equal to the
+// organization's current ones are skipped, so only real changes end up in the audit log.
+func (s *organizationSettingService) UpdateSettings(ctx context.Context, orgID uuid.UUID, req dto.UpdateOrganizationSettingsRequest, updatedBy uuid.UUID) (*dto.OrganizationSettingsResponse, error) {
+	if err := s.ensureOrganizationExists(ctx, orgID); err != nil {
+		return nil, err
+	}
+
+	current, err := s.settingRepo.FindByOrganization(ctx, orgID)
+	if err != nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to load organization settings: %w", err))
+	}
+	currentValues := make(map[string]string, len(current))
+	for _, setting := range current {
+		currentValues[setting.Key] = setting.Value
+	}
+
+	keys := make([]string, 0, len(req.Settings))
+	for key := range req.Settings {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	now := time.Now()
+	var upserts []model.OrganizationSetting
+	var removedKeys []string
+	var issues []string
+	changes := make(map[string]map[string]json.RawMessage)
+	for _, key := range keys {
+		raw := req.Settings[key]
+		definition, ok := findOrganizationSettingDefinition(key)
+		if !ok {
+			issues = append(issues, fmt.Sprintf("%s: unknown setting", key))
+			continue
+		}
+
+		previous, isSet := currentValues[key]
+		if len(raw) == 0 || string(raw) == "null" {
+			if isSet {
+				removedKeys = append(removedKeys, key)
+				changes[key] = map[string]json.RawMessage{"from": json.RawMessage(previous), "to": nil}
+			}
+			continue
+		}
+
+		value, err := definition.normalizeValue(raw)
+		if err != nil {
+			issues = append(issues, fmt.Sprintf("%s: %s", key, err.Error()))
+			continue
+		}
+		if isSet && settingValuesEqual(previous, value) {
+			continue
+		}
+
+		upserts = append(upserts, model.OrganizationSetting{
+			OrganizationID: orgID,
+			Key:            key,
+			Value:          value,
+			UpdatedBy:      &updatedBy,
+			UpdatedAt:      now,
+		})
+		change := map[string]json.RawMessage{"from": nil, "to": json.RawMessage(value)}
+		if isSet {
+			change["from"] = json.RawMessage(previous)
+		}
+		changes[key] = change
+	}
+
+	if len(issues) > 0 {
+		return nil, apperror.NewValidationError("Invalid organization settings: " + strings.Join(issues, "; "))
+	}
+	if len(changes) == 0 {
+		return s.resolveSettings(ctx, orgID)
+	}
+
+	details, err := json.Marshal(map[string]interface{}{"changes": changes})
+	if err != nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to serialize audit details: %w", err))
+	}
+	auditLog := &model.OrganizationAuditLog{
+		OrganizationID: orgID,
+		Action:         constant.OrganizationAuditActionSettings,
+		ActorID:        &updatedBy,
+		Details:        string(details),
+	}
+	if err := s.settingRepo.Save(ctx, orgID, upserts, removedKeys, auditLog); err != nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to save organization settings: %w", err))
+	}
+
+	// Descendants inherit the changed values, so their cached settings are stale as well
+	s.InvalidateSettingsCache(ctx, []uuid.UUID{orgID})
+
+	return s.resolveSettings(ctx, orgID)
+}
+
+// GetEffectiveValue decodes the effective value of a setting of an organization into target.
+func (s *organizationSettingService) GetEffectiveValue(ctx context.Context, orgID uuid.UUID, key string, target interface{}) error {
+	settings, err := s.resolveSettings(ctx, orgID)
+	if err != nil {
+		return err
+	}
+
+	for _, setting := range settings.Settings {
+		if setting.Key != key {
+			continue
+		}
+		if err := json.Unmarshal(setting.Value, target); err != nil {
+			return apperror.NewInternalError(fmt.Errorf("failed to decode organization setting %s: %w", key, err))
+		}
+		return nil
+	}
+	return apperror.NewNotFoundError("organization setting")
+}
+
+// InvalidateSettingsCache drops the cached effective settings of the organizations and their descendants.
+// Failures are logged only; cached entries expire on their own.
+func (s *organizationSettingService) InvalidateSettingsCache(ctx context.Context, orgIDs []uuid.UUID) {
+	subtree, err := s.orgRepo.GetSubtree(ctx, orgIDs)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to find organizations for settings cache invalidation")
+		return
+	}
+	if len(subtree) == 0 {
+		return
+	}
+
+	cacheKeys := make([]string, 0, len(subtree))
+	for _, org := range subtree {
+		cacheKeys = append(cacheKeys, cache.GetOrganizationSettingsCacheKey(org.ID))
+	}
+	if err := s.redis.Del(ctx, cacheKeys...).Err(); err != nil {
+		log.Warn().Err(err).Int("organizations", len(cacheKeys)).Msg("Failed to invalidate organization settings cache")
+	}
+}
+
+// resolveSettings returns the effective settings of an organization from the cache, resolving and
+// caching them on a miss.
+func (s *organizationSettingService) resolveSettings(ctx context.Context, orgID uuid.UUID) (*dto.OrganizationSettingsResponse, error) {
+	cacheKey := cache.GetOrganizationSettingsCacheKey(orgID)
+	if cached, err := s.redis.Get(ctx, cacheKey).Result(); err == nil {
+		var settings dto.OrganizationSettingsResponse
+		if err := json.Unmarshal([]byte(cached), &settings); err == nil {
+			return &settings, nil
+		}
+	}
+
+	chain, err := s.settingRepo.FindInheritanceChain(ctx, orgID)
+	if err != nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to load organization settings: %w", err))
+	}
+	settings := buildEffectiveSettings(orgID, chain)
+
+	if encoded, err := json.Marshal(settings); err != nil {
+		log.Error().Err(err).Str("organization_id", orgID.String()).Msg("Failed to marshal organization settings for caching")
+	} else if err := s.redis.Set(ctx, cacheKey, encoded, cache.OrganizationSettingsCacheDuration).Err(); err != nil {
+		log.Error().Err(err).Str("organization_id", orgID.String()).Msg("Failed to cache organization settings")
+	}
+
+	return settings, nil
+}
+
+// buildEffectiveSettings resolves every setting from the nearest organization of the chain that sets it,
+// falling back to the schema default. Custom keys have no default and are only listed when set.
+func buildEffectiveSettings(orgID uuid.UUID, chain []model.OrganizationSetting) *dto.OrganizationSettingsResponse {
+	nearest := make(map[string]*model.OrganizationSetting)
+	var customKeys []string
+	for i := range chain {
+		key := chain[i].Key
+		if _, ok := nearest[key]; ok {
+			continue
+		}
+		if _, known := findOrganizationSettingDefinition(key); !known {
+			continue
+		}
+		nearest[key] = &chain[i]
+		if strings.HasPrefix(key, constant.OrganizationSettingCustomPrefix) {
+			customKeys = append(customKeys, key)
+		}
+	}
+	sort.Strings(customKeys)
+
+	response := &dto.OrganizationSettingsResponse{
+		OrganizationID: orgID,
+		Settings:       make([]dto.OrganizationSettingValue, 0, len(organizationSettingSchema)+len(customKeys)),
+	}
+	for i := range organizationSettingSchema {
+		definition := &organizationSettingSchema[i]
+		if setting, ok := nearest[definition.Key]; ok {
+			response.Settings = append(response.Settings, mapOrganizationSettingValue(orgID, setting))
+			continue
+		}
+		value := dto.OrganizationSettingValue{
+			Key:    definition.Key,
+			Source: constant.OrganizationSettingSourceDefault,
+		}
+		value.Value, _ = json.Marshal(definition.Default)
+		response.Settings = append(response.Settings, value)
+	}
+	for _, key := range customKeys {
+		response.Settings = append(response.Settings, mapOrganizationSettingValue(orgID, nearest[key]))
+	}
+	return response
+}
+
+func mapOrganizationSettingValue(orgID uuid.UUID, setting *model.OrganizationSetting) dto.OrganizationSettingValue {
+	source := constant.OrganizationSettingSourceInherited
+	if setting.OrganizationID == orgID {
+		source = constant.OrganizationSettingSourceOrganization
+	}
+	sourceOrgID := setting.OrganizationID
+	updatedAt := setting.UpdatedAt
+	return dto.OrganizationSettingValue{
+		Key:                  setting.Key,
+		Value:                json.RawMessage(setting.Value),
+		Source:               source,
+		SourceOrganizationID: &sourceOrgID,
+		UpdatedBy:            setting.UpdatedBy,
+		UpdatedAt:            &updatedAt,
+	}
+}
+
+// settingValuesEqual compares two JSON encoded values regardless of formatting; jsonb does not keep
+// the encoding a value was stored with.
+func settingValuesEqual(a, b string) bool {
+	var decodedA, decodedB interface{}
+	if json.Unmarshal([]byte(a), &decodedA) != nil || json.Unmarshal([]byte(b), &decodedB) != nil {
+		return a == b
+	}
+	encodedA, errA := json.Marshal(decodedA)
+	encodedB, errB := json.Marshal(decodedB)
+	return errA == nil && errB == nil && string(encodedA) == string(encodedB)
+}
+
+func (s *organizationSettingService) ensureOrganizationExists(ctx context.Context, orgID uuid.UUID) error {
+	if _, err := s.orgRepo.FindByID(ctx, orgID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apperror.NewNotFoundError("organization")
+		}
+		return apperror.NewInternalError(fmt.Errorf("failed to find organization: %w", err))
+	}
+	return nil
+}
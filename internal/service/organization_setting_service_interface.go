@@ -0,0 +1,23 @@
+package service
+
+import (
+	"context"
+	"go-base-project/internal/dto"
+
+	"github.com/google/uuid"
+)
+
+// OrganizationSettingServiceInterface defines the business logic for per-organization settings
+type OrganizationSettingServiceInterface interface {
+	// GetSchema lists the settings organizations can configure.
+	GetSchema() []dto.OrganizationSettingDefinitionResponse
+	// GetSettings returns the effective settings of an organization, resolved across its parent chain.
+	GetSettings(ctx context.Context, orgID uuid.UUID) (*dto.OrganizationSettingsResponse, error)
+	// UpdateSettings sets or removes settings of an organization and returns its effective settings.
+	UpdateSettings(ctx context.Context, orgID uuid.UUID, req dto.UpdateOrganizationSettingsRequest, updatedBy uuid.UUID) (*dto.OrganizationSettingsResponse, error)
+	// GetEffectiveValue decodes the effective value of a setting of an organization into target.
+	GetEffectiveValue(ctx context.Context, orgID uuid.UUID, key string, target interface{}) error
+	// InvalidateSettingsCache drops the cached effective settings of the given organizations and their
+	// descendants, e.g. after they moved to another parent.
+	InvalidateSettingsCache(ctx context.Context, orgIDs []uuid.UUID)
+}
@@ -0,0 +1,587 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"go-base-project/internal/apperror"
+	"go-base-project/internal/dto"
+	"go-base-project/internal/model"
+	"go-base-project/internal/repository"
+	"go-base-project/internal/util"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+// maxTemplateOrganizations bounds the number of organizations a single template may create
+const maxTemplateOrganizations = 500
+
+// templateVariablePattern matches {{variable}} placeholders in template strings
+var templateVariablePattern = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_]+)\s*\}\}`)
+
+// organizationTemplateService implements OrganizationTemplateServiceInterface.
+type organizationTemplateService struct {
+	templateRepo      repository.OrganizationTemplateRepositoryInterface
+	orgRepo           repository.OrganizationRepositoryInterface
+	orgTypeRepo       repository.OrganizationTypeRepositoryInterface
+	roleRepo          repository.RoleRepositoryInterface
+	userRepo          repository.UserRepositoryInterface
+	txManager         repository.TransactionManagerInterface
+	quotaService      OrganizationQuotaServiceInterface
+	membershipService MembershipServiceInterface
+	codeGenerator     *util.OrganizationCodeGenerator
+}
+
+// NewOrganizationTemplateService creates a new instance of organizationTemplateService.
+func NewOrganizationTemplateService(
+	templateRepo repository.OrganizationTemplateRepositoryInterface,
+	orgRepo repository.OrganizationRepositoryInterface,
+	orgTypeRepo repository.OrganizationTypeRepositoryInterface,
+	roleRepo repository.RoleRepositoryInterface,
+	userRepo repository.UserRepositoryInterface,
+	txManager repository.TransactionManagerInterface,
+	quotaService OrganizationQuotaServiceInterface,
+	membershipService MembershipServiceInterface,
+	codeGenerator *util.OrganizationCodeGenerator,
+) OrganizationTemplateServiceInterface {
+	return &organizationTemplateService{
+		templateRepo:      templateRepo,
+		orgRepo:           orgRepo,
+		orgTypeRepo:       orgTypeRepo,
+		roleRepo:          roleRepo,
+		userRepo:          userRepo,
+		txManager:         txManager,
+		quotaService:      quotaService,
+		membershipService: membershipService,
+		codeGenerator:     codeGenerator,
+	}
+}
+
+// ListTemplates returns the saved organization templates.
+func (s *organizationTemplateService) ListTemplates(ctx context.Context) ([]dto.OrganizationTemplateResponse, error) {
+	templates, err := s.templateRepo.FindAll(ctx)
+	if err != nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to fetch organization templates: %w", err))
+	}
+
+	responses := make([]dto.OrganizationTemplateResponse, 0, len(templates))
+	for i := range templates {
+		response, err := buildOrganizationTemplateResponse(&templates[i])
+		if err != nil {
+			return nil, err
+		}
+		responses = append(responses, *response)
+	}
+	return responses, nil
+}
+
+// GetTemplate returns a single saved organization template.
+func (s *organizationTemplateService) GetTemplate(ctx context.Context, id uuid.UUID) (*dto.OrganizationTemplateResponse, error) {
+	template, err := s.findTemplate(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return buildOrganizationTemplateResponse(template)
+}
+
+// CreateTemplate saves a reusable organization template. Only the structure is checked here; users, roles
+// and variables are resolved when the template is validated or applied.
+func (s *organizationTemplateService) CreateTemplate(ctx context.Context, req dto.CreateOrganizationTemplateRequest, createdBy uuid.UUID) (*dto.OrganizationTemplateResponse, error) {
+	definition, err := s.checkTemplateStructure(ctx, req.Definition)
+	if err != nil {
+		return nil, err
+	}
+
+	template := &model.OrganizationTemplate{
+		Name:        req.Name,
+		Description: req.Description,
+		Definition:  definition,
+		CreatedBy:   &createdBy,
+		UpdatedBy:   &createdBy,
+	}
+	if err := s.templateRepo.Create(ctx, template); err != nil {
+		if errors.Is(err, repository.ErrOrganizationTemplateNameTaken) {
+			return nil, apperror.NewConflictError(fmt.Sprintf("Organization template '%s' already exists", req.Name))
+		}
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to create organization template: %w", err))
+	}
+
+	log.Info().Str("template_id", template.ID.String()).Str("created_by", createdBy.String()).Msg("Organization template saved")
+	return s.GetTemplate(ctx, template.ID)
+}
+
+// UpdateTemplate replaces the name, description and definition of a saved template.
+func (s *organizationTemplateService) UpdateTemplate(ctx context.Context, id uuid.UUID, req dto.UpdateOrganizationTemplateRequest, updatedBy uuid.UUID) (*dto.OrganizationTemplateResponse, error) {
+	template, err := s.findTemplate(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	definition, err := s.checkTemplateStructure(ctx, req.Definition)
+	if err != nil {
+		return nil, err
+	}
+
+	template.Name = req.Name
+	template.Description = req.Description
+	template.Definition = definition
+	template.UpdatedBy = &updatedBy
+	template.UpdatedAt = time.Now()
+	if err := s.templateRepo.Update(ctx, template); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrOrganizationTemplateNameTaken):
+			return nil, apperror.NewConflictError(fmt.Sprintf("Organization template '%s' already exists", req.Name))
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return nil, apperror.NewNotFoundError("organization template")
+		}
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to update organization template: %w", err))
+	}
+
+	log.Info().Str("template_id", template.ID.String()).Str("updated_by", updatedBy.String()).Msg("Organization template updated")
+	return s.GetTemplate(ctx, template.ID)
+}
+
+// DeleteTemplate removes a saved template. Organizations created from it are kept.
+func (s *organizationTemplateService) DeleteTemplate(ctx context.Context, id uuid.UUID) error {
+	if err := s.templateRepo.Delete(ctx, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apperror.NewNotFoundError("organization template")
+		}
+		return apperror.NewInternalError(fmt.Errorf("failed to delete organization template: %w", err))
+	}
+	return nil
+}
+
+// ValidateTemplate resolves a template against the type registry, users and roles without creating anything.
+func (s *organizationTemplateService) ValidateTemplate(ctx context.Context, req dto.ApplyOrganizationTemplateRequest) (*dto.OrganizationTemplatePlanResponse, error) {
+	planner, err := s.planTemplate(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return planner.response(), nil
+}
+
+// ApplyTemplate creates the organizations of a template, parents first, together with their member
+// assignments. Everything runs in one transaction: a failure leaves no partial structure behind.
+func (s *organizationTemplateService) ApplyTemplate(ctx context.Context, req dto.ApplyOrganizationTemplateRequest, appliedBy uuid.UUID) (*dto.OrganizationTemplatePlanResponse, error) {
+	planner, err := s.planTemplate(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if len(planner.issues) > 0 {
+		first := planner.issues[0]
+		return nil, apperror.NewValidationError(fmt.Sprintf("Template has %d issues, run the validation for details; first issue at %s: %s", len(planner.issues), first.Path, first.Message))
+	}
+
+	createdIDs := make([]uuid.UUID, len(planner.entries))
+	err = s.txManager.RunInTx(ctx, func(ctx context.Context) error {
+		for i := range planner.entries {
+			entry := &planner.entries[i]
+
+			parentID := req.ParentOrganizationID
+			if parentIndex := planner.parentIndexes[i]; parentIndex >= 0 {
+				parentID = &createdIDs[parentIndex]
+			}
+
+			// Limits are checked inside the transaction so organizations created earlier in the
+			// template count, and new organizations inherit the limits of their parent
+			if parentID != nil {
+				if err := reserveChildOrganization(ctx, s.orgRepo, s.quotaService, *parentID); err != nil {
+					return err
+				}
+			}
+
+			org, err := createOrganizationWithGeneratedCode(ctx, s.orgRepo, s.codeGenerator, &model.Organization{
+				Name:                 entry.Name,
+				OrganizationType:     entry.OrganizationType,
+				ParentOrganizationID: parentID,
+				Description:          entry.Description,
+				CreatedBy:            appliedBy,
+				IsActive:             true,
+			})
+			if err != nil {
+				return apperror.NewInternalError(fmt.Errorf("failed to create organization %s: %w", entry.Path, err))
+			}
+			createdIDs[i] = org.ID
+			entry.ID = &org.ID
+			entry.Code = org.Code
+
+			for _, member := range entry.Members {
+				if _, err := s.membershipService.AddMember(ctx, dto.AssignUserToOrganizationRequest{
+					UserID:         *member.UserID,
+					OrganizationID: org.ID,
+					RoleID:         member.RoleID,
+					IsActive:       true,
+					Reason:         "Created from organization template",
+				}, appliedBy); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := planner.response()
+	response.Applied = true
+
+	log.Info().
+		Str("applied_by", appliedBy.String()).
+		Int("organizations", response.OrganizationCount).
+		Int("members", response.MemberCount).
+		Msg("Organization template applied")
+	return response, nil
+}
+
+// findTemplate loads a saved template, mapping a missing one to a not found error
+func (s *organizationTemplateService) findTemplate(ctx context.Context, id uuid.UUID) (*model.OrganizationTemplate, error) {
+	template, err := s.templateRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.NewNotFoundError("organization template")
+		}
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to fetch organization template: %w", err))
+	}
+	return template, nil
+}
+
+// checkTemplateStructure verifies the size of a template, that its types exist and that every child type
+// may be placed under its parent type, and returns the serialized definition. Root placement depends on
+// where the template is applied and is checked then.
+func (s *organizationTemplateService) checkTemplateStructure(ctx context.Context, definition dto.OrganizationTemplateDefinition) (string, error) {
+	if count := countTemplateOrganizations(definition.Organizations); count > maxTemplateOrganizations {
+		return "", apperror.NewValidationError(fmt.Sprintf("A template may create at most %d organizations, this one creates %d", maxTemplateOrganizations, count))
+	}
+
+	orgTypes, err := loadOrganizationTypes(ctx, s.orgTypeRepo)
+	if err != nil {
+		return "", apperror.NewInternalError(err)
+	}
+
+	var check func(nodes []dto.OrganizationTemplateNode, path, parentType string) error
+	check = func(nodes []dto.OrganizationTemplateNode, path, parentType string) error {
+		for i, node := range nodes {
+			nodePath := fmt.Sprintf("%s[%d]", path, i)
+			orgType, ok := orgTypes[node.OrganizationType]
+			if !ok {
+				return apperror.NewValidationError(fmt.Sprintf("%s: invalid organization type '%s'", nodePath, node.OrganizationType))
+			}
+			if parentType != "" && !orgType.AllowsParent(parentType) {
+				return apperror.NewValidationError(fmt.Sprintf("%s: a %s cannot be placed under a %s", nodePath, orgType.Name, parentType))
+			}
+			if err := check(node.Children, nodePath+".children", orgType.Name); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := check(definition.Organizations, "organizations", ""); err != nil {
+		return "", err
+	}
+
+	serialized, err := json.Marshal(definition)
+	if err != nil {
+		return "", apperror.NewInternalError(fmt.Errorf("failed to serialize template definition: %w", err))
+	}
+	return string(serialized), nil
+}
+
+// planTemplate resolves the definition of an apply request into a plan. Problems with the template itself
+// are collected as issues; only infrastructure failures and an unusable parent organization return an error.
+func (s *organizationTemplateService) planTemplate(ctx context.Context, req dto.ApplyOrganizationTemplateRequest) (*organizationTemplatePlanner, error) {
+	var definition dto.OrganizationTemplateDefinition
+	if req.TemplateID != nil {
+		template, err := s.findTemplate(ctx, *req.TemplateID)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(template.Definition), &definition); err != nil {
+			return nil, apperror.NewInternalError(fmt.Errorf("failed to read template definition: %w", err))
+		}
+	} else {
+		definition = *req.Definition
+	}
+
+	if count := countTemplateOrganizations(definition.Organizations); count > maxTemplateOrganizations {
+		return nil, apperror.NewValidationError(fmt.Sprintf("A template may create at most %d organizations, this one creates %d", maxTemplateOrganizations, count))
+	}
+
+	orgTypes, err := loadOrganizationTypes(ctx, s.orgTypeRepo)
+	if err != nil {
+		return nil, apperror.NewInternalError(err)
+	}
+
+	planner := &organizationTemplatePlanner{
+		service:       s,
+		variables:     req.Variables,
+		orgTypes:      orgTypes,
+		users:         make(map[string]*model.User),
+		defaultRoles:  make(map[string]*organizationDefaultRoles),
+		eligibleRoles: make(map[string][]model.Role),
+	}
+
+	parentType, depth := "", 0
+	if req.ParentOrganizationID != nil {
+		parent, err := s.orgRepo.FindByID(ctx, *req.ParentOrganizationID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, apperror.NewNotFoundError("parent organization")
+			}
+			return nil, apperror.NewInternalError(fmt.Errorf("failed to find parent organization: %w", err))
+		}
+		if err := ensureOrganizationNotArchived(parent); err != nil {
+			return nil, err
+		}
+		parentDepth, err := s.orgRepo.GetDepth(ctx, parent.ID)
+		if err != nil {
+			return nil, apperror.NewInternalError(fmt.Errorf("failed to calculate parent depth: %w", err))
+		}
+		parentType, depth = parent.OrganizationType, parentDepth+1
+	}
+
+	for i, node := range definition.Organizations {
+		if err := planner.addNode(ctx, node, fmt.Sprintf("organizations[%d]", i), "", -1, parentType, depth); err != nil {
+			return nil, err
+		}
+	}
+	return planner, nil
+}
+
+// organizationTemplatePlanner accumulates the plan of a template while walking its tree
+type organizationTemplatePlanner struct {
+	service       *organizationTemplateService
+	variables     map[string]string
+	orgTypes      map[string]*model.OrganizationType
+	users         map[string]*model.User // Resolved member references; nil marks an unknown user
+	defaultRoles  map[string]*organizationDefaultRoles
+	eligibleRoles map[string][]model.Role
+
+	entries       []dto.OrganizationTemplatePlanEntry // Parents always precede their children
+	parentIndexes []int                               // Index of the parent entry, -1 for template roots
+	issues        []dto.OrganizationTemplateIssue
+}
+
+// addNode plans a template node and its children
+func (p *organizationTemplatePlanner) addNode(ctx context.Context, node dto.OrganizationTemplateNode, path, parentPath string, parentIndex int, parentType string, depth int) error {
+	entry := dto.OrganizationTemplatePlanEntry{
+		Path:             path,
+		ParentPath:       parentPath,
+		Depth:            depth,
+		Name:             p.substitute(node.Name, path+".name"),
+		OrganizationType: node.OrganizationType,
+		Description:      p.substitute(node.Description, path+".description"),
+	}
+	if len(entry.Name) > 255 {
+		p.addIssue(path+".name", "Name is longer than 255 characters after substituting variables")
+	}
+
+	orgType, ok := p.orgTypes[node.OrganizationType]
+	switch {
+	case !ok:
+		p.addIssue(path+".type", fmt.Sprintf("Invalid organization type '%s'", node.OrganizationType))
+	case !orgType.IsActive:
+		p.addIssue(path+".type", fmt.Sprintf("Organization type '%s' is not active", node.OrganizationType))
+	case !orgType.AllowsParent(parentType):
+		if parentType == "" {
+			p.addIssue(path, fmt.Sprintf("A %s must have a parent organization", orgType.Name))
+		} else {
+			p.addIssue(path, fmt.Sprintf("A %s cannot be placed under a %s", orgType.Name, parentType))
+		}
+	case !orgType.AllowsDepth(depth):
+		p.addIssue(path, fmt.Sprintf("A %s cannot be placed deeper than level %d", orgType.Name, *orgType.MaxDepth))
+	}
+
+	seenUsers := make(map[uuid.UUID]bool, len(node.Members))
+	for i, member := range node.Members {
+		memberPath := fmt.Sprintf("%s.members[%d]", path, i)
+		planned, err := p.planMember(ctx, member, memberPath, node.OrganizationType, ok)
+		if err != nil {
+			return err
+		}
+		if planned == nil {
+			continue
+		}
+		if seenUsers[*planned.UserID] {
+			p.addIssue(memberPath, fmt.Sprintf("User %s is assigned more than once", planned.Username))
+			continue
+		}
+		seenUsers[*planned.UserID] = true
+		entry.Members = append(entry.Members, *planned)
+	}
+
+	p.entries = append(p.entries, entry)
+	p.parentIndexes = append(p.parentIndexes, parentIndex)
+	index := len(p.entries) - 1
+
+	for i, child := range node.Children {
+		if err := p.addNode(ctx, child, fmt.Sprintf("%s.children[%d]", path, i), path, index, node.OrganizationType, depth+1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// planMember resolves the user and role of a member assignment; it returns nil when an issue was recorded
+func (p *organizationTemplatePlanner) planMember(ctx context.Context, member dto.OrganizationTemplateMember, path, organizationType string, knownType bool) (*dto.OrganizationTemplatePlanMember, error) {
+	reference := strings.TrimSpace(p.substitute(member.User, path+".user"))
+	user, err := p.resolveUser(ctx, reference)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		p.addIssue(path+".user", fmt.Sprintf("User '%s' not found", reference))
+		return nil, nil
+	}
+
+	planned := &dto.OrganizationTemplatePlanMember{
+		User:     reference,
+		UserID:   &user.ID,
+		Username: user.Username,
+	}
+	if !knownType {
+		return planned, nil
+	}
+
+	if member.Role == "" {
+		defaults, ok := p.defaultRoles[organizationType]
+		if !ok {
+			defaults, err = resolveOrganizationDefaultRoles(ctx, p.service.roleRepo, organizationType)
+			if err != nil {
+				return nil, apperror.NewInternalError(fmt.Errorf("failed to resolve default member role: %w", err))
+			}
+			p.defaultRoles[organizationType] = defaults
+		}
+		if defaults.Member != nil {
+			planned.RoleID = &defaults.Member.ID
+			planned.RoleName = defaults.Member.Name
+		}
+		return planned, nil
+	}
+
+	eligible, ok := p.eligibleRoles[organizationType]
+	if !ok {
+		eligible, err = p.service.roleRepo.FindRolesByOrganizationType(ctx, organizationType)
+		if err != nil {
+			return nil, apperror.NewInternalError(fmt.Errorf("failed to fetch roles of organization type: %w", err))
+		}
+		p.eligibleRoles[organizationType] = eligible
+	}
+	for i := range eligible {
+		if strings.EqualFold(eligible[i].Name, member.Role) {
+			planned.RoleID = &eligible[i].ID
+			planned.RoleName = eligible[i].Name
+			return planned, nil
+		}
+	}
+	p.addIssue(path+".role", fmt.Sprintf("Role '%s' cannot be assigned in a %s", member.Role, organizationType))
+	return nil, nil
+}
+
+// resolveUser finds a user by ID, email or username, caching the result per reference
+func (p *organizationTemplatePlanner) resolveUser(ctx context.Context, reference string) (*model.User, error) {
+	if user, ok := p.users[reference]; ok {
+		return user, nil
+	}
+
+	var user *model.User
+	var err error
+	if id, parseErr := uuid.Parse(reference); parseErr == nil {
+		user, err = p.service.userRepo.FindByID(ctx, id)
+	} else if strings.Contains(reference, "@") {
+		user, err = p.service.userRepo.FindByEmail(ctx, reference)
+	} else {
+		user, err = p.service.userRepo.FindByUsername(ctx, reference)
+	}
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.NewInternalError(fmt.Errorf("failed to find user: %w", err))
+		}
+		user = nil
+	}
+
+	p.users[reference] = user
+	return user, nil
+}
+
+// substitute fills in {{variable}} placeholders, recording an issue for every variable without a value
+func (p *organizationTemplatePlanner) substitute(value, path string) string {
+	return templateVariablePattern.ReplaceAllStringFunc(value, func(placeholder string) string {
+		name := templateVariablePattern.FindStringSubmatch(placeholder)[1]
+		replacement, ok := p.variables[name]
+		if !ok {
+			p.addIssue(path, fmt.Sprintf("Variable '%s' is not provided", name))
+			return placeholder
+		}
+		return replacement
+	})
+}
+
+// addIssue records a problem found at path
+func (p *organizationTemplatePlanner) addIssue(path, message string) {
+	p.issues = append(p.issues, dto.OrganizationTemplateIssue{Path: path, Message: message})
+}
+
+// response builds the plan response from the planned entries
+func (p *organizationTemplatePlanner) response() *dto.OrganizationTemplatePlanResponse {
+	memberCount := 0
+	for _, entry := range p.entries {
+		memberCount += len(entry.Members)
+	}
+	return &dto.OrganizationTemplatePlanResponse{
+		Valid:             len(p.issues) == 0,
+		OrganizationCount: len(p.entries),
+		MemberCount:       memberCount,
+		Organizations:     p.entries,
+		Issues:            p.issues,
+	}
+}
+
+// buildOrganizationTemplateResponse maps a saved template to its response, listing the variables it uses
+func buildOrganizationTemplateResponse(template *model.OrganizationTemplate) (*dto.OrganizationTemplateResponse, error) {
+	var definition dto.OrganizationTemplateDefinition
+	if err := json.Unmarshal([]byte(template.Definition), &definition); err != nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to read template definition: %w", err))
+	}
+
+	variables := make(map[string]bool)
+	for _, match := range templateVariablePattern.FindAllStringSubmatch(template.Definition, -1) {
+		variables[match[1]] = true
+	}
+	names := make([]string, 0, len(variables))
+	for name := range variables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return &dto.OrganizationTemplateResponse{
+		ID:                template.ID,
+		Name:              template.Name,
+		Description:       template.Description,
+		Definition:        definition,
+		Variables:         names,
+		OrganizationCount: countTemplateOrganizations(definition.Organizations),
+		CreatedBy:         template.CreatedBy,
+		UpdatedBy:         template.UpdatedBy,
+		CreatedAt:         template.CreatedAt,
+		UpdatedAt:         template.UpdatedAt,
+	}, nil
+}
+
+// countTemplateOrganizations counts the nodes of a template tree
+func countTemplateOrganizations(nodes []dto.OrganizationTemplateNode) int {
+	count := len(nodes)
+	for _, node := range nodes {
+		count += countTemplateOrganizations(node.Children)
+	}
+	return count
+}
@@ -0,0 +1,24 @@
+package service
+
+import (
+	"context"
+	"go-base-project/internal/dto"
+
+	"github.com/google/uuid"
+)
+
+// OrganizationTemplateServiceInterface defines the business logic for organization structure templates
+type OrganizationTemplateServiceInterface interface {
+	// Saved templates
+	ListTemplates(ctx context.Context) ([]dto.OrganizationTemplateResponse, error)
+	GetTemplate(ctx context.Context, id uuid.UUID) (*dto.OrganizationTemplateResponse, error)
+	CreateTemplate(ctx context.Context, req dto.CreateOrganizationTemplateRequest, createdBy uuid.UUID) (*dto.OrganizationTemplateResponse, error)
+	UpdateTemplate(ctx context.Context, id uuid.UUID, req dto.UpdateOrganizationTemplateRequest, updatedBy uuid.UUID) (*dto.OrganizationTemplateResponse, error)
+	DeleteTemplate(ctx context.Context, id uuid.UUID) error
+
+	// ValidateTemplate is the dry run of ApplyTemplate: it resolves the template and reports every issue
+	// without creating anything.
+	ValidateTemplate(ctx context.Context, req dto.ApplyOrganizationTemplateRequest) (*dto.OrganizationTemplatePlanResponse, error)
+	// ApplyTemplate creates all organizations and memberships of a template in a single transaction.
+	ApplyTemplate(ctx context.Context, req dto.ApplyOrganizationTemplateRequest, appliedBy uuid.UUID) (*dto.OrganizationTemplatePlanResponse, error)
+}
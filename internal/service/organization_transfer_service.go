@@ -0,0 +1,842 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-base-project/internal/apperror"
+	"go-base-project/internal/constant"
+	"go-base-project/internal/dto"
+	"go-base-project/internal/model"
+	"go-base-project/internal/repository"
+	"go-base-project/internal/util"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+// organizationExportVersion is the version of the export document format written and accepted
+const organizationExportVersion = 1
+
+// maxImportOrganizations bounds the number of organizations a single import may contain
+const maxImportOrganizations = 1000
+
+// organizationTransferService implements OrganizationTransferServiceInterface.
+type organizationTransferService struct {
+	orgRepo           repository.OrganizationRepositoryInterface
+	userRepo          repository.UserRepositoryInterface
+	membershipRepo    repository.MembershipRepositoryInterface
+	roleRepo          repository.RoleRepositoryInterface
+	orgTypeRepo       repository.OrganizationTypeRepositoryInterface
+	settingRepo       repository.OrganizationSettingRepositoryInterface
+	txManager         repository.TransactionManagerInterface
+	settingService    OrganizationSettingServiceInterface
+	quotaService      OrganizationQuotaServiceInterface
+	membershipService MembershipServiceInterface
+	codeGenerator     *util.OrganizationCodeGenerator
+}
+
+// NewOrganizationTransferService creates a new instance of organizationTransferService.
+func NewOrganizationTransferService(
+	orgRepo repository.OrganizationRepositoryInterface,
+	userRepo repository.UserRepositoryInterface,
+	membershipRepo repository.MembershipRepositoryInterface,
+	roleRepo repository.RoleRepositoryInterface,
+	orgTypeRepo repository.OrganizationTypeRepositoryInterface,
+	settingRepo repository.OrganizationSettingRepositoryInterface,
+	txManager repository.TransactionManagerInterface,
+	settingService OrganizationSettingServiceInterface,
+	quotaService OrganizationQuotaServiceInterface,
+	membershipService MembershipServiceInterface,
+	codeGenerator *util.OrganizationCodeGenerator,
+) OrganizationTransferServiceInterface {
+	return &organizationTransferService{
+		orgRepo:           orgRepo,
+		userRepo:          userRepo,
+		membershipRepo:    membershipRepo,
+		roleRepo:          roleRepo,
+		orgTypeRepo:       orgTypeRepo,
+		settingRepo:       settingRepo,
+		txManager:         txManager,
+		settingService:    settingService,
+		quotaService:      quotaService,
+		membershipService: membershipService,
+		codeGenerator:     codeGenerator,
+	}
+}
+
+// ExportOrganizationTree builds the export document of the subtree rooted at rootID.
+func (s *organizationTransferService) ExportOrganizationTree(ctx context.Context, rootID uuid.UUID) (*dto.OrganizationExportDocument, error) {
+	if _, err := s.orgRepo.FindByID(ctx, rootID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.NewNotFoundError("organization")
+		}
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to find organization: %w", err))
+	}
+
+	// The hierarchy lists the root first and descendants by depth, so parents precede their children
+	hierarchy, err := s.orgRepo.GetOrganizationHierarchy(ctx, rootID)
+	if err != nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to load organization hierarchy: %w", err))
+	}
+
+	orgIDs := make([]uuid.UUID, len(hierarchy))
+	for i, org := range hierarchy {
+		orgIDs[i] = org.ID
+	}
+
+	memberships, err := s.membershipRepo.FindByOrganizations(ctx, orgIDs)
+	if err != nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to load memberships: %w", err))
+	}
+	membersByOrg := make(map[uuid.UUID][]dto.OrganizationExportMember)
+	for _, membership := range memberships {
+		member := dto.OrganizationExportMember{
+			Email:    membership.User.Email,
+			Username: membership.User.Username,
+			IsActive: membership.IsActive,
+		}
+		if membership.Role != nil {
+			member.Role = membership.Role.Name
+		}
+		membersByOrg[membership.OrganizationID] = append(membersByOrg[membership.OrganizationID], member)
+	}
+
+	settings, err := s.settingRepo.FindByOrganizations(ctx, orgIDs)
+	if err != nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to load organization settings: %w", err))
+	}
+	settingsByOrg := make(map[uuid.UUID]map[string]json.RawMessage)
+	for _, setting := range settings {
+		if settingsByOrg[setting.OrganizationID] == nil {
+			settingsByOrg[setting.OrganizationID] = make(map[string]json.RawMessage)
+		}
+		settingsByOrg[setting.OrganizationID][setting.Key] = json.RawMessage(setting.Value)
+	}
+
+	document := &dto.OrganizationExportDocument{
+		Version:            organizationExportVersion,
+		ExportedAt:         time.Now().UTC(),
+		RootOrganizationID: rootID,
+		Organizations:      make([]dto.OrganizationExportEntry, 0, len(hierarchy)),
+	}
+	for _, org := range hierarchy {
+		entry := dto.OrganizationExportEntry{
+			ID:               org.ID,
+			Name:             org.Name,
+			Code:             org.Code,
+			OrganizationType: org.OrganizationType,
+			Description:      org.Description,
+			IsActive:         org.IsActive,
+			Settings:         settingsByOrg[org.ID],
+			Members:          membersByOrg[org.ID],
+		}
+		// The parent of the root lies outside the export
+		if org.ID != rootID {
+			entry.ParentID = org.ParentOrganizationID
+		}
+		document.Organizations = append(document.Organizations, entry)
+	}
+	return document, nil
+}
+
+// ExportOrganizationTreeCSV flattens the export document to CSV. Organization columns are repeated on every
+// membership row; organizations without members get a single row with empty member columns.
+func (s *organizationTransferService) ExportOrganizationTreeCSV(ctx context.Context, rootID uuid.UUID) ([]byte, error) {
+	document, err := s.ExportOrganizationTree(ctx, rootID)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	rows := [][]string{{
+		"organization_id", "parent_organization_id", "name", "code", "type", "description", "is_active",
+		"settings", "member_email", "member_username", "member_role", "member_is_active",
+	}}
+	for _, entry := range document.Organizations {
+		parentID := ""
+		if entry.ParentID != nil {
+			parentID = entry.ParentID.String()
+		}
+		settings := ""
+		if len(entry.Settings) > 0 {
+			encoded, err := json.Marshal(entry.Settings)
+			if err != nil {
+				return nil, apperror.NewInternalError(fmt.Errorf("failed to encode settings: %w", err))
+			}
+			settings = string(encoded)
+		}
+		orgColumns := []string{
+			entry.ID.String(), parentID, entry.Name, entry.Code, entry.OrganizationType, entry.Description,
+			strconv.FormatBool(entry.IsActive), settings,
+		}
+
+		if len(entry.Members) == 0 {
+			rows = append(rows, append(orgColumns, "", "", "", ""))
+			continue
+		}
+		for _, member := range entry.Members {
+			row := append(append([]string{}, orgColumns...), member.Email, member.Username, member.Role, strconv.FormatBool(member.IsActive))
+			rows = append(rows, row)
+		}
+	}
+
+	if err := writer.WriteAll(rows); err != nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to write CSV export: %w", err))
+	}
+	return buf.Bytes(), nil
+}
+
+// ImportOrganizationTree plans the import of a document and applies it unless a dry run was requested
+// or the plan has errors.
+func (s *organizationTransferService) ImportOrganizationTree(ctx context.Context, req dto.ImportOrganizationTreeRequest, importedBy uuid.UUID) (*dto.OrganizationImportReport, error) {
+	planner, err := s.planImport(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	report := planner.report()
+	report.DryRun = req.DryRun
+	if req.DryRun || len(report.Errors) > 0 {
+		return report, nil
+	}
+
+	err = s.txManager.RunInTx(ctx, func(ctx context.Context) error {
+		for i := range planner.steps {
+			if err := s.applyImportStep(ctx, planner, i, req.ParentOrganizationID, importedBy); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	report = planner.report()
+	report.Applied = true
+
+	log.Info().
+		Str("imported_by", importedBy.String()).
+		Int("created", report.Created+report.Renamed).
+		Int("updated", report.Updated).
+		Int("skipped", report.Skipped).
+		Msg("Organization tree imported")
+	return report, nil
+}
+
+// applyImportStep creates or updates the organization of one planned step, then its members and settings.
+func (s *organizationTransferService) applyImportStep(ctx context.Context, planner *organizationImportPlanner, index int, rootParentID *uuid.UUID, importedBy uuid.UUID) error {
+	step := &planner.steps[index]
+	entry := &planner.entries[index]
+
+	parentID := rootParentID
+	if step.parentIndex >= 0 {
+		parentID = planner.entries[step.parentIndex].TargetID
+	}
+
+	var orgID uuid.UUID
+	switch entry.Action {
+	case constant.OrganizationImportActionSkip:
+		return nil
+	case constant.OrganizationImportActionUpdate:
+		org := step.existing
+		org.Name = step.source.Name
+		org.Description = step.source.Description
+		org.IsActive = step.source.IsActive
+		org.ParentOrganization = nil
+		org.ChildOrganizations = nil
+		if _, err := s.orgRepo.Update(ctx, org); err != nil {
+			return apperror.NewInternalError(fmt.Errorf("failed to update organization %s: %w", org.Code, err))
+		}
+		orgID = org.ID
+	default:
+		if parentID != nil {
+			if err := reserveChildOrganization(ctx, s.orgRepo, s.quotaService, *parentID); err != nil {
+				return err
+			}
+		}
+		org := &model.Organization{
+			Name:                 step.source.Name,
+			Code:                 step.source.Code,
+			OrganizationType:     step.source.OrganizationType,
+			ParentOrganizationID: parentID,
+			Description:          step.source.Description,
+			CreatedBy:            importedBy,
+			IsActive:             step.source.IsActive,
+		}
+
+		var created *model.Organization
+		var err error
+		if entry.Action == constant.OrganizationImportActionRename {
+			created, err = createOrganizationWithGeneratedCode(ctx, s.orgRepo, s.codeGenerator, org)
+		} else {
+			created, err = s.orgRepo.Create(ctx, org)
+			if errors.Is(err, repository.ErrOrganizationCodeTaken) {
+				return apperror.NewConflictError(fmt.Sprintf("Organization code %s was taken while importing, please retry", org.Code))
+			}
+		}
+		if err != nil {
+			return apperror.NewInternalError(fmt.Errorf("failed to create organization %s: %w", step.source.Name, err))
+		}
+		orgID = created.ID
+		entry.Code = created.Code
+	}
+	entry.TargetID = &orgID
+
+	if err := s.applyImportMembers(ctx, step, orgID, importedBy); err != nil {
+		return err
+	}
+
+	if len(step.settings) > 0 {
+		settingsReq := dto.UpdateOrganizationSettingsRequest{Settings: step.settings}
+		if _, err := s.settingService.UpdateSettings(ctx, orgID, settingsReq, importedBy); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyImportMembers adds the new memberships of a step and updates the changed ones, recording history.
+func (s *organizationTransferService) applyImportMembers(ctx context.Context, step *organizationImportStep, orgID uuid.UUID, importedBy uuid.UUID) error {
+	const reason = "Imported organization tree"
+	for _, member := range step.members {
+		if member.existing == nil {
+			if _, err := s.membershipService.AddMember(ctx, dto.AssignUserToOrganizationRequest{
+				UserID:         member.userID,
+				OrganizationID: orgID,
+				RoleID:         member.roleID,
+				IsActive:       member.isActive,
+				Reason:         reason,
+			}, importedBy); err != nil {
+				return err
+			}
+			continue
+		}
+
+		update := dto.UpdateUserOrganizationRequest{RoleID: member.roleID, IsActive: member.isActive, Reason: reason}
+		if _, err := s.membershipService.UpdateMember(ctx, member.userID, orgID, update, importedBy); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// planImport checks the structure of the document and resolves every organization against the target
+// environment. Problems with individual organizations are collected in the planner; only a malformed
+// document, an unusable parent organization and infrastructure failures return an error.
+func (s *organizationTransferService) planImport(ctx context.Context, req dto.ImportOrganizationTreeRequest) (*organizationImportPlanner, error) {
+	document := req.Document
+	if document.Version != organizationExportVersion {
+		return nil, apperror.NewValidationError(fmt.Sprintf("Unsupported export version %d, expected %d", document.Version, organizationExportVersion))
+	}
+	if len(document.Organizations) > maxImportOrganizations {
+		return nil, apperror.NewValidationError(fmt.Sprintf("An import may contain at most %d organizations, this one contains %d", maxImportOrganizations, len(document.Organizations)))
+	}
+
+	// Every organization except the root must follow its parent
+	indexes := make(map[uuid.UUID]int, len(document.Organizations))
+	parentIndexes := make([]int, len(document.Organizations))
+	for i, entry := range document.Organizations {
+		if _, ok := indexes[entry.ID]; ok {
+			return nil, apperror.NewValidationError(fmt.Sprintf("Organization %s appears more than once in the document", entry.ID))
+		}
+		indexes[entry.ID] = i
+
+		if i == 0 {
+			if entry.ID != document.RootOrganizationID {
+				return nil, apperror.NewValidationError("The first organization of the document must be its root organization")
+			}
+			parentIndexes[i] = -1
+			continue
+		}
+		if entry.ParentID == nil {
+			return nil, apperror.NewValidationError(fmt.Sprintf("Organization %s has no parent; only the root may have none", entry.ID))
+		}
+		parentIndex, ok := indexes[*entry.ParentID]
+		if !ok {
+			return nil, apperror.NewValidationError(fmt.Sprintf("The parent of organization %s must be listed before it", entry.ID))
+		}
+		parentIndexes[i] = parentIndex
+	}
+
+	orgTypes, err := loadOrganizationTypes(ctx, s.orgTypeRepo)
+	if err != nil {
+		return nil, apperror.NewInternalError(err)
+	}
+
+	planner := &organizationImportPlanner{
+		service:       s,
+		strategy:      req.ConflictStrategy,
+		orgTypes:      orgTypes,
+		users:         make(map[string]*model.User),
+		defaultRoles:  make(map[string]*organizationDefaultRoles),
+		eligibleRoles: make(map[string][]model.Role),
+	}
+
+	rootParent := &importTargetParent{}
+	if req.ParentOrganizationID != nil {
+		parent, err := s.orgRepo.FindByID(ctx, *req.ParentOrganizationID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, apperror.NewNotFoundError("parent organization")
+			}
+			return nil, apperror.NewInternalError(fmt.Errorf("failed to find parent organization: %w", err))
+		}
+		if err := ensureOrganizationNotArchived(parent); err != nil {
+			return nil, err
+		}
+		parentDepth, err := s.orgRepo.GetDepth(ctx, parent.ID)
+		if err != nil {
+			return nil, apperror.NewInternalError(fmt.Errorf("failed to calculate parent depth: %w", err))
+		}
+		rootParent = &importTargetParent{id: &parent.ID, organizationType: parent.OrganizationType, depth: parentDepth}
+	}
+
+	for i := range document.Organizations {
+		parent := rootParent
+		if parentIndexes[i] >= 0 {
+			parent = planner.steps[parentIndexes[i]].target
+		}
+		if err := planner.addOrganization(ctx, &document.Organizations[i], parentIndexes[i], parent); err != nil {
+			return nil, err
+		}
+	}
+	return planner, nil
+}
+
+// importTargetParent describes where an organization ends up in the target environment; id is nil for
+// organizations that do not exist yet or for the top level.
+type importTargetParent struct {
+	id               *uuid.UUID
+	organizationType string
+	depth            int
+	exists           bool // The organization exists in the target environment
+	archived         bool
+}
+
+// organizationImportStep is the resolved plan for one organization of the document.
+type organizationImportStep struct {
+	source      *dto.OrganizationExportEntry
+	parentIndex int // Index of the parent step, -1 for the document root
+	existing    *model.Organization
+	target      *importTargetParent // Position of this organization for its children
+	members     []organizationImportMember
+	settings    map[string]json.RawMessage // Settings request; null values remove an override
+}
+
+// organizationImportMember is a resolved membership to add or update.
+type organizationImportMember struct {
+	userID   uuid.UUID
+	username string
+	roleID   *uuid.UUID
+	roleName string
+	isActive bool
+	existing *model.UserOrganization // Current membership in the target organization, nil to add one
+}
+
+// organizationImportPlanner accumulates the plan and the report of an import.
+type organizationImportPlanner struct {
+	service       *organizationTransferService
+	strategy      string
+	orgTypes      map[string]*model.OrganizationType
+	users         map[string]*model.User // Resolved member references; nil marks an unknown user
+	defaultRoles  map[string]*organizationDefaultRoles
+	eligibleRoles map[string][]model.Role
+
+	steps    []organizationImportStep
+	entries  []dto.OrganizationImportEntry
+	warnings []dto.OrganizationImportIssue
+	errors   []dto.OrganizationImportIssue
+}
+
+// addOrganization resolves the action for one organization of the document and its changes.
+func (p *organizationImportPlanner) addOrganization(ctx context.Context, source *dto.OrganizationExportEntry, parentIndex int, parent *importTargetParent) error {
+	sourceID := source.ID
+	step := organizationImportStep{source: source, parentIndex: parentIndex}
+	entry := dto.OrganizationImportEntry{
+		SourceID:   source.ID,
+		Name:       source.Name,
+		SourceCode: source.Code,
+		Action:     constant.OrganizationImportActionCreate,
+	}
+
+	code := p.service.codeGenerator.Normalize(source.Code)
+	existing, err := p.service.orgRepo.FindByCode(ctx, code)
+	switch {
+	case err == nil:
+		step.existing = existing
+		switch p.strategy {
+		case constant.OrganizationImportConflictSkip:
+			entry.Action = constant.OrganizationImportActionSkip
+		case constant.OrganizationImportConflictOverwrite:
+			entry.Action = constant.OrganizationImportActionUpdate
+		default:
+			entry.Action = constant.OrganizationImportActionRename
+			step.existing = nil
+		}
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		// Codes of deleted organizations stay reserved until they are purged
+		taken, err := p.service.orgRepo.CheckCodeExists(ctx, code)
+		if err != nil {
+			return apperror.NewInternalError(fmt.Errorf("failed to check organization code: %w", err))
+		}
+		if taken {
+			entry.Action = constant.OrganizationImportActionRename
+			p.addWarning(sourceID, fmt.Sprintf("Code %s belongs to a deleted organization; a new code is generated", code))
+		}
+	default:
+		return apperror.NewInternalError(fmt.Errorf("failed to find organization by code: %w", err))
+	}
+
+	if parent.archived && entry.Action != constant.OrganizationImportActionSkip {
+		p.addError(sourceID, "The parent organization is archived")
+	}
+
+	organizationType := source.OrganizationType
+	if step.existing != nil {
+		entry.TargetID = &step.existing.ID
+		entry.Code = step.existing.Code
+		organizationType = step.existing.OrganizationType
+
+		depth, err := p.service.orgRepo.GetDepth(ctx, step.existing.ID)
+		if err != nil {
+			return apperror.NewInternalError(fmt.Errorf("failed to calculate organization depth: %w", err))
+		}
+		step.target = &importTargetParent{
+			id:               &step.existing.ID,
+			organizationType: step.existing.OrganizationType,
+			depth:            depth,
+			exists:           true,
+			archived:         step.existing.IsArchived(),
+		}
+
+		if !uuidPtrEqual(step.existing.ParentOrganizationID, parent.id) {
+			p.addWarning(sourceID, fmt.Sprintf("Organization %s exists under a different parent and is not moved", step.existing.Code))
+		}
+		if step.existing.OrganizationType != source.OrganizationType {
+			p.addWarning(sourceID, fmt.Sprintf("Organization %s is a %s, the document's type %s is ignored", step.existing.Code, step.existing.OrganizationType, source.OrganizationType))
+		}
+	} else {
+		if entry.Action == constant.OrganizationImportActionCreate {
+			entry.Code = code
+			if !p.service.codeGenerator.Valid(code) {
+				p.addWarning(sourceID, fmt.Sprintf("Code %s does not match the code format of this environment; it is kept as is", code))
+			}
+		}
+		depth := 0
+		if parent.id != nil || parent.organizationType != "" {
+			depth = parent.depth + 1
+		}
+		step.target = &importTargetParent{organizationType: source.OrganizationType, depth: depth}
+		p.checkPlacement(sourceID, source.OrganizationType, parent.organizationType, depth)
+	}
+
+	if entry.Action == constant.OrganizationImportActionSkip {
+		p.steps = append(p.steps, step)
+		p.entries = append(p.entries, entry)
+		return nil
+	}
+
+	if step.existing != nil {
+		if step.existing.IsArchived() {
+			p.addError(sourceID, fmt.Sprintf("Organization %s is archived and cannot be overwritten", step.existing.Code))
+		}
+		entry.Changes = append(entry.Changes, diffImportField("name", step.existing.Name, source.Name)...)
+		entry.Changes = append(entry.Changes, diffImportField("description", step.existing.Description, source.Description)...)
+		entry.Changes = append(entry.Changes, diffImportField("is_active", strconv.FormatBool(step.existing.IsActive), strconv.FormatBool(source.IsActive))...)
+	}
+
+	if _, ok := p.orgTypes[organizationType]; ok {
+		changes, err := p.planMembers(ctx, &step, organizationType)
+		if err != nil {
+			return err
+		}
+		entry.Changes = append(entry.Changes, changes...)
+	}
+
+	changes, err := p.planSettings(ctx, &step)
+	if err != nil {
+		return err
+	}
+	if step.existing != nil {
+		entry.Changes = append(entry.Changes, changes...)
+	}
+
+	p.steps = append(p.steps, step)
+	p.entries = append(p.entries, entry)
+	return nil
+}
+
+// checkPlacement validates a new organization against the organization type registry.
+func (p *organizationImportPlanner) checkPlacement(sourceID uuid.UUID, organizationType, parentType string, depth int) {
+	orgType, ok := p.orgTypes[organizationType]
+	switch {
+	case !ok:
+		p.addError(sourceID, fmt.Sprintf("Invalid organization type '%s'", organizationType))
+	case !orgType.IsActive:
+		p.addError(sourceID, fmt.Sprintf("Organization type '%s' is not active", organizationType))
+	case !orgType.AllowsParent(parentType):
+		if parentType == "" {
+			p.addError(sourceID, fmt.Sprintf("A %s must have a parent organization", orgType.Name))
+		} else {
+			p.addError(sourceID, fmt.Sprintf("A %s cannot be placed under a %s", orgType.Name, parentType))
+		}
+	case !orgType.AllowsDepth(depth):
+		p.addError(sourceID, fmt.Sprintf("A %s cannot be placed deeper than level %d", orgType.Name, *orgType.MaxDepth))
+	}
+}
+
+// planMembers resolves the members of a document organization. Unknown users are skipped and unknown roles
+// fall back to the member role of the type, both with a warning. Existing members missing from the document
+// are kept.
+func (p *organizationImportPlanner) planMembers(ctx context.Context, step *organizationImportStep, organizationType string) ([]dto.OrganizationImportChange, error) {
+	current := make(map[uuid.UUID]*model.UserOrganization)
+	if step.existing != nil {
+		memberships, err := p.service.membershipRepo.FindByOrganizations(ctx, []uuid.UUID{step.existing.ID})
+		if err != nil {
+			return nil, apperror.NewInternalError(fmt.Errorf("failed to load memberships: %w", err))
+		}
+		for i := range memberships {
+			current[memberships[i].UserID] = &memberships[i]
+		}
+	}
+
+	var changes []dto.OrganizationImportChange
+	seen := make(map[uuid.UUID]bool, len(step.source.Members))
+	for _, member := range step.source.Members {
+		reference := member.Email
+		if reference == "" {
+			reference = member.Username
+		}
+
+		user, err := p.resolveUser(ctx, member)
+		if err != nil {
+			return nil, err
+		}
+		if user == nil {
+			p.addWarning(step.source.ID, fmt.Sprintf("User '%s' not found; membership skipped", reference))
+			continue
+		}
+		if seen[user.ID] {
+			p.addWarning(step.source.ID, fmt.Sprintf("User '%s' is listed more than once; only the first membership is imported", reference))
+			continue
+		}
+		seen[user.ID] = true
+
+		role, err := p.resolveRole(ctx, step.source.ID, member.Role, organizationType)
+		if err != nil {
+			return nil, err
+		}
+
+		planned := organizationImportMember{
+			userID:   user.ID,
+			username: user.Username,
+			isActive: member.IsActive,
+			existing: current[user.ID],
+		}
+		if role != nil {
+			planned.roleID = &role.ID
+			planned.roleName = role.Name
+		}
+
+		field := "member:" + user.Username
+		if planned.existing == nil {
+			changes = append(changes, dto.OrganizationImportChange{Field: field, To: describeImportMembership(planned.roleName, planned.isActive)})
+		} else {
+			previousRole := ""
+			if planned.existing.Role != nil {
+				previousRole = planned.existing.Role.Name
+			}
+			if uuidPtrEqual(planned.existing.RoleID, planned.roleID) && planned.existing.IsActive == planned.isActive {
+				continue
+			}
+			changes = append(changes, dto.OrganizationImportChange{
+				Field: field,
+				From:  describeImportMembership(previousRole, planned.existing.IsActive),
+				To:    describeImportMembership(planned.roleName, planned.isActive),
+			})
+		}
+		step.members = append(step.members, planned)
+	}
+	return changes, nil
+}
+
+// planSettings validates the settings of a document organization against the settings schema. Existing
+// organizations are overwritten as a whole: their own values missing from the document are removed.
+func (p *organizationImportPlanner) planSettings(ctx context.Context, step *organizationImportStep) ([]dto.OrganizationImportChange, error) {
+	current := make(map[string]string)
+	if step.existing != nil {
+		settings, err := p.service.settingRepo.FindByOrganization(ctx, step.existing.ID)
+		if err != nil {
+			return nil, apperror.NewInternalError(fmt.Errorf("failed to load organization settings: %w", err))
+		}
+		for _, setting := range settings {
+			current[setting.Key] = setting.Value
+		}
+	}
+
+	keys := make([]string, 0, len(step.source.Settings))
+	for key := range step.source.Settings {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var changes []dto.OrganizationImportChange
+	step.settings = make(map[string]json.RawMessage)
+	for _, key := range keys {
+		definition, ok := findOrganizationSettingDefinition(key)
+		if !ok {
+			p.addError(step.source.ID, fmt.Sprintf("Unknown setting %s", key))
+			continue
+		}
+		value, err := definition.normalizeValue(step.source.Settings[key])
+		if err != nil {
+			p.addError(step.source.ID, fmt.Sprintf("Setting %s %s", key, err.Error()))
+			continue
+		}
+		previous, isSet := current[key]
+		if isSet && settingValuesEqual(previous, value) {
+			continue
+		}
+		step.settings[key] = json.RawMessage(value)
+		changes = append(changes, dto.OrganizationImportChange{Field: "settings." + key, From: previous, To: value})
+	}
+
+	for key, previous := range current {
+		if _, ok := step.source.Settings[key]; ok {
+			continue
+		}
+		step.settings[key] = nil
+		changes = append(changes, dto.OrganizationImportChange{Field: "settings." + key, From: previous})
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Field < changes[j].Field })
+	return changes, nil
+}
+
+// resolveUser finds the user of a membership by email, then username, caching the result per reference
+func (p *organizationImportPlanner) resolveUser(ctx context.Context, member dto.OrganizationExportMember) (*model.User, error) {
+	lookups := []struct {
+		reference string
+		find      func(context.Context, string) (*model.User, error)
+	}{
+		{strings.ToLower(strings.TrimSpace(member.Email)), p.service.userRepo.FindByEmail},
+		{strings.TrimSpace(member.Username), p.service.userRepo.FindByUsername},
+	}
+
+	for _, lookup := range lookups {
+		if lookup.reference == "" {
+			continue
+		}
+		user, ok := p.users[lookup.reference]
+		if !ok {
+			found, err := lookup.find(ctx, lookup.reference)
+			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, apperror.NewInternalError(fmt.Errorf("failed to find user: %w", err))
+			}
+			if err == nil {
+				user = found
+			}
+			p.users[lookup.reference] = user
+		}
+		if user != nil {
+			return user, nil
+		}
+	}
+	return nil, nil
+}
+
+// resolveRole finds a role by name among the roles of an organization type, falling back to its member role
+func (p *organizationImportPlanner) resolveRole(ctx context.Context, sourceID uuid.UUID, name, organizationType string) (*model.Role, error) {
+	if name != "" {
+		eligible, ok := p.eligibleRoles[organizationType]
+		if !ok {
+			var err error
+			eligible, err = p.service.roleRepo.FindRolesByOrganizationType(ctx, organizationType)
+			if err != nil {
+				return nil, apperror.NewInternalError(fmt.Errorf("failed to fetch roles of organization type: %w", err))
+			}
+			p.eligibleRoles[organizationType] = eligible
+		}
+		for i := range eligible {
+			if strings.EqualFold(eligible[i].Name, name) {
+				return &eligible[i], nil
+			}
+		}
+	}
+
+	defaults, ok := p.defaultRoles[organizationType]
+	if !ok {
+		var err error
+		defaults, err = resolveOrganizationDefaultRoles(ctx, p.service.roleRepo, organizationType)
+		if err != nil {
+			return nil, apperror.NewInternalError(fmt.Errorf("failed to resolve default member role: %w", err))
+		}
+		p.defaultRoles[organizationType] = defaults
+	}
+	if name != "" {
+		fallback := "no role"
+		if defaults.Member != nil {
+			fallback = defaults.Member.Name
+		}
+		p.addWarning(sourceID, fmt.Sprintf("Role '%s' cannot be assigned in a %s; %s is used instead", name, organizationType, fallback))
+	}
+	return defaults.Member, nil
+}
+
+func (p *organizationImportPlanner) addWarning(sourceID uuid.UUID, message string) {
+	p.warnings = append(p.warnings, dto.OrganizationImportIssue{SourceID: &sourceID, Message: message})
+}
+
+func (p *organizationImportPlanner) addError(sourceID uuid.UUID, message string) {
+	p.errors = append(p.errors, dto.OrganizationImportIssue{SourceID: &sourceID, Message: message})
+}
+
+// report summarizes the plan, including target IDs and codes filled in while applying
+func (p *organizationImportPlanner) report() *dto.OrganizationImportReport {
+	report := &dto.OrganizationImportReport{
+		Organizations: p.entries,
+		Warnings:      p.warnings,
+		Errors:        p.errors,
+	}
+	for _, entry := range p.entries {
+		switch entry.Action {
+		case constant.OrganizationImportActionCreate:
+			report.Created++
+		case constant.OrganizationImportActionRename:
+			report.Renamed++
+		case constant.OrganizationImportActionUpdate:
+			report.Updated++
+		case constant.OrganizationImportActionSkip:
+			report.Skipped++
+		}
+	}
+	return report
+}
+
+func diffImportField(field, from, to string) []dto.OrganizationImportChange {
+	if from == to {
+		return nil
+	}
+	return []dto.OrganizationImportChange{{Field: field, From: from, To: to}}
+}
+
+func describeImportMembership(roleName string, isActive bool) string {
+	if roleName == "" {
+		roleName = "no role"
+	}
+	if !isActive {
+		return roleName + " (inactive)"
+	}
+	return roleName
+}
@@ -0,0 +1,19 @@
+package service
+
+import (
+	"context"
+	"go-base-project/internal/dto"
+
+	"github.com/google/uuid"
+)
+
+// OrganizationTransferServiceInterface defines the export and import of organization subtrees between environments
+type OrganizationTransferServiceInterface interface {
+	// ExportOrganizationTree returns the subtree rooted at an organization with its memberships and settings.
+	ExportOrganizationTree(ctx context.Context, rootID uuid.UUID) (*dto.OrganizationExportDocument, error)
+	// ExportOrganizationTreeCSV flattens the export to CSV with one row per membership.
+	ExportOrganizationTreeCSV(ctx context.Context, rootID uuid.UUID) ([]byte, error)
+	// ImportOrganizationTree reports, and unless it is a dry run applies, the changes of an export document
+	// in a single transaction.
+	ImportOrganizationTree(ctx context.Context, req dto.ImportOrganizationTreeRequest, importedBy uuid.UUID) (*dto.OrganizationImportReport, error)
+}
@@ -0,0 +1,189 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go-base-project/internal/dto"
+	"go-base-project/internal/model"
+	"go-base-project/internal/repository"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// Value types of custom organization type attributes
+const (
+	attributeTypeString  = "string"
+	attributeTypeNumber  = "number"
+	attributeTypeBoolean = "boolean"
+)
+
+// defaultAttributeMaxLength bounds string attribute values without an explicit max length
+const defaultAttributeMaxLength = 255
+
+var attributeKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,49}$`)
+
+// buildOrganizationTypeAttributes validates a requested attribute schema and converts it into the stored rows.
+func buildOrganizationTypeAttributes(orgType string, requests []dto.OrganizationTypeAttributeRequest) ([]model.OrganizationTypeAttribute, error) {
+	attributes := make([]model.OrganizationTypeAttribute, 0, len(requests))
+	seen := make(map[string]bool, len(requests))
+	for i, req := range requests {
+		if !attributeKeyPattern.MatchString(req.Key) {
+			return nil, fmt.Errorf("attribute key %q must start with a lowercase letter and contain only lowercase letters, digits and underscores", req.Key)
+		}
+		if seen[req.Key] {
+			return nil, fmt.Errorf("attribute %q is defined more than once", req.Key)
+		}
+		seen[req.Key] = true
+
+		if req.Type != attributeTypeString && (len(req.AllowedValues) > 0 || req.Pattern != "" || req.MaxLength != nil) {
+			return nil, fmt.Errorf("attribute %q: allowed_values, pattern and max_length only apply to string attributes", req.Key)
+		}
+		if req.Pattern != "" {
+			if _, err := regexp.Compile(req.Pattern); err != nil {
+				return nil, fmt.Errorf("attribute %q: pattern is not a valid regular expression", req.Key)
+			}
+		}
+
+		attribute := model.OrganizationTypeAttribute{
+			OrganizationType: orgType,
+			Key:              req.Key,
+			DisplayName:      req.DisplayName,
+			Description:      req.Description,
+			Type:             req.Type,
+			Required:         req.Required,
+			Pattern:          req.Pattern,
+			MaxLength:        req.MaxLength,
+			Position:         i,
+		}
+		if len(req.AllowedValues) > 0 {
+			encoded, err := json.Marshal(req.AllowedValues)
+			if err != nil {
+				return nil, fmt.Errorf("attribute %q: allowed values could not be encoded: %w", req.Key, err)
+			}
+			allowedValues := string(encoded)
+			attribute.AllowedValues = &allowedValues
+		}
+		attributes = append(attributes, attribute)
+	}
+	return attributes, nil
+}
+
+// mapOrganizationTypeAttributeToResponse converts a stored attribute definition into its API representation.
+func mapOrganizationTypeAttributeToResponse(attribute *model.OrganizationTypeAttribute) dto.OrganizationTypeAttributeResponse {
+	return dto.OrganizationTypeAttributeResponse{
+		Key:           attribute.Key,
+		DisplayName:   attribute.DisplayName,
+		Description:   attribute.Description,
+		Type:          attribute.Type,
+		Required:      attribute.Required,
+		AllowedValues: attributeAllowedValues(attribute),
+		Pattern:       attribute.Pattern,
+		MaxLength:     attribute.MaxLength,
+	}
+}
+
+// normalizeMembershipAttributes validates attribute values against the schema of the organization type and
+// returns them as a compact JSON object. Null values are dropped, keys outside the schema are rejected.
+func normalizeMembershipAttributes(schema []model.OrganizationTypeAttribute, values map[string]json.RawMessage) (string, error) {
+	normalized := make(map[string]interface{}, len(values))
+	for key := range values {
+		if !slices.ContainsFunc(schema, func(attribute model.OrganizationTypeAttribute) bool { return attribute.Key == key }) {
+			return "", fmt.Errorf("attribute %q is not defined for this organization type", key)
+		}
+	}
+
+	for i := range schema {
+		attribute := &schema[i]
+		raw, ok := values[attribute.Key]
+		if !ok || bytes.Equal(bytes.TrimSpace(raw), []byte("null")) {
+			if attribute.Required {
+				return "", fmt.Errorf("attribute %q is required", attribute.Key)
+			}
+			continue
+		}
+
+		value, err := normalizeAttributeValue(attribute, raw)
+		if err != nil {
+			return "", fmt.Errorf("attribute %q %w", attribute.Key, err)
+		}
+		normalized[attribute.Key] = value
+	}
+
+	encoded, err := json.Marshal(normalized)
+	if err != nil {
+		return "", fmt.Errorf("attributes could not be encoded: %w", err)
+	}
+	return string(encoded), nil
+}
+
+// normalizeAttributeValue checks a single JSON value against its attribute definition.
+func normalizeAttributeValue(attribute *model.OrganizationTypeAttribute, raw json.RawMessage) (interface{}, error) {
+	switch attribute.Type {
+	case attributeTypeNumber:
+		// json.Number also accepts quoted numbers, which are strings here
+		var value json.Number
+		if bytes.HasPrefix(bytes.TrimSpace(raw), []byte(`"`)) || json.Unmarshal(raw, &value) != nil {
+			return nil, fmt.Errorf("must be a number")
+		}
+		return value, nil
+	case attributeTypeBoolean:
+		var value bool
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return nil, fmt.Errorf("must be a boolean")
+		}
+		return value, nil
+	default:
+		var value string
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return nil, fmt.Errorf("must be a string")
+		}
+		maxLength := defaultAttributeMaxLength
+		if attribute.MaxLength != nil {
+			maxLength = *attribute.MaxLength
+		}
+		if utf8.RuneCountInString(value) > maxLength {
+			return nil, fmt.Errorf("must not exceed %d characters", maxLength)
+		}
+		if allowed := attributeAllowedValues(attribute); len(allowed) > 0 && !slices.Contains(allowed, value) {
+			return nil, fmt.Errorf("must be one of %s", strings.Join(allowed, ", "))
+		}
+		if attribute.Pattern != "" {
+			pattern, err := regexp.Compile(attribute.Pattern)
+			if err != nil || !pattern.MatchString(value) {
+				return nil, fmt.Errorf("does not match the pattern %s", attribute.Pattern)
+			}
+		}
+		return value, nil
+	}
+}
+
+// attributeAllowedValues decodes the allowed values of a string attribute.
+func attributeAllowedValues(attribute *model.OrganizationTypeAttribute) []string {
+	if attribute.AllowedValues == nil {
+		return nil
+	}
+	var values []string
+	if err := json.Unmarshal([]byte(*attribute.AllowedValues), &values); err != nil {
+		return nil
+	}
+	return values
+}
+
+// parseUserAttributeFilters parses listing filters of the form "key:value".
+func parseUserAttributeFilters(filters []string) ([]repository.UserAttributeFilter, error) {
+	if len(filters) == 0 {
+		return nil, nil
+	}
+	parsed := make([]repository.UserAttributeFilter, 0, len(filters))
+	for _, filter := range filters {
+		key, value, ok := strings.Cut(filter, ":")
+		if !ok || !attributeKeyPattern.MatchString(key) {
+			return nil, fmt.Errorf("attribute filter %q must have the form key:value", filter)
+		}
+		parsed = append(parsed, repository.UserAttributeFilter{Key: key, Value: value})
+	}
+	return parsed, nil
+}
@@ -0,0 +1,273 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-base-project/internal/apperror"
+	"go-base-project/internal/dto"
+	"go-base-project/internal/model"
+	"go-base-project/internal/repository"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+// organizationTypeService implements OrganizationTypeServiceInterface.
+type organizationTypeService struct {
+	orgTypeRepo repository.OrganizationTypeRepositoryInterface
+	roleRepo    repository.RoleRepositoryInterface
+}
+
+// NewOrganizationTypeService creates a new instance of organizationTypeService.
+func NewOrganizationTypeService(orgTypeRepo repository.OrganizationTypeRepositoryInterface, roleRepo repository.RoleRepositoryInterface) OrganizationTypeServiceInterface {
+	return &organizationTypeService{
+		orgTypeRepo: orgTypeRepo,
+		roleRepo:    roleRepo,
+	}
+}
+
+// ListOrganizationTypes returns the registered organization types.
+func (s *organizationTypeService) ListOrganizationTypes(ctx context.Context, includeInactive bool) ([]dto.OrganizationTypeResponse, error) {
+	orgTypes, err := s.orgTypeRepo.FindAll(ctx, includeInactive)
+	if err != nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to fetch organization types: %w", err))
+	}
+
+	responses := make([]dto.OrganizationTypeResponse, 0, len(orgTypes))
+	for i := range orgTypes {
+		response, err := s.buildOrganizationTypeResponse(ctx, &orgTypes[i])
+		if err != nil {
+			return nil, err
+		}
+		responses = append(responses, *response)
+	}
+	return responses, nil
+}
+
+// GetOrganizationType returns a single registered organization type.
+func (s *organizationTypeService) GetOrganizationType(ctx context.Context, name string) (*dto.OrganizationTypeResponse, error) {
+	orgType, err := s.orgTypeRepo.FindByName(ctx, name)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.NewNotFoundError("organization type")
+		}
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to fetch organization type: %w", err))
+	}
+	return s.buildOrganizationTypeResponse(ctx, orgType)
+}
+
+// CreateOrganizationType registers a new organization type.
+func (s *organizationTypeService) CreateOrganizationType(ctx context.Context, req dto.CreateOrganizationTypeRequest, createdBy uuid.UUID) (*dto.OrganizationTypeResponse, error) {
+	exists, err := s.orgTypeRepo.Exists(ctx, req.Name)
+	if err != nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to check organization type: %w", err))
+	}
+	if exists {
+		return nil, apperror.NewConflictError(fmt.Sprintf("Organization type '%s' already exists", req.Name))
+	}
+
+	if err := s.validatePlacementRules(ctx, req.Name, req.AllowRoot, req.AllowedParentTypes); err != nil {
+		return nil, err
+	}
+
+	orgType := &model.OrganizationType{
+		Name:         req.Name,
+		DisplayName:  req.DisplayName,
+		Description:  req.Description,
+		AllowRoot:    req.AllowRoot,
+		MaxDepth:     req.MaxDepth,
+		MinRoleLevel: req.MinRoleLevel,
+		MaxRoleLevel: req.MaxRoleLevel,
+		IsActive:     true,
+	}
+	if err := s.orgTypeRepo.Create(ctx, orgType, req.AllowedParentTypes); err != nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to create organization type: %w", err))
+	}
+
+	if err := s.saveDefaultRoles(ctx, orgType.Name, req.CreatorRoleID, req.MemberRoleID, createdBy); err != nil {
+		return nil, err
+	}
+
+	log.Info().Str("organization_type", orgType.Name).Str("created_by", createdBy.String()).Msg("Organization type registered")
+	return s.GetOrganizationType(ctx, orgType.Name)
+}
+
+// UpdateOrganizationType updates the placement rules, level band and default roles of an organization type.
+// A type cannot be deactivated while organizations of that type exist.
+func (s *organizationTypeService) UpdateOrganizationType(ctx context.Context, name string, req dto.UpdateOrganizationTypeRequest, updatedBy uuid.UUID) (*dto.OrganizationTypeResponse, error) {
+	orgType, err := s.orgTypeRepo.FindByName(ctx, name)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.NewNotFoundError("organization type")
+		}
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to fetch organization type: %w", err))
+	}
+
+	if err := s.validatePlacementRules(ctx, name, req.AllowRoot, req.AllowedParentTypes); err != nil {
+		return nil, err
+	}
+
+	if orgType.IsActive && !req.IsActive {
+		count, err := s.orgTypeRepo.CountOrganizations(ctx, name)
+		if err != nil {
+			return nil, apperror.NewInternalError(fmt.Errorf("failed to count organizations: %w", err))
+		}
+		if count > 0 {
+			return nil, apperror.NewConflictError(fmt.Sprintf("Organization type '%s' is used by %d organizations and cannot be deactivated", name, count))
+		}
+	}
+
+	orgType.DisplayName = req.DisplayName
+	orgType.Description = req.Description
+	orgType.AllowRoot = req.AllowRoot
+	orgType.MaxDepth = req.MaxDepth
+	orgType.MinRoleLevel = req.MinRoleLevel
+	orgType.MaxRoleLevel = req.MaxRoleLevel
+	orgType.IsActive = req.IsActive
+	if err := s.orgTypeRepo.Update(ctx, orgType, req.AllowedParentTypes); err != nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to update organization type: %w", err))
+	}
+
+	if err := s.saveDefaultRoles(ctx, name, req.CreatorRoleID, req.MemberRoleID, updatedBy); err != nil {
+		return nil, err
+	}
+
+	log.Info().Str("organization_type", name).Str("updated_by", updatedBy.String()).Msg("Organization type updated")
+	return s.GetOrganizationType(ctx, name)
+}
+
+// GetOrganizationTypeAttributes returns the custom attribute schema of an organization type.
+func (s *organizationTypeService) GetOrganizationTypeAttributes(ctx context.Context, name string) (*dto.OrganizationTypeAttributesResponse, error) {
+	exists, err := s.orgTypeRepo.Exists(ctx, name)
+	if err != nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to check organization type: %w", err))
+	}
+	if !exists {
+		return nil, apperror.NewNotFoundError("organization type")
+	}
+
+	attributes, err := s.orgTypeRepo.FindAttributes(ctx, name)
+	if err != nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to fetch organization type attributes: %w", err))
+	}
+
+	response := &dto.OrganizationTypeAttributesResponse{
+		OrganizationType: name,
+		Attributes:       make([]dto.OrganizationTypeAttributeResponse, 0, len(attributes)),
+	}
+	for i := range attributes {
+		response.Attributes = append(response.Attributes, mapOrganizationTypeAttributeToResponse(&attributes[i]))
+	}
+	return response, nil
+}
+
+// UpdateOrganizationTypeAttributes replaces the custom attribute schema of an organization type.
+func (s *organizationTypeService) UpdateOrganizationTypeAttributes(ctx context.Context, name string, req dto.UpdateOrganizationTypeAttributesRequest, updatedBy uuid.UUID) (*dto.OrganizationTypeAttributesResponse, error) {
+	exists, err := s.orgTypeRepo.Exists(ctx, name)
+	if err != nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to check organization type: %w", err))
+	}
+	if !exists {
+		return nil, apperror.NewNotFoundError("organization type")
+	}
+
+	attributes, err := buildOrganizationTypeAttributes(name, req.Attributes)
+	if err != nil {
+		return nil, apperror.NewValidationError(err.Error())
+	}
+	if err := s.orgTypeRepo.ReplaceAttributes(ctx, name, attributes); err != nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to update organization type attributes: %w", err))
+	}
+
+	log.Info().Str("organization_type", name).Int("attributes", len(attributes)).Str("updated_by", updatedBy.String()).Msg("Organization type attributes updated")
+	return s.GetOrganizationTypeAttributes(ctx, name)
+}
+
+// validatePlacementRules checks that a type can be placed somewhere and that its parent types are registered.
+func (s *organizationTypeService) validatePlacementRules(ctx context.Context, name string, allowRoot bool, parentTypes []string) error {
+	if !allowRoot && len(parentTypes) == 0 {
+		return apperror.NewValidationError("An organization type must allow root placement or at least one parent type")
+	}
+
+	for _, parentType := range parentTypes {
+		if parentType == name {
+			continue // Types may nest under themselves, e.g. regions within regions
+		}
+		exists, err := s.orgTypeRepo.Exists(ctx, parentType)
+		if err != nil {
+			return apperror.NewInternalError(fmt.Errorf("failed to check parent organization type: %w", err))
+		}
+		if !exists {
+			return apperror.NewValidationError(fmt.Sprintf("Parent organization type '%s' is not registered", parentType))
+		}
+	}
+	return nil
+}
+
+// saveDefaultRoles stores the default roles of a type when both are given.
+func (s *organizationTypeService) saveDefaultRoles(ctx context.Context, name string, creatorRoleID, memberRoleID *uuid.UUID, updatedBy uuid.UUID) error {
+	if creatorRoleID == nil && memberRoleID == nil {
+		return nil
+	}
+	if creatorRoleID == nil || memberRoleID == nil {
+		return apperror.NewValidationError("creator_role_id and member_role_id must be provided together")
+	}
+	return saveOrganizationDefaultRoles(ctx, s.roleRepo, name, *creatorRoleID, *memberRoleID, updatedBy)
+}
+
+// buildOrganizationTypeResponse maps an organization type with its configured default roles and usage.
+func (s *organizationTypeService) buildOrganizationTypeResponse(ctx context.Context, orgType *model.OrganizationType) (*dto.OrganizationTypeResponse, error) {
+	count, err := s.orgTypeRepo.CountOrganizations(ctx, orgType.Name)
+	if err != nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to count organizations: %w", err))
+	}
+
+	parentTypes := make([]string, len(orgType.AllowedParents))
+	for i, parent := range orgType.AllowedParents {
+		parentTypes[i] = parent.Name
+	}
+
+	response := &dto.OrganizationTypeResponse{
+		Name:               orgType.Name,
+		DisplayName:        orgType.DisplayName,
+		Description:        orgType.Description,
+		AllowRoot:          orgType.AllowRoot,
+		AllowedParentTypes: parentTypes,
+		MaxDepth:           orgType.MaxDepth,
+		MinRoleLevel:       orgType.MinRoleLevel,
+		MaxRoleLevel:       orgType.MaxRoleLevel,
+		IsActive:           orgType.IsActive,
+		OrganizationCount:  count,
+		CreatedAt:          orgType.CreatedAt,
+		UpdatedAt:          orgType.UpdatedAt,
+	}
+
+	defaults, err := s.roleRepo.FindDefaultRolesByOrganizationType(ctx, orgType.Name)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to fetch default roles: %w", err))
+	}
+	if defaults != nil {
+		response.CreatorRoleID = defaults.CreatorRoleID
+		response.MemberRoleID = defaults.MemberRoleID
+	}
+
+	return response, nil
+}
+
+// findActiveOrganizationType loads a registered, active organization type, returning a validation
+// error for unknown or inactive types.
+func findActiveOrganizationType(ctx context.Context, orgTypeRepo repository.OrganizationTypeRepositoryInterface, name string) (*model.OrganizationType, error) {
+	orgType, err := orgTypeRepo.FindByName(ctx, name)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.NewValidationError(fmt.Sprintf("Invalid organization type '%s'", name))
+		}
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to fetch organization type: %w", err))
+	}
+	if !orgType.IsActive {
+		return nil, apperror.NewValidationError(fmt.Sprintf("Organization type '%s' is not active", name))
+	}
+	return orgType, nil
+}
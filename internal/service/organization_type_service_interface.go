@@ -0,0 +1,22 @@
+package service
+
+import (
+	"context"
+	"go-base-project/internal/dto"
+
+	"github.com/google/uuid"
+)
+
+// OrganizationTypeServiceInterface defines the contract for managing the organization type registry.
+type OrganizationTypeServiceInterface interface {
+	ListOrganizationTypes(ctx context.Context, includeInactive bool) ([]dto.OrganizationTypeResponse, error)
+	GetOrganizationType(ctx context.Context, name string) (*dto.OrganizationTypeResponse, error)
+	CreateOrganizationType(ctx context.Context, req dto.CreateOrganizationTypeRequest, createdBy uuid.UUID) (*dto.OrganizationTypeResponse, error)
+	UpdateOrganizationType(ctx context.Context, name string, req dto.UpdateOrganizationTypeRequest, updatedBy uuid.UUID) (*dto.OrganizationTypeResponse, error)
+
+	// Custom attribute schema
+	GetOrganizationTypeAttributes(ctx context.Context, name string) (*dto.OrganizationTypeAttributesResponse, error)
+	// UpdateOrganizationTypeAttributes replaces the attribute schema of a type. Values already stored on
+	// memberships are kept and checked against the new schema the next time they are set.
+	UpdateOrganizationTypeAttributes(ctx context.Context, name string, req dto.UpdateOrganizationTypeAttributesRequest, updatedBy uuid.UUID) (*dto.OrganizationTypeAttributesResponse, error)
+}
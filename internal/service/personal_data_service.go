@@ -0,0 +1,396 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"go-base-project/internal/apperror"
+	"go-base-project/internal/cache"
+	"go-base-project/internal/constant"
+	"go-base-project/internal/dto"
+	"go-base-project/internal/model"
+	"go-base-project/internal/repository"
+	"go-base-project/platform/storage"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+// erasedEmailDomain is the reserved domain of the placeholder addresses given to erased users
+const erasedEmailDomain = "erased.invalid"
+
+// Roles of a user in an exported audit event
+const (
+	personalDataRoleActor   = "actor"
+	personalDataRoleSubject = "subject"
+)
+
+type personalDataService struct {
+	personalDataRepo repository.PersonalDataRepositoryInterface
+	userRepo         repository.UserRepositoryInterface
+	approvalService  ApprovalServiceInterface
+	txManager        repository.TransactionManagerInterface
+	store            storage.BlobStore
+	redis            *redis.Client
+}
+
+// NewPersonalDataService creates a new instance of PersonalDataService
+func NewPersonalDataService(personalDataRepo repository.PersonalDataRepositoryInterface, userRepo repository.UserRepositoryInterface, approvalService ApprovalServiceInterface, txManager repository.TransactionManagerInterface, store storage.BlobStore, redisClient *redis.Client) PersonalDataServiceInterface {
+	return &personalDataService{
+		personalDataRepo: personalDataRepo,
+		userRepo:         userRepo,
+		approvalService:  approvalService,
+		txManager:        txManager,
+		store:            store,
+		redis:            redisClient,
+	}
+}
+
+// ExportPersonalData collects everything held about a user into a single machine-readable bundle
+func (s *personalDataService) ExportPersonalData(ctx context.Context, userID uuid.UUID) (*dto.PersonalDataExport, error) {
+	user, err := s.findUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	records, err := s.personalDataRepo.FindRecords(ctx, user)
+	if err != nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to collect personal data: %w", err))
+	}
+	sessions, err := s.exportSessions(ctx, user.ID)
+	if err != nil {
+		return nil, apperror.NewInternalError(err)
+	}
+
+	export := &dto.PersonalDataExport{
+		ExportedAt: time.Now().UTC(),
+		Profile: dto.PersonalDataProfile{
+			ID:             user.ID,
+			Username:       user.Username,
+			Email:          user.Email,
+			AvatarURL:      user.AvatarURL,
+			DisplayName:    user.DisplayName,
+			Phone:          user.Phone,
+			Locale:         user.Locale,
+			Timezone:       user.Timezone,
+			JobTitle:       user.JobTitle,
+			Status:         user.Status,
+			StatusReason:   user.StatusReason,
+			SuspendedUntil: user.SuspendedUntil,
+			CreatedAt:      user.CreatedAt,
+			UpdatedAt:      user.UpdatedAt,
+		},
+		Identities:          exportIdentities(user),
+		Memberships:         make([]dto.PersonalDataMembership, 0, len(records.Memberships)),
+		OrganizationHistory: make([]dto.UserOrganizationHistoryResponse, 0, len(records.OrganizationHistory)),
+		StatusHistory:       make([]dto.UserStatusHistoryResponse, 0, len(records.StatusHistory)),
+		Sessions:            sessions,
+		AuditEvents:         exportAuditEvents(user.ID, records),
+	}
+	if user.Role != nil {
+		export.Profile.Role = user.Role.Name
+	}
+
+	for _, membership := range records.Memberships {
+		entry := dto.PersonalDataMembership{
+			OrganizationID:   membership.OrganizationID,
+			OrganizationName: membership.Organization.Name,
+			JoinedAt:         membership.JoinedAt,
+			IsActive:         membership.IsActive,
+		}
+		if membership.Role != nil {
+			entry.Role = membership.Role.Name
+		}
+		if membership.Attributes != "" && membership.Attributes != "{}" {
+			entry.Attributes = json.RawMessage(membership.Attributes)
+		}
+		export.Memberships = append(export.Memberships, entry)
+	}
+	for _, h := range records.OrganizationHistory {
+		export.OrganizationHistory = append(export.OrganizationHistory, *mapMembershipHistoryToResponse(&h))
+	}
+	for _, h := range records.StatusHistory {
+		export.StatusHistory = append(export.StatusHistory, dto.UserStatusHistoryResponse{
+			ID:             h.ID,
+			UserID:         h.UserID,
+			PreviousStatus: h.PreviousStatus,
+			NewStatus:      h.NewStatus,
+			Reason:         h.Reason,
+			SuspendedUntil: h.SuspendedUntil,
+			ActionBy:       h.ActionBy,
+			ActionAt:       h.ActionAt.Format(time.RFC3339),
+		})
+	}
+
+	return export, nil
+}
+
+// RequestErasure validates an erasure up front so requests that cannot run are not sent for approval
+func (s *personalDataService) RequestErasure(ctx context.Context, userID uuid.UUID, req dto.EraseUserRequest, requestedBy uuid.UUID) (*dto.ChangeRequestResponse, error) {
+	user, err := s.findUserForErasure(ctx, userID, requestedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.approvalService.SubmitChangeRequest(
+		ctx,
+		constant.ChangeRequestActionEraseUser,
+		&user.ID,
+		fmt.Sprintf("Erase personal data of user %s", user.Username),
+		dto.EraseUserPayload{UserID: user.ID, Reason: req.Reason, RequestedBy: requestedBy},
+		requestedBy,
+	)
+}
+
+// EraseUser anonymises a user in place. The row is kept, soft-deleted, so history entries and organizations
+// created by the user keep a valid reference. The approval engine runs it inside the transaction of the
+// final decision, so sessions and avatar files are only removed once that transaction has committed.
+func (s *personalDataService) EraseUser(ctx context.Context, userID uuid.UUID, reason string, erasedBy uuid.UUID) error {
+	user, err := s.findUserForErasure(ctx, userID, erasedBy)
+	if err != nil {
+		return err
+	}
+
+	sessions, err := findUserRefreshTokens(ctx, s.redis, user.ID)
+	if err != nil {
+		return apperror.NewInternalError(fmt.Errorf("failed to list sessions: %w", err))
+	}
+
+	originalEmail := user.Email
+	avatarKey := user.AvatarKey
+	placeholder := strings.ReplaceAll(user.ID.String(), "-", "")
+	erasedAt := time.Now()
+	user.Username = "erased-" + placeholder
+	user.Email = placeholder + "@" + erasedEmailDomain
+	user.Password = ""
+	user.GoogleID = nil
+	user.AvatarURL = ""
+	user.AvatarKey = ""
+	user.DisplayName = ""
+	user.Phone = ""
+	user.Locale = ""
+	user.Timezone = ""
+	user.JobTitle = ""
+	user.RoleID = nil
+	user.StatusReason = ""
+	user.SuspendedUntil = nil
+	user.ErasedAt = &erasedAt
+
+	erasure := &model.UserErasure{
+		UserID:          user.ID,
+		ErasedBy:        &erasedBy,
+		Reason:          reason,
+		SessionsRevoked: len(sessions),
+	}
+	if err := s.personalDataRepo.Erase(ctx, user, originalEmail, erasure); err != nil {
+		if errors.Is(err, repository.ErrUserAlreadyErased) {
+			return apperror.NewConflictError("personal data of this user has already been erased")
+		}
+		return apperror.NewInternalError(fmt.Errorf("failed to erase user: %w", err))
+	}
+
+	s.txManager.AfterCommit(ctx, func(ctx context.Context) {
+		s.removeErasedUserAccess(ctx, user.ID, avatarKey)
+
+		log.Info().
+			Str("erasure_id", erasure.ID.String()).
+			Str("user_id", user.ID.String()).
+			Str("erased_by", erasedBy.String()).
+			Int("memberships_removed", erasure.MembershipsRemoved).
+			Int("sessions_revoked", erasure.SessionsRevoked).
+			Msg("User personal data erased")
+	})
+
+	return nil
+}
+
+// removeErasedUserAccess signs an erased user out and deletes their avatar files. The erasure has already
+// committed at this point, so failures are logged rather than returned.
+func (s *personalDataService) removeErasedUserAccess(ctx context.Context, userID uuid.UUID, avatarKey string) {
+	if _, err := revokeUserSessions(ctx, s.redis, userID); err != nil {
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to revoke sessions of erased user")
+	}
+	if err := s.redis.Del(ctx, cache.GetUserStatusCacheKey(userID)).Err(); err != nil {
+		log.Warn().Err(err).Str("user_id", userID.String()).Msg("Failed to invalidate user status cache")
+	}
+	if avatarKey != "" {
+		deleteImageVariants(ctx, s.store, avatarKey, avatarSizes)
+	}
+}
+
+// findUser loads a user including soft-deleted ones, whose personal data is still held
+func (s *personalDataService) findUser(ctx context.Context, userID uuid.UUID) (*model.User, error) {
+	user, err := s.personalDataRepo.FindUserIncludingDeleted(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.NewNotFoundError("user")
+		}
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to find user: %w", err))
+	}
+	return user, nil
+}
+
+// findUserForErasure loads a user that has not been erased yet and checks that the caller may erase them
+func (s *personalDataService) findUserForErasure(ctx context.Context, userID, currentUserID uuid.UUID) (*model.User, error) {
+	if userID == currentUserID {
+		return nil, apperror.NewAppError(http.StatusForbidden, constant.ErrMsgCannotEraseOwnAccount, nil)
+	}
+
+	user, err := s.findUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.ErasedAt != nil {
+		return nil, apperror.NewConflictError("personal data of this user has already been erased")
+	}
+	if err := checkAuthorityOverUser(ctx, s.userRepo, user, currentUserID); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// exportSessions lists the active sessions of a user by a fingerprint of their refresh token
+func (s *personalDataService) exportSessions(ctx context.Context, userID uuid.UUID) ([]dto.PersonalDataSession, error) {
+	tokens, err := findUserRefreshTokens(ctx, s.redis, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	sessions := make([]dto.PersonalDataSession, 0, len(tokens))
+	for _, token := range tokens {
+		sum := sha256.Sum256([]byte(token))
+		session := dto.PersonalDataSession{ID: hex.EncodeToString(sum[:6])}
+		if ttl, err := s.redis.TTL(ctx, token).Result(); err == nil && ttl > 0 {
+			expiresAt := time.Now().Add(ttl).UTC().Truncate(time.Second)
+			session.ExpiresAt = &expiresAt
+		}
+		sessions = append(sessions, session)
+	}
+	return sessions, nil
+}
+
+// exportIdentities lists the ways a user can sign in
+func exportIdentities(user *model.User) []dto.PersonalDataIdentity {
+	identities := []dto.PersonalDataIdentity{}
+	if user.Password != "" {
+		identities = append(identities, dto.PersonalDataIdentity{Provider: "local", Subject: user.Username, Email: user.Email})
+	}
+	if user.GoogleID != nil && *user.GoogleID != "" {
+		identities = append(identities, dto.PersonalDataIdentity{Provider: "google", Subject: *user.GoogleID, Email: user.Email})
+	}
+	return identities
+}
+
+// exportAuditEvents flattens the records a user appears in, other than their own history entries, into a
+// single timeline
+func exportAuditEvents(userID uuid.UUID, records *repository.PersonalDataRecords) []dto.PersonalDataAuditEvent {
+	events := []dto.PersonalDataAuditEvent{}
+	role := func(actor bool) string {
+		if actor {
+			return personalDataRoleActor
+		}
+		return personalDataRoleSubject
+	}
+
+	for _, l := range records.OrganizationAuditLogs {
+		orgID := l.OrganizationID
+		events = append(events, dto.PersonalDataAuditEvent{
+			Source:         "organization_audit_logs",
+			ID:             l.ID,
+			Action:         l.Action,
+			Role:           personalDataRoleActor,
+			OrganizationID: &orgID,
+			Details:        l.Reason,
+			OccurredAt:     l.CreatedAt,
+		})
+	}
+	for _, r := range records.ChangeRequests {
+		events = append(events, dto.PersonalDataAuditEvent{
+			Source:     "change_requests",
+			ID:         r.ID,
+			Action:     r.Action,
+			Role:       personalDataRoleActor,
+			TargetID:   r.TargetID,
+			Status:     r.Status,
+			Details:    r.Summary,
+			OccurredAt: r.CreatedAt,
+		})
+	}
+	for _, a := range records.ChangeRequestApprovals {
+		changeRequestID := a.ChangeRequestID
+		events = append(events, dto.PersonalDataAuditEvent{
+			Source:     "change_request_approvals",
+			ID:         a.ID,
+			Action:     "change_request." + a.Decision,
+			Role:       personalDataRoleActor,
+			TargetID:   &changeRequestID,
+			Details:    a.Comment,
+			OccurredAt: a.CreatedAt,
+		})
+	}
+	for _, a := range records.RoleApprovals {
+		events = append(events, dto.PersonalDataAuditEvent{
+			Source:         "role_approvals",
+			ID:             a.ID,
+			Action:         "role.request",
+			Role:           role(a.RequestedBy == userID),
+			OrganizationID: a.OrganizationID,
+			TargetID:       a.CreatedRoleID,
+			Status:         a.Status,
+			Details:        a.RequestedRoleName,
+			OccurredAt:     a.CreatedAt,
+		})
+	}
+	for _, j := range records.JoinRequests {
+		orgID := j.OrganizationID
+		events = append(events, dto.PersonalDataAuditEvent{
+			Source:         "organization_join_requests",
+			ID:             j.ID,
+			Action:         "organization.join_request",
+			Role:           role(j.UserID != userID),
+			OrganizationID: &orgID,
+			Status:         j.Status,
+			Details:        j.Message,
+			OccurredAt:     j.CreatedAt,
+		})
+	}
+	for _, i := range records.Invitations {
+		orgID := i.OrganizationID
+		events = append(events, dto.PersonalDataAuditEvent{
+			Source:         "organization_invitations",
+			ID:             i.ID,
+			Action:         "organization.invitation",
+			Role:           role(i.InvitedBy == userID),
+			OrganizationID: &orgID,
+			TargetID:       i.InvitedUserID,
+			Status:         i.Status,
+			Details:        i.Email,
+			OccurredAt:     i.CreatedAt,
+		})
+	}
+	for _, j := range records.Jobs {
+		events = append(events, dto.PersonalDataAuditEvent{
+			Source:     "background_jobs",
+			ID:         j.ID,
+			Action:     j.Type,
+			Role:       personalDataRoleActor,
+			Status:     j.Status,
+			OccurredAt: j.CreatedAt,
+		})
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].OccurredAt.Before(events[j].OccurredAt)
+	})
+	return events
+}
@@ -0,0 +1,20 @@
+package service
+
+import (
+	"context"
+	"go-base-project/internal/dto"
+
+	"github.com/google/uuid"
+)
+
+// PersonalDataServiceInterface defines the export and erasure of a user's personal data
+type PersonalDataServiceInterface interface {
+	// ExportPersonalData returns everything held about a user: profile, identities, memberships, history
+	// entries, sessions and the audit records they appear in.
+	ExportPersonalData(ctx context.Context, userID uuid.UUID) (*dto.PersonalDataExport, error)
+	// RequestErasure checks that the caller may erase the user and submits the erasure for approval.
+	RequestErasure(ctx context.Context, userID uuid.UUID, req dto.EraseUserRequest, requestedBy uuid.UUID) (*dto.ChangeRequestResponse, error)
+	// EraseUser anonymises the user in place, removes their memberships, revokes their sessions and logs
+	// the erasure. Records referring to the user keep pointing at the anonymised row.
+	EraseUser(ctx context.Context, userID uuid.UUID, reason string, erasedBy uuid.UUID) error
+}
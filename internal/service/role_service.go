@@ -10,6 +10,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/rs/zerolog/log"
@@ -19,13 +22,25 @@ import (
 // roleService implements the RoleService interface for role management.
 type roleService struct {
 	roleRepo             repository.RoleRepositoryInterface
+	userRepo             repository.UserRepositoryInterface
+	membershipRepo       repository.MembershipRepositoryInterface
+	orgTypeRepo          repository.OrganizationTypeRepositoryInterface
+	txManager            repository.TransactionManagerInterface
+	orgService           OrganizationServiceInterface
+	membershipService    MembershipServiceInterface
 	authorizationService AuthorizationServiceInterface
 }
 
 // NewRoleService creates a new instance of roleService.
-func NewRoleService(roleRepo repository.RoleRepositoryInterface, authorizationService AuthorizationServiceInterface) RoleServiceInterface {
+func NewRoleService(roleRepo repository.RoleRepositoryInterface, userRepo repository.UserRepositoryInterface, membershipRepo repository.MembershipRepositoryInterface, orgTypeRepo repository.OrganizationTypeRepositoryInterface, txManager repository.TransactionManagerInterface, orgService OrganizationServiceInterface, membershipService MembershipServiceInterface, authorizationService AuthorizationServiceInterface) RoleServiceInterface {
 	return &roleService{
 		roleRepo:             roleRepo,
+		userRepo:             userRepo,
+		membershipRepo:       membershipRepo,
+		orgTypeRepo:          orgTypeRepo,
+		txManager:            txManager,
+		orgService:           orgService,
+		membershipService:    membershipService,
 		authorizationService: authorizationService,
 	}
 }
@@ -47,6 +62,12 @@ func (s *roleService) CreateRole(ctx context.Context, req dto.CreateRoleRequest,
 		return nil, apperror.NewValidationError(fmt.Sprintf("Role level must be between %d and %d", minLevel, maxLevel))
 	}
 
+	// Default: assign to all active organization types if none specified
+	organizationTypes, err := s.resolveRoleOrganizationTypes(ctx, req.OrganizationTypes)
+	if err != nil {
+		return nil, err
+	}
+
 	// Use repository to check if role name already exists (more efficient)
 	existingRole, err := s.roleRepo.FindByName(ctx, req.Name)
 	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
@@ -67,25 +88,24 @@ func (s *roleService) CreateRole(ctx context.Context, req dto.CreateRoleRequest,
 		IsActive:       true,  // New roles are active by default
 	}
 
-	// Use repository create method
-	createdRole, err := s.roleRepo.Create(ctx, newRole)
-	if err != nil {
-		log.Error().Err(err).Interface("role", newRole).Msg("Failed to create role")
-		return nil, apperror.NewInternalError(fmt.Errorf("failed to create role: %w", err))
-	}
-
-	// Determine organization types to assign
-	organizationTypes := req.OrganizationTypes
-	if len(organizationTypes) == 0 {
-		// Default: assign to all organization types if none specified
-		organizationTypes = []string{"platform", "holding", "company", "store"}
-	}
+	// The role and its organization type mappings are created together
+	var createdRole *model.Role
+	err = s.txManager.RunInTx(ctx, func(ctx context.Context) error {
+		var err error
+		createdRole, err = s.roleRepo.Create(ctx, newRole)
+		if err != nil {
+			log.Error().Err(err).Interface("role", newRole).Msg("Failed to create role")
+			return apperror.NewInternalError(fmt.Errorf("failed to create role: %w", err))
+		}
 
-	// Create organization type mappings
-	err = s.roleRepo.CreateRoleOrganizationTypes(ctx, createdRole.ID, organizationTypes)
+		if err := s.roleRepo.CreateRoleOrganizationTypes(ctx, createdRole.ID, organizationTypes); err != nil {
+			log.Error().Err(err).Str("role_id", createdRole.ID.String()).Msg("Failed to create role organization types")
+			return apperror.NewInternalError(fmt.Errorf("failed to create role organization types: %w", err))
+		}
+		return nil
+	})
 	if err != nil {
-		log.Error().Err(err).Str("role_id", createdRole.ID.String()).Msg("Failed to create role organization types")
-		// Note: Role is already created, so we continue but log the error
+		return nil, err
 	}
 
 	// Get the created role with organization types for response
@@ -202,6 +222,12 @@ func (s *roleService) UpdateRole(ctx context.Context, roleID uuid.UUID, req dto.
 		return nil, apperror.NewValidationError(fmt.Sprintf("Role level must be between %d and %d", minLevel, maxLevel))
 	}
 
+	if len(req.OrganizationTypes) > 0 {
+		if _, err := s.resolveRoleOrganizationTypes(ctx, req.OrganizationTypes); err != nil {
+			return nil, err
+		}
+	}
+
 	// Check if new name conflicts with existing roles (excluding current role)
 	if req.Name != existingRole.Name {
 		existingByName, err := s.roleRepo.FindByName(ctx, req.Name)
@@ -272,13 +298,86 @@ func (s *roleService) UpdateRolePermissions(ctx context.Context, roleID uuid.UUI
 	return nil
 }
 
-// DISABLED: Role approval functionality
-/*
+// Role Approval Workflow
+
+// roleApprovalTransitions lists the statuses a role approval request can move to from its current status.
+// Approved, rejected and cancelled are terminal states.
+var roleApprovalTransitions = map[string][]string{
+	constant.RoleApprovalStatusPending: {
+		constant.RoleApprovalStatusApproved,
+		constant.RoleApprovalStatusRejected,
+		constant.RoleApprovalStatusCancelled,
+	},
+}
+
+// validateRoleApprovalTransition ensures a role approval request is allowed to move from one status to another.
+func validateRoleApprovalTransition(from, to string) error {
+	for _, allowed := range roleApprovalTransitions[from] {
+		if allowed == to {
+			return nil
+		}
+	}
+	return apperror.NewConflictError(fmt.Sprintf("Role approval request cannot move from '%s' to '%s'", from, to))
+}
+
+// validateRequestedLevelForOrganizationType ensures the requested role level fits the level band of the organization type.
+func validateRequestedLevelForOrganizationType(level int, orgType *model.OrganizationType) error {
+	if !orgType.AllowsRoleLevel(level) {
+		return apperror.NewValidationError(fmt.Sprintf("Requested level for a %s organization must be between %d and %d", orgType.Name, orgType.MinRoleLevel, orgType.MaxRoleLevel))
+	}
+	return nil
+}
+
+// resolveRoleOrganizationTypes validates the organization types a role applies to against the registry.
+// An empty list resolves to every active organization type.
+func (s *roleService) resolveRoleOrganizationTypes(ctx context.Context, organizationTypes []string) ([]string, error) {
+	if len(organizationTypes) == 0 {
+		orgTypes, err := s.orgTypeRepo.FindAll(ctx, false)
+		if err != nil {
+			return nil, apperror.NewInternalError(fmt.Errorf("failed to fetch organization types: %w", err))
+		}
+		names := make([]string, len(orgTypes))
+		for i, orgType := range orgTypes {
+			names[i] = orgType.Name
+		}
+		return names, nil
+	}
+
+	for _, name := range organizationTypes {
+		if _, err := findActiveOrganizationType(ctx, s.orgTypeRepo, name); err != nil {
+			return nil, err
+		}
+	}
+	return organizationTypes, nil
+}
+
 // CreateRoleApprovalRequest creates a new role approval request.
-func (s *roleService) CreateRoleApprovalRequest(ctx context.Context, req dto.CreateRoleApprovalRequest, requestedBy uuid.UUID) (*dto.RoleApprovalResponse, error) {
-	// Additional validation: Level 100 is reserved for superadmin only
-	if req.RequestedLevel >= 100 {
-		return nil, apperror.NewValidationError("Level 100 is reserved for superadmin and cannot be requested")
+// The request can optionally target an existing organization (by code) or ask for a new organization to be created.
+func (s *roleService) CreateRoleApprovalRequest(ctx context.Context, req dto.CreateRoleWithOrganizationRequest, requestedBy uuid.UUID) (*dto.RoleApprovalWithOrganizationResponse, error) {
+	// Level 99 and above are reserved for platform administration and cannot be requested
+	if req.RequestedLevel < constant.RoleLevelStoreStaff || req.RequestedLevel >= constant.RoleLevelPlatformAdmin {
+		return nil, apperror.NewValidationError(fmt.Sprintf("Requested level must be between %d and %d", constant.RoleLevelStoreStaff, constant.RoleLevelPlatformAdmin-1))
+	}
+	if req.IsNewOrganization && req.OrganizationCode != "" {
+		return nil, apperror.NewValidationError("A role request can either join an existing organization or create a new one, not both")
+	}
+
+	existingRole, err := s.roleRepo.FindByName(ctx, req.RequestedRoleName)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to validate role name: %w", err))
+	}
+	if existingRole != nil {
+		return nil, apperror.NewConflictError(fmt.Sprintf("Role with name '%s' already exists", req.RequestedRoleName))
+	}
+
+	ownRequests, err := s.roleRepo.FindRoleApprovalsByRequester(ctx, requestedBy)
+	if err != nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to fetch existing role requests: %w", err))
+	}
+	for _, existing := range ownRequests {
+		if existing.Status == constant.RoleApprovalStatusPending && strings.EqualFold(existing.RequestedRoleName, req.RequestedRoleName) {
+			return nil, apperror.NewConflictError("You already have a pending request for this role")
+		}
 	}
 
 	newApproval := &model.RoleApproval{
@@ -286,7 +385,46 @@ func (s *roleService) CreateRoleApprovalRequest(ctx context.Context, req dto.Cre
 		RequestedLevel:    req.RequestedLevel,
 		Description:       req.Description,
 		RequestedBy:       requestedBy,
-		Status:            "pending",
+		Status:            constant.RoleApprovalStatusPending,
+		IsNewOrganization: req.IsNewOrganization,
+	}
+
+	switch {
+	case req.IsNewOrganization:
+		if req.RequestedOrganizationType == constant.OrganizationTypePlatform {
+			return nil, apperror.NewValidationError("Platform organizations cannot be requested")
+		}
+		orgType, err := findActiveOrganizationType(ctx, s.orgTypeRepo, req.RequestedOrganizationType)
+		if err != nil {
+			return nil, err
+		}
+		// Requested organizations are created without a parent
+		if !orgType.AllowsParent("") {
+			return nil, apperror.NewValidationError(fmt.Sprintf("A %s organization cannot be created without a parent organization", orgType.Name))
+		}
+		if err := validateRequestedLevelForOrganizationType(req.RequestedLevel, orgType); err != nil {
+			return nil, err
+		}
+		newApproval.RequestedOrganizationName = req.RequestedOrganizationName
+		newApproval.RequestedOrganizationType = req.RequestedOrganizationType
+		newApproval.OrganizationDescription = req.OrganizationDescription
+	case req.OrganizationCode != "":
+		org, err := s.orgService.GetOrganizationByCode(ctx, req.OrganizationCode)
+		if err != nil {
+			return nil, err
+		}
+		if !org.IsActive {
+			return nil, apperror.NewValidationError("Organization is not active")
+		}
+		orgType, err := findActiveOrganizationType(ctx, s.orgTypeRepo, org.OrganizationType)
+		if err != nil {
+			return nil, err
+		}
+		if err := validateRequestedLevelForOrganizationType(req.RequestedLevel, orgType); err != nil {
+			return nil, err
+		}
+		newApproval.OrganizationID = &org.ID
+		newApproval.OrganizationCode = org.Code
 	}
 
 	createdApproval, err := s.roleRepo.CreateRoleApproval(ctx, newApproval)
@@ -294,78 +432,298 @@ func (s *roleService) CreateRoleApprovalRequest(ctx context.Context, req dto.Cre
 		return nil, apperror.NewInternalError(fmt.Errorf("failed to create role approval request: %w", err))
 	}
 
-	// Fetch the approval with user information
-	approvalWithUsers, err := s.roleRepo.FindRoleApprovalByID(ctx, createdApproval.ID)
+	log.Info().
+		Str("approval_id", createdApproval.ID.String()).
+		Str("requested_by", requestedBy.String()).
+		Str("role_name", createdApproval.RequestedRoleName).
+		Int("requested_level", createdApproval.RequestedLevel).
+		Msg("Role approval request created")
+
+	return s.getRoleApprovalResponse(ctx, createdApproval.ID)
+}
+
+// ListRoleApprovalRequests retrieves role approval requests, optionally filtered by status.
+func (s *roleService) ListRoleApprovalRequests(ctx context.Context, status string) ([]dto.RoleApprovalWithOrganizationResponse, error) {
+	var approvals []model.RoleApproval
+	var err error
+
+	switch status {
+	case "":
+		approvals, err = s.roleRepo.FindAllRoleApprovals(ctx)
+	case constant.RoleApprovalStatusPending, constant.RoleApprovalStatusApproved,
+		constant.RoleApprovalStatusRejected, constant.RoleApprovalStatusCancelled:
+		approvals, err = s.roleRepo.FindRoleApprovalsByStatus(ctx, status)
+	default:
+		return nil, apperror.NewValidationError(fmt.Sprintf("Invalid approval status '%s'", status))
+	}
 	if err != nil {
-		return nil, apperror.NewInternalError(fmt.Errorf("failed to fetch created approval: %w", err))
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to fetch role approval requests: %w", err))
 	}
 
-	return s.mapRoleApprovalToResponse(approvalWithUsers), nil
+	response := make([]dto.RoleApprovalWithOrganizationResponse, len(approvals))
+	for i := range approvals {
+		response[i] = *s.mapRoleApprovalToResponse(&approvals[i])
+	}
+
+	return response, nil
 }
-*/
 
-/*
-// ListRoleApprovalRequests retrieves all role approval requests.
-func (s *roleService) ListRoleApprovalRequests(ctx context.Context) ([]dto.RoleApprovalResponse, error) {
-	approvals, err := s.roleRepo.FindAllRoleApprovals(ctx)
+// ListMyRoleApprovalRequests retrieves all role approval requests made by the given user.
+func (s *roleService) ListMyRoleApprovalRequests(ctx context.Context, requestedBy uuid.UUID) ([]dto.RoleApprovalWithOrganizationResponse, error) {
+	approvals, err := s.roleRepo.FindRoleApprovalsByRequester(ctx, requestedBy)
 	if err != nil {
 		return nil, apperror.NewInternalError(fmt.Errorf("failed to fetch role approval requests: %w", err))
 	}
 
-	response := make([]dto.RoleApprovalResponse, len(approvals))
-	for i, approval := range approvals {
-		response[i] = *s.mapRoleApprovalToResponse(&approval)
+	response := make([]dto.RoleApprovalWithOrganizationResponse, len(approvals))
+	for i := range approvals {
+		response[i] = *s.mapRoleApprovalToResponse(&approvals[i])
 	}
 
 	return response, nil
 }
-*/
 
-/*
-// ApproveRejectRoleRequest approves or rejects a role approval request.
-func (s *roleService) ApproveRejectRoleRequest(ctx context.Context, approvalID uuid.UUID, decision dto.ApprovalDecisionRequest, approverID uuid.UUID) (*dto.RoleApprovalResponse, error) {
+// ApproveRejectRoleRequest approves or rejects a pending role approval request.
+// On approval the role is created and the requester is placed in the requested organization,
+// creating that organization first when the request asked for a new one. All of it commits together
+// with the decision, so a failed approval leaves nothing behind and can simply be retried.
+func (s *roleService) ApproveRejectRoleRequest(ctx context.Context, approvalID uuid.UUID, decision dto.ApprovalDecisionRequest, approverID uuid.UUID) (*dto.RoleApprovalWithOrganizationResponse, error) {
+	err := s.txManager.RunInTx(ctx, func(ctx context.Context) error {
+		// The request stays locked until the decision commits, so a concurrent decision or cancellation
+		// sees the new status and fails the transition check
+		approval, err := s.findRoleApprovalForUpdate(ctx, approvalID)
+		if err != nil {
+			return err
+		}
+
+		if err := validateRoleApprovalTransition(approval.Status, decision.Status); err != nil {
+			return err
+		}
+
+		approverLevel, err := s.checkRoleApprovalEligibility(ctx, approval, approverID)
+		if err != nil {
+			return err
+		}
+
+		if decision.Status == constant.RoleApprovalStatusApproved {
+			if err := s.fulfillRoleApproval(ctx, approval, approverID, approverLevel); err != nil {
+				return err
+			}
+		}
+
+		now := time.Now()
+		approval.Status = decision.Status
+		approval.Reason = decision.Reason
+		approval.ApproverID = &approverID
+		approval.DecidedAt = &now
+
+		if _, err := s.roleRepo.UpdateRoleApproval(ctx, approval); err != nil {
+			return apperror.NewInternalError(fmt.Errorf("failed to update approval request: %w", err))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info().
+		Str("approval_id", approvalID.String()).
+		Str("approver_id", approverID.String()).
+		Str("status", decision.Status).
+		Msg("Role approval request decided")
+
+	return s.getRoleApprovalResponse(ctx, approvalID)
+}
+
+// CancelRoleApprovalRequest cancels a pending role approval request. Only the requester can cancel.
+func (s *roleService) CancelRoleApprovalRequest(ctx context.Context, approvalID uuid.UUID, req dto.CancelRoleApprovalRequest, requestedBy uuid.UUID) (*dto.RoleApprovalWithOrganizationResponse, error) {
+	err := s.txManager.RunInTx(ctx, func(ctx context.Context) error {
+		approval, err := s.findRoleApprovalForUpdate(ctx, approvalID)
+		if err != nil {
+			return err
+		}
+
+		if approval.RequestedBy != requestedBy {
+			return apperror.NewForbiddenError("Only the requester can cancel a role request")
+		}
+
+		if err := validateRoleApprovalTransition(approval.Status, constant.RoleApprovalStatusCancelled); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		approval.Status = constant.RoleApprovalStatusCancelled
+		approval.Reason = req.Reason
+		approval.DecidedAt = &now
+
+		if _, err := s.roleRepo.UpdateRoleApproval(ctx, approval); err != nil {
+			return apperror.NewInternalError(fmt.Errorf("failed to cancel approval request: %w", err))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.getRoleApprovalResponse(ctx, approvalID)
+}
+
+// findRoleApproval loads a role approval request and translates repository errors.
+func (s *roleService) findRoleApproval(ctx context.Context, approvalID uuid.UUID) (*model.RoleApproval, error) {
 	approval, err := s.roleRepo.FindRoleApprovalByID(ctx, approvalID)
+	return approval, translateRoleApprovalError(err)
+}
+
+// findRoleApprovalForUpdate loads a role approval request and locks it for the surrounding transaction.
+func (s *roleService) findRoleApprovalForUpdate(ctx context.Context, approvalID uuid.UUID) (*model.RoleApproval, error) {
+	approval, err := s.roleRepo.FindRoleApprovalByIDForUpdate(ctx, approvalID)
+	return approval, translateRoleApprovalError(err)
+}
+
+// translateRoleApprovalError maps a role approval lookup error to an application error.
+func translateRoleApprovalError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return apperror.NewNotFoundError("role approval request")
+	}
+	return apperror.NewInternalError(fmt.Errorf("failed to fetch approval request: %w", err))
+}
+
+// getRoleApprovalResponse reloads a role approval request with its relations and maps it to a response.
+func (s *roleService) getRoleApprovalResponse(ctx context.Context, approvalID uuid.UUID) (*dto.RoleApprovalWithOrganizationResponse, error) {
+	approval, err := s.findRoleApproval(ctx, approvalID)
+	if err != nil {
+		return nil, err
+	}
+	return s.mapRoleApprovalToResponse(approval), nil
+}
+
+// checkRoleApprovalEligibility ensures the approver may decide on the request and returns the approver's level.
+// Approvers must rank above the requested level, new organizations need a platform level approver,
+// and requests for an existing organization need an approver with access to that organization.
+func (s *roleService) checkRoleApprovalEligibility(ctx context.Context, approval *model.RoleApproval, approverID uuid.UUID) (int, error) {
+	if approval.RequestedBy == approverID {
+		return 0, apperror.NewForbiddenError("You cannot decide on your own role request")
+	}
+
+	approver, err := s.userRepo.FindByIDWithRole(ctx, approverID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, apperror.NewNotFoundError("role approval request")
+			return 0, apperror.NewNotFoundError("approver")
 		}
-		return nil, apperror.NewInternalError(fmt.Errorf("failed to fetch approval request: %w", err))
+		return 0, apperror.NewInternalError(fmt.Errorf("failed to fetch approver: %w", err))
+	}
+	if approver.Role == nil {
+		return 0, apperror.NewForbiddenError("Approver has no role assigned")
 	}
 
-	if approval.Status != "pending" {
-		return nil, apperror.NewConflictError("approval request has already been processed")
+	approverLevel := approver.Role.Level
+	if approverLevel <= approval.RequestedLevel {
+		return 0, apperror.NewForbiddenError(fmt.Sprintf("Your level (%d) must be above the requested level (%d)", approverLevel, approval.RequestedLevel))
 	}
 
-	approval.Status = decision.Status
-	approval.Reason = decision.Reason
-	approval.ApproverID = &approverID
+	if approval.IsNewOrganization {
+		if approverLevel < constant.RoleLevelPlatformManager {
+			return 0, apperror.NewForbiddenError("Only platform level users can approve requests that create a new organization")
+		}
+		return approverLevel, nil
+	}
 
-	updatedApproval, err := s.roleRepo.UpdateRoleApproval(ctx, approval)
+	if approval.OrganizationID != nil && approverLevel < constant.RoleLevelPlatformManager {
+		accessibleOrgIDs, err := s.orgService.GetAccessibleOrganizationIDs(ctx, approverID, approverLevel)
+		if err != nil {
+			return 0, apperror.NewInternalError(fmt.Errorf("failed to resolve approver organizations: %w", err))
+		}
+		if !slices.Contains(accessibleOrgIDs, *approval.OrganizationID) {
+			return 0, apperror.NewForbiddenError("You do not have access to the organization of this request")
+		}
+	}
+
+	return approverLevel, nil
+}
+
+// fulfillRoleApproval creates the requested role and places the requester in the requested organization.
+func (s *roleService) fulfillRoleApproval(ctx context.Context, approval *model.RoleApproval, approverID uuid.UUID, approverLevel int) error {
+	var organizationTypes []string
+	if approval.IsNewOrganization {
+		organizationTypes = []string{approval.RequestedOrganizationType}
+	} else if approval.Organization != nil {
+		organizationTypes = []string{approval.Organization.OrganizationType}
+	}
+
+	role, err := s.CreateRole(ctx, dto.CreateRoleRequest{
+		Name:              approval.RequestedRoleName,
+		Description:       approval.Description,
+		Level:             approval.RequestedLevel,
+		PredefinedName:    s.getPredefinedNameByLevel(approval.RequestedLevel),
+		OrganizationTypes: organizationTypes,
+	}, approverLevel)
 	if err != nil {
-		return nil, apperror.NewInternalError(fmt.Errorf("failed to update approval request: %w", err))
+		return err
+	}
+	roleID := role.ID
+	approval.CreatedRoleID = &roleID
+
+	if approval.IsNewOrganization {
+		org, err := s.orgService.CreateOrganization(ctx, dto.CreateOrganizationRequest{
+			Name:             approval.RequestedOrganizationName,
+			OrganizationType: approval.RequestedOrganizationType,
+			Description:      approval.OrganizationDescription,
+		}, approval.RequestedBy)
+		if err != nil {
+			return err
+		}
+		approval.OrganizationID = &org.ID
+		approval.OrganizationCode = org.Code
 	}
 
-	// If approved, create the actual role
-	if decision.Status == "approved" {
-		roleRequest := dto.CreateRoleRequest{
-			Name:           approval.RequestedRoleName,
-			Description:    approval.Description,
-			Level:          approval.RequestedLevel,
-			PredefinedName: s.getPredefinedNameByLevel(approval.RequestedLevel),
+	if approval.OrganizationID != nil {
+		if err := s.assignApprovedRoleInOrganization(ctx, approval.RequestedBy, *approval.OrganizationID, roleID, approverID); err != nil {
+			return err
 		}
+	}
 
-		// For approved role creation, use system-level access (level 100) to bypass hierarchical validation
-		// since the approval process already validates the appropriateness of the role level
-		_, err := s.CreateRole(ctx, roleRequest, constant.RoleLevelPlatformAdmin)
-		if err != nil {
-			// Log the error but don't fail the approval process
-			log.Error().Err(err).Msgf("Failed to create role after approval for request %s", approvalID)
+	// Requesters without a global role (e.g. new OAuth users) take the approved role as their primary role
+	requester, err := s.userRepo.FindByID(ctx, approval.RequestedBy)
+	if err != nil {
+		return apperror.NewInternalError(fmt.Errorf("failed to fetch requester: %w", err))
+	}
+	if requester.RoleID == nil {
+		requester.RoleID = &roleID
+		if err := s.userRepo.Update(ctx, requester); err != nil {
+			return apperror.NewInternalError(fmt.Errorf("failed to assign approved role to requester: %w", err))
 		}
 	}
 
-	return s.mapRoleApprovalToResponse(updatedApproval), nil
+	return nil
+}
+
+// assignApprovedRoleInOrganization makes the user an active member of the organization with the approved role.
+func (s *roleService) assignApprovedRoleInOrganization(ctx context.Context, userID, organizationID, roleID, approverID uuid.UUID) error {
+	const reason = "Approved role request"
+	membership, err := s.membershipRepo.Find(ctx, userID, organizationID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return apperror.NewInternalError(fmt.Errorf("failed to check organization membership: %w", err))
+	}
+
+	// Active members only change role; anyone else joins, or rejoins, within the member limit
+	if err == nil && membership.IsActive {
+		update := dto.UpdateUserOrganizationRequest{RoleID: &roleID, IsActive: true, Reason: reason}
+		_, err := s.membershipService.UpdateMember(ctx, userID, organizationID, update, approverID)
+		return err
+	}
+
+	_, err = s.membershipService.AddMember(ctx, dto.AssignUserToOrganizationRequest{
+		UserID:         userID,
+		OrganizationID: organizationID,
+		RoleID:         &roleID,
+		IsActive:       true,
+		Reason:         reason,
+	}, approverID)
+	return err
 }
-*/
 
 // GetPredefinedRoleOptions returns the available predefined role options based on user's level (hierarchical access control).
 func (s *roleService) GetPredefinedRoleOptions(ctx context.Context, userLevel int) ([]dto.PredefinedRoleOption, error) {
@@ -437,19 +795,26 @@ func (s *roleService) GetPredefinedRoleOptions(ctx context.Context, userLevel in
 	return filteredOptions, nil
 }
 
-/*
 // Helper function to map RoleApproval model to response DTO
-func (s *roleService) mapRoleApprovalToResponse(approval *model.RoleApproval) *dto.RoleApprovalResponse {
-	response := &dto.RoleApprovalResponse{
-		ID:                approval.ID,
-		RequestedRoleName: approval.RequestedRoleName,
-		RequestedLevel:    approval.RequestedLevel,
-		Description:       approval.Description,
-		RequestedBy:       approval.RequestedBy,
-		Status:            approval.Status,
-		Reason:            approval.Reason,
-		CreatedAt:         approval.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
-		UpdatedAt:         approval.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+func (s *roleService) mapRoleApprovalToResponse(approval *model.RoleApproval) *dto.RoleApprovalWithOrganizationResponse {
+	response := &dto.RoleApprovalWithOrganizationResponse{
+		RoleApprovalResponse: dto.RoleApprovalResponse{
+			ID:                approval.ID,
+			RequestedRoleName: approval.RequestedRoleName,
+			RequestedLevel:    approval.RequestedLevel,
+			Description:       approval.Description,
+			RequestedBy:       approval.RequestedBy,
+			Status:            approval.Status,
+			Reason:            approval.Reason,
+			CreatedRoleID:     approval.CreatedRoleID,
+			CreatedAt:         approval.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+			UpdatedAt:         approval.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		},
+		Organization:              util.MapOrganizationToResponse(approval.Organization),
+		OrganizationCode:          approval.OrganizationCode,
+		IsNewOrganization:         approval.IsNewOrganization,
+		RequestedOrganizationName: approval.RequestedOrganizationName,
+		RequestedOrganizationType: approval.RequestedOrganizationType,
 	}
 
 	if approval.RequestedByUser.Username != "" {
@@ -464,9 +829,13 @@ func (s *roleService) mapRoleApprovalToResponse(approval *model.RoleApproval) *d
 		}
 	}
 
+	if approval.DecidedAt != nil {
+		decidedAt := approval.DecidedAt.Format("2006-01-02T15:04:05Z07:00")
+		response.DecidedAt = &decidedAt
+	}
+
 	return response
 }
-*/
 
 // Helper function to get predefined name by level
 func (s *roleService) getPredefinedNameByLevel(level int) string {
@@ -613,16 +982,8 @@ func (s *roleService) DeletePermission(ctx context.Context, id uuid.UUID) error
 // GetRolesForOrganizationType returns roles that are applicable to a specific organization type.
 func (s *roleService) GetRolesForOrganizationType(ctx context.Context, organizationType string, userLevel int) ([]dto.RoleResponse, error) {
 	// Validate organization type
-	validTypes := []string{"platform", "holding", "company", "store"}
-	isValid := false
-	for _, validType := range validTypes {
-		if organizationType == validType {
-			isValid = true
-			break
-		}
-	}
-	if !isValid {
-		return nil, apperror.NewValidationError("Invalid organization type. Must be one of: platform, holding, company, store")
+	if _, err := findActiveOrganizationType(ctx, s.orgTypeRepo, organizationType); err != nil {
+		return nil, err
 	}
 
 	// Get roles for the organization type
@@ -672,20 +1033,20 @@ func (s *roleService) GetRolesForOrganizationType(ctx context.Context, organizat
 
 // AssignRoleToUserInOrganization assigns a role to a user within a specific organization context.
 func (s *roleService) AssignRoleToUserInOrganization(ctx context.Context, req dto.OrganizationRoleAssignmentRequest) (*dto.OrganizationRoleResponse, error) {
-	// This functionality is handled by the user service's AssignUserToOrganization method
+	// This functionality is handled by the membership service's AddMember method
 	// which already includes role assignment. This method can be implemented for more
 	// specific role management if needed.
 
 	// For now, return a placeholder implementation
-	return nil, apperror.NewValidationError("Use UserService.AssignUserToOrganization for role assignment")
+	return nil, apperror.NewValidationError("Use MembershipService.AddMember for role assignment")
 }
 
 // GetUserRolesInOrganization retrieves all roles a user has in a specific organization.
 func (s *roleService) GetUserRolesInOrganization(ctx context.Context, userID, organizationID uuid.UUID) ([]dto.OrganizationRoleResponse, error) {
-	// This functionality is handled by the user service's GetUserOrganizations method
+	// This functionality is handled by the membership service's ListUserMemberships method
 	// which already includes role information. This method can be implemented for more
 	// specific role queries if needed.
 
 	// For now, return a placeholder implementation
-	return nil, apperror.NewValidationError("Use UserService.GetUserOrganizations for user role information")
+	return nil, apperror.NewValidationError("Use MembershipService.ListUserMemberships for user role information")
 }
@@ -15,10 +15,12 @@ type RoleServiceInterface interface {
 	UpdateRole(ctx context.Context, roleID uuid.UUID, req dto.UpdateRoleRequest, userLevel int) (*dto.RoleResponse, error)
 	UpdateRolePermissions(ctx context.Context, roleID uuid.UUID, permissionNames []string) error
 
-	// DISABLED: Role Approval Workflow methods
-	// CreateRoleApprovalRequest(ctx context.Context, req dto.CreateRoleApprovalRequest, requestedBy uuid.UUID) (*dto.RoleApprovalResponse, error)
-	// ListRoleApprovalRequests(ctx context.Context) ([]dto.RoleApprovalResponse, error)
-	// ApproveRejectRoleRequest(ctx context.Context, approvalID uuid.UUID, decision dto.ApprovalDecisionRequest, approverID uuid.UUID) (*dto.RoleApprovalResponse, error)
+	// Role Approval Workflow methods
+	CreateRoleApprovalRequest(ctx context.Context, req dto.CreateRoleWithOrganizationRequest, requestedBy uuid.UUID) (*dto.RoleApprovalWithOrganizationResponse, error)
+	ListRoleApprovalRequests(ctx context.Context, status string) ([]dto.RoleApprovalWithOrganizationResponse, error)
+	ListMyRoleApprovalRequests(ctx context.Context, requestedBy uuid.UUID) ([]dto.RoleApprovalWithOrganizationResponse, error)
+	ApproveRejectRoleRequest(ctx context.Context, approvalID uuid.UUID, decision dto.ApprovalDecisionRequest, approverID uuid.UUID) (*dto.RoleApprovalWithOrganizationResponse, error)
+	CancelRoleApprovalRequest(ctx context.Context, approvalID uuid.UUID, req dto.CancelRoleApprovalRequest, requestedBy uuid.UUID) (*dto.RoleApprovalWithOrganizationResponse, error)
 	GetPredefinedRoleOptions(ctx context.Context, userLevel int) ([]dto.PredefinedRoleOption, error)
 
 	// Permission Management methods
@@ -31,4 +33,8 @@ type RoleServiceInterface interface {
 	GetRolesForOrganizationType(ctx context.Context, organizationType string, userLevel int) ([]dto.RoleResponse, error)
 	AssignRoleToUserInOrganization(ctx context.Context, req dto.OrganizationRoleAssignmentRequest) (*dto.OrganizationRoleResponse, error)
 	GetUserRolesInOrganization(ctx context.Context, userID, organizationID uuid.UUID) ([]dto.OrganizationRoleResponse, error)
+
+	// Default roles per organization type
+	ListOrganizationTypeDefaultRoles(ctx context.Context) ([]dto.OrganizationTypeDefaultRolesResponse, error)
+	UpdateOrganizationTypeDefaultRoles(ctx context.Context, organizationType string, req dto.UpdateOrganizationTypeDefaultRolesRequest, updatedBy uuid.UUID) (*dto.OrganizationTypeDefaultRolesResponse, error)
 }
@@ -0,0 +1,228 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"go-base-project/internal/apperror"
+	"go-base-project/internal/dto"
+	"go-base-project/internal/model"
+	"go-base-project/internal/repository"
+	"go-base-project/internal/util"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// defaultSearchLimit is the number of results per type when the request does not set one
+	defaultSearchLimit = 10
+	// searchSnippetRunes bounds highlighted snippets of long fields such as descriptions
+	searchSnippetRunes = 160
+)
+
+type searchService struct {
+	userRepo             repository.UserRepositoryInterface
+	orgRepo              repository.OrganizationRepositoryInterface
+	orgService           OrganizationServiceInterface
+	authorizationService AuthorizationServiceInterface
+}
+
+// NewSearchService creates a new instance of SearchService
+func NewSearchService(
+	userRepo repository.UserRepositoryInterface,
+	orgRepo repository.OrganizationRepositoryInterface,
+	orgService OrganizationServiceInterface,
+	authorizationService AuthorizationServiceInterface,
+) SearchServiceInterface {
+	return &searchService{
+		userRepo:             userRepo,
+		orgRepo:              orgRepo,
+		orgService:           orgService,
+		authorizationService: authorizationService,
+	}
+}
+
+// Search ranks users and organizations against the query. Users are searched with the same visibility
+// rules as the user listing and only for callers allowed to read users; organizations are limited to
+// the ones the caller can access.
+func (s *searchService) Search(ctx context.Context, req dto.SearchRequest, currentUserID uuid.UUID) (*dto.SearchResponse, error) {
+	term := strings.TrimSpace(req.Query)
+	if utf8.RuneCountInString(term) < 2 {
+		return nil, apperror.NewValidationError("Search query must contain at least 2 characters")
+	}
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+
+	visibility, err := resolveUserVisibility(ctx, s.userRepo, s.orgService, currentUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.SearchResponse{Query: term}
+	if searchIncludes(req.Types, "users") {
+		canReadUsers, err := s.canReadUsers(ctx, visibility.user)
+		if err != nil {
+			return nil, err
+		}
+		if canReadUsers {
+			filter := repository.UserListFilter{
+				VisibleBelow:   visibility.visibleBelow,
+				AccessibleOrgs: visibility.accessibleOrgs,
+				ExcludeUserID:  &currentUserID,
+			}
+			hits, err := s.userRepo.Search(ctx, filter, term, limit)
+			if err != nil {
+				return nil, apperror.NewInternalError(fmt.Errorf("failed to search users: %w", err))
+			}
+			response.Users = make([]dto.UserSearchResult, 0, len(hits))
+			for i := range hits {
+				response.Users = append(response.Users, dto.UserSearchResult{
+					User:       *util.MapUserToResponse(&hits[i].User),
+					Score:      hits[i].Score,
+					Highlights: searchHighlights(term, map[string]string{"username": hits[i].User.Username, "email": hits[i].User.Email}),
+				})
+			}
+		}
+	}
+
+	// Organization-level callers without any accessible organization have nothing to find
+	if searchIncludes(req.Types, "organizations") && (visibility.accessibleOrgs == nil || len(visibility.accessibleOrgs) > 0) {
+		hits, err := s.orgRepo.Search(ctx, term, visibility.accessibleOrgs, limit)
+		if err != nil {
+			return nil, apperror.NewInternalError(fmt.Errorf("failed to search organizations: %w", err))
+		}
+		response.Organizations = make([]dto.OrganizationSearchResult, 0, len(hits))
+		for i := range hits {
+			org := &hits[i].Organization
+			highlights := searchHighlights(term, map[string]string{"name": org.Name, "code": org.Code})
+			if snippet, ok := highlightMatches(org.Description, term, searchSnippetRunes); ok {
+				if highlights == nil {
+					highlights = make(map[string]string)
+				}
+				highlights["description"] = snippet
+			}
+			response.Organizations = append(response.Organizations, dto.OrganizationSearchResult{
+				Organization: *util.MapOrganizationToResponse(org),
+				Score:        hits[i].Score,
+				Highlights:   highlights,
+			})
+		}
+	}
+	return response, nil
+}
+
+// canReadUsers reports whether the global role of the caller grants users:read
+func (s *searchService) canReadUsers(ctx context.Context, user *model.User) (bool, error) {
+	if user.RoleID == nil {
+		return false, nil
+	}
+	isSuperAdmin, err := s.authorizationService.IsRoleSuperAdmin(ctx, *user.RoleID)
+	if err != nil {
+		return false, apperror.NewInternalError(fmt.Errorf("failed to check super admin status: %w", err))
+	}
+	if isSuperAdmin {
+		return true, nil
+	}
+	allowed, err := s.authorizationService.CheckPermission(ctx, *user.RoleID, "users:read")
+	if err != nil {
+		return false, apperror.NewInternalError(fmt.Errorf("failed to check permission: %w", err))
+	}
+	return allowed, nil
+}
+
+func searchIncludes(types []string, resultType string) bool {
+	if len(types) == 0 {
+		return true
+	}
+	for _, t := range types {
+		if t == resultType {
+			return true
+		}
+	}
+	return false
+}
+
+// searchHighlights highlights the fields whose values contain a word of term; nil when none does
+func searchHighlights(term string, fields map[string]string) map[string]string {
+	var highlights map[string]string
+	for field, value := range fields {
+		if highlighted, ok := highlightMatches(value, term, 0); ok {
+			if highlights == nil {
+				highlights = make(map[string]string)
+			}
+			highlights[field] = highlighted
+		}
+	}
+	return highlights
+}
+
+// highlightMatches wraps the case-insensitive occurrences of the words of term in value with <mark> and
+// escapes everything else as HTML. With maxRunes > 0 a longer value is cut to a snippet around the first
+// match. It reports false when no word occurs literally, as happens for fuzzy matches.
+func highlightMatches(value, term string, maxRunes int) (string, bool) {
+	runes := []rune(value)
+	folded := make([]rune, len(runes))
+	for i, r := range runes {
+		folded[i] = unicode.ToLower(r)
+	}
+
+	marked := make([]bool, len(runes))
+	first := -1
+	for _, word := range strings.Fields(term) {
+		needle := []rune(strings.ToLower(word))
+		for i := 0; i+len(needle) <= len(folded); i++ {
+			if string(folded[i:i+len(needle)]) != string(needle) {
+				continue
+			}
+			for j := i; j < i+len(needle); j++ {
+				marked[j] = true
+			}
+			if first == -1 || i < first {
+				first = i
+			}
+		}
+	}
+	if first == -1 {
+		return "", false
+	}
+
+	start, end := 0, len(runes)
+	if maxRunes > 0 && len(runes) > maxRunes {
+		start = first - maxRunes/4
+		if start < 0 {
+			start = 0
+		}
+		end = start + maxRunes
+		if end > len(runes) {
+			end = len(runes)
+			start = end - maxRunes
+		}
+	}
+
+	var b strings.Builder
+	if start > 0 {
+		b.WriteString("…")
+	}
+	for i := start; i < end; {
+		j := i
+		for j < end && marked[j] == marked[i] {
+			j++
+		}
+		segment := html.EscapeString(string(runes[i:j]))
+		if marked[i] {
+			segment = "<mark>" + segment + "</mark>"
+		}
+		b.WriteString(segment)
+		i = j
+	}
+	if end < len(runes) {
+		b.WriteString("…")
+	}
+	return b.String(), true
+}
@@ -0,0 +1,14 @@
+package service
+
+import (
+	"context"
+	"go-base-project/internal/dto"
+
+	"github.com/google/uuid"
+)
+
+// SearchServiceInterface defines ranked search across users and organizations
+type SearchServiceInterface interface {
+	// Search returns the users and organizations matching the query that the caller may see.
+	Search(ctx context.Context, req dto.SearchRequest, currentUserID uuid.UUID) (*dto.SearchResponse, error)
+}
@@ -0,0 +1,590 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go-base-project/internal/apperror"
+	"go-base-project/internal/cache"
+	"go-base-project/internal/constant"
+	"go-base-project/internal/dto"
+	"go-base-project/internal/model"
+	"go-base-project/internal/repository"
+	"go-base-project/internal/util"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+const (
+	// maxUserImportRows bounds the number of data rows of an import file
+	maxUserImportRows = 5000
+	// userImportBatchSize is the number of rows applied per transaction; a failing row fails its batch only
+	userImportBatchSize = 100
+)
+
+// userImportRequiredColumns must be present in the header row; organization_code, password and invite are optional
+var userImportRequiredColumns = []string{"username", "email", "role"}
+
+// userImportResult is the result file of an import as kept in Redis
+type userImportResult struct {
+	OwnerID uuid.UUID `json:"owner_id"`
+	CSV     []byte    `json:"csv"`
+}
+
+// userImportRow is a validated row waiting to be applied
+type userImportRow struct {
+	report   *dto.UserImportRowReport
+	password string
+	role     *model.Role
+	org      *model.Organization
+}
+
+type userImportService struct {
+	userRepo          repository.UserRepositoryInterface
+	roleRepo          repository.RoleRepositoryInterface
+	orgRepo           repository.OrganizationRepositoryInterface
+	txManager         repository.TransactionManagerInterface
+	orgService        OrganizationServiceInterface
+	invitationService InvitationServiceInterface
+	membershipService MembershipServiceInterface
+	redis             *redis.Client
+	validate          *validator.Validate
+}
+
+// NewUserImportService creates a new instance of UserImportService
+func NewUserImportService(
+	userRepo repository.UserRepositoryInterface,
+	roleRepo repository.RoleRepositoryInterface,
+	orgRepo repository.OrganizationRepositoryInterface,
+	txManager repository.TransactionManagerInterface,
+	orgService OrganizationServiceInterface,
+	invitationService InvitationServiceInterface,
+	membershipService MembershipServiceInterface,
+	redisClient *redis.Client,
+) UserImportServiceInterface {
+	return &userImportService{
+		userRepo:          userRepo,
+		roleRepo:          roleRepo,
+		orgRepo:           orgRepo,
+		txManager:         txManager,
+		orgService:        orgService,
+		invitationService: invitationService,
+		membershipService: membershipService,
+		redis:             redisClient,
+		validate:          validator.New(),
+	}
+}
+
+// ImportUsers validates the file and applies it unless dryRun is set. Invalid rows are never applied;
+// valid rows are applied in batches of userImportBatchSize, each in its own transaction.
+func (s *userImportService) ImportUsers(ctx context.Context, filename string, data []byte, dryRun bool, importedBy uuid.UUID) (*dto.UserImportReport, error) {
+	records, err := util.ReadSpreadsheet(filename, data)
+	if err != nil {
+		return nil, apperror.NewValidationError(err.Error())
+	}
+
+	planner, err := s.newImportPlanner(ctx, importedBy)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := planner.plan(ctx, records)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &dto.UserImportReport{
+		ImportID: uuid.New(),
+		DryRun:   dryRun,
+		Rows:     planner.reports,
+	}
+	if !dryRun {
+		for start := 0; start < len(rows); start += userImportBatchSize {
+			end := start + userImportBatchSize
+			if end > len(rows) {
+				end = len(rows)
+			}
+			s.applyBatch(ctx, rows[start:end], importedBy)
+
+			summarizeUserImport(report)
+			if err := reportJobProgress(ctx, end, len(rows), report); err != nil {
+				return nil, err
+			}
+		}
+		report.Applied = true
+	}
+	summarizeUserImport(report)
+
+	resultCSV, err := userImportResultCSV(report.Rows)
+	if err != nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to build import result file: %w", err))
+	}
+	encoded, err := json.Marshal(userImportResult{OwnerID: importedBy, CSV: resultCSV})
+	if err != nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to encode import result file: %w", err))
+	}
+	if err := s.redis.Set(ctx, cache.GetUserImportResultKey(report.ImportID), encoded, cache.UserImportResultDuration).Err(); err != nil {
+		// The report itself is complete, only the download is lost
+		log.Warn().Err(err).Str("import_id", report.ImportID.String()).Msg("Failed to store user import result file")
+	}
+
+	log.Info().
+		Str("imported_by", importedBy.String()).
+		Bool("dry_run", dryRun).
+		Int("rows", report.TotalRows).
+		Int("created", report.Created).
+		Int("invited", report.Invited).
+		Int("failed", report.Failed).
+		Msg("User import processed")
+	return report, nil
+}
+
+// GetImportResult returns the result file of an import; other users get a not found error.
+func (s *userImportService) GetImportResult(ctx context.Context, importID, requestedBy uuid.UUID) ([]byte, error) {
+	raw, err := s.redis.Get(ctx, cache.GetUserImportResultKey(importID)).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, apperror.NewNotFoundError("import result")
+		}
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to load import result file: %w", err))
+	}
+
+	var result userImportResult
+	if err := json.Unmarshal(raw, &result); err != nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to decode import result file: %w", err))
+	}
+	if result.OwnerID != requestedBy {
+		return nil, apperror.NewNotFoundError("import result")
+	}
+	return result.CSV, nil
+}
+
+// applyBatch applies a batch of valid rows in one transaction and records the outcome on their reports.
+// When anything in the batch fails, every row of the batch is reported as failed with that error.
+func (s *userImportService) applyBatch(ctx context.Context, rows []userImportRow, importedBy uuid.UUID) {
+	// Hash outside the transaction; bcrypt is deliberately slow
+	hashes := make([]string, len(rows))
+	for i, row := range rows {
+		if row.report.Action != constant.UserImportActionCreate {
+			continue
+		}
+		hash, err := bcrypt.GenerateFromPassword([]byte(row.password), bcrypt.DefaultCost)
+		if err != nil {
+			failUserImportBatch(rows, apperror.NewInternalError(fmt.Errorf("failed to hash password: %w", err)))
+			return
+		}
+		hashes[i] = string(hash)
+	}
+
+	userIDs := make([]*uuid.UUID, len(rows))
+	invitationURLs := make([]string, len(rows))
+	err := s.txManager.RunInTx(ctx, func(ctx context.Context) error {
+		// A row that does not fit the member limit of its organization rejects the whole batch
+		for i, row := range rows {
+			if row.report.Action == constant.UserImportActionInvite {
+				invitation, err := s.invitationService.CreateInvitation(ctx, row.org.ID, dto.CreateInvitationRequest{
+					RoleID: row.role.ID,
+					Email:  row.report.Email,
+				}, importedBy)
+				if err != nil {
+					return fmt.Errorf("row %d: %w", row.report.Row, err)
+				}
+				invitationURLs[i] = invitation.AcceptURL
+				continue
+			}
+
+			user := &model.User{
+				Username:     row.report.Username,
+				Email:        row.report.Email,
+				Password:     hashes[i],
+				RoleID:       &row.role.ID,
+				AuthProvider: "local",
+			}
+			if err := s.userRepo.Create(ctx, user); err != nil {
+				return fmt.Errorf("row %d: %w", row.report.Row, apperror.NewInternalError(fmt.Errorf("failed to create user: %w", err)))
+			}
+			userIDs[i] = &user.ID
+
+			if row.org == nil {
+				continue
+			}
+			if _, err := s.membershipService.AddMember(ctx, dto.AssignUserToOrganizationRequest{
+				UserID:         user.ID,
+				OrganizationID: row.org.ID,
+				RoleID:         &row.role.ID,
+				IsActive:       true,
+				Reason:         "Imported user",
+			}, importedBy); err != nil {
+				return fmt.Errorf("row %d: %w", row.report.Row, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		failUserImportBatch(rows, err)
+		return
+	}
+
+	for i, row := range rows {
+		row.report.UserID = userIDs[i]
+		row.report.InvitationURL = invitationURLs[i]
+		row.report.Status = constant.UserImportStatusCreated
+		if row.report.Action == constant.UserImportActionInvite {
+			row.report.Status = constant.UserImportStatusInvited
+		}
+	}
+}
+
+func failUserImportBatch(rows []userImportRow, err error) {
+	message := "an unexpected error occurred"
+	var appErr *apperror.AppError
+	if errors.As(err, &appErr) {
+		message = appErr.Message
+	}
+	// Errors of a single row carry its number; the others apply to the whole batch
+	if prefix, _, ok := strings.Cut(err.Error(), ": "); ok && strings.HasPrefix(prefix, "row ") {
+		message = prefix + ": " + message
+	}
+	for _, row := range rows {
+		row.report.Status = constant.UserImportStatusFailed
+		row.report.Errors = append(row.report.Errors, "Batch not applied: "+message)
+	}
+}
+
+func summarizeUserImport(report *dto.UserImportReport) {
+	report.TotalRows = len(report.Rows)
+	report.ValidRows, report.InvalidRows = 0, 0
+	report.Created, report.Invited, report.Failed = 0, 0, 0
+	for _, row := range report.Rows {
+		if row.Status == constant.UserImportStatusInvalid {
+			report.InvalidRows++
+			continue
+		}
+		report.ValidRows++
+		switch row.Status {
+		case constant.UserImportStatusCreated:
+			report.Created++
+		case constant.UserImportStatusInvited:
+			report.Invited++
+		case constant.UserImportStatusFailed:
+			report.Failed++
+		}
+	}
+}
+
+// userImportResultCSV renders the row reports as the downloadable result file
+func userImportResultCSV(rows []dto.UserImportRowReport) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	records := [][]string{{"row", "username", "email", "role", "organization_code", "action", "status", "user_id", "invitation_url", "errors"}}
+	for _, row := range rows {
+		userID := ""
+		if row.UserID != nil {
+			userID = row.UserID.String()
+		}
+		records = append(records, []string{
+			strconv.Itoa(row.Row), row.Username, row.Email, row.Role, row.OrganizationCode, row.Action, row.Status,
+			userID, row.InvitationURL, strings.Join(row.Errors, "; "),
+		})
+	}
+	if err := writer.WriteAll(records); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// userImportPlanner validates import rows against the CreateUserRequest rules, the existing users and the
+// level and organizations of the importing user.
+type userImportPlanner struct {
+	service    *userImportService
+	visibility *userVisibility
+
+	roles          map[string]*model.Role         // By lower-case name; nil marks an unknown role
+	orgs           map[string]*model.Organization // By code; nil marks an unknown organization
+	eligibleRoles  map[string]map[uuid.UUID]bool  // Role IDs by organization type
+	accessibleOrgs map[uuid.UUID]bool
+	usernames      map[string]int // Row that claimed a lower-case username
+	emails         map[string]int // Row that claimed a lower-case email
+	invitations    map[string]int // Row that invited an email to an organization
+
+	reports []dto.UserImportRowReport
+}
+
+func (s *userImportService) newImportPlanner(ctx context.Context, importedBy uuid.UUID) (*userImportPlanner, error) {
+	visibility, err := resolveUserVisibility(ctx, s.userRepo, s.orgService, importedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	planner := &userImportPlanner{
+		service:       s,
+		visibility:    visibility,
+		roles:         make(map[string]*model.Role),
+		orgs:          make(map[string]*model.Organization),
+		eligibleRoles: make(map[string]map[uuid.UUID]bool),
+		usernames:     make(map[string]int),
+		emails:        make(map[string]int),
+		invitations:   make(map[string]int),
+	}
+	if visibility.accessibleOrgs != nil {
+		planner.accessibleOrgs = make(map[uuid.UUID]bool, len(visibility.accessibleOrgs))
+		for _, orgID := range visibility.accessibleOrgs {
+			planner.accessibleOrgs[orgID] = true
+		}
+	}
+	return planner, nil
+}
+
+// plan validates every data row. Only a malformed file returns an error; row problems are reported on the row.
+func (p *userImportPlanner) plan(ctx context.Context, records [][]string) ([]userImportRow, error) {
+	if len(records) == 0 {
+		return nil, apperror.NewValidationError("The import file is empty")
+	}
+
+	columns := make(map[string]int)
+	for i, name := range records[0] {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range userImportRequiredColumns {
+		if _, ok := columns[name]; !ok {
+			return nil, apperror.NewValidationError(fmt.Sprintf("The header row must contain a %s column", name))
+		}
+	}
+	if len(records)-1 > maxUserImportRows {
+		return nil, apperror.NewValidationError(fmt.Sprintf("An import may contain at most %d rows, this one contains %d", maxUserImportRows, len(records)-1))
+	}
+
+	var rows []userImportRow
+	// Rows keep pointers into reports, so it must never grow beyond this capacity
+	p.reports = make([]dto.UserImportRowReport, 0, len(records)-1)
+	for i, record := range records[1:] {
+		cell := func(name string) string {
+			if index, ok := columns[name]; ok && index < len(record) {
+				return strings.TrimSpace(record[index])
+			}
+			return ""
+		}
+		if strings.TrimSpace(strings.Join(record, "")) == "" {
+			continue // Spreadsheets often end with blank rows
+		}
+
+		p.reports = append(p.reports, dto.UserImportRowReport{
+			Row:              i + 2,
+			Username:         cell("username"),
+			Email:            strings.ToLower(cell("email")),
+			Role:             cell("role"),
+			OrganizationCode: strings.ToUpper(cell("organization_code")),
+		})
+		row, err := p.planRow(ctx, &p.reports[len(p.reports)-1], cell("password"), cell("invite"))
+		if err != nil {
+			return nil, err
+		}
+		if row != nil {
+			rows = append(rows, *row)
+		}
+	}
+	return rows, nil
+}
+
+// planRow validates one row and returns it when it can be applied
+func (p *userImportPlanner) planRow(ctx context.Context, report *dto.UserImportRowReport, password, invite string) (*userImportRow, error) {
+	row := &userImportRow{report: report, password: password}
+	report.Action = constant.UserImportActionCreate
+	switch strings.ToLower(invite) {
+	case "", "false", "no", "0":
+	case "true", "yes", "1":
+		report.Action = constant.UserImportActionInvite
+	default:
+		report.Errors = append(report.Errors, "invite must be true or false")
+	}
+
+	role, err := p.resolveRole(ctx, report)
+	if err != nil {
+		return nil, err
+	}
+	row.role = role
+
+	if report.OrganizationCode != "" {
+		org, err := p.resolveOrganization(ctx, report, role)
+		if err != nil {
+			return nil, err
+		}
+		row.org = org
+	} else if report.Action == constant.UserImportActionInvite {
+		report.Errors = append(report.Errors, "organization_code is required for invitations")
+	}
+
+	if report.Action == constant.UserImportActionInvite {
+		if err := p.service.validate.Var(report.Email, "required,email,max=255"); err != nil {
+			report.Errors = append(report.Errors, "email must be a valid email address")
+		}
+		key := report.Email + "/" + report.OrganizationCode
+		if previous, ok := p.invitations[key]; ok {
+			report.Errors = append(report.Errors, fmt.Sprintf("Row %d already invites this email to the organization", previous))
+		} else {
+			p.invitations[key] = report.Row
+		}
+	} else if err := p.checkNewUser(ctx, report, password, role); err != nil {
+		return nil, err
+	}
+
+	if len(report.Errors) > 0 {
+		report.Status = constant.UserImportStatusInvalid
+		return nil, nil
+	}
+	report.Status = constant.UserImportStatusValid
+	return row, nil
+}
+
+// checkNewUser applies the CreateUserRequest rules to a row and checks its username and email are free
+func (p *userImportPlanner) checkNewUser(ctx context.Context, report *dto.UserImportRowReport, password string, role *model.Role) error {
+	req := dto.CreateUserRequest{
+		Username:     report.Username,
+		Email:        report.Email,
+		Password:     password,
+		RoleID:       uuid.Nil,
+		AuthProvider: "local",
+	}
+	if role != nil {
+		req.RoleID = role.ID
+	}
+	if err := p.service.validate.Struct(req); err != nil {
+		var validationErrors validator.ValidationErrors
+		if errors.As(err, &validationErrors) {
+			for _, fieldError := range validationErrors {
+				if fieldError.StructField() == "RoleID" {
+					continue // Reported by resolveRole
+				}
+				report.Errors = append(report.Errors, fmt.Sprintf("%s failed the '%s' rule", strings.ToLower(fieldError.Field()), fieldError.Tag()))
+			}
+		}
+	}
+	if password == "" {
+		report.Errors = append(report.Errors, "password is required for new accounts; set invite to true to send an invitation instead")
+	}
+	if len(report.Username) > 50 {
+		report.Errors = append(report.Errors, "username must be at most 50 characters")
+	}
+
+	if report.Username != "" {
+		key := strings.ToLower(report.Username)
+		if previous, ok := p.usernames[key]; ok {
+			report.Errors = append(report.Errors, fmt.Sprintf("username is already used by row %d", previous))
+		} else {
+			p.usernames[key] = report.Row
+			if _, err := p.service.userRepo.FindByUsername(ctx, report.Username); err == nil {
+				report.Errors = append(report.Errors, "username already exists")
+			} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+				return apperror.NewInternalError(fmt.Errorf("failed to check username availability: %w", err))
+			}
+		}
+	}
+	if report.Email != "" {
+		if previous, ok := p.emails[report.Email]; ok {
+			report.Errors = append(report.Errors, fmt.Sprintf("email is already used by row %d", previous))
+		} else {
+			p.emails[report.Email] = report.Row
+			if _, err := p.service.userRepo.FindByEmail(ctx, report.Email); err == nil {
+				report.Errors = append(report.Errors, "email already exists")
+			} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+				return apperror.NewInternalError(fmt.Errorf("failed to check email availability: %w", err))
+			}
+		}
+	}
+	return nil
+}
+
+// resolveRole finds the role of a row by name and checks the importing user may grant it
+func (p *userImportPlanner) resolveRole(ctx context.Context, report *dto.UserImportRowReport) (*model.Role, error) {
+	if report.Role == "" {
+		report.Errors = append(report.Errors, "role is required")
+		return nil, nil
+	}
+
+	key := strings.ToLower(report.Role)
+	role, ok := p.roles[key]
+	if !ok {
+		found, err := p.service.roleRepo.FindByName(ctx, report.Role)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.NewInternalError(fmt.Errorf("failed to find role: %w", err))
+		}
+		if err == nil {
+			role = found
+		}
+		p.roles[key] = role
+	}
+
+	switch {
+	case role == nil:
+		report.Errors = append(report.Errors, fmt.Sprintf("Role '%s' not found", report.Role))
+	case !role.IsActive:
+		report.Errors = append(report.Errors, fmt.Sprintf("Role '%s' is not active", role.Name))
+	case role.Level >= constant.RoleLevelSuperAdmin:
+		report.Errors = append(report.Errors, "The super admin role cannot be imported")
+	case role.Level >= p.visibility.level && p.visibility.level < constant.RoleLevelSuperAdmin:
+		report.Errors = append(report.Errors, constant.ErrMsgInsufficientAuthorityLevel)
+	default:
+		return role, nil
+	}
+	return nil, nil
+}
+
+// resolveOrganization finds the organization of a row by code and checks the importing user can access it
+// and the role can be held there
+func (p *userImportPlanner) resolveOrganization(ctx context.Context, report *dto.UserImportRowReport, role *model.Role) (*model.Organization, error) {
+	org, ok := p.orgs[report.OrganizationCode]
+	if !ok {
+		found, err := p.service.orgRepo.FindByCode(ctx, report.OrganizationCode)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.NewInternalError(fmt.Errorf("failed to find organization: %w", err))
+		}
+		if err == nil {
+			org = found
+		}
+		p.orgs[report.OrganizationCode] = org
+	}
+
+	// Organizations outside the importing user's reach are reported as unknown
+	if org == nil || (p.accessibleOrgs != nil && !p.accessibleOrgs[org.ID]) {
+		report.Errors = append(report.Errors, fmt.Sprintf("Organization %s not found", report.OrganizationCode))
+		return nil, nil
+	}
+	if org.IsArchived() {
+		report.Errors = append(report.Errors, fmt.Sprintf("Organization %s is archived", org.Code))
+		return nil, nil
+	}
+	if !org.IsActive {
+		report.Errors = append(report.Errors, fmt.Sprintf("Organization %s is not active", org.Code))
+		return nil, nil
+	}
+
+	if role != nil {
+		eligible, ok := p.eligibleRoles[org.OrganizationType]
+		if !ok {
+			roles, err := p.service.roleRepo.FindRolesByOrganizationType(ctx, org.OrganizationType)
+			if err != nil {
+				return nil, apperror.NewInternalError(fmt.Errorf("failed to fetch roles of organization type: %w", err))
+			}
+			eligible = make(map[uuid.UUID]bool, len(roles))
+			for _, r := range roles {
+				eligible[r.ID] = true
+			}
+			p.eligibleRoles[org.OrganizationType] = eligible
+		}
+		if !eligible[role.ID] {
+			report.Errors = append(report.Errors, fmt.Sprintf("Role '%s' is not available for %s organizations", role.Name, org.OrganizationType))
+			return nil, nil
+		}
+	}
+	return org, nil
+}
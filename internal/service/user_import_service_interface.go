@@ -0,0 +1,17 @@
+package service
+
+import (
+	"context"
+	"go-base-project/internal/dto"
+
+	"github.com/google/uuid"
+)
+
+// UserImportServiceInterface defines bulk onboarding of users from spreadsheet files
+type UserImportServiceInterface interface {
+	// ImportUsers validates every row of a CSV or XLSX file and, unless it is a dry run, applies the valid
+	// rows in batches. The report of both is kept as a downloadable result file.
+	ImportUsers(ctx context.Context, filename string, data []byte, dryRun bool, importedBy uuid.UUID) (*dto.UserImportReport, error)
+	// GetImportResult returns the CSV result file of an import to the user who ran it.
+	GetImportResult(ctx context.Context, importID, requestedBy uuid.UUID) ([]byte, error)
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"go-base-project/internal/apperror"
+	"go-base-project/internal/dto"
+	"go-base-project/internal/model"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+// phonePattern accepts phone numbers in E.164 format
+var phonePattern = regexp.MustCompile(`^\+[1-9][0-9]{6,14}$`)
+
+// GetUserProfile returns the profile of a user.
+func (s *userService) GetUserProfile(ctx context.Context, userID uuid.UUID) (*dto.UserProfileResponse, error) {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.NewNotFoundError("user")
+		}
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to find user: %w", err))
+	}
+	return mapUserProfileToResponse(user), nil
+}
+
+// UpdateUserProfile applies the fields present in req to the profile of a user. Users edit their own
+// profile; editing someone else's requires authority over them.
+func (s *userService) UpdateUserProfile(ctx context.Context, userID uuid.UUID, req dto.UpdateUserProfileRequest, updatedBy uuid.UUID) (*dto.UserProfileResponse, error) {
+	user, err := s.userRepo.FindByIDWithRole(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.NewNotFoundError("user")
+		}
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to find user: %w", err))
+	}
+	if userID != updatedBy {
+		if err := checkAuthorityOverUser(ctx, s.userRepo, user, updatedBy); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := applyProfileUpdate(user, req); err != nil {
+		return nil, apperror.NewValidationError(err.Error())
+	}
+	if err := s.userRepo.UpdateProfile(ctx, user); err != nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to update user profile: %w", err))
+	}
+
+	log.Info().Str("user_id", userID.String()).Str("updated_by", updatedBy.String()).Msg("User profile updated")
+	return s.GetUserProfile(ctx, userID)
+}
+
+// applyProfileUpdate validates the fields present in req and sets them on user. Empty values clear a field.
+func applyProfileUpdate(user *model.User, req dto.UpdateUserProfileRequest) error {
+	if req.DisplayName != nil {
+		user.DisplayName = strings.TrimSpace(*req.DisplayName)
+	}
+	if req.JobTitle != nil {
+		user.JobTitle = strings.TrimSpace(*req.JobTitle)
+	}
+	if req.Phone != nil {
+		phone := strings.TrimSpace(*req.Phone)
+		if phone != "" && !phonePattern.MatchString(phone) {
+			return fmt.Errorf("phone %q must be in E.164 format, e.g. +6281234567890", phone)
+		}
+		user.Phone = phone
+	}
+	if req.Locale != nil {
+		locale := strings.TrimSpace(*req.Locale)
+		if locale != "" && !languageTagPattern.MatchString(locale) {
+			return fmt.Errorf("%q is not a valid language tag", locale)
+		}
+		user.Locale = locale
+	}
+	if req.Timezone != nil {
+		timezone := strings.TrimSpace(*req.Timezone)
+		if timezone != "" {
+			if _, err := time.LoadLocation(timezone); err != nil || timezone == "Local" {
+				return fmt.Errorf("%q is not a valid IANA time zone", timezone)
+			}
+		}
+		user.Timezone = timezone
+	}
+	return nil
+}
+
+// mapUserProfileToResponse converts the profile fields of a user into the profile response.
+func mapUserProfileToResponse(user *model.User) *dto.UserProfileResponse {
+	return &dto.UserProfileResponse{
+		UserID:      user.ID,
+		Username:    user.Username,
+		Email:       user.Email,
+		AvatarURL:   user.AvatarURL,
+		DisplayName: user.DisplayName,
+		Phone:       user.Phone,
+		Locale:      user.Locale,
+		Timezone:    user.Timezone,
+		JobTitle:    user.JobTitle,
+		UpdatedAt:   user.UpdatedAt,
+	}
+}
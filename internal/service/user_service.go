@@ -12,7 +12,6 @@ import (
 	"fmt"
 	"math"
 	"net/http"
-	"time"
 
 	"github.com/go-redis/redis/v8"
 	"github.com/google/uuid"
@@ -22,19 +21,21 @@ import (
 
 // userService implements the UserService interface for user management.
 type userService struct {
-	userRepo   repository.UserRepositoryInterface
-	roleRepo   repository.RoleRepositoryInterface
-	orgService OrganizationServiceInterface
-	redis      *redis.Client
+	userRepo       repository.UserRepositoryInterface
+	roleRepo       repository.RoleRepositoryInterface
+	membershipRepo repository.MembershipRepositoryInterface
+	orgService     OrganizationServiceInterface
+	redis          *redis.Client
 }
 
 // NewUserService creates a new instance of userService.
-func NewUserService(userRepo repository.UserRepositoryInterface, roleRepo repository.RoleRepositoryInterface, orgService OrganizationServiceInterface, redisClient *redis.Client) UserServiceInterface {
+func NewUserService(userRepo repository.UserRepositoryInterface, roleRepo repository.RoleRepositoryInterface, membershipRepo repository.MembershipRepositoryInterface, orgService OrganizationServiceInterface, redisClient *redis.Client) UserServiceInterface {
 	return &userService{
-		userRepo:   userRepo,
-		roleRepo:   roleRepo,
-		orgService: orgService,
-		redis:      redisClient,
+		userRepo:       userRepo,
+		roleRepo:       roleRepo,
+		membershipRepo: membershipRepo,
+		orgService:     orgService,
+		redis:          redisClient,
 	}
 }
 
@@ -105,6 +106,7 @@ func (s *userService) CreateUser(ctx context.Context, req dto.CreateUserRequest)
 		RoleID:       &req.RoleID,
 		AuthProvider: authProvider,
 		GoogleID:     req.GoogleID,
+		Status:       req.Status,
 	}
 
 	if err := s.userRepo.Create(ctx, newUser); err != nil {
@@ -120,10 +122,13 @@ func (s *userService) CreateUser(ctx context.Context, req dto.CreateUserRequest)
 	return util.MapUserToResponse(createdUser), nil
 }
 
-// ListUsers retrieves a paginated list of users with level and organization filtering
-func (s *userService) ListUsers(ctx context.Context, page, limit int, search string) (*dto.PagedUserResponse, error) {
+// defaultUserListSort is the order of user listings that do not ask for one
+const defaultUserListSort = "username"
+
+// ListUsers retrieves a page of the users the caller may see, filtered and sorted as requested
+func (s *userService) ListUsers(ctx context.Context, req dto.ListUsersRequest) (*dto.PagedUserResponse, error) {
 	// Apply default pagination values and validate using utility function
-	page, limit, offset := util.ValidateAndSetPaginationParams(page, limit)
+	page, limit, offset := util.ValidateAndSetPaginationParams(req.Page, req.Limit)
 
 	// Get current user making the request
 	currentUserID, ok := ctx.Value("current_user_id").(uuid.UUID)
@@ -131,113 +136,62 @@ func (s *userService) ListUsers(ctx context.Context, page, limit int, search str
 		return nil, apperror.NewAppError(http.StatusUnauthorized, constant.ErrMsgAuthorizationContextMissing, nil)
 	}
 
-	// Get current user with role information
-	currentUser, err := s.userRepo.FindByIDWithRole(ctx, currentUserID)
-	if err != nil {
-		return nil, apperror.NewInternalError(fmt.Errorf("failed to find current user: %w", err))
+	if req.MinLevel != nil && req.MaxLevel != nil && *req.MinLevel > *req.MaxLevel {
+		return nil, apperror.NewValidationError("min_level must not be greater than max_level")
 	}
-
-	// Determine current user's level
-	var currentUserLevel int
-	if currentUser.Role != nil {
-		currentUserLevel = currentUser.Role.Level
-	} else {
-		// If no role, set very low level
-		currentUserLevel = 0
+	if req.CreatedAfter != nil && req.CreatedBefore != nil && !req.CreatedAfter.Before(*req.CreatedBefore) {
+		return nil, apperror.NewValidationError("created_after must be before created_before")
 	}
 
-	// Super Admin (level 100) can see ALL users except themselves
-	// Platform Admin (level >= 76) can see users with level < their level, except themselves
-	if currentUserLevel >= 76 {
-		// For Platform Admin: set max level they can see
-		var maxLevelToSee int
-		if currentUserLevel >= 100 {
-			// Super Admin can see all levels except themselves
-			maxLevelToSee = 999 // Use high number to include all levels
-		} else {
-			// Platform Admin can only see users with level < their level
-			maxLevelToSee = currentUserLevel - 1
-		}
-
-		// Use filtered method that excludes current user and respects level hierarchy
-		users, err := s.userRepo.ListWithFilters(ctx, offset, limit, search, maxLevelToSee, nil)
-		if err != nil {
-			return nil, apperror.NewInternalError(fmt.Errorf("failed to list users: %w", err))
-		}
-
-		// Filter out current user from results
-		filteredUsers := make([]model.User, 0, len(users))
-		for _, user := range users {
-			if user.ID != currentUserID {
-				filteredUsers = append(filteredUsers, user)
-			}
-		}
-
-		// Get total count with same filtering (excluding current user and level restriction)
-		total, err := s.userRepo.CountWithFilters(ctx, search, maxLevelToSee, nil)
-		if err != nil {
-			return nil, apperror.NewInternalError(fmt.Errorf("failed to count users: %w", err))
-		}
-
-		// Adjust total count to exclude current user
-		if total > 0 {
-			total = total - 1
-		}
-
-		// Convert users to response DTOs using utility function
-		userResponses := make([]dto.UserResponse, len(filteredUsers))
-		for i, user := range filteredUsers {
-			userResponses[i] = *util.MapUserToResponse(&user)
-		}
-
-		// Calculate total pages
-		totalPages := int(math.Ceil(float64(total) / float64(limit)))
-
-		return &dto.PagedUserResponse{
-			Users:      userResponses,
-			Page:       page,
-			Limit:      limit,
-			Total:      total,
-			TotalPages: totalPages,
-		}, nil
+	attributes, err := parseUserAttributeFilters(req.Attributes)
+	if err != nil {
+		return nil, apperror.NewValidationError(err.Error())
 	}
 
-	// For non-platform users, apply hierarchical filtering
-	// Get organizations accessible to current user using hierarchical filtering
-	accessibleOrgIDs, err := s.orgService.GetAccessibleOrganizationIDs(ctx, currentUserID, currentUserLevel)
+	query, err := buildListQuery(req.Sort, defaultUserListSort, req.Cursor, offset, limit)
 	if err != nil {
-		return nil, apperror.NewInternalError(fmt.Errorf("failed to get accessible organizations: %w", err))
+		return nil, err
 	}
 
-	// Fetch users with level and organization filtering
-	users, err := s.userRepo.ListWithFilters(ctx, offset, limit, search, currentUserLevel, accessibleOrgIDs)
+	visibility, err := resolveUserVisibility(ctx, s.userRepo, s.orgService, currentUserID)
 	if err != nil {
-		return nil, apperror.NewInternalError(fmt.Errorf("failed to list users: %w", err))
+		return nil, err
 	}
 
-	// Filter out current user from results for non-platform users too
-	filteredUsers := make([]model.User, 0, len(users))
-	for _, user := range users {
-		if user.ID != currentUserID {
-			filteredUsers = append(filteredUsers, user)
-		}
+	filter := repository.UserListFilter{
+		Search:          req.Search,
+		VisibleBelow:    visibility.visibleBelow,
+		AccessibleOrgs:  visibility.accessibleOrgs,
+		ExcludeUserID:   &currentUserID,
+		RoleIDs:         req.RoleIDs,
+		MinLevel:        req.MinLevel,
+		MaxLevel:        req.MaxLevel,
+		AuthProviders:   req.AuthProviders,
+		Statuses:        req.Statuses,
+		OrganizationIDs: req.OrganizationIDs,
+		CreatedAfter:    req.CreatedAfter,
+		CreatedBefore:   req.CreatedBefore,
+		Attributes:      attributes,
 	}
 
-	// Get total count with the same filters
-	total, err := s.userRepo.CountWithFilters(ctx, search, currentUserLevel, accessibleOrgIDs)
+	result, err := s.userRepo.ListWithFilters(ctx, filter, query)
 	if err != nil {
-		return nil, apperror.NewInternalError(fmt.Errorf("failed to count users: %w", err))
+		if queryErr := listQueryError(err); queryErr != nil {
+			return nil, queryErr
+		}
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to list users: %w", err))
 	}
 
-	// Adjust total count to exclude current user
-	if total > 0 {
-		total = total - 1
+	// The caller is excluded by the filter, so the count is exact
+	total, err := s.userRepo.CountWithFilters(ctx, filter)
+	if err != nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to count users: %w", err))
 	}
 
 	// Convert users to response DTOs using utility function
-	userResponses := make([]dto.UserResponse, len(filteredUsers))
-	for i, user := range filteredUsers {
-		userResponses[i] = *util.MapUserToResponse(&user)
+	userResponses := make([]dto.UserResponse, len(result.Items))
+	for i := range result.Items {
+		userResponses[i] = *util.MapUserToResponse(&result.Items[i])
 	}
 
 	// Calculate total pages
@@ -249,9 +203,56 @@ func (s *userService) ListUsers(ctx context.Context, page, limit int, search str
 		Limit:      limit,
 		Total:      total,
 		TotalPages: totalPages,
+		NextCursor: encodeListCursor(query, result.NextCursor),
 	}, nil
 }
 
+// userVisibility describes the users a caller may see: users whose role level is below visibleBelow and,
+// for organization-level callers, only members of accessibleOrgs plus platform-level users.
+type userVisibility struct {
+	user           *model.User
+	level          int
+	visibleBelow   int
+	accessibleOrgs []uuid.UUID // nil for platform-level callers
+}
+
+// resolveUserVisibility determines which users the current user may see in listings and search results
+func resolveUserVisibility(ctx context.Context, userRepo repository.UserRepositoryInterface, orgService OrganizationServiceInterface, currentUserID uuid.UUID) (*userVisibility, error) {
+	// Get current user with role information
+	currentUser, err := userRepo.FindByIDWithRole(ctx, currentUserID)
+	if err != nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to find current user: %w", err))
+	}
+
+	// Determine current user's level; users without a role get the lowest level
+	visibility := &userVisibility{user: currentUser}
+	if currentUser.Role != nil {
+		visibility.level = currentUser.Role.Level
+	}
+
+	// Super Admin (level 100) can see ALL users except themselves
+	// Platform Admin (level >= 76) can see users with level < their level, except themselves
+	switch {
+	case visibility.level >= 100:
+		visibility.visibleBelow = 999 // Use high number to include all levels
+	case visibility.level >= 76:
+		// Platform Admin can only see users with level < their level
+		visibility.visibleBelow = visibility.level - 1
+	default:
+		// For non-platform users, apply hierarchical filtering
+		visibility.visibleBelow = visibility.level
+		accessibleOrgIDs, err := orgService.GetAccessibleOrganizationIDs(ctx, currentUserID, visibility.level)
+		if err != nil {
+			return nil, apperror.NewInternalError(fmt.Errorf("failed to get accessible organizations: %w", err))
+		}
+		if accessibleOrgIDs == nil {
+			accessibleOrgIDs = []uuid.UUID{}
+		}
+		visibility.accessibleOrgs = accessibleOrgIDs
+	}
+	return visibility, nil
+}
+
 // GetUserByID retrieves a single user by their ID with enhanced error handling
 func (s *userService) GetUserByID(ctx context.Context, id uuid.UUID) (*dto.UserResponse, error) {
 	user, err := s.userRepo.FindByIDWithRole(ctx, id)
@@ -292,7 +293,7 @@ func (s *userService) UpdateUser(ctx context.Context, id uuid.UUID, req dto.Upda
 
 		// When removing role, also remove all organization assignments
 		// since user cannot have organizations without a role
-		if err := s.removeAllUserOrganizations(ctx, id); err != nil {
+		if _, err := s.membershipRepo.DeleteAllByUser(ctx, id, membershipChange(currentUserID, "Global role removed")); err != nil {
 			return nil, apperror.NewInternalError(fmt.Errorf("failed to remove user organizations: %w", err))
 		}
 
@@ -362,417 +363,6 @@ func (s *userService) DeleteUser(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
-// User-Organization Management Implementation
-
-// AssignUserToOrganization assigns a user to an organization with a specific role.
-func (s *userService) AssignUserToOrganization(ctx context.Context, req dto.AssignUserToOrganizationRequest) (*dto.UserOrganizationResponse, error) {
-	// Validate user exists
-	_, err := s.userRepo.FindByID(ctx, req.UserID)
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, apperror.NewNotFoundError("user")
-		}
-		return nil, apperror.NewInternalError(fmt.Errorf("failed to find user: %w", err))
-	}
-
-	// Check if user is already assigned to this organization
-	existingAssignment, err := s.userRepo.FindUserOrganization(ctx, req.UserID, req.OrganizationID)
-	if err == nil && existingAssignment != nil {
-		return nil, apperror.NewConflictError("User is already assigned to this organization")
-	} else if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
-		return nil, apperror.NewInternalError(fmt.Errorf("failed to check existing assignment: %w", err))
-	}
-
-	// Create user-organization assignment
-	userOrg := &model.UserOrganization{
-		UserID:         req.UserID,
-		OrganizationID: req.OrganizationID,
-		RoleID:         req.RoleID,
-		IsActive:       req.IsActive,
-		JoinedAt:       time.Now(),
-	}
-
-	_, err = s.userRepo.CreateUserOrganization(ctx, userOrg)
-	if err != nil {
-		return nil, apperror.NewInternalError(fmt.Errorf("failed to assign user to organization: %w", err))
-	}
-
-	// Get full user-organization data with relationships
-	fullUserOrg, err := s.userRepo.FindUserOrganization(ctx, req.UserID, req.OrganizationID)
-	if err != nil {
-		return nil, apperror.NewInternalError(fmt.Errorf("failed to fetch created assignment: %w", err))
-	}
-
-	return s.mapUserOrganizationToResponse(fullUserOrg), nil
-}
-
-// RemoveUserFromOrganization removes a user from an organization.
-func (s *userService) RemoveUserFromOrganization(ctx context.Context, userID, organizationID uuid.UUID) error {
-	// Check if assignment exists
-	_, err := s.userRepo.FindUserOrganization(ctx, userID, organizationID)
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return apperror.NewNotFoundError("user organization assignment")
-		}
-		return apperror.NewInternalError(fmt.Errorf("failed to find assignment: %w", err))
-	}
-
-	// Remove assignment
-	if err := s.userRepo.DeleteUserOrganization(ctx, userID, organizationID); err != nil {
-		return apperror.NewInternalError(fmt.Errorf("failed to remove user from organization: %w", err))
-	}
-
-	return nil
-}
-
-// UpdateUserOrganizationRole updates a user's role in an organization.
-func (s *userService) UpdateUserOrganizationRole(ctx context.Context, userID, organizationID uuid.UUID, req dto.UpdateUserOrganizationRequest) (*dto.UserOrganizationResponse, error) {
-	// Find existing assignment
-	userOrg, err := s.userRepo.FindUserOrganization(ctx, userID, organizationID)
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, apperror.NewNotFoundError("user organization assignment")
-		}
-		return nil, apperror.NewInternalError(fmt.Errorf("failed to find assignment: %w", err))
-	}
-
-	// Update assignment
-	userOrg.RoleID = req.RoleID
-	userOrg.IsActive = req.IsActive
-
-	updatedUserOrg, err := s.userRepo.UpdateUserOrganization(ctx, userOrg)
-	if err != nil {
-		return nil, apperror.NewInternalError(fmt.Errorf("failed to update user organization role: %w", err))
-	}
-
-	return s.mapUserOrganizationToResponse(updatedUserOrg), nil
-}
-
-// GetUserOrganizations retrieves all organizations that a user belongs to.
-func (s *userService) GetUserOrganizations(ctx context.Context, userID uuid.UUID, page, limit int) (*dto.PagedUserOrganizationResponse, error) {
-	// Apply default pagination values
-	page, limit, offset := util.ValidateAndSetPaginationParams(page, limit)
-
-	// Get user organizations
-	userOrgs, err := s.userRepo.FindUserOrganizations(ctx, userID, offset, limit)
-	if err != nil {
-		return nil, apperror.NewInternalError(fmt.Errorf("failed to get user organizations: %w", err))
-	}
-
-	// Get total count
-	total, err := s.userRepo.CountUserOrganizations(ctx, userID)
-	if err != nil {
-		return nil, apperror.NewInternalError(fmt.Errorf("failed to count user organizations: %w", err))
-	}
-
-	// Convert to response DTOs
-	userOrgResponses := make([]dto.UserOrganizationResponse, len(userOrgs))
-	for i, userOrg := range userOrgs {
-		userOrgResponses[i] = *s.mapUserOrganizationToResponse(&userOrg)
-	}
-
-	// Calculate total pages
-	totalPages := int(math.Ceil(float64(total) / float64(limit)))
-
-	return &dto.PagedUserOrganizationResponse{
-		UserOrganizations: userOrgResponses,
-		Page:              page,
-		Limit:             limit,
-		Total:             total,
-		TotalPages:        totalPages,
-	}, nil
-}
-
-// GetOrganizationMembers retrieves all members of an organization.
-func (s *userService) GetOrganizationMembers(ctx context.Context, organizationID uuid.UUID, page, limit int) (*dto.PagedUserOrganizationResponse, error) {
-	// Apply default pagination values
-	page, limit, offset := util.ValidateAndSetPaginationParams(page, limit)
-
-	// Get organization members
-	userOrgs, err := s.userRepo.FindOrganizationMembers(ctx, organizationID, offset, limit)
-	if err != nil {
-		return nil, apperror.NewInternalError(fmt.Errorf("failed to get organization members: %w", err))
-	}
-
-	// Get total count
-	total, err := s.userRepo.CountOrganizationMembers(ctx, organizationID)
-	if err != nil {
-		return nil, apperror.NewInternalError(fmt.Errorf("failed to count organization members: %w", err))
-	}
-
-	// Convert to response DTOs
-	userOrgResponses := make([]dto.UserOrganizationResponse, len(userOrgs))
-	for i, userOrg := range userOrgs {
-		userOrgResponses[i] = *s.mapUserOrganizationToResponse(&userOrg)
-	}
-
-	// Calculate total pages
-	totalPages := int(math.Ceil(float64(total) / float64(limit)))
-
-	return &dto.PagedUserOrganizationResponse{
-		UserOrganizations: userOrgResponses,
-		Page:              page,
-		Limit:             limit,
-		Total:             total,
-		TotalPages:        totalPages,
-	}, nil
-}
-
-// BulkAssignUsersToOrganization assigns multiple users to an organization.
-func (s *userService) BulkAssignUsersToOrganization(ctx context.Context, req dto.BulkAssignUsersToOrganizationRequest) (*dto.BulkAssignResponse, error) {
-	var userOrgs []model.UserOrganization
-	var errors []dto.BulkAssignError
-
-	// Prepare user-organization assignments
-	for _, userID := range req.UserIDs {
-		// Check if user exists
-		_, err := s.userRepo.FindByID(ctx, userID)
-		if err != nil {
-			errors = append(errors, dto.BulkAssignError{
-				UserID: userID,
-				Error:  "User not found",
-			})
-			continue
-		}
-
-		// Check if already assigned
-		_, err = s.userRepo.FindUserOrganization(ctx, userID, req.OrganizationID)
-		if err == nil {
-			errors = append(errors, dto.BulkAssignError{
-				UserID: userID,
-				Error:  "User already assigned to organization",
-			})
-			continue
-		}
-
-		userOrgs = append(userOrgs, model.UserOrganization{
-			UserID:         userID,
-			OrganizationID: req.OrganizationID,
-			RoleID:         req.RoleID,
-			IsActive:       true,
-			JoinedAt:       time.Now(),
-		})
-	}
-
-	// Bulk create assignments
-	created, createErrors := s.userRepo.BulkCreateUserOrganizations(ctx, userOrgs)
-
-	// Convert create errors to BulkAssignError
-	for i, err := range createErrors {
-		if i < len(userOrgs) {
-			errors = append(errors, dto.BulkAssignError{
-				UserID: userOrgs[i].UserID,
-				Error:  err.Error(),
-			})
-		}
-	}
-
-	// Convert created assignments to responses
-	assignments := make([]dto.UserOrganizationResponse, len(created))
-	for i, userOrg := range created {
-		assignments[i] = *s.mapUserOrganizationToResponse(&userOrg)
-	}
-
-	successCount := len(created)
-	failureCount := len(errors)
-
-	return &dto.BulkAssignResponse{
-		SuccessCount: successCount,
-		FailureCount: failureCount,
-		Assignments:  assignments,
-		Errors:       errors,
-		Message:      fmt.Sprintf("%d users assigned successfully, %d failed", successCount, failureCount),
-	}, nil
-}
-
-// GetUserOrganizationHistory retrieves the organization assignment history for a user.
-func (s *userService) GetUserOrganizationHistory(ctx context.Context, userID uuid.UUID, page, limit int) (*dto.PagedUserOrganizationHistoryResponse, error) {
-	// Apply default pagination values
-	page, limit, offset := util.ValidateAndSetPaginationParams(page, limit)
-
-	// Get user organization history
-	history, err := s.userRepo.FindUserOrganizationHistory(ctx, userID, offset, limit)
-	if err != nil {
-		return nil, apperror.NewInternalError(fmt.Errorf("failed to get user organization history: %w", err))
-	}
-
-	// Get total count
-	total, err := s.userRepo.CountUserOrganizationHistory(ctx, userID)
-	if err != nil {
-		return nil, apperror.NewInternalError(fmt.Errorf("failed to count user organization history: %w", err))
-	}
-
-	// Convert to response DTOs
-	historyResponses := make([]dto.UserOrganizationHistoryResponse, len(history))
-	for i, h := range history {
-		historyResponses[i] = *s.mapUserOrganizationHistoryToResponse(&h)
-	}
-
-	// Calculate total pages
-	totalPages := int(math.Ceil(float64(total) / float64(limit)))
-
-	return &dto.PagedUserOrganizationHistoryResponse{
-		History:    historyResponses,
-		Page:       page,
-		Limit:      limit,
-		Total:      total,
-		TotalPages: totalPages,
-	}, nil
-}
-
-// GetOrganizationUserHistory retrieves the user assignment history for an organization.
-func (s *userService) GetOrganizationUserHistory(ctx context.Context, organizationID uuid.UUID, page, limit int) (*dto.PagedUserOrganizationHistoryResponse, error) {
-	// Apply default pagination values
-	page, limit, offset := util.ValidateAndSetPaginationParams(page, limit)
-
-	// Get organization user history
-	history, err := s.userRepo.FindOrganizationUserHistory(ctx, organizationID, offset, limit)
-	if err != nil {
-		return nil, apperror.NewInternalError(fmt.Errorf("failed to get organization user history: %w", err))
-	}
-
-	// Get total count
-	total, err := s.userRepo.CountOrganizationUserHistory(ctx, organizationID)
-	if err != nil {
-		return nil, apperror.NewInternalError(fmt.Errorf("failed to count organization user history: %w", err))
-	}
-
-	// Convert to response DTOs
-	historyResponses := make([]dto.UserOrganizationHistoryResponse, len(history))
-	for i, h := range history {
-		historyResponses[i] = *s.mapUserOrganizationHistoryToResponse(&h)
-	}
-
-	// Calculate total pages
-	totalPages := int(math.Ceil(float64(total) / float64(limit)))
-
-	return &dto.PagedUserOrganizationHistoryResponse{
-		History:    historyResponses,
-		Page:       page,
-		Limit:      limit,
-		Total:      total,
-		TotalPages: totalPages,
-	}, nil
-}
-
-// LogUserOrganizationAction manually logs a user organization action.
-func (s *userService) LogUserOrganizationAction(ctx context.Context, req dto.LogUserOrganizationActionRequest, actionBy uuid.UUID) (*dto.UserOrganizationHistoryResponse, error) {
-	// Validate user exists
-	_, err := s.userRepo.FindByID(ctx, req.UserID)
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, apperror.NewNotFoundError("user")
-		}
-		return nil, apperror.NewInternalError(fmt.Errorf("failed to find user: %w", err))
-	}
-
-	// Create history record
-	history := &model.UserOrganizationHistory{
-		UserID:         req.UserID,
-		OrganizationID: req.OrganizationID,
-		Action:         req.Action,
-		PreviousRole:   req.PreviousRole,
-		NewRole:        req.NewRole,
-		PreviousStatus: req.PreviousStatus,
-		NewStatus:      req.NewStatus,
-		ActionBy:       actionBy,
-		ActionAt:       time.Now(),
-		Reason:         req.Reason,
-	}
-
-	createdHistory, err := s.userRepo.CreateUserOrganizationHistory(ctx, history)
-	if err != nil {
-		return nil, apperror.NewInternalError(fmt.Errorf("failed to log user organization action: %w", err))
-	}
-
-	// Get full history record with relationships
-	historyRecords, err := s.userRepo.FindUserOrganizationHistory(ctx, req.UserID, 0, 1)
-	if err != nil || len(historyRecords) == 0 {
-		// Return basic response if we can't fetch full record
-		return s.mapUserOrganizationHistoryToResponse(createdHistory), nil
-	}
-
-	return s.mapUserOrganizationHistoryToResponse(&historyRecords[0]), nil
-}
-
-// Helper method to map UserOrganization model to response DTO
-func (s *userService) mapUserOrganizationToResponse(userOrg *model.UserOrganization) *dto.UserOrganizationResponse {
-	response := &dto.UserOrganizationResponse{
-		UserID:         userOrg.UserID,
-		OrganizationID: userOrg.OrganizationID,
-		RoleID:         userOrg.RoleID,
-		IsActive:       userOrg.IsActive,
-		JoinedAt:       userOrg.JoinedAt,
-	}
-
-	// Include organization data if loaded
-	if userOrg.Organization.ID != uuid.Nil {
-		response.Organization = dto.OrganizationResponse{
-			ID:                   userOrg.Organization.ID,
-			Name:                 userOrg.Organization.Name,
-			Code:                 userOrg.Organization.Code,
-			OrganizationType:     userOrg.Organization.OrganizationType,
-			ParentOrganizationID: userOrg.Organization.ParentOrganizationID,
-			Description:          userOrg.Organization.Description,
-			CreatedBy:            userOrg.Organization.CreatedBy,
-			IsActive:             userOrg.Organization.IsActive,
-			CreatedAt:            userOrg.Organization.CreatedAt,
-			UpdatedAt:            userOrg.Organization.UpdatedAt,
-		}
-	}
-
-	// Include role data if loaded
-	if userOrg.Role != nil && userOrg.Role.ID != uuid.Nil {
-		response.Role = &dto.RoleResponse{
-			ID:             userOrg.Role.ID,
-			Name:           userOrg.Role.Name,
-			Description:    userOrg.Role.Description,
-			Level:          userOrg.Role.Level,
-			IsSystemRole:   userOrg.Role.IsSystemRole,
-			PredefinedName: userOrg.Role.PredefinedName,
-			IsActive:       userOrg.Role.IsActive,
-		}
-	}
-
-	return response
-}
-
-// Helper method to map UserOrganizationHistory model to response DTO
-func (s *userService) mapUserOrganizationHistoryToResponse(history *model.UserOrganizationHistory) *dto.UserOrganizationHistoryResponse {
-	response := &dto.UserOrganizationHistoryResponse{
-		ID:             history.ID,
-		UserID:         history.UserID,
-		OrganizationID: history.OrganizationID,
-		Action:         history.Action,
-		PreviousRole:   history.PreviousRole,
-		NewRole:        history.NewRole,
-		ActionBy:       history.ActionBy,
-		ActionAt:       history.ActionAt.Format(time.RFC3339),
-		Reason:         history.Reason,
-	}
-
-	// Handle boolean pointers for status
-	if history.PreviousStatus != nil {
-		prevStatus := *history.PreviousStatus
-		if prevStatus {
-			response.PreviousRole = "active"
-		} else {
-			response.PreviousRole = "inactive"
-		}
-	}
-
-	if history.NewStatus != nil {
-		newStatus := *history.NewStatus
-		if newStatus {
-			response.NewRole = "active"
-		} else {
-			response.NewRole = "inactive"
-		}
-	}
-
-	return response
-}
-
 // Helper methods for user service
 // validateRoleChangeAuthorization validates if the current user can change roles
 func (s *userService) validateRoleChangeAuthorization(ctx context.Context, currentUserID, targetUserID, newRoleID uuid.UUID, targetUser *model.User) error {
@@ -862,58 +452,56 @@ func (s *userService) hashPassword(password string) (string, error) {
 	return string(hashedPassword), nil
 }
 
-// removeAllUserOrganizations removes all organization assignments for a user
-func (s *userService) removeAllUserOrganizations(ctx context.Context, userID uuid.UUID) error {
-	// Get all user organization assignments
-	userOrgs, err := s.userRepo.FindUserOrganizations(ctx, userID, 0, 1000) // Use high limit to get all
-	if err != nil {
-		return fmt.Errorf("failed to fetch user organizations: %w", err)
-	}
-
-	// Remove each organization assignment using the organization service
-	for _, userOrg := range userOrgs {
-		if err := s.RemoveUserFromOrganization(ctx, userID, userOrg.OrganizationID); err != nil {
-			return fmt.Errorf("failed to remove user from organization %s: %w", userOrg.OrganizationID.String(), err)
-		}
-	}
-
-	return nil
-}
-
 // invalidateUserSessions invalidates all active sessions for a user
 func (s *userService) invalidateUserSessions(ctx context.Context, userID uuid.UUID) error {
+	_, err := revokeUserSessions(ctx, s.redis, userID)
+	return err
+}
+
+// findUserRefreshTokens returns the refresh tokens of all active sessions of a user
+func findUserRefreshTokens(ctx context.Context, redisClient *redis.Client, userID uuid.UUID) ([]string, error) {
 	// Pattern to find all refresh tokens for this user
 	// Refresh tokens are stored with user ID as value
 	pattern := "*"
 
 	// Scan all keys to find refresh tokens for this user
-	iter := s.redis.Scan(ctx, 0, pattern, 0).Iterator()
-	var keysToDelete []string
+	iter := redisClient.Scan(ctx, 0, pattern, 0).Iterator()
+	var tokens []string
 
 	for iter.Next(ctx) {
 		key := iter.Val()
 		// Get the value to check if it's this user's token
-		val, err := s.redis.Get(ctx, key).Result()
+		val, err := redisClient.Get(ctx, key).Result()
 		if err != nil {
 			continue // Skip if error reading value
 		}
 
 		// If the value matches our user ID, this is a refresh token for this user
 		if val == userID.String() {
-			keysToDelete = append(keysToDelete, key)
+			tokens = append(tokens, key)
 		}
 	}
 
 	if err := iter.Err(); err != nil {
-		return fmt.Errorf("failed to scan redis keys: %w", err)
+		return nil, fmt.Errorf("failed to scan redis keys: %w", err)
+	}
+
+	return tokens, nil
+}
+
+// revokeUserSessions deletes the refresh tokens of all active sessions of a user and returns how many there were
+func revokeUserSessions(ctx context.Context, redisClient *redis.Client, userID uuid.UUID) (int, error) {
+	tokens, err := findUserRefreshTokens(ctx, redisClient, userID)
+	if err != nil {
+		return 0, err
 	}
 
 	// Delete all found refresh tokens
-	if len(keysToDelete) > 0 {
-		if err := s.redis.Del(ctx, keysToDelete...).Err(); err != nil {
-			return fmt.Errorf("failed to delete refresh tokens: %w", err)
+	if len(tokens) > 0 {
+		if err := redisClient.Del(ctx, tokens...).Err(); err != nil {
+			return 0, fmt.Errorf("failed to delete refresh tokens: %w", err)
 		}
 	}
 
-	return nil
+	return len(tokens), nil
 }
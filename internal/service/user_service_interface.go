@@ -10,24 +10,23 @@ import (
 // UserService mendefinisikan kontrak untuk layanan manajemen pengguna.
 type UserServiceInterface interface {
 	CreateUser(ctx context.Context, req dto.CreateUserRequest) (*dto.UserResponse, error)
-	ListUsers(ctx context.Context, page, limit int, search string) (*dto.PagedUserResponse, error)
+	ListUsers(ctx context.Context, req dto.ListUsersRequest) (*dto.PagedUserResponse, error)
 	GetUserByID(ctx context.Context, id uuid.UUID) (*dto.UserResponse, error)
 	UpdateUser(ctx context.Context, id uuid.UUID, req dto.UpdateUserRequest) (*dto.UserResponse, error)
 	DeleteUser(ctx context.Context, id uuid.UUID) error
 
-	// User-Organization Management
-	AssignUserToOrganization(ctx context.Context, req dto.AssignUserToOrganizationRequest) (*dto.UserOrganizationResponse, error)
-	RemoveUserFromOrganization(ctx context.Context, userID, organizationID uuid.UUID) error
-	UpdateUserOrganizationRole(ctx context.Context, userID, organizationID uuid.UUID, req dto.UpdateUserOrganizationRequest) (*dto.UserOrganizationResponse, error)
-	GetUserOrganizations(ctx context.Context, userID uuid.UUID, page, limit int) (*dto.PagedUserOrganizationResponse, error)
-	GetOrganizationMembers(ctx context.Context, organizationID uuid.UUID, page, limit int) (*dto.PagedUserOrganizationResponse, error)
-	BulkAssignUsersToOrganization(ctx context.Context, req dto.BulkAssignUsersToOrganizationRequest) (*dto.BulkAssignResponse, error)
-	// GetUserOrganizationHistory retrieves the organization assignment history for a user.
-	GetUserOrganizationHistory(ctx context.Context, userID uuid.UUID, page, limit int) (*dto.PagedUserOrganizationHistoryResponse, error)
+	// Account status
+	// SuspendUser blocks a user from signing in, indefinitely or until req.Until, and ends their sessions.
+	SuspendUser(ctx context.Context, userID uuid.UUID, req dto.SuspendUserRequest, suspendedBy uuid.UUID) (*dto.UserResponse, error)
+	// ReactivateUser makes a suspended or not yet activated account active again.
+	ReactivateUser(ctx context.Context, userID uuid.UUID, req dto.ReactivateUserRequest, reactivatedBy uuid.UUID) (*dto.UserResponse, error)
+	// GetUserStatusHistory retrieves the account status transitions of a user, newest first.
+	GetUserStatusHistory(ctx context.Context, userID uuid.UUID, page, limit int) (*dto.PagedUserStatusHistoryResponse, error)
+	// ReactivateExpiredSuspensions marks users whose suspension has ended as active and returns how many.
+	ReactivateExpiredSuspensions(ctx context.Context) (int64, error)
 
-	// GetOrganizationUserHistory retrieves the user assignment history for an organization.
-	GetOrganizationUserHistory(ctx context.Context, organizationID uuid.UUID, page, limit int) (*dto.PagedUserOrganizationHistoryResponse, error)
-
-	// LogUserOrganizationAction manually logs a user organization action (for manual tracking).
-	LogUserOrganizationAction(ctx context.Context, req dto.LogUserOrganizationActionRequest, actionBy uuid.UUID) (*dto.UserOrganizationHistoryResponse, error)
+	// Profile
+	GetUserProfile(ctx context.Context, userID uuid.UUID) (*dto.UserProfileResponse, error)
+	// UpdateUserProfile applies the fields present in req; editing another user's profile requires authority over them.
+	UpdateUserProfile(ctx context.Context, userID uuid.UUID, req dto.UpdateUserProfileRequest, updatedBy uuid.UUID) (*dto.UserProfileResponse, error)
 }
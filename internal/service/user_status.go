@@ -0,0 +1,252 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"time"
+
+	"go-base-project/internal/apperror"
+	"go-base-project/internal/cache"
+	"go-base-project/internal/constant"
+	"go-base-project/internal/dto"
+	"go-base-project/internal/model"
+	"go-base-project/internal/repository"
+	"go-base-project/internal/util"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+// expiredSuspensionBatchSize bounds how many expired suspensions are lifted per query
+const expiredSuspensionBatchSize = 100
+
+// expiredSuspensionReason is recorded in the status history when a suspension lifts itself
+const expiredSuspensionReason = "Suspension period ended"
+
+// checkUserStatus returns the error that keeps a user out of the application, or nil when the account may
+// be used. A suspension whose end has passed no longer applies, even before the expiry job records it.
+func checkUserStatus(user *model.User, now time.Time) error {
+	switch user.Status {
+	case constant.UserStatusSuspended:
+		if user.SuspendedUntil != nil && !now.Before(*user.SuspendedUntil) {
+			return nil
+		}
+		if user.SuspendedUntil != nil {
+			return apperror.NewAccountSuspendedError(fmt.Sprintf("%s until %s", constant.ErrMsgAccountSuspended, user.SuspendedUntil.UTC().Format(time.RFC3339)))
+		}
+		return apperror.NewAccountSuspendedError(constant.ErrMsgAccountSuspended)
+	case constant.UserStatusPendingActivation:
+		return apperror.NewAccountPendingActivationError(constant.ErrMsgAccountPendingActivation)
+	}
+	return nil
+}
+
+// SuspendUser blocks a user from signing in, indefinitely or until req.Until, and ends their sessions.
+// Suspending an already suspended user replaces the reason and end of the suspension.
+func (s *userService) SuspendUser(ctx context.Context, userID uuid.UUID, req dto.SuspendUserRequest, suspendedBy uuid.UUID) (*dto.UserResponse, error) {
+	if req.Until != nil && !req.Until.After(time.Now()) {
+		return nil, apperror.NewValidationError("until must be in the future")
+	}
+
+	user, err := s.findUserForStatusChange(ctx, userID, suspendedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	history := &model.UserStatusHistory{
+		UserID:         user.ID,
+		PreviousStatus: user.Status,
+		NewStatus:      constant.UserStatusSuspended,
+		Reason:         req.Reason,
+		SuspendedUntil: req.Until,
+		ActionBy:       &suspendedBy,
+	}
+	user.Status = constant.UserStatusSuspended
+	user.StatusReason = req.Reason
+	user.SuspendedUntil = req.Until
+	if err := s.changeUserStatus(ctx, user, history); err != nil {
+		return nil, err
+	}
+
+	// Refresh tokens would otherwise outlive the suspension check of the next refresh
+	if err := s.invalidateUserSessions(ctx, user.ID); err != nil {
+		log.Warn().Err(err).Str("user_id", user.ID.String()).Msg("Failed to invalidate sessions of suspended user")
+	}
+
+	return util.MapUserToResponse(user), nil
+}
+
+// ReactivateUser makes a suspended or not yet activated account active again.
+func (s *userService) ReactivateUser(ctx context.Context, userID uuid.UUID, req dto.ReactivateUserRequest, reactivatedBy uuid.UUID) (*dto.UserResponse, error) {
+	user, err := s.findUserForStatusChange(ctx, userID, reactivatedBy)
+	if err != nil {
+		return nil, err
+	}
+	if user.Status == constant.UserStatusActive {
+		return nil, apperror.NewConflictError("user is already active")
+	}
+
+	history := &model.UserStatusHistory{
+		UserID:         user.ID,
+		PreviousStatus: user.Status,
+		NewStatus:      constant.UserStatusActive,
+		Reason:         req.Reason,
+		ActionBy:       &reactivatedBy,
+	}
+	user.Status = constant.UserStatusActive
+	user.StatusReason = ""
+	user.SuspendedUntil = nil
+	if err := s.changeUserStatus(ctx, user, history); err != nil {
+		return nil, err
+	}
+
+	return util.MapUserToResponse(user), nil
+}
+
+// GetUserStatusHistory retrieves the account status transitions of a user, newest first.
+func (s *userService) GetUserStatusHistory(ctx context.Context, userID uuid.UUID, page, limit int) (*dto.PagedUserStatusHistoryResponse, error) {
+	page, limit, offset := util.ValidateAndSetPaginationParams(page, limit)
+
+	history, err := s.userRepo.FindUserStatusHistory(ctx, userID, offset, limit)
+	if err != nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to get user status history: %w", err))
+	}
+	total, err := s.userRepo.CountUserStatusHistory(ctx, userID)
+	if err != nil {
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to count user status history: %w", err))
+	}
+
+	responses := make([]dto.UserStatusHistoryResponse, len(history))
+	for i, h := range history {
+		responses[i] = dto.UserStatusHistoryResponse{
+			ID:             h.ID,
+			UserID:         h.UserID,
+			PreviousStatus: h.PreviousStatus,
+			NewStatus:      h.NewStatus,
+			Reason:         h.Reason,
+			SuspendedUntil: h.SuspendedUntil,
+			ActionBy:       h.ActionBy,
+			ActionAt:       h.ActionAt.Format(time.RFC3339),
+		}
+		if h.Actor != nil {
+			responses[i].ActionByName = h.Actor.Username
+		}
+	}
+
+	return &dto.PagedUserStatusHistoryResponse{
+		History:    responses,
+		Page:       page,
+		Limit:      limit,
+		Total:      total,
+		TotalPages: int(math.Ceil(float64(total) / float64(limit))),
+	}, nil
+}
+
+// ReactivateExpiredSuspensions marks users whose suspension has ended as active again and records the
+// transition without an actor. Sign-in already ignores expired suspensions; this keeps the stored status,
+// listings and history in line with it.
+func (s *userService) ReactivateExpiredSuspensions(ctx context.Context) (int64, error) {
+	var reactivated int64
+	for {
+		users, err := s.userRepo.FindExpiredSuspensions(ctx, time.Now(), expiredSuspensionBatchSize)
+		if err != nil {
+			return reactivated, fmt.Errorf("failed to find expired suspensions: %w", err)
+		}
+
+		for i := range users {
+			user := &users[i]
+			history := &model.UserStatusHistory{
+				UserID:         user.ID,
+				PreviousStatus: user.Status,
+				NewStatus:      constant.UserStatusActive,
+				Reason:         expiredSuspensionReason,
+				SuspendedUntil: user.SuspendedUntil,
+			}
+			user.Status = constant.UserStatusActive
+			user.StatusReason = ""
+			user.SuspendedUntil = nil
+
+			changed, err := s.userRepo.ChangeStatus(ctx, user, history)
+			if err != nil {
+				return reactivated, fmt.Errorf("failed to reactivate user %s: %w", user.ID, err)
+			}
+			if changed {
+				reactivated++
+				s.invalidateUserStatusCache(ctx, user.ID)
+			}
+		}
+
+		if len(users) < expiredSuspensionBatchSize {
+			return reactivated, nil
+		}
+	}
+}
+
+// findUserForStatusChange loads the target of a status change and checks that the caller may change it.
+func (s *userService) findUserForStatusChange(ctx context.Context, userID, currentUserID uuid.UUID) (*model.User, error) {
+	if userID == currentUserID {
+		return nil, apperror.NewAppError(http.StatusForbidden, constant.ErrMsgCannotChangeOwnStatus, nil)
+	}
+
+	user, err := s.userRepo.FindByIDWithRole(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.NewNotFoundError("user")
+		}
+		return nil, apperror.NewInternalError(fmt.Errorf("failed to find user: %w", err))
+	}
+	if err := checkAuthorityOverUser(ctx, s.userRepo, user, currentUserID); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// checkAuthorityOverUser checks that the current user may act on the account of target: super administrators
+// can only be handled by super administrators and everyone else only by users with a higher role level.
+func checkAuthorityOverUser(ctx context.Context, userRepo repository.UserRepositoryInterface, target *model.User, currentUserID uuid.UUID) error {
+	currentUser, err := userRepo.FindByIDWithRole(ctx, currentUserID)
+	if err != nil {
+		return apperror.NewInternalError(fmt.Errorf("failed to find current user: %w", err))
+	}
+	if currentUser.Role == nil {
+		return apperror.NewAppError(http.StatusForbidden, constant.ErrMsgCurrentUserHasNoRole, nil)
+	}
+
+	if target.Role != nil {
+		if target.Role.Name == "super_admin" && currentUser.Role.Name != "super_admin" {
+			return apperror.NewAppError(http.StatusForbidden, constant.ErrMsgOnlySuperAdminCanModify, nil)
+		}
+		if target.Role.Level >= currentUser.Role.Level {
+			return apperror.NewAppError(http.StatusForbidden, constant.ErrMsgInsufficientAuthorityOverUser, nil)
+		}
+	}
+
+	return nil
+}
+
+// changeUserStatus saves a status transition of user and drops the cached status so it applies to the
+// next request.
+func (s *userService) changeUserStatus(ctx context.Context, user *model.User, history *model.UserStatusHistory) error {
+	changed, err := s.userRepo.ChangeStatus(ctx, user, history)
+	if err != nil {
+		return apperror.NewInternalError(fmt.Errorf("failed to change user status: %w", err))
+	}
+	if !changed {
+		return apperror.NewConflictError("user status was changed concurrently, please retry")
+	}
+
+	s.invalidateUserStatusCache(ctx, user.ID)
+	return nil
+}
+
+// invalidateUserStatusCache removes the cached account status checked on every request
+func (s *userService) invalidateUserStatusCache(ctx context.Context, userID uuid.UUID) {
+	if err := s.redis.Del(ctx, cache.GetUserStatusCacheKey(userID)).Err(); err != nil {
+		log.Warn().Err(err).Str("user_id", userID.String()).Msg("Failed to invalidate user status cache")
+	}
+}
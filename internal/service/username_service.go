@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"go-base-project/internal/model"
+	"go-base-project/internal/repository"
+	"go-base-project/pkg/generator"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// maxUsernameAttempts bounds how often a username lost to a concurrent sign-up is replaced
+	maxUsernameAttempts = 5
+	// usernameSequentialSuffixes is how many readable suffixes (john2, john3, ...) are tried first
+	usernameSequentialSuffixes = 8
+	// usernameRandomSuffixes is how many random four-digit suffixes are tried after those
+	usernameRandomSuffixes = 5
+)
+
+type usernameService struct {
+	userRepo repository.UserRepositoryInterface
+}
+
+// NewUsernameService creates a new instance of UsernameService
+func NewUsernameService(userRepo repository.UserRepositoryInterface) UsernameServiceInterface {
+	return &usernameService{userRepo: userRepo}
+}
+
+// CreateUserWithGeneratedUsername checks the candidates against the repository first and then claims
+// the first free one through the unique index, so sign-ups racing for the same name both succeed.
+func (s *usernameService) CreateUserWithGeneratedUsername(ctx context.Context, user *model.User, hint string) error {
+	base := generator.NormalizeUsername(usernameHint(hint))
+
+	for attempt := 1; attempt <= maxUsernameAttempts; attempt++ {
+		username, err := s.allocate(ctx, base)
+		if err != nil {
+			return err
+		}
+		user.Username = username
+
+		err = s.userRepo.CreateWithUniqueUsername(ctx, user)
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, repository.ErrUsernameTaken) {
+			return err
+		}
+		log.Warn().Str("username", username).Int("attempt", attempt).Msg("Generated username already taken, retrying")
+	}
+	return fmt.Errorf("no free username derived from %q after %d attempts", base, maxUsernameAttempts)
+}
+
+// allocate returns the most readable candidate for base that is not in use yet.
+func (s *usernameService) allocate(ctx context.Context, base string) (string, error) {
+	candidates := generator.UsernameCandidates(base, usernameSequentialSuffixes, usernameRandomSuffixes)
+	taken, err := s.userRepo.FindTakenUsernames(ctx, candidates)
+	if err != nil {
+		return "", fmt.Errorf("failed to check username availability: %w", err)
+	}
+	for _, candidate := range candidates {
+		if !taken[candidate] {
+			return candidate, nil
+		}
+	}
+	return "", fmt.Errorf("all %d username candidates derived from %q are taken", len(candidates), base)
+}
+
+// usernameHint reduces an email address to its local part without a +tag; other hints are used as is.
+func usernameHint(hint string) string {
+	if at := strings.LastIndex(hint, "@"); at >= 0 {
+		hint = hint[:at]
+		if plus := strings.Index(hint, "+"); plus >= 0 {
+			hint = hint[:plus]
+		}
+	}
+	return hint
+}
@@ -0,0 +1,15 @@
+package service
+
+import (
+	"context"
+	"go-base-project/internal/model"
+)
+
+// UsernameServiceInterface defines the allocation of usernames for accounts created without one,
+// such as sign-ups through Google
+type UsernameServiceInterface interface {
+	// CreateUserWithGeneratedUsername derives a free username from hint, e.g. an email address or a
+	// name, assigns it to user and creates the user. A username claimed concurrently by another
+	// sign-up is replaced by the next free candidate.
+	CreateUserWithGeneratedUsername(ctx context.Context, user *model.User, hint string) error
+}
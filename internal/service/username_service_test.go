@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"go-base-project/internal/model"
+	"go-base-project/pkg/generator"
+)
+
+func TestCreateUserWithGeneratedUsername(t *testing.T) {
+	tests := []struct {
+		name  string
+		hint  string
+		taken []string
+		raced []string
+		want  string
+	}{
+		{name: "uses the normalized hint", hint: "John.Doe@example.com", want: "john.doe"},
+		{name: "drops the email tag", hint: "john+newsletter@example.com", want: "john"},
+		{name: "uses a plain name as is", hint: "José Müller", want: "jose_muller"},
+		{name: "takes the next sequential suffix", hint: "john@example.com", taken: []string{"john", "john2"}, want: "john3"},
+		{name: "replaces a username lost to a concurrent sign-up", hint: "john@example.com", taken: []string{"john"}, raced: []string{"john2"}, want: "john3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeUserRepo{taken: make(map[string]bool), raced: make(map[string]bool)}
+			for _, username := range tt.taken {
+				repo.taken[username] = true
+			}
+			for _, username := range tt.raced {
+				repo.raced[username] = true
+			}
+
+			user := &model.User{}
+			if err := NewUsernameService(repo).CreateUserWithGeneratedUsername(context.Background(), user, tt.hint); err != nil {
+				t.Fatalf("CreateUserWithGeneratedUsername() error = %v", err)
+			}
+			if user.Username != tt.want {
+				t.Errorf("username = %q, want %q", user.Username, tt.want)
+			}
+			if len(repo.created) != 1 || repo.created[0] != tt.want {
+				t.Errorf("created users = %v, want [%s]", repo.created, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreateUserWithGeneratedUsernameFallsBackToRandomSuffix(t *testing.T) {
+	repo := &fakeUserRepo{taken: map[string]bool{"john": true}, raced: make(map[string]bool)}
+	for i := 2; i <= usernameSequentialSuffixes+1; i++ {
+		repo.taken[generator.WithSuffix("john", i)] = true
+	}
+
+	user := &model.User{}
+	if err := NewUsernameService(repo).CreateUserWithGeneratedUsername(context.Background(), user, "john"); err != nil {
+		t.Fatalf("CreateUserWithGeneratedUsername() error = %v", err)
+	}
+	suffix := strings.TrimPrefix(user.Username, "john")
+	if len(suffix) != 4 {
+		t.Errorf("username = %q, want a random four-digit suffix once the sequential ones are taken", user.Username)
+	}
+}
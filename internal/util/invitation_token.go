@@ -0,0 +1,71 @@
+package util
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// ErrInvalidInvitationToken is returned when an invitation token is malformed or its signature does not match.
+var ErrInvalidInvitationToken = errors.New("invalid invitation token")
+
+const invitationNonceSize = 32
+
+// GenerateInvitationToken creates a signed invitation token for the given invitation ID.
+// Format: base64url(id || nonce) "." base64url(HMAC-SHA256(secret, id || nonce))
+func GenerateInvitationToken(invitationID uuid.UUID, secret string) (string, error) {
+	payload := make([]byte, 16+invitationNonceSize)
+	copy(payload, invitationID[:])
+	if _, err := rand.Read(payload[16:]); err != nil {
+		return "", fmt.Errorf("failed to generate invitation nonce: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(payload) + "." + base64.RawURLEncoding.EncodeToString(signInvitationPayload(payload, secret)), nil
+}
+
+// ParseInvitationToken verifies the token signature and returns the invitation ID it was issued for.
+func ParseInvitationToken(token, secret string) (uuid.UUID, error) {
+	encodedPayload, encodedSignature, ok := strings.Cut(token, ".")
+	if !ok {
+		return uuid.Nil, ErrInvalidInvitationToken
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil || len(payload) != 16+invitationNonceSize {
+		return uuid.Nil, ErrInvalidInvitationToken
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil {
+		return uuid.Nil, ErrInvalidInvitationToken
+	}
+
+	if !hmac.Equal(signature, signInvitationPayload(payload, secret)) {
+		return uuid.Nil, ErrInvalidInvitationToken
+	}
+
+	invitationID, err := uuid.FromBytes(payload[:16])
+	if err != nil {
+		return uuid.Nil, ErrInvalidInvitationToken
+	}
+	return invitationID, nil
+}
+
+// HashInvitationToken returns the hex SHA-256 digest stored in place of the raw token.
+func HashInvitationToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// signInvitationPayload computes the HMAC-SHA256 signature of an invitation payload.
+func signInvitationPayload(payload []byte, secret string) []byte {
+	mac := hmac.New(sha256.New, []byte("organization-invitation:"+secret))
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
@@ -0,0 +1,102 @@
+package util
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestInvitationTokenRoundTrip(t *testing.T) {
+	invitationID := uuid.New()
+	token, err := GenerateInvitationToken(invitationID, "secret")
+	if err != nil {
+		t.Fatalf("GenerateInvitationToken() error = %v", err)
+	}
+
+	parsed, err := ParseInvitationToken(token, "secret")
+	if err != nil {
+		t.Fatalf("ParseInvitationToken() error = %v", err)
+	}
+	if parsed != invitationID {
+		t.Errorf("ParseInvitationToken() = %s, want %s", parsed, invitationID)
+	}
+}
+
+func TestGenerateInvitationTokenIsUnique(t *testing.T) {
+	invitationID := uuid.New()
+	first, err := GenerateInvitationToken(invitationID, "secret")
+	if err != nil {
+		t.Fatalf("GenerateInvitationToken() error = %v", err)
+	}
+	second, err := GenerateInvitationToken(invitationID, "secret")
+	if err != nil {
+		t.Fatalf("GenerateInvitationToken() error = %v", err)
+	}
+	if first == second {
+		t.Error("two tokens for the same invitation are equal, want a fresh nonce in each")
+	}
+}
+
+func TestParseInvitationTokenRejectsInvalidTokens(t *testing.T) {
+	invitationID := uuid.New()
+	token, err := GenerateInvitationToken(invitationID, "secret")
+	if err != nil {
+		t.Fatalf("GenerateInvitationToken() error = %v", err)
+	}
+	payload, signature, _ := strings.Cut(token, ".")
+
+	// Flipping a bit of the payload keeps it well-formed but breaks the signature
+	rawPayload, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		t.Fatalf("DecodeString() error = %v", err)
+	}
+	rawPayload[0] ^= 0x01
+	tamperedPayload := base64.RawURLEncoding.EncodeToString(rawPayload)
+
+	tests := []struct {
+		name   string
+		token  string
+		secret string
+	}{
+		{name: "wrong secret", token: token, secret: "other-secret"},
+		{name: "tampered payload", token: tamperedPayload + "." + signature, secret: "secret"},
+		{name: "tampered signature", token: payload + "." + strings.Repeat("A", len(signature)), secret: "secret"},
+		{name: "missing separator", token: payload + signature, secret: "secret"},
+		{name: "missing signature", token: payload + ".", secret: "secret"},
+		{name: "short payload", token: base64.RawURLEncoding.EncodeToString(invitationID[:]) + "." + signature, secret: "secret"},
+		{name: "payload not base64", token: "not*base64." + signature, secret: "secret"},
+		{name: "signature not base64", token: payload + ".not*base64", secret: "secret"},
+		{name: "empty", token: "", secret: "secret"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed, err := ParseInvitationToken(tt.token, tt.secret)
+			if !errors.Is(err, ErrInvalidInvitationToken) {
+				t.Errorf("ParseInvitationToken() error = %v, want ErrInvalidInvitationToken", err)
+			}
+			if parsed != uuid.Nil {
+				t.Errorf("ParseInvitationToken() = %s, want uuid.Nil", parsed)
+			}
+		})
+	}
+}
+
+func TestHashInvitationToken(t *testing.T) {
+	tests := []struct {
+		token string
+		want  string
+	}{
+		{token: "", want: "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"},
+		{token: "abc", want: "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"},
+	}
+
+	for _, tt := range tests {
+		if got := HashInvitationToken(tt.token); got != tt.want {
+			t.Errorf("HashInvitationToken(%q) = %s, want %s", tt.token, got, tt.want)
+		}
+	}
+}
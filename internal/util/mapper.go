@@ -13,12 +13,21 @@ func MapUserToResponse(user *model.User) *dto.UserResponse {
 	}
 
 	response := &dto.UserResponse{
-		ID:           user.ID,
-		Username:     user.Username,
-		Email:        user.Email,
-		AvatarURL:    user.AvatarURL,
-		RoleID:       user.RoleID,
-		AuthProvider: user.AuthProvider,
+		ID:             user.ID,
+		Username:       user.Username,
+		Email:          user.Email,
+		AvatarURL:      user.AvatarURL,
+		DisplayName:    user.DisplayName,
+		Phone:          user.Phone,
+		Locale:         user.Locale,
+		Timezone:       user.Timezone,
+		JobTitle:       user.JobTitle,
+		RoleID:         user.RoleID,
+		AuthProvider:   user.AuthProvider,
+		Status:         user.Status,
+		StatusReason:   user.StatusReason,
+		SuspendedUntil: user.SuspendedUntil,
+		CreatedAt:      user.CreatedAt,
 	}
 
 	// Get first active organization from many-to-many relationship
@@ -100,10 +109,15 @@ func MapOrganizationToResponse(org *model.Organization) *dto.OrganizationRespons
 		Description:      org.Description,
 		CreatedBy:        org.CreatedBy,
 		IsActive:         org.IsActive,
+		ArchivedAt:       org.ArchivedAt,
 		CreatedAt:        org.CreatedAt,
 		UpdatedAt:        org.UpdatedAt,
 	}
 
+	if org.DeletedAt.Valid {
+		response.DeletedAt = &org.DeletedAt.Time
+	}
+
 	if org.ParentOrganizationID != nil {
 		response.ParentOrganizationID = org.ParentOrganizationID
 	}
@@ -1,124 +1,137 @@
 package util
 
 import (
-	"math/rand"
-	"regexp"
+	"crypto/rand"
+	"fmt"
+	"math/big"
 	"strings"
-	"time"
 )
 
-// GenerateOrganizationCode generates a unique organization code from organization name
-// Format: [5-char-from-name][3-random-digits]
-// Example: "PT ABC Tech" → "ABC12345"
-func GenerateOrganizationCode(organizationName string) string {
-	// Clean the name: remove special chars, spaces, numbers
-	cleanName := cleanOrganizationName(organizationName)
-
-	// Take first 5 characters (pad with 'X' if shorter)
-	namePrefix := extractNamePrefix(cleanName, 5)
-
-	// Generate 3 random digits
-	randomSuffix := generateRandomDigits(3)
-
-	return namePrefix + randomSuffix
+// organizationBusinessTerms are legal-form words skipped when deriving the name prefix of a code
+var organizationBusinessTerms = map[string]bool{
+	"PT": true, "CV": true, "UD": true, "TOKO": true, "STORE": true,
+	"COMPANY": true, "CORP": true, "LTD": true, "INC": true,
 }
 
-// cleanOrganizationName removes unwanted characters and normalizes the name
-func cleanOrganizationName(name string) string {
-	// Convert to uppercase
-	name = strings.ToUpper(name)
-
-	// Remove common business prefixes/suffixes
-	businessTerms := []string{"PT", "CV", "UD", "TOKO", "STORE", "COMPANY", "CORP", "LTD", "INC"}
-	for _, term := range businessTerms {
-		name = strings.ReplaceAll(name, term, "")
-	}
-
-	// Keep only alphabetic characters
-	reg := regexp.MustCompile(`[^A-Z]`)
-	name = reg.ReplaceAllString(name, "")
-
-	return name
+// OrganizationCodeGenerator builds organization codes of the form [name prefix][random][check character].
+// Random characters come from crypto/rand and every character is taken from the configured alphabet,
+// so codes stay readable. The optional check character (Luhn mod N) catches single typos and most
+// transpositions before a lookup.
+type OrganizationCodeGenerator struct {
+	alphabet     string
+	length       int
+	prefixLength int
+	checkDigit   bool
 }
 
-// extractNamePrefix extracts the first N characters from cleaned name
-func extractNamePrefix(cleanName string, length int) string {
-	if len(cleanName) >= length {
-		return cleanName[:length]
-	}
-
-	// Pad with 'X' if name is shorter than required length
-	result := cleanName
-	for len(result) < length {
-		result += "X"
+// NewOrganizationCodeGenerator creates a generator. length is the full code length including the check
+// character; prefixLength characters are derived from the organization name. The settings are validated
+// when the configuration is loaded.
+func NewOrganizationCodeGenerator(alphabet string, length, prefixLength int, checkDigit bool) *OrganizationCodeGenerator {
+	return &OrganizationCodeGenerator{
+		alphabet:     strings.ToUpper(alphabet),
+		length:       length,
+		prefixLength: prefixLength,
+		checkDigit:   checkDigit,
 	}
-
-	return result
 }
 
-// generateRandomDigits generates N random digits as string
-func generateRandomDigits(length int) string {
-	rand.Seed(time.Now().UnixNano())
+// Generate creates a new candidate code for an organization name. Uniqueness is enforced by the database;
+// callers retry with a fresh candidate when the code is already taken.
+func (g *OrganizationCodeGenerator) Generate(organizationName string) (string, error) {
+	prefix := g.namePrefix(organizationName)
 
-	result := ""
-	for i := 0; i < length; i++ {
-		result += string(rune('0' + rand.Intn(10)))
+	randomLength := g.length - len(prefix)
+	if g.checkDigit {
+		randomLength--
+	}
+	random, err := g.randomString(randomLength)
+	if err != nil {
+		return "", err
 	}
 
-	return result
+	code := prefix + random
+	if g.checkDigit {
+		code += string(g.checkCharacter(code))
+	}
+	return code, nil
 }
 
-// ValidateOrganizationCode validates organization code format
-func ValidateOrganizationCode(code string) bool {
-	if len(code) != 8 {
+// Valid reports whether a code has the configured length, uses only the alphabet and, when enabled,
+// carries a correct check character. Codes issued under older settings may not pass.
+func (g *OrganizationCodeGenerator) Valid(code string) bool {
+	if len(code) != g.length {
 		return false
 	}
-
-	// First 5 should be letters, last 3 should be digits
-	namePattern := regexp.MustCompile(`^[A-Z]{5}[0-9]{3}$`)
-	return namePattern.MatchString(code)
+	for i := 0; i < len(code); i++ {
+		if strings.IndexByte(g.alphabet, code[i]) < 0 {
+			return false
+		}
+	}
+	if !g.checkDigit {
+		return true
+	}
+	return g.checkCharacter(code[:len(code)-1]) == code[len(code)-1]
 }
 
-// GenerateUniqueOrganizationCode generates a unique code with collision checking
-func GenerateUniqueOrganizationCode(organizationName string, existingCodes []string) string {
-	baseCode := GenerateOrganizationCode(organizationName)
+// Normalize brings user input into the stored code form
+func (g *OrganizationCodeGenerator) Normalize(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
 
-	// Check for collisions
-	codeExists := func(code string) bool {
-		for _, existing := range existingCodes {
-			if existing == code {
-				return true
+// namePrefix derives up to prefixLength characters from the organization name, padding short names
+// with random characters
+func (g *OrganizationCodeGenerator) namePrefix(organizationName string) string {
+	var prefix strings.Builder
+	for _, word := range strings.Fields(strings.ToUpper(organizationName)) {
+		if organizationBusinessTerms[word] {
+			continue
+		}
+		for i := 0; i < len(word) && prefix.Len() < g.prefixLength; i++ {
+			if word[i] >= 'A' && word[i] <= 'Z' && strings.IndexByte(g.alphabet, word[i]) >= 0 {
+				prefix.WriteByte(word[i])
 			}
 		}
-		return false
 	}
 
-	// If no collision, return base code
-	if !codeExists(baseCode) {
-		return baseCode
+	if missing := g.prefixLength - prefix.Len(); missing > 0 {
+		// The alphabet was validated at startup, so padding cannot fail in practice; fall back to the
+		// shorter prefix and let the random part fill the code if it does
+		if padding, err := g.randomString(missing); err == nil {
+			prefix.WriteString(padding)
+		}
 	}
+	return prefix.String()
+}
 
-	// If collision exists, try different random suffixes
-	namePrefix := baseCode[:5]
-	for attempt := 0; attempt < 100; attempt++ { // Max 100 attempts
-		randomSuffix := generateRandomDigits(3)
-		newCode := namePrefix + randomSuffix
-
-		if !codeExists(newCode) {
-			return newCode
+// randomString returns n characters drawn uniformly from the alphabet using crypto/rand
+func (g *OrganizationCodeGenerator) randomString(n int) (string, error) {
+	max := big.NewInt(int64(len(g.alphabet)))
+	result := make([]byte, n)
+	for i := range result {
+		index, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", fmt.Errorf("failed to read random bytes: %w", err)
 		}
+		result[i] = g.alphabet[index.Int64()]
 	}
-
-	// If still collision after 100 attempts, fallback to timestamp-based
-	timestamp := time.Now().Unix() % 1000
-	return namePrefix + padNumber(int(timestamp), 3)
+	return string(result), nil
 }
 
-// padNumber pads a number with leading zeros to specified length
-func padNumber(num int, length int) string {
-	str := string(rune('0' + num%1000))
-	for len(str) < length {
-		str = "0" + str
+// checkCharacter computes the Luhn mod N check character of input over the alphabet. Summing the base N
+// digits of doubled values only maps every character to a distinct addend when N is even; for an odd N,
+// like the default alphabet, doubled values are reduced mod N instead so every single typo still shows.
+func (g *OrganizationCodeGenerator) checkCharacter(input string) byte {
+	n := len(g.alphabet)
+	factor := 2
+	sum := 0
+	for i := len(input) - 1; i >= 0; i-- {
+		addend := factor * strings.IndexByte(g.alphabet, input[i])
+		factor = 3 - factor
+		if n%2 == 0 {
+			addend = addend/n + addend%n
+		}
+		sum += addend
 	}
-	return str
+	return g.alphabet[(n-sum%n)%n]
 }
@@ -0,0 +1,146 @@
+package util
+
+import (
+	"strings"
+	"testing"
+)
+
+const testOrganizationCodeAlphabet = "ABCDEFGHJKMNPQRSTUVWXYZ23456789"
+
+func TestOrganizationCodeGeneratorGenerate(t *testing.T) {
+	generator := NewOrganizationCodeGenerator(testOrganizationCodeAlphabet, 12, 3, true)
+
+	tests := []struct {
+		name       string
+		orgName    string
+		wantPrefix string
+	}{
+		{name: "prefix from the first letters", orgName: "Maju Jaya", wantPrefix: "MAJ"},
+		{name: "skips legal-form words", orgName: "PT Sumber Rejeki", wantPrefix: "SUM"},
+		{name: "skips letters outside the alphabet", orgName: "Oil Mill", wantPrefix: "M"},
+		{name: "pads short names with random characters", orgName: "Ax", wantPrefix: "AX"},
+		{name: "fully random prefix without usable letters", orgName: "123 Store", wantPrefix: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, err := generator.Generate(tt.orgName)
+			if err != nil {
+				t.Fatalf("Generate() error = %v", err)
+			}
+			if len(code) != 12 {
+				t.Errorf("Generate() = %q, want 12 characters", code)
+			}
+			if !strings.HasPrefix(code, tt.wantPrefix) {
+				t.Errorf("Generate() = %q, want prefix %q", code, tt.wantPrefix)
+			}
+			for i := 0; i < len(code); i++ {
+				if !strings.ContainsRune(testOrganizationCodeAlphabet, rune(code[i])) {
+					t.Errorf("Generate() = %q contains %q outside the alphabet", code, code[i])
+				}
+			}
+			if !generator.Valid(code) {
+				t.Errorf("Valid(%q) = false for a generated code", code)
+			}
+		})
+	}
+}
+
+func TestOrganizationCodeGeneratorGenerateIsRandom(t *testing.T) {
+	generator := NewOrganizationCodeGenerator(testOrganizationCodeAlphabet, 12, 3, true)
+
+	seen := make(map[string]bool)
+	for i := 0; i < 100; i++ {
+		code, err := generator.Generate("Maju Jaya")
+		if err != nil {
+			t.Fatalf("Generate() error = %v", err)
+		}
+		if seen[code] {
+			t.Fatalf("Generate() repeated %q within 100 codes", code)
+		}
+		seen[code] = true
+	}
+}
+
+func TestOrganizationCodeGeneratorWithoutCheckDigit(t *testing.T) {
+	generator := NewOrganizationCodeGenerator(testOrganizationCodeAlphabet, 9, 3, false)
+
+	code, err := generator.Generate("Maju Jaya")
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if len(code) != 9 || !strings.HasPrefix(code, "MAJ") {
+		t.Errorf("Generate() = %q, want 9 characters starting with MAJ", code)
+	}
+	if !generator.Valid(code) {
+		t.Errorf("Valid(%q) = false for a generated code", code)
+	}
+}
+
+func TestOrganizationCodeCheckCharacter(t *testing.T) {
+	// Over the decimal alphabet Luhn mod N is the classic Luhn algorithm
+	luhn := NewOrganizationCodeGenerator("0123456789", 11, 0, true)
+	if got := luhn.checkCharacter("7992739871"); got != '3' {
+		t.Errorf("checkCharacter(7992739871) = %q, want '3'", got)
+	}
+
+	generator := NewOrganizationCodeGenerator(testOrganizationCodeAlphabet, 11, 3, true)
+	if got := generator.checkCharacter("MAJ2345678"); got != 'G' {
+		t.Errorf("checkCharacter(MAJ2345678) = %q, want 'G'", got)
+	}
+}
+
+func TestOrganizationCodeGeneratorValid(t *testing.T) {
+	generator := NewOrganizationCodeGenerator(testOrganizationCodeAlphabet, 11, 3, true)
+
+	tests := []struct {
+		name string
+		code string
+		want bool
+	}{
+		{name: "correct check character", code: "MAJ2345678G", want: true},
+		{name: "wrong check character", code: "MAJ2345678H"},
+		{name: "adjacent characters swapped", code: "MAJ2354678G"},
+		{name: "too short", code: "MAJ234567G"},
+		{name: "character outside the alphabet", code: "MAJ2345670G"},
+		{name: "lower case", code: "maj2345678G"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := generator.Valid(tt.code); got != tt.want {
+				t.Errorf("Valid(%q) = %v, want %v", tt.code, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOrganizationCodeCheckCharacterCatchesSingleSubstitutions(t *testing.T) {
+	// The default alphabet has an odd size, the decimal one an even size
+	for _, alphabet := range []string{testOrganizationCodeAlphabet, "0123456789"} {
+		generator := NewOrganizationCodeGenerator(alphabet, 11, 0, true)
+		code, err := generator.Generate("")
+		if err != nil {
+			t.Fatalf("Generate() error = %v", err)
+		}
+
+		for i := 0; i < len(code); i++ {
+			for j := 0; j < len(alphabet); j++ {
+				if alphabet[j] == code[i] {
+					continue
+				}
+				typo := code[:i] + string(alphabet[j]) + code[i+1:]
+				if generator.Valid(typo) {
+					t.Errorf("Valid(%q) = true, want the substitution at position %d of %q caught", typo, i, code)
+				}
+			}
+		}
+	}
+}
+
+func TestOrganizationCodeGeneratorNormalize(t *testing.T) {
+	generator := NewOrganizationCodeGenerator(testOrganizationCodeAlphabet, 11, 3, true)
+	if got := generator.Normalize("  maj2345678g "); got != "MAJ2345678G" {
+		t.Errorf("Normalize() = %q, want %q", got, "MAJ2345678G")
+	}
+}
@@ -0,0 +1,204 @@
+package util
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+)
+
+// maxSpreadsheetPartSize bounds the uncompressed size of a single XLSX part, guarding against zip bombs
+const maxSpreadsheetPartSize = 64 << 20
+
+// maxSpreadsheetColumns bounds the column a cell may refer to
+const maxSpreadsheetColumns = 256
+
+// ErrUnsupportedSpreadsheet is returned for uploads that are neither CSV nor XLSX
+var ErrUnsupportedSpreadsheet = errors.New("unsupported spreadsheet format, expected CSV or XLSX")
+
+// ReadSpreadsheet returns the rows of a CSV file or of the first worksheet of an XLSX workbook.
+// The format is detected from the content; XLSX files are zip archives.
+func ReadSpreadsheet(filename string, data []byte) ([][]string, error) {
+	if bytes.HasPrefix(data, []byte("PK\x03\x04")) {
+		return readXLSX(data)
+	}
+	if ext := strings.ToLower(path.Ext(filename)); ext == ".xlsx" || ext == ".xls" {
+		return nil, ErrUnsupportedSpreadsheet
+	}
+
+	// Spreadsheet applications often prepend a byte order mark to exported CSV files
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV: %w", err)
+	}
+	return rows, nil
+}
+
+type xlsxWorkbook struct {
+	Sheets []struct {
+		RelationshipID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+type xlsxRelationships struct {
+	Relationships []struct {
+		ID     string `xml:"Id,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+type xlsxText struct {
+	Text string `xml:"t"`
+	Runs []struct {
+		Text string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (t xlsxText) value() string {
+	if len(t.Runs) == 0 {
+		return t.Text
+	}
+	var b strings.Builder
+	for _, run := range t.Runs {
+		b.WriteString(run.Text)
+	}
+	return b.String()
+}
+
+type xlsxSharedStrings struct {
+	Items []xlsxText `xml:"si"`
+}
+
+type xlsxWorksheet struct {
+	Rows []struct {
+		Cells []struct {
+			Reference string   `xml:"r,attr"`
+			Type      string   `xml:"t,attr"`
+			Value     string   `xml:"v"`
+			Inline    xlsxText `xml:"is"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+// readXLSX reads the cell values of the first worksheet. Shared, inline and formula strings, numbers and
+// booleans are supported; numbers are returned as stored, so dates appear as serial numbers.
+func readXLSX(data []byte) ([][]string, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid XLSX: %w", err)
+	}
+	files := make(map[string]*zip.File, len(archive.File))
+	for _, file := range archive.File {
+		files[file.Name] = file
+	}
+
+	var workbook xlsxWorkbook
+	if err := decodeXLSXPart(files, "xl/workbook.xml", &workbook); err != nil {
+		return nil, err
+	}
+	if len(workbook.Sheets) == 0 {
+		return nil, errors.New("invalid XLSX: workbook has no worksheets")
+	}
+	var relationships xlsxRelationships
+	if err := decodeXLSXPart(files, "xl/_rels/workbook.xml.rels", &relationships); err != nil {
+		return nil, err
+	}
+	sheetPath := ""
+	for _, relationship := range relationships.Relationships {
+		if relationship.ID == workbook.Sheets[0].RelationshipID {
+			sheetPath = relationship.Target
+			if strings.HasPrefix(sheetPath, "/") {
+				sheetPath = strings.TrimPrefix(sheetPath, "/")
+			} else {
+				sheetPath = path.Join("xl", sheetPath)
+			}
+		}
+	}
+	if sheetPath == "" {
+		return nil, errors.New("invalid XLSX: first worksheet not found")
+	}
+
+	var sharedStrings xlsxSharedStrings
+	if _, ok := files["xl/sharedStrings.xml"]; ok {
+		if err := decodeXLSXPart(files, "xl/sharedStrings.xml", &sharedStrings); err != nil {
+			return nil, err
+		}
+	}
+
+	var sheet xlsxWorksheet
+	if err := decodeXLSXPart(files, sheetPath, &sheet); err != nil {
+		return nil, err
+	}
+
+	rows := make([][]string, 0, len(sheet.Rows))
+	for _, sheetRow := range sheet.Rows {
+		var row []string
+		for i, cell := range sheetRow.Cells {
+			column := xlsxColumnIndex(cell.Reference)
+			if column < 0 {
+				column = i
+			}
+			if column >= maxSpreadsheetColumns {
+				return nil, fmt.Errorf("invalid XLSX: cell %s is beyond column %d", cell.Reference, maxSpreadsheetColumns)
+			}
+			for len(row) <= column {
+				row = append(row, "")
+			}
+
+			switch cell.Type {
+			case "s":
+				var index int
+				if _, err := fmt.Sscanf(cell.Value, "%d", &index); err != nil || index < 0 || index >= len(sharedStrings.Items) {
+					return nil, fmt.Errorf("invalid XLSX: cell %s refers to an unknown shared string", cell.Reference)
+				}
+				row[column] = sharedStrings.Items[index].value()
+			case "inlineStr":
+				row[column] = cell.Inline.value()
+			case "b":
+				row[column] = map[string]string{"1": "true", "0": "false"}[cell.Value]
+			default:
+				row[column] = cell.Value
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func decodeXLSXPart(files map[string]*zip.File, name string, target interface{}) error {
+	file, ok := files[name]
+	if !ok {
+		return fmt.Errorf("invalid XLSX: %s is missing", name)
+	}
+	reader, err := file.Open()
+	if err != nil {
+		return fmt.Errorf("invalid XLSX: %w", err)
+	}
+	defer reader.Close()
+
+	if err := xml.NewDecoder(io.LimitReader(reader, maxSpreadsheetPartSize)).Decode(target); err != nil {
+		return fmt.Errorf("invalid XLSX: %s: %w", name, err)
+	}
+	return nil
+}
+
+// xlsxColumnIndex converts the column letters of a cell reference such as "AB12" to a zero-based index,
+// or -1 when the reference has none
+func xlsxColumnIndex(reference string) int {
+	index := 0
+	for _, r := range reference {
+		if r < 'A' || r > 'Z' {
+			break
+		}
+		index = index*26 + int(r-'A'+1)
+	}
+	return index - 1
+}
@@ -0,0 +1,69 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- The role_approvals table from 003 never matched model.RoleApproval (the workflow was disabled),
+-- so it cannot hold any usable rows. Recreate it with the columns the role request workflow uses.
+DROP TABLE IF EXISTS role_approvals;
+
+CREATE TABLE role_approvals (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    requested_role_name VARCHAR(50) NOT NULL,
+    requested_level INTEGER NOT NULL CHECK (requested_level BETWEEN 1 AND 99),
+    description TEXT,
+    requested_by UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    approver_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'approved', 'rejected', 'cancelled')),
+    reason TEXT,
+    organization_id UUID REFERENCES organizations(id) ON DELETE SET NULL,
+    organization_code VARCHAR(8),
+    is_new_organization BOOLEAN NOT NULL DEFAULT FALSE,
+    requested_organization_name VARCHAR(100),
+    requested_organization_type VARCHAR(20) CHECK (requested_organization_type IN ('platform', 'holding', 'company', 'store')),
+    organization_description TEXT,
+    created_role_id UUID REFERENCES roles(id) ON DELETE SET NULL,
+    decided_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_role_approvals_requested_by ON role_approvals(requested_by);
+CREATE INDEX IF NOT EXISTS idx_role_approvals_approver_id ON role_approvals(approver_id);
+CREATE INDEX IF NOT EXISTS idx_role_approvals_org_id ON role_approvals(organization_id);
+CREATE INDEX IF NOT EXISTS idx_role_approvals_status ON role_approvals(status);
+
+-- A requester can only have one pending request per role name
+CREATE UNIQUE INDEX IF NOT EXISTS idx_role_approvals_pending_unique
+ON role_approvals(requested_by, LOWER(requested_role_name))
+WHERE status = 'pending';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS role_approvals;
+
+CREATE TABLE IF NOT EXISTS role_approvals (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    requested_role_id UUID NOT NULL REFERENCES roles(id) ON DELETE CASCADE,
+    approver_id UUID REFERENCES users(id),
+    status VARCHAR(20) DEFAULT 'pending',
+    request_message TEXT,
+    approval_message TEXT,
+    requested_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    reviewed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_role_approvals_user_id ON role_approvals(user_id);
+CREATE INDEX IF NOT EXISTS idx_role_approvals_org_id ON role_approvals(organization_id);
+CREATE INDEX IF NOT EXISTS idx_role_approvals_role_id ON role_approvals(requested_role_id);
+CREATE INDEX IF NOT EXISTS idx_role_approvals_approver_id ON role_approvals(approver_id);
+CREATE INDEX IF NOT EXISTS idx_role_approvals_status ON role_approvals(status);
+CREATE INDEX IF NOT EXISTS idx_role_approvals_deleted_at ON role_approvals(deleted_at);
+
+-- +goose StatementEnd
@@ -0,0 +1,47 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Change requests hold sensitive admin operations until enough approvers sign off (four-eyes approval)
+CREATE TABLE IF NOT EXISTS change_requests (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    action VARCHAR(64) NOT NULL,
+    target_id UUID,
+    summary TEXT,
+    payload JSONB NOT NULL DEFAULT '{}'::jsonb,
+    requested_by UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'executing', 'executed', 'rejected', 'cancelled', 'expired')),
+    required_approvals INTEGER NOT NULL DEFAULT 1 CHECK (required_approvals >= 1),
+    min_approver_level INTEGER NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    executed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Individual approver decisions on a change request
+CREATE TABLE IF NOT EXISTS change_request_approvals (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    change_request_id UUID NOT NULL REFERENCES change_requests(id) ON DELETE CASCADE,
+    approver_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    decision VARCHAR(20) NOT NULL CHECK (decision IN ('approved', 'rejected')),
+    comment TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE(change_request_id, approver_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_change_requests_status ON change_requests(status);
+-- At most one pending request per action and target
+CREATE UNIQUE INDEX IF NOT EXISTS idx_change_requests_pending_action_target ON change_requests(action, target_id) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_change_requests_requested_by ON change_requests(requested_by);
+CREATE INDEX IF NOT EXISTS idx_change_requests_expires_at ON change_requests(expires_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_change_request_approvals_request_id ON change_request_approvals(change_request_id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS change_request_approvals;
+DROP TABLE IF EXISTS change_requests;
+
+-- +goose StatementEnd
@@ -0,0 +1,35 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Invitations carry a pre-selected role and are redeemed with a signed token.
+-- Only a hash of the token is stored; the token itself is shown once at creation.
+CREATE TABLE IF NOT EXISTS organization_invitations (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    role_id UUID NOT NULL REFERENCES roles(id) ON DELETE CASCADE,
+    email VARCHAR(255),
+    invited_user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'accepted', 'revoked')),
+    max_uses INTEGER NOT NULL DEFAULT 1 CHECK (max_uses >= 1),
+    use_count INTEGER NOT NULL DEFAULT 0 CHECK (use_count >= 0 AND use_count <= max_uses),
+    expires_at TIMESTAMPTZ NOT NULL,
+    invited_by UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    revoked_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    revoked_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_organization_invitations_org_status ON organization_invitations(organization_id, status);
+CREATE INDEX IF NOT EXISTS idx_organization_invitations_email ON organization_invitations(LOWER(email));
+CREATE INDEX IF NOT EXISTS idx_organization_invitations_invited_user ON organization_invitations(invited_user_id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS organization_invitations;
+
+-- +goose StatementEnd
@@ -0,0 +1,35 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Join requests are created when a user joins by code an organization whose membership.join_policy
+-- setting is approval_required; an org admin approves or rejects them.
+CREATE TABLE IF NOT EXISTS organization_join_requests (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'approved', 'rejected')),
+    message TEXT,
+    role_id UUID REFERENCES roles(id) ON DELETE SET NULL,
+    decided_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    decided_at TIMESTAMPTZ,
+    reason TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_organization_join_requests_org_status ON organization_join_requests(organization_id, status);
+CREATE INDEX IF NOT EXISTS idx_organization_join_requests_user ON organization_join_requests(user_id);
+
+-- A user can only have one pending request per organization
+CREATE UNIQUE INDEX IF NOT EXISTS idx_organization_join_requests_pending_unique
+ON organization_join_requests(organization_id, user_id)
+WHERE status = 'pending';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS organization_join_requests;
+
+-- +goose StatementEnd
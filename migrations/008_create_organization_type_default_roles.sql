@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Default roles granted per organization type: the creator role goes to whoever creates an
+-- organization, the member role to users who join it. Rows are seeded and managed via the admin API.
+CREATE TABLE IF NOT EXISTS organization_type_default_roles (
+    organization_type VARCHAR(20) PRIMARY KEY CHECK (organization_type IN ('platform', 'holding', 'company', 'store')),
+    creator_role_id UUID REFERENCES roles(id) ON DELETE SET NULL,
+    member_role_id UUID REFERENCES roles(id) ON DELETE SET NULL,
+    updated_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS organization_type_default_roles;
+
+-- +goose StatementEnd
@@ -0,0 +1,102 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Closure table for the organization hierarchy: one row per (ancestor, descendant) pair,
+-- including each organization's self row at depth 0. Ancestor, descendant and subtree
+-- lookups become single indexed queries instead of walking parent_organization_id.
+CREATE TABLE IF NOT EXISTS organization_closures (
+    ancestor_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    descendant_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    depth INT NOT NULL CHECK (depth >= 0),
+    PRIMARY KEY (ancestor_id, descendant_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_organization_closures_descendant ON organization_closures(descendant_id, depth);
+CREATE INDEX IF NOT EXISTS idx_organization_closures_ancestor_depth ON organization_closures(ancestor_id, depth);
+
+-- Backfill from the existing parent links
+INSERT INTO organization_closures (ancestor_id, descendant_id, depth)
+WITH RECURSIVE tree AS (
+    SELECT id AS ancestor_id, id AS descendant_id, 0 AS depth
+    FROM organizations
+    UNION ALL
+    SELECT tree.ancestor_id, child.id, tree.depth + 1
+    FROM tree
+    JOIN organizations child ON child.parent_organization_id = tree.descendant_id
+    WHERE tree.depth < 64
+)
+SELECT ancestor_id, descendant_id, MIN(depth)
+FROM tree
+GROUP BY ancestor_id, descendant_id
+ON CONFLICT DO NOTHING;
+
+-- New organizations inherit every ancestor of their parent
+CREATE OR REPLACE FUNCTION organization_closures_after_insert()
+RETURNS TRIGGER AS $$
+BEGIN
+    INSERT INTO organization_closures (ancestor_id, descendant_id, depth)
+    VALUES (NEW.id, NEW.id, 0);
+
+    IF NEW.parent_organization_id IS NOT NULL THEN
+        INSERT INTO organization_closures (ancestor_id, descendant_id, depth)
+        SELECT ancestor_id, NEW.id, depth + 1
+        FROM organization_closures
+        WHERE descendant_id = NEW.parent_organization_id;
+    END IF;
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Re-parenting (including ON DELETE SET NULL of a parent) moves the whole subtree:
+-- links from the old ancestors are dropped and links from the new ancestors are added
+CREATE OR REPLACE FUNCTION organization_closures_after_parent_update()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF NEW.parent_organization_id IS NOT NULL AND EXISTS (
+        SELECT 1 FROM organization_closures
+        WHERE ancestor_id = NEW.id AND descendant_id = NEW.parent_organization_id
+    ) THEN
+        RAISE EXCEPTION 'organization % cannot be moved under its own descendant %', NEW.id, NEW.parent_organization_id
+            USING ERRCODE = 'check_violation';
+    END IF;
+
+    DELETE FROM organization_closures
+    WHERE descendant_id IN (SELECT descendant_id FROM organization_closures WHERE ancestor_id = NEW.id)
+      AND ancestor_id IN (SELECT ancestor_id FROM organization_closures WHERE descendant_id = NEW.id AND ancestor_id <> NEW.id);
+
+    IF NEW.parent_organization_id IS NOT NULL THEN
+        INSERT INTO organization_closures (ancestor_id, descendant_id, depth)
+        SELECT above.ancestor_id, below.descendant_id, above.depth + below.depth + 1
+        FROM organization_closures above
+        CROSS JOIN organization_closures below
+        WHERE above.descendant_id = NEW.parent_organization_id
+          AND below.ancestor_id = NEW.id;
+    END IF;
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_organization_closures_insert
+AFTER INSERT ON organizations
+FOR EACH ROW EXECUTE FUNCTION organization_closures_after_insert();
+
+CREATE TRIGGER trg_organization_closures_parent_update
+AFTER UPDATE OF parent_organization_id ON organizations
+FOR EACH ROW
+WHEN (OLD.parent_organization_id IS DISTINCT FROM NEW.parent_organization_id)
+EXECUTE FUNCTION organization_closures_after_parent_update();
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TRIGGER IF EXISTS trg_organization_closures_parent_update ON organizations;
+DROP TRIGGER IF EXISTS trg_organization_closures_insert ON organizations;
+DROP FUNCTION IF EXISTS organization_closures_after_parent_update();
+DROP FUNCTION IF EXISTS organization_closures_after_insert();
+DROP TABLE IF EXISTS organization_closures;
+
+-- +goose StatementEnd
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Audit trail of structural changes to organizations (moves, archives, code rotations, ...)
+CREATE TABLE IF NOT EXISTS organization_audit_logs (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    action VARCHAR(50) NOT NULL,
+    actor_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    details JSONB NOT NULL DEFAULT '{}',
+    reason TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_organization_audit_logs_org_created ON organization_audit_logs(organization_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_organization_audit_logs_action ON organization_audit_logs(action);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS organization_audit_logs;
+
+-- +goose StatementEnd
@@ -0,0 +1,82 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Registry of organization types. Placement rules (root allowed, allowed parent types, maximum depth)
+-- and the role level band of each type live here instead of in CHECK constraints and code.
+CREATE TABLE IF NOT EXISTS organization_types (
+    name VARCHAR(50) PRIMARY KEY CHECK (name ~ '^[a-z][a-z0-9_]*$'),
+    display_name VARCHAR(100) NOT NULL,
+    description TEXT,
+    allow_root BOOLEAN NOT NULL DEFAULT FALSE,
+    max_depth INT CHECK (max_depth >= 0), -- Deepest level (roots are 0) an organization of this type may sit at; NULL means unlimited
+    min_role_level INT NOT NULL CHECK (min_role_level BETWEEN 1 AND 99),
+    max_role_level INT NOT NULL CHECK (max_role_level BETWEEN 1 AND 99),
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (min_role_level <= max_role_level)
+);
+
+-- Which types an organization of a given type may be placed under
+CREATE TABLE IF NOT EXISTS organization_type_parents (
+    organization_type VARCHAR(50) NOT NULL REFERENCES organization_types(name) ON DELETE CASCADE ON UPDATE CASCADE,
+    parent_type VARCHAR(50) NOT NULL REFERENCES organization_types(name) ON DELETE CASCADE ON UPDATE CASCADE,
+    PRIMARY KEY (organization_type, parent_type)
+);
+
+INSERT INTO organization_types (name, display_name, description, allow_root, max_depth, min_role_level, max_role_level) VALUES
+    ('platform', 'Platform', 'Platform operator organization', TRUE, 0, 76, 98),
+    ('holding', 'Holding', 'Group owning several companies', TRUE, 0, 51, 75),
+    ('company', 'Company', 'Individual business, standalone or part of a holding', TRUE, 1, 26, 50),
+    ('store', 'Store', 'Branch or outlet of a company', FALSE, 2, 1, 25)
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO organization_type_parents (organization_type, parent_type) VALUES
+    ('company', 'holding'),
+    ('store', 'company')
+ON CONFLICT DO NOTHING;
+
+-- Types referenced elsewhere must exist in the registry. NOT VALID keeps legacy rows loadable
+-- while enforcing the reference for every new or updated row.
+ALTER TABLE organizations
+    ADD CONSTRAINT fk_organizations_organization_type
+    FOREIGN KEY (organization_type) REFERENCES organization_types(name) ON UPDATE CASCADE NOT VALID;
+
+ALTER TABLE role_organization_types
+    ADD CONSTRAINT fk_role_organization_types_organization_type
+    FOREIGN KEY (organization_type) REFERENCES organization_types(name) ON DELETE CASCADE ON UPDATE CASCADE NOT VALID;
+
+ALTER TABLE organization_type_default_roles DROP CONSTRAINT IF EXISTS organization_type_default_roles_organization_type_check;
+ALTER TABLE organization_type_default_roles ALTER COLUMN organization_type TYPE VARCHAR(50);
+ALTER TABLE organization_type_default_roles
+    ADD CONSTRAINT fk_organization_type_default_roles_organization_type
+    FOREIGN KEY (organization_type) REFERENCES organization_types(name) ON DELETE CASCADE ON UPDATE CASCADE;
+
+ALTER TABLE role_approvals DROP CONSTRAINT IF EXISTS role_approvals_requested_organization_type_check;
+ALTER TABLE role_approvals ALTER COLUMN requested_organization_type TYPE VARCHAR(50);
+ALTER TABLE role_approvals
+    ADD CONSTRAINT fk_role_approvals_requested_organization_type
+    FOREIGN KEY (requested_organization_type) REFERENCES organization_types(name) ON UPDATE CASCADE;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE role_approvals DROP CONSTRAINT IF EXISTS fk_role_approvals_requested_organization_type;
+ALTER TABLE role_approvals ALTER COLUMN requested_organization_type TYPE VARCHAR(20);
+ALTER TABLE role_approvals
+    ADD CONSTRAINT role_approvals_requested_organization_type_check
+    CHECK (requested_organization_type IN ('platform', 'holding', 'company', 'store'));
+ALTER TABLE organization_type_default_roles DROP CONSTRAINT IF EXISTS fk_organization_type_default_roles_organization_type;
+ALTER TABLE organization_type_default_roles ALTER COLUMN organization_type TYPE VARCHAR(20);
+ALTER TABLE organization_type_default_roles
+    ADD CONSTRAINT organization_type_default_roles_organization_type_check
+    CHECK (organization_type IN ('platform', 'holding', 'company', 'store'));
+ALTER TABLE role_organization_types DROP CONSTRAINT IF EXISTS fk_role_organization_types_organization_type;
+ALTER TABLE organizations DROP CONSTRAINT IF EXISTS fk_organizations_organization_type;
+
+DROP TABLE IF EXISTS organization_type_parents;
+DROP TABLE IF EXISTS organization_types;
+
+-- +goose StatementEnd
@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Archived organizations keep their data but accept no new members, grants or org-context access.
+-- Soft-deleted organizations (deleted_at, present since 002) are hidden until restored or purged.
+ALTER TABLE organizations ADD COLUMN IF NOT EXISTS archived_at TIMESTAMPTZ;
+ALTER TABLE organizations ADD COLUMN IF NOT EXISTS archived_by UUID REFERENCES users(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_organizations_archived_at ON organizations(archived_at) WHERE archived_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_organizations_deleted_at ON organizations(deleted_at) WHERE deleted_at IS NOT NULL;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_organizations_deleted_at;
+DROP INDEX IF EXISTS idx_organizations_archived_at;
+ALTER TABLE organizations DROP COLUMN IF EXISTS archived_by;
+ALTER TABLE organizations DROP COLUMN IF EXISTS archived_at;
+
+-- +goose StatementEnd
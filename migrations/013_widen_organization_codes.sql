@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Organization code length is configurable (up to 16 characters including the check character)
+ALTER TABLE organizations ALTER COLUMN code TYPE VARCHAR(16);
+ALTER TABLE role_approvals ALTER COLUMN organization_code TYPE VARCHAR(16);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE role_approvals ALTER COLUMN organization_code TYPE VARCHAR(8);
+ALTER TABLE organizations ALTER COLUMN code TYPE VARCHAR(8);
+
+-- +goose StatementEnd
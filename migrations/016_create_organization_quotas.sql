@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Plan limits per organization. Like settings, only explicit limits are stored and organizations
+-- without one inherit the limit of their nearest ancestor. A NULL max_value lifts an inherited limit.
+CREATE TABLE IF NOT EXISTS organization_quotas (
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    quota VARCHAR(50) NOT NULL,
+    max_value INT CHECK (max_value IS NULL OR max_value >= 0),
+    updated_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (organization_id, quota)
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS organization_quotas;
+
+-- +goose StatementEnd
@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Keyset pagination orders user listings by the sort field followed by the user ID
+CREATE INDEX IF NOT EXISTS idx_users_created_at_id ON users(created_at, id) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_users_updated_at_id ON users(updated_at, id) WHERE deleted_at IS NULL;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_users_updated_at_id;
+DROP INDEX IF EXISTS idx_users_created_at_id;
+
+-- +goose StatementEnd
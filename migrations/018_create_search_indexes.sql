@@ -0,0 +1,34 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Trigram matching for fuzzy search; the trigram indexes also serve ILIKE '%term%' filters
+CREATE EXTENSION IF NOT EXISTS "pg_trgm";
+
+CREATE INDEX IF NOT EXISTS idx_users_username_trgm ON users USING GIN (username gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_users_email_trgm ON users USING GIN (email gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_organizations_name_trgm ON organizations USING GIN (name gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_organizations_code_trgm ON organizations USING GIN (code gin_trgm_ops);
+
+-- Word search over organization names and descriptions. The simple configuration does not stem,
+-- so it behaves the same for every language organizations are named in.
+ALTER TABLE organizations ADD COLUMN search_vector TSVECTOR GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', coalesce(name, '')), 'A') ||
+    setweight(to_tsvector('simple', coalesce(description, '')), 'B')
+) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_organizations_search_vector ON organizations USING GIN (search_vector);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_organizations_search_vector;
+ALTER TABLE organizations DROP COLUMN IF EXISTS search_vector;
+
+DROP INDEX IF EXISTS idx_organizations_code_trgm;
+DROP INDEX IF EXISTS idx_organizations_name_trgm;
+DROP INDEX IF EXISTS idx_users_email_trgm;
+DROP INDEX IF EXISTS idx_users_username_trgm;
+
+-- +goose StatementEnd
@@ -0,0 +1,40 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Background jobs run long operations (bulk assignment, user import, cascade archive, exports) outside
+-- the HTTP request. Workers claim jobs with FOR UPDATE SKIP LOCKED and hold a lease (locked_until) that
+-- they renew while running, so jobs of a crashed worker are picked up again once the lease runs out.
+CREATE TABLE IF NOT EXISTS background_jobs (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    type VARCHAR(64) NOT NULL,
+    payload JSONB NOT NULL DEFAULT '{}'::jsonb,
+    status VARCHAR(20) NOT NULL DEFAULT 'queued' CHECK (status IN ('queued', 'running', 'succeeded', 'failed', 'cancelled')),
+    submitted_by UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    processed_items INTEGER NOT NULL DEFAULT 0,
+    total_items INTEGER NOT NULL DEFAULT 0,
+    result JSONB,
+    error TEXT,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    max_attempts INTEGER NOT NULL DEFAULT 3 CHECK (max_attempts >= 1),
+    cancel_requested BOOLEAN NOT NULL DEFAULT FALSE,
+    run_after TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    locked_until TIMESTAMPTZ,
+    started_at TIMESTAMPTZ,
+    finished_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_background_jobs_queued ON background_jobs(run_after) WHERE status = 'queued';
+CREATE INDEX IF NOT EXISTS idx_background_jobs_running ON background_jobs(locked_until) WHERE status = 'running';
+CREATE INDEX IF NOT EXISTS idx_background_jobs_submitted_by ON background_jobs(submitted_by);
+CREATE INDEX IF NOT EXISTS idx_background_jobs_finished_at ON background_jobs(finished_at) WHERE finished_at IS NOT NULL;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS background_jobs;
+
+-- +goose StatementEnd
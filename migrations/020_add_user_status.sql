@@ -0,0 +1,41 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Account status lets administrators block sign-in without deleting the user or their memberships.
+-- A suspension with suspended_until lifts itself once that time has passed.
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'suspended', 'pending_activation')),
+    ADD COLUMN IF NOT EXISTS status_reason TEXT,
+    ADD COLUMN IF NOT EXISTS suspended_until TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_users_status ON users(status) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_users_suspended_until ON users(suspended_until) WHERE status = 'suspended' AND suspended_until IS NOT NULL;
+
+-- Every status transition is kept for auditing; action_by is NULL when a suspension expired on its own
+CREATE TABLE IF NOT EXISTS user_status_history (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    previous_status VARCHAR(20) NOT NULL,
+    new_status VARCHAR(20) NOT NULL,
+    reason TEXT,
+    suspended_until TIMESTAMPTZ,
+    action_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    action_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_status_history_user_id ON user_status_history(user_id, action_at DESC);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS user_status_history;
+DROP INDEX IF EXISTS idx_users_suspended_until;
+DROP INDEX IF EXISTS idx_users_status;
+ALTER TABLE users
+    DROP COLUMN IF EXISTS suspended_until,
+    DROP COLUMN IF EXISTS status_reason,
+    DROP COLUMN IF EXISTS status;
+
+-- +goose StatementEnd
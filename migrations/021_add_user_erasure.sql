@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Erasing a user anonymises the users row in place and soft-deletes it, so rows that refer to the user
+-- (history entries, created organizations, change requests) stay valid. erased_at marks erased rows.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS erased_at TIMESTAMPTZ;
+
+-- Every erasure is logged. The log itself holds no personal data of the erased user.
+CREATE TABLE IF NOT EXISTS user_erasures (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    user_id UUID NOT NULL REFERENCES users(id),
+    erased_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    reason TEXT,
+    memberships_removed INTEGER NOT NULL DEFAULT 0,
+    sessions_revoked INTEGER NOT NULL DEFAULT 0,
+    erased_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_erasures_user_id ON user_erasures(user_id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS user_erasures;
+ALTER TABLE users DROP COLUMN IF EXISTS erased_at;
+
+-- +goose StatementEnd
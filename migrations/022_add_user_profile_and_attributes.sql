@@ -0,0 +1,50 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Profile fields users edit themselves. Locale and timezone are validated by the application and fall back
+-- to the organization settings when empty.
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS display_name VARCHAR(100),
+    ADD COLUMN IF NOT EXISTS phone VARCHAR(32),
+    ADD COLUMN IF NOT EXISTS locale VARCHAR(35),
+    ADD COLUMN IF NOT EXISTS timezone VARCHAR(64),
+    ADD COLUMN IF NOT EXISTS job_title VARCHAR(100);
+
+-- Custom attribute schema per organization type, e.g. an employee number for stores
+CREATE TABLE IF NOT EXISTS organization_type_attributes (
+    organization_type VARCHAR(50) NOT NULL REFERENCES organization_types(name) ON DELETE CASCADE,
+    key VARCHAR(50) NOT NULL,
+    display_name VARCHAR(100) NOT NULL,
+    description TEXT,
+    type VARCHAR(20) NOT NULL CHECK (type IN ('string', 'number', 'boolean')),
+    required BOOLEAN NOT NULL DEFAULT FALSE,
+    allowed_values JSONB,
+    pattern VARCHAR(255),
+    max_length INTEGER,
+    position INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (organization_type, key)
+);
+
+-- Attribute values belong to a membership, validated against the schema of the organization's type
+ALTER TABLE user_organizations ADD COLUMN IF NOT EXISTS attributes JSONB NOT NULL DEFAULT '{}';
+
+CREATE INDEX IF NOT EXISTS idx_user_organizations_attributes ON user_organizations USING GIN (attributes jsonb_path_ops);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_user_organizations_attributes;
+ALTER TABLE user_organizations DROP COLUMN IF EXISTS attributes;
+DROP TABLE IF EXISTS organization_type_attributes;
+ALTER TABLE users
+    DROP COLUMN IF EXISTS display_name,
+    DROP COLUMN IF EXISTS phone,
+    DROP COLUMN IF EXISTS locale,
+    DROP COLUMN IF EXISTS timezone,
+    DROP COLUMN IF EXISTS job_title;
+
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Storage prefix of an uploaded avatar, e.g. avatars/<user id>/<version>, holding one JPEG per standard size.
+-- Empty when the avatar URL points to an external picture such as the Google profile photo.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS avatar_key VARCHAR(255);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE users DROP COLUMN IF EXISTS avatar_key;
+
+-- +goose StatementEnd
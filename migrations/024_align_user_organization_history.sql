@@ -0,0 +1,57 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- The history table still had the shape of the first role-change log. Every membership change is now
+-- recorded with its action, the role names and statuses before and after, and the actor, which is NULL
+-- for changes made by the system.
+ALTER TABLE user_organization_history RENAME COLUMN changed_by TO action_by;
+ALTER TABLE user_organization_history RENAME COLUMN change_reason TO reason;
+ALTER TABLE user_organization_history RENAME COLUMN changed_at TO action_at;
+
+ALTER TABLE user_organization_history
+    ADD COLUMN IF NOT EXISTS action VARCHAR(50),
+    ADD COLUMN IF NOT EXISTS previous_role VARCHAR(50),
+    ADD COLUMN IF NOT EXISTS new_role VARCHAR(50),
+    ADD COLUMN IF NOT EXISTS previous_status BOOLEAN,
+    ADD COLUMN IF NOT EXISTS new_status BOOLEAN;
+
+-- Existing entries only ever recorded role changes; keep the role names, roles are referenced by name
+UPDATE user_organization_history h
+SET action = 'role_updated',
+    previous_role = (SELECT r.name FROM roles r WHERE r.id = h.old_role_id),
+    new_role = (SELECT r.name FROM roles r WHERE r.id = h.new_role_id);
+
+ALTER TABLE user_organization_history
+    ALTER COLUMN action SET NOT NULL,
+    ADD CONSTRAINT chk_user_organization_history_action
+        CHECK (action IN ('assigned', 'removed', 'role_updated', 'status_changed', 'attributes_updated')),
+    DROP COLUMN IF EXISTS old_role_id,
+    DROP COLUMN IF EXISTS new_role_id;
+
+ALTER INDEX IF EXISTS idx_user_org_history_changed_by RENAME TO idx_user_org_history_action_by;
+ALTER INDEX IF EXISTS idx_user_org_history_changed_at RENAME TO idx_user_org_history_action_at;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+-- Role IDs cannot be recovered from role names, so earlier entries keep empty role references
+ALTER INDEX IF EXISTS idx_user_org_history_action_at RENAME TO idx_user_org_history_changed_at;
+ALTER INDEX IF EXISTS idx_user_org_history_action_by RENAME TO idx_user_org_history_changed_by;
+
+ALTER TABLE user_organization_history
+    DROP CONSTRAINT IF EXISTS chk_user_organization_history_action,
+    ADD COLUMN IF NOT EXISTS old_role_id UUID,
+    ADD COLUMN IF NOT EXISTS new_role_id UUID,
+    DROP COLUMN IF EXISTS action,
+    DROP COLUMN IF EXISTS previous_role,
+    DROP COLUMN IF EXISTS new_role,
+    DROP COLUMN IF EXISTS previous_status,
+    DROP COLUMN IF EXISTS new_status;
+
+ALTER TABLE user_organization_history RENAME COLUMN action_at TO changed_at;
+ALTER TABLE user_organization_history RENAME COLUMN reason TO change_reason;
+ALTER TABLE user_organization_history RENAME COLUMN action_by TO changed_by;
+
+-- +goose StatementEnd
@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Outcome reported by the executor of an approved change request, e.g. the ID of the job it queued
+ALTER TABLE change_requests ADD COLUMN IF NOT EXISTS result JSONB;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE change_requests DROP COLUMN IF EXISTS result;
+
+-- +goose StatementEnd
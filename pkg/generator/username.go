@@ -1,11 +1,122 @@
 package generator
 
 import (
-	"fmt"
+	"crypto/rand"
+	"math/big"
+	"strconv"
 	"strings"
-	"time"
+	"unicode"
 )
 
-func GenerateFromEmail(email string) string {
-	return fmt.Sprintf("%s_%d", strings.Split(email, "@")[0], time.Now().Unix())
+// Username limits, matching the users.username column and the minimum length of local usernames
+const (
+	MaxUsernameLength = 50
+	MinUsernameLength = 3
+)
+
+// fallbackUsername is used when nothing usable is left of the input, e.g. a name in a non-Latin script
+const fallbackUsername = "user"
+
+// transliterations maps lowercase Latin letters with diacritics and ligatures to plain ASCII
+var transliterations = map[rune]string{
+	'à': "a", 'á': "a", 'â': "a", 'ã': "a", 'ä': "a", 'å': "a", 'ā': "a", 'ă': "a", 'ą': "a",
+	'ç': "c", 'ć': "c", 'ĉ': "c", 'ċ': "c", 'č': "c",
+	'ď': "d", 'đ': "d", 'ð': "d",
+	'è': "e", 'é': "e", 'ê': "e", 'ë': "e", 'ē': "e", 'ĕ': "e", 'ė': "e", 'ę': "e", 'ě': "e",
+	'ĝ': "g", 'ğ': "g", 'ġ': "g", 'ģ': "g",
+	'ĥ': "h", 'ħ': "h",
+	'ì': "i", 'í': "i", 'î': "i", 'ï': "i", 'ĩ': "i", 'ī': "i", 'ĭ': "i", 'į': "i", 'ı': "i",
+	'ĵ': "j", 'ķ': "k",
+	'ĺ': "l", 'ļ': "l", 'ľ': "l", 'ŀ': "l", 'ł': "l",
+	'ñ': "n", 'ń': "n", 'ņ': "n", 'ň': "n",
+	'ò': "o", 'ó': "o", 'ô': "o", 'õ': "o", 'ö': "o", 'ø': "o", 'ō': "o", 'ŏ': "o", 'ő': "o",
+	'ŕ': "r", 'ŗ': "r", 'ř': "r",
+	'ś': "s", 'ŝ': "s", 'ş': "s", 'š': "s", 'ș': "s",
+	'ţ': "t", 'ť': "t", 'ŧ': "t", 'ț': "t",
+	'ù': "u", 'ú': "u", 'û': "u", 'ü': "u", 'ũ': "u", 'ū': "u", 'ŭ': "u", 'ů': "u", 'ű': "u", 'ų': "u",
+	'ŵ': "w", 'ý': "y", 'ÿ': "y", 'ŷ': "y",
+	'ź': "z", 'ż': "z", 'ž': "z",
+	'ß': "ss", 'æ': "ae", 'œ': "oe", 'þ': "th",
+}
+
+// NormalizeUsername turns free text such as an email local part or a display name into a username
+// candidate: lowercase ASCII letters and digits, with single dots, underscores or hyphens between them.
+// Accented letters are transliterated, other characters become separators, and the result is cut to
+// MaxUsernameLength. Inputs with fewer than MinUsernameLength usable characters fall back to "user".
+func NormalizeUsername(input string) string {
+	var b strings.Builder
+	pendingSeparator := rune(0)
+	for _, r := range strings.ToLower(input) {
+		var part string
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			part = string(r)
+		case transliterations[r] != "":
+			part = transliterations[r]
+		case r == '.' || r == '_' || r == '-':
+			if pendingSeparator == 0 {
+				pendingSeparator = r
+			}
+			continue
+		case unicode.IsSpace(r) || unicode.IsPunct(r) || unicode.IsSymbol(r):
+			if pendingSeparator == 0 {
+				pendingSeparator = '_'
+			}
+			continue
+		default:
+			continue // Letters of other scripts and marks have no ASCII form
+		}
+
+		if pendingSeparator != 0 && b.Len() > 0 {
+			b.WriteRune(pendingSeparator)
+		}
+		pendingSeparator = 0
+		b.WriteString(part)
+	}
+
+	username := trimUsername(b.String(), MaxUsernameLength)
+	if len(username) < MinUsernameLength {
+		return fallbackUsername
+	}
+	return username
+}
+
+// WithSuffix appends a numeric suffix to a normalized username, shortening the username so the result
+// stays within MaxUsernameLength. An underscore keeps the suffix apart from a username ending in a digit.
+func WithSuffix(username string, suffix int) string {
+	tail := strconv.Itoa(suffix)
+	base := trimUsername(username, MaxUsernameLength-len(tail)-1)
+	if base == "" {
+		base = fallbackUsername
+	}
+	if last := base[len(base)-1]; last >= '0' && last <= '9' {
+		return base + "_" + tail
+	}
+	return base + tail
+}
+
+// UsernameCandidates lists usernames to try for a normalized username, most readable first: the
+// username itself, then sequential suffixes 2 to sequential+1 and finally random four-digit suffixes.
+func UsernameCandidates(username string, sequential, random int) []string {
+	candidates := make([]string, 0, 1+sequential+random)
+	candidates = append(candidates, username)
+	for i := 2; i <= sequential+1; i++ {
+		candidates = append(candidates, WithSuffix(username, i))
+	}
+	for i := 0; i < random; i++ {
+		n, err := rand.Int(rand.Reader, big.NewInt(9000))
+		if err != nil {
+			continue
+		}
+		candidates = append(candidates, WithSuffix(username, 1000+int(n.Int64())))
+	}
+	return candidates
+}
+
+// trimUsername cuts a normalized username to at most maxLength bytes without leaving a trailing separator.
+func trimUsername(username string, maxLength int) string {
+	if len(username) > maxLength {
+		username = username[:maxLength]
+	}
+	return strings.TrimRight(username, "._-")
 }
@@ -0,0 +1,86 @@
+package generator
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeUsername(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{name: "lowercases", input: "John.Doe", want: "john.doe"},
+		{name: "transliterates diacritics", input: "José Müller", want: "jose_muller"},
+		{name: "expands ligatures", input: "Straße Æsir", want: "strasse_aesir"},
+		{name: "collapses separator runs to the first", input: "a--b__c", want: "a-b_c"},
+		{name: "drops leading and trailing separators", input: "  _john_  ", want: "john"},
+		{name: "punctuation and symbols become underscores", input: "john+tag@example", want: "john_tag_example"},
+		{name: "keeps the first separator of a mixed run", input: "Dr. O'Neil", want: "dr.o_neil"},
+		{name: "keeps digits", input: "agent007", want: "agent007"},
+		{name: "drops other scripts", input: "ana日本", want: "ana"},
+		{name: "falls back when too short", input: "ab", want: "user"},
+		{name: "falls back for other scripts only", input: "日本語", want: "user"},
+		{name: "falls back when empty", input: "", want: "user"},
+		{name: "cuts to the maximum length", input: strings.Repeat("a", 60), want: strings.Repeat("a", MaxUsernameLength)},
+		{name: "cut leaves no trailing separator", input: strings.Repeat("a", 49) + ".b", want: strings.Repeat("a", 49)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormalizeUsername(tt.input); got != tt.want {
+				t.Errorf("NormalizeUsername(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithSuffix(t *testing.T) {
+	tests := []struct {
+		name     string
+		username string
+		suffix   int
+		want     string
+	}{
+		{name: "appends directly", username: "john", suffix: 2, want: "john2"},
+		{name: "separates from a trailing digit", username: "agent007", suffix: 2, want: "agent007_2"},
+		{name: "shortens long usernames", username: strings.Repeat("a", MaxUsernameLength), suffix: 12, want: strings.Repeat("a", 47) + "12"},
+		{name: "shortening drops a trailing separator", username: strings.Repeat("a", 46) + ".bcd", suffix: 12, want: strings.Repeat("a", 46) + "12"},
+		{name: "falls back on an empty base", username: "", suffix: 5, want: "user5"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := WithSuffix(tt.username, tt.suffix)
+			if got != tt.want {
+				t.Errorf("WithSuffix(%q, %d) = %q, want %q", tt.username, tt.suffix, got, tt.want)
+			}
+			if len(got) > MaxUsernameLength {
+				t.Errorf("WithSuffix(%q, %d) is %d bytes, want at most %d", tt.username, tt.suffix, len(got), MaxUsernameLength)
+			}
+		})
+	}
+}
+
+func TestUsernameCandidates(t *testing.T) {
+	candidates := UsernameCandidates("john", 3, 2)
+
+	wantPrefix := []string{"john", "john2", "john3", "john4"}
+	if len(candidates) != len(wantPrefix)+2 {
+		t.Fatalf("UsernameCandidates() returned %d candidates, want %d", len(candidates), len(wantPrefix)+2)
+	}
+	for i, want := range wantPrefix {
+		if candidates[i] != want {
+			t.Errorf("candidate %d = %q, want %q", i, candidates[i], want)
+		}
+	}
+
+	random := regexp.MustCompile(`^john[1-9][0-9]{3}$`)
+	for _, candidate := range candidates[len(wantPrefix):] {
+		if !random.MatchString(candidate) {
+			t.Errorf("random candidate %q does not have a four-digit suffix", candidate)
+		}
+	}
+}
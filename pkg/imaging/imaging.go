@@ -0,0 +1,127 @@
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"net/http"
+
+	// Register the decoders of the accepted formats
+	_ "image/gif"
+	_ "image/png"
+)
+
+// Content types accepted by Decode
+const (
+	ContentTypeJPEG = "image/jpeg"
+	ContentTypePNG  = "image/png"
+	ContentTypeGIF  = "image/gif"
+)
+
+// MaxPixels bounds the decoded size of an image so small files cannot expand into huge bitmaps.
+const MaxPixels = 40_000_000
+
+var (
+	// ErrUnsupportedFormat is returned for content that is not a JPEG, PNG or GIF image.
+	ErrUnsupportedFormat = errors.New("unsupported image format, use JPEG, PNG or GIF")
+	// ErrTooLarge is returned for images with more than MaxPixels pixels.
+	ErrTooLarge = errors.New("image dimensions are too large")
+)
+
+// DetectContentType sniffs the content type from the data itself, ignoring any declared type.
+func DetectContentType(data []byte) string {
+	return http.DetectContentType(data)
+}
+
+// Decode sniffs and decodes a JPEG, PNG or GIF image, rejecting images smaller than minSize
+// pixels on either side.
+func Decode(data []byte, minSize int) (image.Image, error) {
+	switch DetectContentType(data) {
+	case ContentTypeJPEG, ContentTypePNG, ContentTypeGIF:
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupportedFormat, err)
+	}
+	if config.Width*config.Height > MaxPixels {
+		return nil, ErrTooLarge
+	}
+	if config.Width < minSize || config.Height < minSize {
+		return nil, fmt.Errorf("image must be at least %dx%d pixels", minSize, minSize)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupportedFormat, err)
+	}
+	return img, nil
+}
+
+// SquareThumbnail crops the largest centered square out of img and scales it to size x size pixels.
+// Transparent areas are flattened onto white.
+func SquareThumbnail(img image.Image, size int) *image.RGBA {
+	bounds := img.Bounds()
+	side := min(bounds.Dx(), bounds.Dy())
+	crop := image.Rect(0, 0, side, side).Add(image.Pt(
+		bounds.Min.X+(bounds.Dx()-side)/2,
+		bounds.Min.Y+(bounds.Dy()-side)/2,
+	))
+
+	source := image.NewRGBA(image.Rect(0, 0, side, side))
+	draw.Draw(source, source.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(source, source.Bounds(), img, crop.Min, draw.Over)
+
+	return resize(source, size, size)
+}
+
+// EncodeJPEG encodes img as a JPEG of the given quality.
+func EncodeJPEG(img image.Image, quality int) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: quality}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// resize scales src to width x height. Every target pixel averages the source area it covers,
+// which keeps downscaled images smooth; upscaling falls back to the nearest source pixel.
+func resize(src *image.RGBA, width, height int) *image.RGBA {
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	srcW, srcH := src.Bounds().Dx(), src.Bounds().Dy()
+
+	for y := 0; y < height; y++ {
+		y0 := y * srcH / height
+		y1 := max((y+1)*srcH/height, y0+1)
+		for x := 0; x < width; x++ {
+			x0 := x * srcW / width
+			x1 := max((x+1)*srcW/width, x0+1)
+
+			var r, g, b, a, n uint32
+			for sy := y0; sy < y1; sy++ {
+				offset := sy*src.Stride + x0*4
+				for sx := x0; sx < x1; sx++ {
+					r += uint32(src.Pix[offset])
+					g += uint32(src.Pix[offset+1])
+					b += uint32(src.Pix[offset+2])
+					a += uint32(src.Pix[offset+3])
+					offset += 4
+					n++
+				}
+			}
+
+			offset := y*dst.Stride + x*4
+			dst.Pix[offset] = uint8(r / n)
+			dst.Pix[offset+1] = uint8(g / n)
+			dst.Pix[offset+2] = uint8(b / n)
+			dst.Pix[offset+3] = uint8(a / n)
+		}
+	}
+	return dst
+}
@@ -0,0 +1,169 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LocalStore menyimpan objek di filesystem lokal dan menyajikannya lewat HTTP.
+// Dengan signedOnly, objek hanya disajikan lewat URL bertanda tangan yang belum kedaluwarsa.
+type LocalStore struct {
+	root       string
+	baseURL    string
+	signingKey []byte
+	signedOnly bool
+}
+
+// NewLocalStore membuat LocalStore dengan root directory yang dibuat bila belum ada.
+func NewLocalStore(root, baseURL, signingKey string, signedOnly bool) (*LocalStore, error) {
+	if root == "" {
+		return nil, fmt.Errorf("local storage path is required")
+	}
+	if baseURL == "" {
+		return nil, fmt.Errorf("local storage public URL is required")
+	}
+	if signedOnly && signingKey == "" {
+		return nil, fmt.Errorf("a signing key is required to serve local storage through signed URLs")
+	}
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create local storage directory: %w", err)
+	}
+	return &LocalStore{
+		root:       root,
+		baseURL:    baseURL,
+		signingKey: []byte(signingKey),
+		signedOnly: signedOnly,
+	}, nil
+}
+
+// Put writes the object to a temporary file first so readers never see a partial object.
+func (s *LocalStore) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	target := s.path(key)
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return fmt.Errorf("failed to create object directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(target), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("failed to create object file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, body); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write object: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write object: %w", err)
+	}
+	return os.Rename(tmp.Name(), target)
+}
+
+// Delete removes the object file.
+func (s *LocalStore) Delete(ctx context.Context, key string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	if err := os.Remove(s.path(key)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// PublicURL returns the URL the object is served from by ServeHTTP.
+func (s *LocalStore) PublicURL(key string) string {
+	return joinURL(s.baseURL, key)
+}
+
+// SignedURL returns the URL of the object with an expiry and an HMAC signature over key and expiry.
+func (s *LocalStore) SignedURL(ctx context.Context, key string, expiry time.Duration) (string, error) {
+	if err := validateKey(key); err != nil {
+		return "", err
+	}
+	if len(s.signingKey) == 0 {
+		return "", fmt.Errorf("local storage has no signing key")
+	}
+	expires := strconv.FormatInt(time.Now().Add(expiry).Unix(), 10)
+	query := url.Values{}
+	query.Set("expires", expires)
+	query.Set("signature", s.sign(key, expires))
+	return s.PublicURL(key) + "?" + query.Encode(), nil
+}
+
+// ServeHTTP serves objects below the base URL path. Requests are expected with that prefix stripped.
+func (s *LocalStore) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	key := strings.TrimPrefix(path.Clean("/"+r.URL.Path), "/")
+	if validateKey(key) != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	signature := r.URL.Query().Get("signature")
+	if s.signedOnly || signature != "" {
+		if !s.verify(key, r.URL.Query().Get("expires"), signature) {
+			http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+			return
+		}
+		w.Header().Set("Cache-Control", "private, max-age=300")
+	} else {
+		w.Header().Set("Cache-Control", "public, max-age=86400")
+	}
+
+	file, err := os.Open(s.path(key))
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil || info.IsDir() {
+		http.NotFound(w, r)
+		return
+	}
+
+	if contentType := mime.TypeByExtension(filepath.Ext(key)); contentType != "" {
+		w.Header().Set("Content-Type", contentType)
+	}
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	http.ServeContent(w, r, "", info.ModTime(), file)
+}
+
+func (s *LocalStore) path(key string) string {
+	return filepath.Join(s.root, filepath.FromSlash(key))
+}
+
+func (s *LocalStore) sign(key, expires string) string {
+	mac := hmac.New(sha256.New, s.signingKey)
+	mac.Write([]byte(key + "\n" + expires))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// verify checks the signature of a signed URL and that it has not expired.
+func (s *LocalStore) verify(key, expires, signature string) bool {
+	if len(s.signingKey) == 0 {
+		return false
+	}
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || time.Now().Unix() > expiresAt {
+		return false
+	}
+	return hmac.Equal([]byte(signature), []byte(s.sign(key, expires)))
+}